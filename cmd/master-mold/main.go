@@ -2,27 +2,54 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"math"
 	"os"
+	"strconv"
+	"time"
 
 	"log/slog"
 
+	"github.com/oscarrieken/master-mold/pkg/azuredevops"
+	"github.com/oscarrieken/master-mold/pkg/binary"
 	"github.com/oscarrieken/master-mold/pkg/command"
 	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/oscarrieken/master-mold/pkg/logging"
+	"github.com/oscarrieken/master-mold/pkg/terminal"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
 )
 
-// initLogger initializes the logger
+// initLogger initializes the logger from the global flags and log settings
 func initLogger() *slog.Logger {
-	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-	}))
+	return logging.New()
+}
+
+// isQuietCommand checks if the command prints output consumed by the shell, so nothing must be logged while it runs
+func isQuietCommand(cmd *cobra.Command) bool {
+	switch cmd.Name() {
+	case "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return true
+	}
+	return false
+}
+
+// configPaths are the directories searched for config.toml, in order
+var configPaths = []string{
+	"./config",
+	"$HOME/.master-mold",
+}
+
+// userConfigFile gets the configuration file the setup wizard writes: config.toml in the master-mold directory of
+// the home directory, which applies wherever master-mold runs, unlike the one of the working directory
+func userConfigFile() string {
+	return config.DefaultConfigFile(configPaths[len(configPaths)-1:])
 }
 
 // loadConfig loads the configuration
 func loadConfig(logger *slog.Logger) (*config.Config, error) {
-	return loadConfigWithPaths(logger, []string{
-		"./config",
-		"$HOME/.master-mold",
-	})
+	return loadConfigWithPaths(logger, configPaths)
 }
 
 // loadConfigWithPaths loads the configuration from the specified paths
@@ -30,46 +57,186 @@ func loadConfigWithPaths(logger *slog.Logger, configPaths []string) (*config.Con
 	return config.LoadConfig(configPaths, logger)
 }
 
-// CommandExecutor is an interface for executing commands
-type CommandExecutor interface {
-	Execute(commandName string, args []string) error
+// globalFlags are the flags given to master-mold before the command
+type globalFlags struct {
+	// timeout overrides the timeout of the configuration
+	timeout timeoutFlag
+	json    bool
+	verbose bool
+	quiet   bool
+	noColor bool
+	// dryRun prints how the command would be executed instead of executing it
+	dryRun bool
+	// baseDirOnly resolves subcommands from the base directory only, as base_dir_only does
+	baseDirOnly bool
+	// output is the file the output of subcommands goes to instead of stdout; appendOutput appends to it rather
+	// than replacing it, and tee prints the output too
+	output       string
+	appendOutput bool
+	tee          bool
+	// outputFile is the opened output file, closed once the command completes
+	outputFile *os.File
 }
 
-// handleCommands handles command execution
-func handleCommands(registry CommandExecutor) error {
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: master-mold <command> [options]")
-		fmt.Println("Run 'master-mold list-binaries' to see available commands")
-		return fmt.Errorf("no command specified")
+// exportEnv passes the flags to built-in commands and subcommands through environment variables
+func (f globalFlags) exportEnv() {
+	if f.json {
+		os.Setenv(env.Output, env.OutputJSON)
+	}
+	if f.verbose {
+		os.Setenv(env.Verbose, "1")
+	}
+	if f.quiet {
+		os.Setenv(env.Quiet, "1")
+	}
+	if f.noColor {
+		os.Setenv(env.NoColor, "1")
+		os.Setenv("NO_COLOR", "1")
 	}
+}
 
-	commandName := os.Args[1]
-	args := os.Args[2:]
+// exportLogSettings passes the log settings of the configuration to master-mold and its subcommands through
+// environment variables; MM_LOG_LEVEL and MM_LOG_FORMAT already set in the environment win
+func exportLogSettings(cfg *config.Config) {
+	if cfg.LogLevel != "" && os.Getenv(env.LogLevel) == "" {
+		os.Setenv(env.LogLevel, cfg.LogLevel)
+	}
+	if cfg.LogFormat != "" && os.Getenv(env.LogFormat) == "" {
+		os.Setenv(env.LogFormat, cfg.LogFormat)
+	}
+}
 
-	return registry.Execute(commandName, args)
+// apply applies the flags to the configuration
+func (f globalFlags) apply(cfg *config.Config) {
+	if f.timeout.seconds != nil {
+		cfg.Timeout = *f.timeout.seconds
+	}
+	if f.baseDirOnly {
+		cfg.BaseDirOnly = true
+	}
 }
 
-func main() {
+// openOutput opens the file of --output, and gets where the output of subcommands goes: the file, or both stdout and
+// the file with --tee. Without --output, the output goes to stdout.
+func (f *globalFlags) openOutput(stdout io.Writer) (io.Writer, error) {
+	if f.output == "" {
+		if f.appendOutput || f.tee {
+			return nil, errors.New("--append and --tee need --output")
+		}
+		return stdout, nil
+	}
+
+	mode := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if f.appendOutput {
+		mode = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	file, err := os.OpenFile(f.output, mode, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open the output file")
+	}
+	f.outputFile = file
+
+	if f.tee {
+		return io.MultiWriter(stdout, file), nil
+	}
+	return file, nil
+}
+
+// closeOutput closes the file of --output, if any
+func (f *globalFlags) closeOutput() error {
+	if f.outputFile == nil {
+		return nil
+	}
+	if err := f.outputFile.Close(); err != nil {
+		return errors.Wrap(err, "failed to write the output file")
+	}
+	return nil
+}
+
+// parseTimeout parses a timeout given in seconds or as a duration such as "5m", rounded up to whole seconds
+func parseTimeout(value string) (int, error) {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return seconds, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid timeout '%s', expected seconds or a duration such as 5m", value)
+	}
+	return int(math.Ceil(duration.Seconds())), nil
+}
+
+// setup applies the global flags and loads the configuration into the registry, before the command runs
+func setup(registry *command.Registry, cfg *config.Config, flags *globalFlags, cmd *cobra.Command) error {
+	flags.exportEnv()
+
 	// Initialize the logger
 	logger := initLogger()
+	if isQuietCommand(cmd) {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	registry.SetLogger(logger)
 	logger.Info("Starting master-mold CLI")
 
-	// Load the configuration
-	cfg, err := loadConfig(logger)
+	// Offer to set up the configuration on first run, when someone can answer
+	if !isQuietCommand(cmd) && config.FindConfigFile(configPaths) == "" && terminal.IsTerminal(os.Stdin) {
+		if err := newSetupWizard().run(userConfigFile()); err != nil {
+			return errors.Wrap(err, "setup failed")
+		}
+	}
+
+	// Load the configuration into the one the commands share
+	loaded, err := loadConfig(logger)
 	if err != nil {
-		logger.Error("Error loading configuration", "error", err)
-		os.Exit(1)
+		return command.ConfigError(errors.Wrap(err, "error loading configuration"))
 	}
+	*cfg = *loaded
+	flags.apply(cfg)
 
-	// Create the command registry
-	registry := command.NewRegistry(cfg, logger)
+	// Switch to the log settings of the configuration
+	exportLogSettings(cfg)
+	if !isQuietCommand(cmd) {
+		registry.SetLogger(initLogger())
+	}
+
+	registry.SetDryRun(flags.dryRun)
+
+	// Send the output of subcommands to the file of --output; logs stay on stderr
+	output, err := flags.openOutput(os.Stdout)
+	if err != nil {
+		return err
+	}
+	registry.SetOutput(output)
+	return nil
+}
+
+func main() {
+	// Create the command registry; the configuration is loaded once the global flags are parsed
+	cfg := &config.Config{}
+	registry := command.NewRegistry(cfg, initLogger())
 	command.RegisterCommands(registry)
 
+	// Check every binary master-mold runs, whatever it runs it for
+	binary.SetVerifier(registry.VerifyBinary)
+
+	// Bundle the azure-devops subcommand, for when its binary isn't installed
+	azuredevops.Version = command.Version
+	registry.RegisterBundled("azure-devops", command.ContextHandlerFunc(azuredevops.ExecuteContext))
+
+	var flags globalFlags
+	rootCmd := newRootCommand(registry, &flags, func(cmd *cobra.Command) error {
+		return setup(registry, cfg, &flags, cmd)
+	})
+
 	// Handle commands
-	if err := handleCommands(registry); err != nil {
-		logger.Error("Error executing command", "error", err)
-		os.Exit(1)
+	err := rootCmd.Execute()
+	if closeErr := flags.closeOutput(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		registry.Logger().Error("Error executing command", "error", err, "category", command.ErrorCategory(err))
+		os.Exit(command.ExitCode(err))
 	}
 
-	logger.Info("Command completed successfully")
+	registry.Logger().Info("Command completed successfully")
 }
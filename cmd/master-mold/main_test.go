@@ -2,10 +2,17 @@ package main
 
 import (
 	"bytes"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"log/slog"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/spf13/cobra"
 )
 
 func TestInitLogger(t *testing.T) {
@@ -60,8 +67,8 @@ func TestLoadConfig(t *testing.T) {
 	if cfg.BaseDir == "" {
 		t.Error("loadConfigWithPaths() returned config with empty BaseDir")
 	}
-	if cfg.Timeout <= 0 {
-		t.Error("loadConfigWithPaths() returned config with invalid Timeout")
+	if cfg.Timeout != 0 {
+		t.Errorf("loadConfigWithPaths() returned config with Timeout %d, want no limit", cfg.Timeout)
 	}
 
 	// Check that the config file was created in the temporary directory
@@ -71,73 +78,135 @@ func TestLoadConfig(t *testing.T) {
 	}
 }
 
-func TestHandleCommands_NoCommand(t *testing.T) {
-	// Save the original os.Args
-	oldArgs := os.Args
-	defer func() { os.Args = oldArgs }()
+func TestUserConfigFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if got, want := userConfigFile(), filepath.Join(home, ".master-mold", "config.toml"); got != want {
+		t.Errorf("userConfigFile() = %s, want %s", got, want)
+	}
+}
 
-	// Set os.Args to simulate no command
-	os.Args = []string{"master-mold"}
+func TestIsQuietCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"completion", true},
+		{cobra.ShellCompRequestCmd, true},
+		{"list-binaries", false},
+	}
 
-	// Create a mock registry
-	registry := &MockRegistry{}
+	for _, tt := range tests {
+		if got := isQuietCommand(&cobra.Command{Use: tt.name}); got != tt.want {
+			t.Errorf("isQuietCommand(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
 
-	// Call the function
-	err := handleCommands(registry)
+func TestGlobalFlags_Apply(t *testing.T) {
+	var flags globalFlags
+	cfg := &config.Config{Timeout: 10}
+	flags.apply(cfg)
+	if cfg.Timeout != 10 {
+		t.Errorf("apply() without --timeout set Timeout = %d, want 10", cfg.Timeout)
+	}
 
-	// Check that there was an error
-	if err == nil {
-		t.Fatal("handleCommands() did not return an error when no command was specified")
+	if err := flags.timeout.Set("2m"); err != nil {
+		t.Fatalf("Set(2m) error = %v", err)
+	}
+	flags.apply(cfg)
+	if cfg.Timeout != 120 {
+		t.Errorf("apply() set Timeout = %d, want 120", cfg.Timeout)
 	}
 
-	// Check that the error message is as expected
-	if err.Error() != "no command specified" {
-		t.Errorf("handleCommands() returned error = %v, want 'no command specified'", err)
+	flags.baseDirOnly = true
+	flags.apply(cfg)
+	if !cfg.BaseDirOnly {
+		t.Error("apply() with --base-dir-only left BaseDirOnly false")
 	}
 }
 
-func TestHandleCommands_WithCommand(t *testing.T) {
-	// Save the original os.Args
-	oldArgs := os.Args
-	defer func() { os.Args = oldArgs }()
+func TestGlobalFlags_ExportEnv(t *testing.T) {
+	for _, name := range []string{env.Output, env.Verbose, env.Quiet, env.NoColor, "NO_COLOR"} {
+		t.Setenv(name, "")
+	}
 
-	// Set os.Args to simulate a command
-	os.Args = []string{"master-mold", "test-command", "arg1", "arg2"}
+	globalFlags{json: true, verbose: true, noColor: true}.exportEnv()
 
-	// Create a mock registry
-	registry := &MockRegistry{}
+	if !env.JSONOutput() || !env.Enabled(env.Verbose) || env.Enabled(env.Quiet) || !env.ColorDisabled() {
+		t.Errorf("exportEnv() set %s=%q %s=%q %s=%q %s=%q", env.Output, os.Getenv(env.Output), env.Verbose, os.Getenv(env.Verbose),
+			env.Quiet, os.Getenv(env.Quiet), env.NoColor, os.Getenv(env.NoColor))
+	}
+}
 
-	// Call the function
-	err := handleCommands(registry)
+func TestGlobalFlags_OpenOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.txt")
+	write := func(flags globalFlags, stdout io.Writer, text string) {
+		t.Helper()
+		output, err := flags.openOutput(stdout)
+		if err != nil {
+			t.Fatalf("openOutput() error = %v", err)
+		}
+		fmt.Fprint(output, text)
+		if err := flags.closeOutput(); err != nil {
+			t.Fatalf("closeOutput() error = %v", err)
+		}
+	}
 
-	// Check that there was no error
+	stdout := &bytes.Buffer{}
+	write(globalFlags{output: path}, stdout, "old\n")
+	write(globalFlags{output: path}, stdout, "first\n")
+	write(globalFlags{output: path, appendOutput: true, tee: true}, stdout, "second\n")
+
+	data, err := os.ReadFile(path)
 	if err != nil {
-		t.Fatalf("handleCommands() returned an error: %v", err)
+		t.Fatalf("Failed to read the output file: %v", err)
 	}
-
-	// Check that the registry's Execute method was called with the correct arguments
-	if !registry.ExecuteCalled {
-		t.Fatal("handleCommands() did not call registry.Execute()")
+	if string(data) != "first\nsecond\n" {
+		t.Errorf("output file = %q, want %q", data, "first\nsecond\n")
 	}
-	if registry.CommandName != "test-command" {
-		t.Errorf("handleCommands() called registry.Execute() with commandName = %s, want 'test-command'", registry.CommandName)
+	if stdout.String() != "second\n" {
+		t.Errorf("stdout = %q, want only the output of --tee", stdout.String())
 	}
-	if len(registry.Args) != 2 || registry.Args[0] != "arg1" || registry.Args[1] != "arg2" {
-		t.Errorf("handleCommands() called registry.Execute() with args = %v, want ['arg1', 'arg2']", registry.Args)
+
+	if _, err := (&globalFlags{tee: true}).openOutput(stdout); err == nil {
+		t.Error("openOutput() with --tee and no --output succeeded, want an error")
 	}
 }
 
-// MockRegistry is a mock implementation of the command.Registry interface for testing
-type MockRegistry struct {
-	ExecuteCalled bool
-	CommandName   string
-	Args          []string
+func TestParseTimeout(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"30", 30, false},
+		{"0", 0, false},
+		{"5m", 300, false},
+		{"1500ms", 2, false},
+		{"-5", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseTimeout(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseTimeout(%s) = %d, %v, want %d (error %v)", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
 }
 
-// Execute records the call and returns nil
-func (m *MockRegistry) Execute(commandName string, args []string) error {
-	m.ExecuteCalled = true
-	m.CommandName = commandName
-	m.Args = args
-	return nil
+func TestExportLogSettings(t *testing.T) {
+	t.Setenv(env.LogLevel, "")
+	t.Setenv(env.LogFormat, "text")
+
+	exportLogSettings(&config.Config{LogLevel: "debug", LogFormat: "json"})
+
+	// The configuration fills in what the environment doesn't set
+	if got := os.Getenv(env.LogLevel); got != "debug" {
+		t.Errorf("%s = %q, want debug from the configuration", env.LogLevel, got)
+	}
+	if got := os.Getenv(env.LogFormat); got != "text" {
+		t.Errorf("%s = %q, want text from the environment", env.LogFormat, got)
+	}
 }
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/oscarrieken/master-mold/pkg/command"
+	"github.com/spf13/cobra"
+)
+
+// newRootCommand creates the master-mold command line. The built-in commands of the registry become cobra
+// commands; any other command falls through to the registry, which runs the alias or the subcommand binary.
+// setup runs once the global flags are parsed, before the command.
+func newRootCommand(registry *command.Registry, flags *globalFlags, setup func(cmd *cobra.Command) error) *cobra.Command {
+	completer := command.NewCompleter(registry)
+
+	// cobra shows the help of --help before the pre-run hooks, so the help sets up first too
+	ready := false
+	prepare := func(cmd *cobra.Command) error {
+		if ready {
+			return nil
+		}
+		ready = true
+		return setup(cmd)
+	}
+
+	rootCmd := &cobra.Command{
+		Use:   "master-mold",
+		Short: "Runs built-in commands and subcommand binaries",
+		// Flags before the command are global flags; the flags after it belong to the command
+		TraverseChildren: true,
+		Args:             cobra.ArbitraryArgs,
+		SilenceErrors:    true,
+		SilenceUsage:     true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return prepare(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				fmt.Fprintln(cmd.OutOrStdout(), "Usage: master-mold [global flags] <command> [options]")
+				fmt.Fprintln(cmd.OutOrStdout(), "Run 'master-mold help' to see available commands")
+				return fmt.Errorf("no command specified")
+			}
+			return registry.Execute(args[0], args[1:])
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				// cobra completes the built-in commands itself, with their descriptions
+				var completions []string
+				for _, name := range completer.Complete([]string{toComplete}) {
+					if _, builtin := registry.Get(name); !builtin {
+						completions = append(completions, name)
+					}
+				}
+				return completions, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			// Subcommands that don't describe their arguments complete file names
+			completions := completer.Complete(append(args, toComplete))
+			if len(completions) == 0 {
+				return nil, cobra.ShellCompDirectiveDefault
+			}
+			return completions, cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+	// The completion command of the registry replaces the one of cobra
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+
+	global := rootCmd.PersistentFlags()
+	global.Var(&flags.timeout, "timeout", "Timeout of the command in seconds, or a duration such as 5m; 0 disables it")
+	global.BoolVar(&flags.json, "json", false, "Output JSON")
+	global.BoolVar(&flags.verbose, "verbose", false, "Log debug messages")
+	global.BoolVar(&flags.quiet, "quiet", false, "Log errors only")
+	global.BoolVar(&flags.noColor, "no-color", false, "Disable colored output")
+	global.BoolVar(&flags.dryRun, "dry-run", false, "Print how the command would be dispatched instead of running it")
+	global.BoolVar(&flags.baseDirOnly, "base-dir-only", false, "Run subcommands from the base directory only, ignoring the PATH")
+	global.StringVar(&flags.output, "output", "", "Write the output of the subcommand to a file; logs stay on stderr")
+	global.BoolVar(&flags.appendOutput, "append", false, "Append to the file of --output instead of replacing it")
+	global.BoolVar(&flags.tee, "tee", false, "Print the output of the subcommand as well as writing it to the file of --output")
+	rootCmd.Flags().SetInterspersed(false)
+
+	for _, name := range registry.Names() {
+		builtinCmd := newBuiltinCommand(registry, completer, name)
+		rootCmd.AddCommand(builtinCmd)
+		if name == "help" {
+			rootCmd.SetHelpCommand(builtinCmd)
+		}
+	}
+
+	if handler, ok := registry.Get("completion"); ok {
+		if completion, ok := handler.(*command.CompletionHandler); ok {
+			completion.SetRoot(rootCmd)
+		}
+	}
+
+	// master-mold --help shows the help overview of the registry
+	defaultHelp := rootCmd.HelpFunc()
+	rootCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		if err := prepare(cmd); err != nil {
+			registry.Logger().Error("Error showing help", "error", err)
+			return
+		}
+
+		var err error
+		switch _, builtin := registry.Get(cmd.Name()); {
+		case cmd == rootCmd:
+			err = registry.Execute("help", nil)
+		case builtin:
+			err = registry.Execute("help", []string{cmd.Name()})
+		default:
+			defaultHelp(cmd, args)
+		}
+		if err != nil {
+			registry.Logger().Error("Error showing help", "error", err)
+		}
+	})
+
+	return rootCmd
+}
+
+// newBuiltinCommand creates the cobra command of a built-in command of the registry. The command is executed
+// through the registry, which parses its flags, routes its help flags and honors --dry-run.
+func newBuiltinCommand(registry *command.Registry, completer *command.Completer, name string) *cobra.Command {
+	builtinCmd := &cobra.Command{
+		Use:                name,
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return registry.Execute(name, args)
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			words := append(append([]string{name}, args...), toComplete)
+			return completer.Complete(words), cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+
+	if handler, ok := registry.Get(name); ok {
+		if provider, ok := handler.(command.HelpProvider); ok {
+			builtinCmd.Long = provider.Help()
+			builtinCmd.Short = helpSummary(provider.Help())
+		}
+	}
+
+	return builtinCmd
+}
+
+// helpSummary gets the first sentence of the description following the usage line of a help text
+func helpSummary(help string) string {
+	_, description, found := strings.Cut(help, "\n\n")
+	if !found {
+		return ""
+	}
+	description, _, _ = strings.Cut(description, "\n\n")
+	description = strings.ReplaceAll(description, "\n", " ")
+	if sentence, _, found := strings.Cut(description, ". "); found {
+		return sentence + "."
+	}
+	return description
+}
+
+// timeoutFlag is the value of the --timeout flag, in seconds
+type timeoutFlag struct {
+	// seconds is nil when the flag isn't given
+	seconds *int
+}
+
+// String returns the timeout in seconds, or an empty string when it isn't set
+func (f *timeoutFlag) String() string {
+	if f.seconds == nil {
+		return ""
+	}
+	return strconv.Itoa(*f.seconds)
+}
+
+// Set parses a timeout given in seconds or as a duration
+func (f *timeoutFlag) Set(value string) error {
+	seconds, err := parseTimeout(value)
+	if err != nil {
+		return err
+	}
+	f.seconds = &seconds
+	return nil
+}
+
+// Type returns the type of the flag shown in its usage
+func (f *timeoutFlag) Type() string {
+	return "duration"
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/command"
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// newTestRoot creates a root command over a registry with the real commands, a recording "greet" built-in
+// command and the subcommands of the base directory
+func newTestRoot(t *testing.T, baseDir string) (*cobra.Command, *globalFlags, *[]string) {
+	t.Helper()
+
+	registry := command.NewRegistry(&config.Config{BaseDir: baseDir}, slog.New(slog.DiscardHandler))
+	command.RegisterCommands(registry)
+
+	var greeted []string
+	registry.RegisterFunc("greet", func(args []string) error {
+		greeted = append([]string{}, args...)
+		return nil
+	})
+
+	flags := &globalFlags{}
+	setups := 0
+	rootCmd := newRootCommand(registry, flags, func(cmd *cobra.Command) error {
+		setups++
+		if setups > 1 {
+			t.Errorf("setup ran %d times, want once", setups)
+		}
+		return nil
+	})
+	rootCmd.SetOut(&bytes.Buffer{})
+	return rootCmd, flags, &greeted
+}
+
+func TestRootCommand_Builtin(t *testing.T) {
+	rootCmd, flags, greeted := newTestRoot(t, t.TempDir())
+	rootCmd.SetArgs([]string{"--json", "greet", "--loud", "world"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	// The built-in command parses its own flags
+	if want := []string{"--loud", "world"}; !reflect.DeepEqual(*greeted, want) {
+		t.Errorf("greet got args %v, want %v", *greeted, want)
+	}
+	if !flags.json {
+		t.Error("--json before the command was not parsed as a global flag")
+	}
+}
+
+func TestRootCommand_Subcommand(t *testing.T) {
+	baseDir := t.TempDir()
+	output := filepath.Join(baseDir, "args")
+	script := "#!/bin/sh\necho \"$@\" > " + output + "\n"
+	if err := os.WriteFile(filepath.Join(baseDir, "mm-deploy"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create binary: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	rootCmd, flags, _ := newTestRoot(t, baseDir)
+	rootCmd.SetArgs([]string{"--timeout", "2m", "deploy", "--env", "prod", "--timeout", "5"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	// Flags after the command belong to the subcommand
+	got, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Subcommand did not run: %v", err)
+	}
+	if want := "--env prod --timeout 5\n"; string(got) != want {
+		t.Errorf("Subcommand got args %q, want %q", got, want)
+	}
+	if flags.timeout.seconds == nil || *flags.timeout.seconds != 120 {
+		t.Errorf("--timeout = %v, want 120 seconds", flags.timeout.String())
+	}
+}
+
+func TestRootCommand_OutputPipe(t *testing.T) {
+	baseDir := t.TempDir()
+	scripts := map[string]string{
+		"mm-hello":  "#!/bin/sh\necho \"hello $1\"\n",
+		"mm-prefix": "#!/bin/sh\nwhile read line; do echo \"$1 $line\"; done\n",
+	}
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(baseDir, name), []byte(script), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	t.Setenv("PATH", "")
+
+	registry := command.NewRegistry(&config.Config{BaseDir: baseDir}, slog.New(slog.DiscardHandler))
+	command.RegisterCommands(registry)
+	flags := &globalFlags{}
+	rootCmd := newRootCommand(registry, flags, func(cmd *cobra.Command) error {
+		output, err := flags.openOutput(&bytes.Buffer{})
+		if err != nil {
+			return err
+		}
+		registry.SetOutput(output)
+		return nil
+	})
+	rootCmd.SetOut(&bytes.Buffer{})
+	outputFile := filepath.Join(baseDir, "output.txt")
+	rootCmd.SetArgs([]string{"--output", outputFile, "pipe", "hello world | prefix >>"})
+
+	err := rootCmd.Execute()
+	if closeErr := flags.closeOutput(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got, err := os.ReadFile(outputFile); err != nil || string(got) != ">> hello world\n" {
+		t.Errorf("--output file = %q, %v, want the output of the pipeline", got, err)
+	}
+}
+
+func TestRootCommand_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"no command", nil, "no command specified"},
+		{"unknown global flag", []string{"--bogus", "greet"}, "unknown flag: --bogus"},
+		{"invalid timeout", []string{"--timeout", "soon", "greet"}, "invalid timeout 'soon'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootCmd, _, _ := newTestRoot(t, t.TempDir())
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Execute(%v) error = %v, want %q", tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRootCommand_Complete(t *testing.T) {
+	baseDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(baseDir, "mm-deploy"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("Failed to create binary: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"de"}, []string{"deploy"}},
+		{[]string{"gr"}, []string{"greet"}},
+		{[]string{"uninstall", ""}, []string{"deploy"}},
+		{[]string{"completion", "f"}, []string{"fish"}},
+	}
+
+	for _, tt := range tests {
+		rootCmd, _, _ := newTestRoot(t, baseDir)
+		output := &bytes.Buffer{}
+		rootCmd.SetOut(output)
+		rootCmd.SetArgs(append([]string{cobra.ShellCompNoDescRequestCmd}, tt.args...))
+
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("Execute(%v) error = %v", tt.args, err)
+		}
+
+		// The completions are followed by the completion directive
+		lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+		if got := lines[:len(lines)-1]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("completions of %q = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestHelpSummary(t *testing.T) {
+	tests := []struct {
+		help string
+		want string
+	}{
+		{"Usage: master-mold which [-v] <command>\n\nPrints the path of the binary. With -v, also prints\nwhere it was found.", "Prints the path of the binary."},
+		{"Usage: master-mold help\n\nShows the help of a command,\nor lists the commands.\n\nExamples follow.", "Shows the help of a command, or lists the commands."},
+		{"Usage: master-mold greet", ""},
+	}
+
+	for _, tt := range tests {
+		if got := helpSummary(tt.help); got != tt.want {
+			t.Errorf("helpSummary(%q) = %q, want %q", tt.help, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/keyring"
+	"github.com/pkg/errors"
+)
+
+// azureDevOpsPATSecret is the name of the keyring secret the setup wizard stores the Azure DevOps PAT as
+const azureDevOpsPATSecret = "azure-devops-pat"
+
+// setupWizard asks for the settings of a new configuration file
+type setupWizard struct {
+	input  *bufio.Reader
+	output io.Writer
+	// storeSecret stores a secret in the system keyring, under the master-mold service
+	storeSecret func(name, secret string) error
+	// hideInput stops echoing what is typed, returning a function that restores it, or nil when it is not possible
+	hideInput func() func()
+}
+
+// newSetupWizard creates a setup wizard on the terminal
+func newSetupWizard() *setupWizard {
+	return &setupWizard{
+		input:  bufio.NewReader(os.Stdin),
+		output: os.Stderr,
+		storeSecret: func(name, secret string) error {
+			return keyring.Set(config.KeyringService, name, secret)
+		},
+		hideInput: disableEcho,
+	}
+}
+
+// run offers to set up the configuration file; when the user declines, nothing is written and the defaults are
+// created as usual
+func (w *setupWizard) run(file string) error {
+	fmt.Fprintln(w.output, "No master-mold configuration found.")
+	setUp, err := w.confirm("Set up master-mold now?", true)
+	if err != nil || !setUp {
+		return err
+	}
+
+	defaults := config.DefaultConfig()
+	baseDir, err := w.ask("Base directory", defaults.BaseDir)
+	if err != nil {
+		return err
+	}
+	logLevel, err := w.askLogLevel()
+	if err != nil {
+		return err
+	}
+	settings := map[string]interface{}{
+		"base_dir":  baseDir,
+		"timeout":   defaults.Timeout,
+		"log_level": logLevel,
+	}
+
+	azureDevOps, err := w.askAzureDevOps()
+	if err != nil {
+		return err
+	}
+	if len(azureDevOps) > 0 {
+		settings["plugins"] = map[string]interface{}{"azure-devops": azureDevOps}
+	}
+
+	if err := config.CreateConfigFile(file, settings); err != nil {
+		return err
+	}
+	fmt.Fprintf(w.output, "Wrote %s; change it later with 'master-mold config'\n", file)
+	return nil
+}
+
+// askLogLevel asks for the minimum level of logs until a valid one is given
+func (w *setupWizard) askLogLevel() (string, error) {
+	for {
+		value, err := w.ask("Log level (debug, info, warn or error)", "info")
+		if err != nil {
+			return "", err
+		}
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(value)); err == nil {
+			return strings.ToLower(value), nil
+		}
+		fmt.Fprintf(w.output, "Invalid log level '%s'\n", value)
+	}
+}
+
+// askAzureDevOps asks for the settings of the azure-devops plugin, storing the PAT in the keyring so the
+// configuration only references it. It returns no settings when the user skips the plugin.
+func (w *setupWizard) askAzureDevOps() (map[string]interface{}, error) {
+	configure, err := w.confirm("Configure Azure DevOps?", false)
+	if err != nil || !configure {
+		return nil, err
+	}
+
+	settings := map[string]interface{}{}
+	for _, setting := range []struct{ key, prompt string }{
+		{"organization", "Azure DevOps organization"},
+		{"project", "Azure DevOps project"},
+	} {
+		value, err := w.ask(setting.prompt, "")
+		if err != nil {
+			return nil, err
+		}
+		if value != "" {
+			settings[setting.key] = value
+		}
+	}
+
+	pat, err := w.askSecret("Personal Access Token, stored in the system keyring (empty to skip)")
+	if err != nil || pat == "" {
+		return settings, err
+	}
+	if err := w.storeSecret(azureDevOpsPATSecret, pat); err != nil {
+		fmt.Fprintf(w.output, "Could not store the token in the keyring: %v\nRun 'master-mold azure-devops auth login' to store it later\n", err)
+		return settings, nil
+	}
+	settings["token"] = config.KeyringSecretPrefix + azureDevOpsPATSecret
+	return settings, nil
+}
+
+// ask asks for a value, returning the default when the answer is empty
+func (w *setupWizard) ask(prompt, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		fmt.Fprintf(w.output, "%s [%s]: ", prompt, defaultValue)
+	} else {
+		fmt.Fprintf(w.output, "%s: ", prompt)
+	}
+
+	answer, err := w.readLine()
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return defaultValue, nil
+	}
+	return answer, nil
+}
+
+// askSecret asks for a value without echoing it
+func (w *setupWizard) askSecret(prompt string) (string, error) {
+	fmt.Fprintf(w.output, "%s: ", prompt)
+	if restore := w.hideInput(); restore != nil {
+		defer func() {
+			restore()
+			fmt.Fprintln(w.output)
+		}()
+	}
+	return w.readLine()
+}
+
+// confirm asks a yes or no question
+func (w *setupWizard) confirm(prompt string, defaultYes bool) (bool, error) {
+	choices := "[y/N]"
+	if defaultYes {
+		choices = "[Y/n]"
+	}
+	fmt.Fprintf(w.output, "%s %s ", prompt, choices)
+
+	answer, err := w.readLine()
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "":
+		return defaultYes, nil
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+// readLine reads a line of input without its surrounding whitespace; the end of the input is an empty answer
+func (w *setupWizard) readLine() (string, error) {
+	line, err := w.input.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", errors.Wrap(err, "failed to read answer")
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// disableEcho turns off terminal echo with stty, returning a function that turns it back on, or nil when it is not possible
+func disableEcho() func() {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	stty := func(arg string) error {
+		cmd := exec.Command("stty", arg)
+		cmd.Stdin = os.Stdin
+		return cmd.Run()
+	}
+	if err := stty("-echo"); err != nil {
+		return nil
+	}
+	return func() {
+		stty("echo")
+	}
+}
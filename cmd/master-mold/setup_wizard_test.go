@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+// newTestWizard creates a setup wizard answering with the lines of input and storing secrets in a map
+func newTestWizard(input string, secrets map[string]string) (*setupWizard, *bytes.Buffer) {
+	output := &bytes.Buffer{}
+	return &setupWizard{
+		input:  bufio.NewReader(strings.NewReader(input)),
+		output: output,
+		storeSecret: func(name, secret string) error {
+			if secrets == nil {
+				return errors.New("no keyring")
+			}
+			secrets[name] = secret
+			return nil
+		},
+		hideInput: func() func() { return nil },
+	}, output
+}
+
+func TestSetupWizard_Run(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config", "config.toml")
+	secrets := map[string]string{}
+	wizard, _ := newTestWizard("\n/opt/mm\nloud\ndebug\ny\ncontoso\nweb\ns3cret\n", secrets)
+
+	if err := wizard.run(file); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	settings, err := config.ListSettings(file)
+	if err != nil {
+		t.Fatalf("ListSettings() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"base_dir":                          "/opt/mm",
+		"timeout":                           int64(0),
+		"log_level":                         "debug",
+		"plugins.azure-devops.organization": "contoso",
+		"plugins.azure-devops.project":      "web",
+		"plugins.azure-devops.token":        "keyring:azure-devops-pat",
+	}
+	if !reflect.DeepEqual(settings, want) {
+		t.Errorf("settings = %v, want %v", settings, want)
+	}
+	if secrets[azureDevOpsPATSecret] != "s3cret" {
+		t.Errorf("keyring = %v, want the PAT stored", secrets)
+	}
+}
+
+func TestSetupWizard_Run_Defaults(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.toml")
+
+	// Without a keyring the token is left out, never written in plain text
+	wizard, output := newTestWizard("y\n\n\ny\n\n\ns3cret\n", nil)
+	if err := wizard.run(file); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	settings, err := config.ListSettings(file)
+	if err != nil {
+		t.Fatalf("ListSettings() error = %v", err)
+	}
+	want := map[string]interface{}{"base_dir": "${HOME}/.master-mold", "timeout": int64(0), "log_level": "info"}
+	if !reflect.DeepEqual(settings, want) {
+		t.Errorf("settings = %v, want %v", settings, want)
+	}
+	if !strings.Contains(output.String(), "auth login") {
+		t.Errorf("output = %q, want it to explain how to store the token", output.String())
+	}
+}
+
+func TestSetupWizard_Run_Declined(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.toml")
+	wizard, _ := newTestWizard("n\n", nil)
+
+	if err := wizard.run(file); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("run() wrote %s after being declined", file)
+	}
+}
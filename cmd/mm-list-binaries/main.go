@@ -1,36 +1,88 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"log/slog"
 
 	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/oscarrieken/master-mold/pkg/describe"
 	"github.com/oscarrieken/master-mold/pkg/display"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/oscarrieken/master-mold/pkg/logging"
+	"github.com/spf13/pflag"
 )
 
-// initLogger initializes the logger
+// describeTimeout limits how long a binary may take to describe itself
+const describeTimeout = 5 * time.Second
+
+// version is the version of the subcommand, set at build time with -ldflags "-X main.version=<version>"
+var version = "dev"
+
+// initLogger initializes the logger from the global flags and log settings of master-mold
 func initLogger() *slog.Logger {
-	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-	}))
+	return logging.New()
 }
 
-// findBinaries finds all master-mold binaries
-func findBinaries(logger *slog.Logger) ([]string, error) {
-	// Get the home directory
+// parseFlags parses the flags of the subcommand; --json defaults to the global --json flag of master-mold
+func parseFlags(args []string, output io.Writer) (jsonOutput bool, err error) {
+	flags := pflag.NewFlagSet("mm-list-binaries", pflag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.BoolVar(&jsonOutput, "json", env.JSONOutput(), "Print the binaries in JSON format")
+	err = flags.Parse(args)
+	return jsonOutput, err
+}
+
+// masterMoldDir gets the master-mold directory in the home directory
+func masterMoldDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get home directory: %w", err)
+		return "", fmt.Errorf("failed to get home directory: %w", err)
 	}
+	return filepath.Join(homeDir, ".master-mold"), nil
+}
 
+// findBinaries finds all master-mold binaries
+func findBinaries(logger *slog.Logger) ([]string, error) {
 	// Add the master-mold directory to the search paths
-	masterMoldDir := filepath.Join(homeDir, ".master-mold")
+	dir, err := masterMoldDir()
+	if err != nil {
+		return nil, err
+	}
 
 	// Find all binaries
-	return binary.FindAll(masterMoldDir)
+	return binary.FindAll(dir)
+}
+
+// describeBinaries fills in where the binaries were found, and the versions and descriptions of those that
+// implement the describe protocol, cached like master-mold does
+func describeBinaries(binaries []display.BinaryInfo) []display.BinaryInfo {
+	dir, err := masterMoldDir()
+	if err != nil {
+		return binaries
+	}
+	binaries = display.SetSources(binaries, dir)
+
+	paths := make([]string, len(binaries))
+	for i, info := range binaries {
+		paths[i] = info.FullPath
+	}
+	cache := describe.LoadCache(filepath.Join(dir, "cache", "describe.json"), describeTimeout)
+	descriptions := cache.DescribeAll(paths)
+	cache.Save()
+
+	for i, info := range binaries {
+		if description, ok := descriptions[info.FullPath]; ok {
+			binaries[i].Version = description.Version
+			binaries[i].Description = description.Description
+		}
+	}
+	return binaries
 }
 
 // checkIfRunningAsSubcommand checks if we're running as a subcommand of master-mold
@@ -49,6 +101,29 @@ func checkIfRunningAsSubcommand(logger *slog.Logger) {
 }
 
 func main() {
+	// Print the version for 'master-mold version'
+	if len(os.Args) > 1 && os.Args[1] == "--version" {
+		fmt.Printf("mm-list-binaries version %s\n", version)
+		return
+	}
+
+	// Describe the subcommand to master-mold
+	if describe.Handle(os.Args[1:], describe.Description{
+		Name:        "list-binaries",
+		Version:     version,
+		Description: "List the available master-mold subcommands",
+	}) {
+		return
+	}
+
+	jsonOutput, err := parseFlags(os.Args[1:], os.Stderr)
+	if errors.Is(err, pflag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		os.Exit(2)
+	}
+
 	// Initialize the logger
 	logger := initLogger()
 	logger.Info("Running mm-list-binaries subcommand")
@@ -60,11 +135,19 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Display the binaries
-	display.PrintBinaryPaths(binaries)
+	// Display the binaries, in JSON format when requested
+	if jsonOutput {
+		if err := display.PrintBinariesJSON(describeBinaries(display.ProcessBinaries(binaries))); err != nil {
+			logger.Error("Failed to print binaries", "error", err)
+			os.Exit(1)
+		}
+	} else {
+		dir, _ := masterMoldDir()
+		display.PrintBinaries(display.SetSources(display.ProcessBinaries(binaries), dir))
 
-	// Check if we're running as a subcommand
-	checkIfRunningAsSubcommand(logger)
+		// Check if we're running as a subcommand
+		checkIfRunningAsSubcommand(logger)
+	}
 
 	logger.Info("mm-list-binaries completed successfully")
 }
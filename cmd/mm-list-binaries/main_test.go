@@ -2,12 +2,15 @@ package main
 
 import (
 	"bytes"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"log/slog"
+
+	"github.com/oscarrieken/master-mold/pkg/env"
 )
 
 func TestInitLogger(t *testing.T) {
@@ -130,4 +133,31 @@ func TestCheckIfRunningAsSubcommand(t *testing.T) {
 	if !strings.Contains(output, "Running as a") {
 		t.Errorf("checkIfRunningAsSubcommand() did not print expected output: %s", output)
 	}
-}
\ No newline at end of file
+}
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		global  string
+		want    bool
+		wantErr bool
+	}{
+		{name: "text", args: nil, want: false},
+		{name: "json flag", args: []string{"--json"}, want: true},
+		{name: "global json flag", global: env.OutputJSON, want: true},
+		{name: "json flag turned off", args: []string{"--json=false"}, global: env.OutputJSON, want: false},
+		{name: "unknown flag", args: []string{"--jsn"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(env.Output, tt.global)
+			got, err := parseFlags(tt.args, io.Discard)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFlags(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseFlags(%v) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
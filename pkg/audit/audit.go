@@ -0,0 +1,114 @@
+// Package audit records the commands master-mold runs in an append-only log, one JSON object per line, with who
+// ran them, for shared machines and compliance. Unlike the history, the log is never truncated.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/history"
+	"github.com/pkg/errors"
+)
+
+// maxLineSize is the size of the longest entry read back from the log
+const maxLineSize = 1 << 20
+
+// Entry is a command run with master-mold
+type Entry struct {
+	Time time.Time `json:"time"`
+	// User is the name of the user who ran the command
+	User string `json:"user"`
+	Host string `json:"host,omitempty"`
+	// Command is the command as typed, before aliases are expanded
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+	// DurationMS is how long the command ran, in milliseconds
+	DurationMS int64 `json:"duration_ms"`
+	ExitCode   int   `json:"exit_code"`
+}
+
+// Line gets the command line of the entry, quoting the arguments that need it
+func (e Entry) Line() string {
+	return history.CommandLine(e.Command, e.Args)
+}
+
+// Duration gets how long the command ran
+func (e Entry) Duration() time.Duration {
+	return time.Duration(e.DurationMS) * time.Millisecond
+}
+
+// Matches checks if the user, the host or the command line of the entry contains a term, ignoring case
+func (e Entry) Matches(term string) bool {
+	term = strings.ToLower(term)
+	for _, field := range []string{e.User, e.Host, e.Line()} {
+		if strings.Contains(strings.ToLower(field), term) {
+			return true
+		}
+	}
+	return false
+}
+
+// CurrentUser gets the name of the user running master-mold, from the system or else the environment
+func CurrentUser() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
+
+// Append adds an entry at the end of an audit log, creating it and its directory when needed. The log is only
+// ever appended to.
+func Append(path string, entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the audit entry")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "failed to create the audit log directory")
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the audit log")
+	}
+	_, err = file.Write(append(data, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to write the audit log")
+	}
+	return nil
+}
+
+// Load reads the entries of an audit log, oldest first. A missing log is empty, and lines that can't be read are
+// skipped.
+func Load(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the audit log")
+	}
+
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil && entry.Command != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "audit.jsonl")
+	for _, entry := range []Entry{{User: "alice", Command: "deploy", Args: []string{"--env", "prod"}}, {User: "bob", Host: "build-01", Command: "lint", ExitCode: 1}} {
+		if err := Append(path, entry); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat the audit log: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("audit log permissions = %v, want 0600", info.Mode().Perm())
+	}
+
+	entries, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Line() != "deploy --env prod" || entries[1].ExitCode != 1 {
+		t.Fatalf("Load() = %+v, want both entries", entries)
+	}
+
+	for term, want := range map[string]bool{"ALICE": true, "build-01": true, "prod": true, "carol": false} {
+		if got := entries[0].Matches(term) || entries[1].Matches(term); got != want {
+			t.Errorf("Matches(%s) = %v, want %v", term, got, want)
+		}
+	}
+}
+
+func TestLoad_Missing(t *testing.T) {
+	entries, err := Load(filepath.Join(t.TempDir(), "audit.jsonl"))
+	if err != nil || entries != nil {
+		t.Errorf("Load() = %v, %v, want an empty log", entries, err)
+	}
+}
@@ -0,0 +1,204 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/keyring"
+	"github.com/pkg/errors"
+)
+
+// Azure AD settings of the device-code flow
+const (
+	// azureDevOpsResource is the application ID of Azure DevOps in Azure AD
+	azureDevOpsResource = "499b84ac-1321-427f-aa17-267ca6975798"
+	// azureDevOpsScope requests access to Azure DevOps, plus a refresh token
+	azureDevOpsScope = azureDevOpsResource + "/.default offline_access"
+	// defaultAADClientID is the public client ID of the Azure CLI, which is pre-authorized for Azure DevOps
+	defaultAADClientID = "04b07795-8ddb-461a-bbee-02f9e1bf7b46"
+	// defaultAADTenant accepts any work or school account
+	defaultAADTenant = "organizations"
+	// aadCredentialType marks keyring entries holding Azure AD tokens rather than PATs
+	aadCredentialType = "aad"
+	// tokenExpirySkew is how long before its expiry an access token is refreshed
+	tokenExpirySkew = 5 * time.Minute
+)
+
+// aadAuthority is the Azure AD endpoint; it is a variable so tests can point it at a local server
+var aadAuthority = "https://login.microsoftonline.com"
+
+// aadCredential holds Azure AD tokens stored in the keyring
+type aadCredential struct {
+	Type         string    `json:"type"`
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	Tenant       string    `json:"tenant"`
+	ClientID     string    `json:"clientId"`
+}
+
+// deviceCode is the response of the device authorization endpoint
+type deviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+	Message         string `json:"message"`
+}
+
+// tokenResponse is the response of the token endpoint
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// aadEndpoint builds the URL of an OAuth 2.0 endpoint of a tenant
+func aadEndpoint(tenant string, endpoint string) string {
+	return fmt.Sprintf("%s/%s/oauth2/v2.0/%s", strings.TrimSuffix(aadAuthority, "/"), url.PathEscape(tenant), endpoint)
+}
+
+// postAADForm posts a form to an OAuth 2.0 endpoint and decodes the JSON response into v
+func postAADForm(endpoint string, form url.Values, v interface{}) error {
+	request, err := http.NewRequestWithContext(commandContext, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return errors.Wrap(err, "failed to create Azure AD request")
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return errors.Wrap(err, "failed to contact Azure AD")
+	}
+	defer response.Body.Close()
+
+	if err := json.NewDecoder(response.Body).Decode(v); err != nil {
+		return errors.Wrapf(err, "failed to read Azure AD response (%s)", response.Status)
+	}
+	return nil
+}
+
+// requestDeviceCode starts the device-code flow
+func requestDeviceCode(tenant string, clientID string) (*deviceCode, error) {
+	var result struct {
+		deviceCode
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	err := postAADForm(aadEndpoint(tenant, "devicecode"), url.Values{
+		"client_id": {clientID},
+		"scope":     {azureDevOpsScope},
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != "" {
+		return nil, errors.Errorf("device code request failed: %s: %s", result.Error, result.ErrorDescription)
+	}
+	return &result.deviceCode, nil
+}
+
+// pollDeviceCodeToken waits until the user has signed in with the device code and returns the issued tokens
+func pollDeviceCodeToken(tenant string, clientID string, code *deviceCode) (*aadCredential, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	for {
+		var response tokenResponse
+		err := postAADForm(aadEndpoint(tenant, "token"), url.Values{
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+			"client_id":   {clientID},
+			"device_code": {code.DeviceCode},
+		}, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		switch response.Error {
+		case "":
+			return newAADCredential(tenant, clientID, response, time.Now()), nil
+		case "authorization_pending":
+			// The user has not signed in yet
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return nil, errors.Errorf("sign-in failed: %s: %s", response.Error, response.ErrorDescription)
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return nil, errors.New("the device code expired before sign-in completed")
+		}
+		if err := sleepContext(commandContext, interval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// refreshAADCredential gets a new access token with the refresh token of a credential
+func refreshAADCredential(credential *aadCredential) (*aadCredential, error) {
+	var response tokenResponse
+	err := postAADForm(aadEndpoint(credential.Tenant, "token"), url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {credential.ClientID},
+		"refresh_token": {credential.RefreshToken},
+		"scope":         {azureDevOpsScope},
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	if response.Error != "" {
+		return nil, errors.Errorf("token refresh failed: %s: %s", response.Error, response.ErrorDescription)
+	}
+
+	refreshed := newAADCredential(credential.Tenant, credential.ClientID, response, time.Now())
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = credential.RefreshToken
+	}
+	return refreshed, nil
+}
+
+// newAADCredential creates a credential from a token response received at the given time
+func newAADCredential(tenant string, clientID string, response tokenResponse, received time.Time) *aadCredential {
+	return &aadCredential{
+		Type:         aadCredentialType,
+		AccessToken:  response.AccessToken,
+		RefreshToken: response.RefreshToken,
+		ExpiresAt:    received.Add(time.Duration(response.ExpiresIn) * time.Second),
+		Tenant:       tenant,
+		ClientID:     clientID,
+	}
+}
+
+// parseAADCredential parses a keyring entry holding Azure AD tokens; it reports false for other entries such as PATs
+func parseAADCredential(secret string) (*aadCredential, bool) {
+	if !strings.HasPrefix(secret, "{") {
+		return nil, false
+	}
+
+	var credential aadCredential
+	if err := json.Unmarshal([]byte(secret), &credential); err != nil || credential.Type != aadCredentialType {
+		return nil, false
+	}
+	return &credential, true
+}
+
+// needsRefresh checks if the access token of a credential has expired or is about to
+func (credential *aadCredential) needsRefresh(now time.Time) bool {
+	return now.Add(tokenExpirySkew).After(credential.ExpiresAt)
+}
+
+// storeAADCredential stores Azure AD tokens in the keyring for an organization
+func storeAADCredential(organization string, credential *aadCredential) error {
+	data, err := json.Marshal(credential)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal credential")
+	}
+	return keyring.Set(keyringService, organization, string(data))
+}
@@ -0,0 +1,133 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// useAADServer points the Azure AD endpoints at a local server for the duration of a test
+func useAADServer(t *testing.T, handler http.HandlerFunc) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	previous := aadAuthority
+	aadAuthority = server.URL
+	t.Cleanup(func() { aadAuthority = previous })
+}
+
+func TestDeviceCodeFlow(t *testing.T) {
+	polls := 0
+	useAADServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+		if got := r.PostForm.Get("client_id"); got != "client" {
+			t.Errorf("client_id = %s, want client", got)
+		}
+
+		switch r.URL.Path {
+		case "/contoso/oauth2/v2.0/devicecode":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"device_code": "device-123",
+				"user_code":   "ABCD-EFGH",
+				"expires_in":  900,
+				"interval":    0,
+				"message":     "To sign in, enter ABCD-EFGH",
+			})
+		case "/contoso/oauth2/v2.0/token":
+			if got := r.PostForm.Get("device_code"); got != "device-123" {
+				t.Errorf("device_code = %s, want device-123", got)
+			}
+			polls++
+			if polls < 2 {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": "authorization_pending"})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token":  "access",
+				"refresh_token": "refresh",
+				"expires_in":    3600,
+			})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	code, err := requestDeviceCode("contoso", "client")
+	if err != nil {
+		t.Fatalf("requestDeviceCode() error = %v", err)
+	}
+	if code.UserCode != "ABCD-EFGH" || code.Message == "" {
+		t.Errorf("requestDeviceCode() = %+v", code)
+	}
+
+	credential, err := pollDeviceCodeToken("contoso", "client", code)
+	if err != nil {
+		t.Fatalf("pollDeviceCodeToken() error = %v", err)
+	}
+	if polls != 2 {
+		t.Errorf("token endpoint polled %d times, want 2", polls)
+	}
+	if credential.AccessToken != "access" || credential.RefreshToken != "refresh" || credential.Tenant != "contoso" || credential.ClientID != "client" {
+		t.Errorf("pollDeviceCodeToken() = %+v", credential)
+	}
+	if time.Until(credential.ExpiresAt) < 59*time.Minute {
+		t.Errorf("ExpiresAt = %v, want about an hour from now", credential.ExpiresAt)
+	}
+}
+
+func TestPollDeviceCodeTokenDeclined(t *testing.T) {
+	useAADServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "authorization_declined", "error_description": "The user declined"})
+	})
+
+	if _, err := pollDeviceCodeToken("contoso", "client", &deviceCode{DeviceCode: "device-123", ExpiresIn: 900}); err == nil {
+		t.Error("pollDeviceCodeToken() error = nil, want an error")
+	}
+}
+
+func TestRefreshAADCredential(t *testing.T) {
+	useAADServer(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("grant_type") != "refresh_token" || r.PostForm.Get("refresh_token") != "old-refresh" {
+			t.Errorf("unexpected refresh request: %v", r.PostForm)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "new-access", "expires_in": 3600})
+	})
+
+	refreshed, err := refreshAADCredential(&aadCredential{Type: aadCredentialType, RefreshToken: "old-refresh", Tenant: "contoso", ClientID: "client"})
+	if err != nil {
+		t.Fatalf("refreshAADCredential() error = %v", err)
+	}
+	if refreshed.AccessToken != "new-access" || refreshed.RefreshToken != "old-refresh" {
+		t.Errorf("refreshAADCredential() = %+v, want the new access token and the old refresh token", refreshed)
+	}
+}
+
+func TestParseAADCredential(t *testing.T) {
+	data, _ := json.Marshal(aadCredential{Type: aadCredentialType, AccessToken: "access"})
+
+	if credential, ok := parseAADCredential(string(data)); !ok || credential.AccessToken != "access" {
+		t.Errorf("parseAADCredential() = %+v, %v, want the credential", credential, ok)
+	}
+	for _, secret := range []string{"plain-pat", `{"type":"other"}`, "{invalid"} {
+		if _, ok := parseAADCredential(secret); ok {
+			t.Errorf("parseAADCredential(%s) = true, want false", secret)
+		}
+	}
+}
+
+func TestNeedsRefresh(t *testing.T) {
+	now := time.Now()
+	if (&aadCredential{ExpiresAt: now.Add(time.Hour)}).needsRefresh(now) {
+		t.Error("needsRefresh() = true for a token valid for an hour")
+	}
+	if !(&aadCredential{ExpiresAt: now.Add(time.Minute)}).needsRefresh(now) {
+		t.Error("needsRefresh() = false for a token expiring within the skew")
+	}
+}
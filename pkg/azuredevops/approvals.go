@@ -0,0 +1,284 @@
+package azuredevops
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// approvalsAPIVersion is the REST API version used for pipeline approvals
+const approvalsAPIVersion = "7.1-preview.1"
+
+// Statuses of a pipeline approval
+const (
+	approvalStatusPending  = "pending"
+	approvalStatusApproved = "approved"
+	approvalStatusRejected = "rejected"
+)
+
+// PipelineApproval represents an approval a pipeline run is waiting for
+type PipelineApproval struct {
+	ID           string    `json:"id"`
+	Pipeline     string    `json:"pipeline"`
+	Run          string    `json:"run"`
+	Status       string    `json:"status"`
+	Instructions string    `json:"instructions,omitempty"`
+	Created      time.Time `json:"created"`
+	URL          string    `json:"url"`
+}
+
+// approval is a pipeline approval as returned by the REST API, which the SDK does not support
+type approval struct {
+	ID           string            `json:"id"`
+	Status       string            `json:"status"`
+	Instructions string            `json:"instructions"`
+	CreatedOn    *azuredevops.Time `json:"createdOn"`
+	Pipeline     *struct {
+		Name  string `json:"name"`
+		Owner *struct {
+			Name  string      `json:"name"`
+			Links interface{} `json:"_links"`
+		} `json:"owner"`
+	} `json:"pipeline"`
+}
+
+// approvalUpdate is the request body entry used to approve or reject an approval
+type approvalUpdate struct {
+	ApprovalID string `json:"approvalId"`
+	Status     string `json:"status"`
+	Comment    string `json:"comment,omitempty"`
+}
+
+// listApprovalsCommand lists the pending pipeline approvals assigned to the authenticated user
+func listApprovalsCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing pipeline approvals")
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the approvals
+	approvals, err := getPendingApprovals()
+	if err != nil {
+		return handleError("Failed to get pipeline approvals", err)
+	}
+
+	// Print the approvals
+	if jsonOutput {
+		printApprovalsAsJSON(approvals)
+	} else {
+		printApprovalsAsText(approvals)
+	}
+
+	logger.Info("Pipeline approvals listed successfully")
+	return nil
+}
+
+// approveApprovalCommand approves a pending pipeline approval
+func approveApprovalCommand(cmd *cobra.Command, args []string) error {
+	return decideApprovalCommand(cmd, args[0], approvalStatusApproved)
+}
+
+// rejectApprovalCommand rejects a pending pipeline approval
+func rejectApprovalCommand(cmd *cobra.Command, args []string) error {
+	return decideApprovalCommand(cmd, args[0], approvalStatusRejected)
+}
+
+// decideApprovalCommand approves or rejects a pending pipeline approval
+func decideApprovalCommand(cmd *cobra.Command, id string, status string) error {
+	logger.Info("Updating pipeline approval", "approval", id, "status", status)
+
+	// Get the comment
+	comment, err := cmd.Flags().GetString("comment")
+	if err != nil {
+		return handleError("Failed to get comment flag", err)
+	}
+
+	// Update the approval
+	updated, err := updateApproval(id, status, comment)
+	if err != nil {
+		return handleError("Failed to update pipeline approval", err)
+	}
+
+	fmt.Printf("Approval %s of %s is now %s\n", updated.ID, describeApprovalRun(updated), updated.Status)
+
+	logger.Info("Pipeline approval updated successfully", "approval", id, "status", updated.Status)
+	return nil
+}
+
+// approvalsURL builds the URL of the pipeline approvals REST resource of a project
+func approvalsURL(baseURL string, project string, query url.Values) string {
+	result := fmt.Sprintf("%s/%s/_apis/pipelines/approvals", strings.TrimSuffix(baseURL, "/"), url.PathEscape(project))
+	if len(query) > 0 {
+		result += "?" + query.Encode()
+	}
+	return result
+}
+
+// sendApprovalsRequest sends a request to the pipeline approvals REST resource and reads the approvals it returns
+func sendApprovalsRequest(connectionDetails *ConnectionDetails, method string, query url.Values, body []byte) ([]approval, error) {
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+	client := connection.GetClientByUrl(connection.BaseUrl)
+
+	var reader io.Reader
+	mediaType := ""
+	if body != nil {
+		reader = bytes.NewReader(body)
+		mediaType = "application/json"
+	}
+	request, err := client.CreateRequestMessage(commandContext, method, approvalsURL(connection.BaseUrl, connectionDetails.Project, query), approvalsAPIVersion, reader, mediaType, "application/json", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create approvals request")
+	}
+
+	response, err := client.SendRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var approvals []approval
+	if err := client.UnmarshalCollectionBody(response, &approvals); err != nil {
+		return nil, errors.Wrap(err, "failed to read approvals")
+	}
+	return approvals, nil
+}
+
+// getPendingApprovals gets the pending pipeline approvals assigned to the authenticated user
+func getPendingApprovals() ([]PipelineApproval, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the authenticated user
+	user, err := getAuthenticatedUser(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("state", approvalStatusPending)
+	query.Set("userIds", user.Id.String())
+	approvals, err := sendApprovalsRequest(connectionDetails, http.MethodGet, query, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query approvals")
+	}
+
+	result := []PipelineApproval{}
+	for _, a := range approvals {
+		result = append(result, convertApproval(a))
+	}
+	return result, nil
+}
+
+// updateApproval sets the status of a pipeline approval
+func updateApproval(id string, status string, comment string) (*PipelineApproval, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal([]approvalUpdate{{ApprovalID: id, Status: status, Comment: comment}})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal approval update")
+	}
+
+	approvals, err := sendApprovalsRequest(connectionDetails, http.MethodPatch, nil, body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update approval %s", id)
+	}
+	if len(approvals) == 0 {
+		return nil, errors.Errorf("approval %s was not returned after the update", id)
+	}
+
+	result := convertApproval(approvals[0])
+	return &result, nil
+}
+
+// convertApproval converts a REST approval to our model
+func convertApproval(a approval) PipelineApproval {
+	result := PipelineApproval{
+		ID:           a.ID,
+		Status:       a.Status,
+		Instructions: a.Instructions,
+	}
+	if a.CreatedOn != nil {
+		result.Created = a.CreatedOn.Time
+	}
+	if a.Pipeline != nil {
+		result.Pipeline = a.Pipeline.Name
+		if a.Pipeline.Owner != nil {
+			result.Run = a.Pipeline.Owner.Name
+			result.URL = linkHref(a.Pipeline.Owner.Links, "web")
+		}
+	}
+	return result
+}
+
+// describeApprovalRun describes the pipeline run an approval belongs to
+func describeApprovalRun(a *PipelineApproval) string {
+	switch {
+	case a.Pipeline != "" && a.Run != "":
+		return fmt.Sprintf("%s (run %s)", a.Pipeline, a.Run)
+	case a.Pipeline != "":
+		return a.Pipeline
+	default:
+		return "the pipeline run"
+	}
+}
+
+// printApprovalsAsText prints pipeline approvals in a human-readable format
+func printApprovalsAsText(approvals []PipelineApproval) {
+	if len(approvals) == 0 {
+		fmt.Println("No pending approvals found.")
+		return
+	}
+
+	fmt.Printf("Found %d pending approvals:\n\n", len(approvals))
+
+	for _, a := range approvals {
+		fmt.Printf("ID: %s\n", a.ID)
+		fmt.Printf("Pipeline: %s\n", a.Pipeline)
+		if a.Run != "" {
+			fmt.Printf("Run: %s\n", a.Run)
+		}
+		if !a.Created.IsZero() {
+			fmt.Printf("Requested: %s ago\n", formatAge(time.Since(a.Created)))
+		}
+		if a.Instructions != "" {
+			fmt.Printf("Instructions: %s\n", a.Instructions)
+		}
+		if a.URL != "" {
+			fmt.Printf("URL: %s\n", a.URL)
+		}
+		fmt.Println()
+	}
+}
+
+// printApprovalsAsJSON prints pipeline approvals in JSON format
+func printApprovalsAsJSON(approvals []PipelineApproval) {
+	// Marshal the approvals to JSON with indentation
+	jsonData, err := json.MarshalIndent(approvals, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal approvals to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal approvals to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
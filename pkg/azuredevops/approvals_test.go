@@ -0,0 +1,80 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestApprovalsURL(t *testing.T) {
+	query := url.Values{}
+	query.Set("state", "pending")
+
+	got := approvalsURL("https://dev.azure.com/myorg/", "My Project", query)
+	want := "https://dev.azure.com/myorg/My%20Project/_apis/pipelines/approvals?state=pending"
+	if got != want {
+		t.Errorf("approvalsURL() = %s, want %s", got, want)
+	}
+
+	got = approvalsURL("https://dev.azure.com/myorg", "web", nil)
+	want = "https://dev.azure.com/myorg/web/_apis/pipelines/approvals"
+	if got != want {
+		t.Errorf("approvalsURL() = %s, want %s", got, want)
+	}
+}
+
+func TestConvertApproval(t *testing.T) {
+	body := `{
+		"id": "7b1f0a2c-2e4f-4f53-9b35-1d5c3e8a9f10",
+		"status": "pending",
+		"instructions": "Check the release notes",
+		"createdOn": "2024-06-03T10:00:00Z",
+		"pipeline": {
+			"name": "deploy",
+			"owner": {
+				"name": "20240603.1",
+				"_links": {"web": {"href": "https://dev.azure.com/myorg/web/_build/results?buildId=12"}}
+			}
+		}
+	}`
+	var a approval
+	if err := json.Unmarshal([]byte(body), &a); err != nil {
+		t.Fatalf("failed to unmarshal approval: %v", err)
+	}
+
+	got := convertApproval(a)
+	want := PipelineApproval{
+		ID:           "7b1f0a2c-2e4f-4f53-9b35-1d5c3e8a9f10",
+		Pipeline:     "deploy",
+		Run:          "20240603.1",
+		Status:       "pending",
+		Instructions: "Check the release notes",
+		Created:      time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC),
+		URL:          "https://dev.azure.com/myorg/web/_build/results?buildId=12",
+	}
+	if !got.Created.Equal(want.Created) {
+		t.Errorf("convertApproval().Created = %v, want %v", got.Created, want.Created)
+	}
+	got.Created = want.Created
+	if got != want {
+		t.Errorf("convertApproval() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDescribeApprovalRun(t *testing.T) {
+	tests := []struct {
+		approval PipelineApproval
+		want     string
+	}{
+		{PipelineApproval{Pipeline: "deploy", Run: "20240603.1"}, "deploy (run 20240603.1)"},
+		{PipelineApproval{Pipeline: "deploy"}, "deploy"},
+		{PipelineApproval{}, "the pipeline run"},
+	}
+
+	for _, tt := range tests {
+		if got := describeApprovalRun(&tt.approval); got != tt.want {
+			t.Errorf("describeApprovalRun(%+v) = %s, want %s", tt.approval, got, tt.want)
+		}
+	}
+}
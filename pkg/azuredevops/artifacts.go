@@ -0,0 +1,519 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/feed"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/npm"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/nuget"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// packagePageSize is the number of packages requested per page
+const packagePageSize = 100
+
+// Package protocol types that can be downloaded
+const (
+	protocolNuGet = "NuGet"
+	protocolNpm   = "Npm"
+)
+
+// Feed represents an Azure Artifacts feed
+type Feed struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Project     string `json:"project,omitempty"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+}
+
+// ArtifactPackage represents a package in an Azure Artifacts feed
+type ArtifactPackage struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Type      string     `json:"type"`
+	Version   string     `json:"version"`
+	Published *time.Time `json:"published,omitempty"`
+}
+
+// listFeedsCommand lists the Azure Artifacts feeds of the organization and project
+func listFeedsCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing feeds")
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the feeds
+	feeds, err := getFeeds()
+	if err != nil {
+		return handleError("Failed to get feeds", err)
+	}
+
+	// Print the feeds
+	if jsonOutput {
+		printFeedsAsJSON(feeds)
+	} else {
+		printFeedsAsText(feeds)
+	}
+
+	logger.Info("Feeds listed successfully")
+	return nil
+}
+
+// listPackagesCommand lists the packages of a feed
+func listPackagesCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing packages", "feed", args[0])
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the package filters
+	query, err := cmd.Flags().GetString("query")
+	if err != nil {
+		return handleError("Failed to get query flag", err)
+	}
+	protocolType, err := cmd.Flags().GetString("type")
+	if err != nil {
+		return handleError("Failed to get type flag", err)
+	}
+
+	// Get the packages
+	packages, err := getPackages(args[0], query, protocolType)
+	if err != nil {
+		return handleError("Failed to get packages", err)
+	}
+
+	// Print the packages
+	if jsonOutput {
+		printPackagesAsJSON(packages)
+	} else {
+		printPackagesAsText(packages)
+	}
+
+	logger.Info("Packages listed successfully", "feed", args[0])
+	return nil
+}
+
+// downloadPackageCommand downloads a version of a package from a feed
+func downloadPackageCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Downloading package", "feed", args[0], "package", args[1], "version", args[2])
+
+	// Get the output file
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return handleError("Failed to get output flag", err)
+	}
+
+	// Download the package
+	path, err := downloadPackage(args[0], args[1], args[2], output)
+	if err != nil {
+		return handleError("Failed to download package", err)
+	}
+
+	fmt.Printf("Downloaded %s %s to %s\n", args[1], args[2], path)
+
+	logger.Info("Package downloaded successfully", "package", args[1], "version", args[2], "path", path)
+	return nil
+}
+
+// createArtifactsConnection creates a connection to Azure DevOps for the packaging clients
+func createArtifactsConnection(connectionDetails *ConnectionDetails) *azuredevops.Connection {
+	return newConnection(connectionDetails)
+}
+
+// getProjectAndOrganizationFeeds gets the organization-scoped feeds and the feeds of the project
+func getProjectAndOrganizationFeeds(client feed.Client, project string) ([]feed.Feed, error) {
+	organizationFeeds, err := client.GetFeeds(commandContext, feed.GetFeedsArgs{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get organization feeds")
+	}
+
+	projectFeeds, err := client.GetFeeds(commandContext, feed.GetFeedsArgs{
+		Project: &project,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get project feeds")
+	}
+
+	return append(*organizationFeeds, *projectFeeds...), nil
+}
+
+// getFeeds gets the feeds of the organization and the project
+func getFeeds() ([]Feed, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Feed API
+	client, err := feed.NewClient(commandContext, createArtifactsConnection(connectionDetails))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Feed client")
+	}
+
+	feeds, err := getProjectAndOrganizationFeeds(client, connectionDetails.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []Feed{}
+	for _, f := range feeds {
+		result = append(result, convertFeed(f))
+	}
+	return result, nil
+}
+
+// findFeed finds a feed by name or ID, ignoring case
+func findFeed(feeds []feed.Feed, nameOrID string) (feed.Feed, error) {
+	for _, f := range feeds {
+		if strings.EqualFold(stringValue(f.Name), nameOrID) || (f.Id != nil && strings.EqualFold(f.Id.String(), nameOrID)) {
+			return f, nil
+		}
+	}
+	return feed.Feed{}, errors.Errorf("no feed named '%s'", nameOrID)
+}
+
+// feedProject gets the project a feed must be addressed through, or nil for organization-scoped feeds
+func feedProject(f feed.Feed) *string {
+	if f.Project == nil || f.Project.Name == nil {
+		return nil
+	}
+	return f.Project.Name
+}
+
+// resolveFeed looks up a feed of the organization or the project by name or ID
+func resolveFeed(client feed.Client, project, nameOrID string) (feed.Feed, error) {
+	feeds, err := getProjectAndOrganizationFeeds(client, project)
+	if err != nil {
+		return feed.Feed{}, err
+	}
+
+	f, err := findFeed(feeds, nameOrID)
+	if err != nil {
+		return feed.Feed{}, err
+	}
+	if f.Id == nil {
+		return feed.Feed{}, errors.Errorf("feed '%s' has no ID", nameOrID)
+	}
+	return f, nil
+}
+
+// listFeedPackages gets the packages of a feed matching the query and protocol type, one page at a time
+func listFeedPackages(client feed.Client, f feed.Feed, query, protocolType string) ([]feed.Package, error) {
+	feedID := f.Id.String()
+	args := feed.GetPackagesArgs{
+		FeedId:  &feedID,
+		Project: feedProject(f),
+	}
+	if query != "" {
+		args.PackageNameQuery = &query
+	}
+	if protocolType != "" {
+		args.ProtocolType = &protocolType
+	}
+
+	var result []feed.Package
+	top := packagePageSize
+	for skip := 0; ; skip += top {
+		args.Top = &top
+		args.Skip = &skip
+		packages, err := client.GetPackages(commandContext, args)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get packages of feed %s", stringValue(f.Name))
+		}
+
+		result = append(result, *packages...)
+
+		if len(*packages) < top {
+			return result, nil
+		}
+	}
+}
+
+// getPackages gets the packages of a feed, optionally filtered by name and protocol type
+func getPackages(feedName, query, protocolType string) ([]ArtifactPackage, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Feed API
+	client, err := feed.NewClient(commandContext, createArtifactsConnection(connectionDetails))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Feed client")
+	}
+
+	f, err := resolveFeed(client, connectionDetails.Project, feedName)
+	if err != nil {
+		return nil, err
+	}
+
+	packages, err := listFeedPackages(client, f, query, protocolType)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []ArtifactPackage{}
+	for _, p := range packages {
+		result = append(result, convertPackage(p))
+	}
+	return result, nil
+}
+
+// findPackage finds a package by exact name, ignoring case
+func findPackage(packages []feed.Package, name string) (feed.Package, error) {
+	for _, p := range packages {
+		if strings.EqualFold(stringValue(p.Name), name) {
+			return p, nil
+		}
+	}
+	return feed.Package{}, errors.Errorf("no package named '%s'", name)
+}
+
+// packageFileName builds the default file name of a downloaded package
+func packageFileName(protocolType, name, version string) string {
+	if strings.EqualFold(protocolType, protocolNpm) {
+		// Scoped npm packages are named @scope/name
+		name = strings.ReplaceAll(strings.TrimPrefix(name, "@"), "/", "-")
+		return fmt.Sprintf("%s-%s.tgz", name, version)
+	}
+	return fmt.Sprintf("%s.%s.nupkg", strings.ToLower(name), strings.ToLower(version))
+}
+
+// downloadPackage downloads a version of a package to the output file, which defaults to the package file name
+func downloadPackage(feedName, packageName, version, output string) (string, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+	connection := createArtifactsConnection(connectionDetails)
+
+	// Create a client for the Feed API
+	client, err := feed.NewClient(commandContext, connection)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create Feed client")
+	}
+
+	// Find the package to learn its protocol type
+	f, err := resolveFeed(client, connectionDetails.Project, feedName)
+	if err != nil {
+		return "", err
+	}
+	packages, err := listFeedPackages(client, f, packageName, "")
+	if err != nil {
+		return "", err
+	}
+	p, err := findPackage(packages, packageName)
+	if err != nil {
+		return "", err
+	}
+
+	protocolType := stringValue(p.ProtocolType)
+	content, err := openPackageContent(connection, f, protocolType, stringValue(p.Name), version)
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+
+	if output == "" {
+		output = packageFileName(protocolType, stringValue(p.Name), version)
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create %s", output)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, content); err != nil {
+		return "", errors.Wrapf(err, "failed to write %s", output)
+	}
+
+	return output, nil
+}
+
+// openPackageContent opens the content of a package version with the client for its protocol
+func openPackageContent(connection *azuredevops.Connection, f feed.Feed, protocolType, name, version string) (io.ReadCloser, error) {
+	feedID := f.Id.String()
+
+	switch {
+	case strings.EqualFold(protocolType, protocolNuGet):
+		client, err := nuget.NewClient(commandContext, connection)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create NuGet client")
+		}
+		content, err := client.DownloadPackage(commandContext, nuget.DownloadPackageArgs{
+			FeedId:         &feedID,
+			PackageName:    &name,
+			PackageVersion: &version,
+			Project:        feedProject(f),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to download %s %s", name, version)
+		}
+		return content, nil
+
+	case strings.EqualFold(protocolType, protocolNpm):
+		client, err := npm.NewClient(commandContext, connection)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create npm client")
+		}
+
+		var content io.ReadCloser
+		if scope, unscoped, found := strings.Cut(strings.TrimPrefix(name, "@"), "/"); found && strings.HasPrefix(name, "@") {
+			content, err = client.GetContentScopedPackage(commandContext, npm.GetContentScopedPackageArgs{
+				FeedId:              &feedID,
+				PackageScope:        &scope,
+				UnscopedPackageName: &unscoped,
+				PackageVersion:      &version,
+			})
+		} else {
+			content, err = client.GetContentUnscopedPackage(commandContext, npm.GetContentUnscopedPackageArgs{
+				FeedId:         &feedID,
+				PackageName:    &name,
+				PackageVersion: &version,
+			})
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to download %s %s", name, version)
+		}
+		return content, nil
+
+	default:
+		return nil, errors.Errorf("downloading %s packages is not supported; only NuGet and npm packages can be downloaded", protocolType)
+	}
+}
+
+// convertFeed converts an Azure Artifacts feed to our model
+func convertFeed(f feed.Feed) Feed {
+	result := Feed{
+		Name:        stringValue(f.Name),
+		Description: stringValue(f.Description),
+		URL:         linkHref(f.Links, "web"),
+	}
+	if result.URL == "" {
+		result.URL = stringValue(f.Url)
+	}
+	if f.Id != nil {
+		result.ID = f.Id.String()
+	}
+	if project := feedProject(f); project != nil {
+		result.Project = *project
+	}
+	return result
+}
+
+// convertPackage converts an Azure Artifacts package to our model, using its latest version
+func convertPackage(p feed.Package) ArtifactPackage {
+	result := ArtifactPackage{
+		Name: stringValue(p.Name),
+		Type: stringValue(p.ProtocolType),
+	}
+	if p.Id != nil {
+		result.ID = p.Id.String()
+	}
+	if p.Versions == nil {
+		return result
+	}
+
+	for _, version := range *p.Versions {
+		if version.IsLatest == nil || !*version.IsLatest {
+			continue
+		}
+		result.Version = stringValue(version.Version)
+		if version.PublishDate != nil {
+			published := version.PublishDate.Time
+			result.Published = &published
+		}
+		break
+	}
+	return result
+}
+
+// printFeedsAsText prints feeds in a human-readable format
+func printFeedsAsText(feeds []Feed) {
+	if len(feeds) == 0 {
+		fmt.Println("No feeds found.")
+		return
+	}
+
+	fmt.Printf("Found %d feeds:\n\n", len(feeds))
+
+	for _, f := range feeds {
+		fmt.Printf("Name: %s\n", f.Name)
+		if f.Project != "" {
+			fmt.Printf("Project: %s\n", f.Project)
+		} else {
+			fmt.Println("Project: (organization)")
+		}
+		if f.Description != "" {
+			fmt.Printf("Description: %s\n", f.Description)
+		}
+		fmt.Printf("URL: %s\n", f.URL)
+		fmt.Println()
+	}
+}
+
+// printFeedsAsJSON prints feeds in JSON format
+func printFeedsAsJSON(feeds []Feed) {
+	// Marshal the feeds to JSON with indentation
+	jsonData, err := json.MarshalIndent(feeds, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal feeds to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal feeds to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
+
+// printPackagesAsText prints packages in a human-readable format
+func printPackagesAsText(packages []ArtifactPackage) {
+	if len(packages) == 0 {
+		fmt.Println("No packages found.")
+		return
+	}
+
+	fmt.Printf("Found %d packages:\n\n", len(packages))
+
+	for _, p := range packages {
+		fmt.Printf("Name: %s\n", p.Name)
+		fmt.Printf("Type: %s\n", p.Type)
+		fmt.Printf("Version: %s\n", p.Version)
+		if p.Published != nil {
+			fmt.Printf("Published: %s\n", p.Published.Format(time.RFC3339))
+		}
+		fmt.Println()
+	}
+}
+
+// printPackagesAsJSON prints packages in JSON format
+func printPackagesAsJSON(packages []ArtifactPackage) {
+	// Marshal the packages to JSON with indentation
+	jsonData, err := json.MarshalIndent(packages, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal packages to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal packages to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
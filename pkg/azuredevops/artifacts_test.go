@@ -0,0 +1,86 @@
+package azuredevops
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/feed"
+)
+
+func TestFindFeed(t *testing.T) {
+	id := uuid.New()
+	name, projectName := "shared", "MyProject"
+	feeds := []feed.Feed{
+		{Id: &id, Name: &name, Project: &feed.ProjectReference{Name: &projectName}},
+	}
+
+	if got, err := findFeed(feeds, "Shared"); err != nil || *got.Id != id {
+		t.Errorf("findFeed(\"Shared\") = %v, want the shared feed", err)
+	}
+	if _, err := findFeed(feeds, id.String()); err != nil {
+		t.Errorf("findFeed(id) error = %v", err)
+	}
+	if _, err := findFeed(feeds, "missing"); err == nil {
+		t.Error("findFeed(\"missing\") error = nil, want an error")
+	}
+
+	if project := feedProject(feeds[0]); project == nil || *project != "MyProject" {
+		t.Errorf("feedProject() = %v, want MyProject", project)
+	}
+	if project := feedProject(feed.Feed{}); project != nil {
+		t.Errorf("feedProject() for an organization feed = %s, want nil", *project)
+	}
+}
+
+func TestPackageFileName(t *testing.T) {
+	tests := []struct {
+		protocolType, name, version, want string
+	}{
+		{"NuGet", "Contoso.Utils", "1.2.3-Beta", "contoso.utils.1.2.3-beta.nupkg"},
+		{"Npm", "left-pad", "1.3.0", "left-pad-1.3.0.tgz"},
+		{"Npm", "@contoso/ui", "2.0.0", "contoso-ui-2.0.0.tgz"},
+	}
+
+	for _, tt := range tests {
+		if got := packageFileName(tt.protocolType, tt.name, tt.version); got != tt.want {
+			t.Errorf("packageFileName(%s, %s, %s) = %s, want %s", tt.protocolType, tt.name, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestConvertPackage(t *testing.T) {
+	name, protocolType := "Contoso.Utils", "NuGet"
+	oldVersion, newVersion := "1.0.0", "1.1.0"
+	isLatest, notLatest := true, false
+	published := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	got := convertPackage(feed.Package{
+		Name:         &name,
+		ProtocolType: &protocolType,
+		Versions: &[]feed.MinimalPackageVersion{
+			{Version: &oldVersion, IsLatest: &notLatest},
+			{Version: &newVersion, IsLatest: &isLatest, PublishDate: &azuredevops.Time{Time: published}},
+		},
+	})
+
+	if got.Name != name || got.Type != protocolType || got.Version != "1.1.0" {
+		t.Errorf("convertPackage() = %+v", got)
+	}
+	if got.Published == nil || !got.Published.Equal(published) {
+		t.Errorf("convertPackage() published = %v, want %v", got.Published, published)
+	}
+}
+
+func TestFindPackage(t *testing.T) {
+	first, second := "Contoso.Utils", "Contoso.Utils.Extensions"
+	packages := []feed.Package{{Name: &second}, {Name: &first}}
+
+	if got, err := findPackage(packages, "contoso.utils"); err != nil || *got.Name != first {
+		t.Errorf("findPackage() = %v, want %s", err, first)
+	}
+	if _, err := findPackage(packages, "Contoso"); err == nil {
+		t.Error("findPackage(\"Contoso\") error = nil, want an error")
+	}
+}
@@ -1,12 +1,10 @@
-package main
+package azuredevops
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
-	"github.com/microsoft/azure-devops-go-api/azuredevops"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -24,28 +22,37 @@ type AssignedWorkItem struct {
 }
 
 // listAssignedWorkItems lists all work items assigned to a user
-func listAssignedWorkItems(cmd *cobra.Command, args []string) {
+func listAssignedWorkItems(cmd *cobra.Command, args []string) error {
 	logger.Info("Listing work items assigned to user")
 
 	// Get the username from the flag
 	username, err := cmd.Flags().GetString("user")
 	if err != nil {
-		handleError("Failed to get user flag", err)
-		return
+		return handleError("Failed to get user flag", err)
 	}
 
 	// Check if JSON output is requested
 	jsonOutput, err := cmd.Flags().GetBool("json")
 	if err != nil {
-		handleError("Failed to get json flag", err)
-		return
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the optional query filters
+	filters, err := getWorkItemFilters(cmd)
+	if err != nil {
+		return handleError("Failed to get filter flags", err)
+	}
+
+	// Get the team used to resolve @CurrentIteration
+	team, err := cmd.Flags().GetString("team")
+	if err != nil {
+		return handleError("Failed to get team flag", err)
 	}
 
 	// Get the work items
-	workItems, err := getAssignedWorkItems(username)
+	workItems, err := getAssignedWorkItems(username, filters, team)
 	if err != nil {
-		handleError("Failed to get assigned work items", err)
-		return
+		return handleError("Failed to get assigned work items", err)
 	}
 
 	// Print the work items
@@ -56,10 +63,35 @@ func listAssignedWorkItems(cmd *cobra.Command, args []string) {
 	}
 
 	logger.Info("Work items listed successfully")
+	return nil
+}
+
+// getWorkItemFilters reads the work item filter flags from the command
+func getWorkItemFilters(cmd *cobra.Command) (WorkItemFilters, error) {
+	var filters WorkItemFilters
+	var err error
+
+	if filters.CreatedSince, err = cmd.Flags().GetString("created-since"); err != nil {
+		return filters, errors.Wrap(err, "failed to get created-since flag")
+	}
+	if filters.ChangedSince, err = cmd.Flags().GetString("changed-since"); err != nil {
+		return filters, errors.Wrap(err, "failed to get changed-since flag")
+	}
+	if filters.Iteration, err = cmd.Flags().GetString("iteration"); err != nil {
+		return filters, errors.Wrap(err, "failed to get iteration flag")
+	}
+
+	return filters, nil
 }
 
 // getAssignedWorkItems gets all work items assigned to a user
-func getAssignedWorkItems(username string) ([]AssignedWorkItem, error) {
+func getAssignedWorkItems(username string, filters WorkItemFilters, team string) ([]AssignedWorkItem, error) {
+	// Build the WIQL query to find work items assigned to the user
+	wiql, err := buildAssignedWorkItemsQuery(username, filters)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get the Azure DevOps connection details from environment variables
 	connectionDetails, err := getAzureDevOpsConnectionDetails()
 	if err != nil {
@@ -67,20 +99,14 @@ func getAssignedWorkItems(username string) ([]AssignedWorkItem, error) {
 	}
 
 	// Create a connection to Azure DevOps
-	connection := azuredevops.NewPatConnection(
-		fmt.Sprintf("https://dev.azure.com/%s", connectionDetails.Organization),
-		connectionDetails.Token,
-	)
+	connection := newConnection(connectionDetails)
 
 	// Create a client for the Work Item Tracking API
-	client, err := workitemtracking.NewClient(context.Background(), connection)
+	client, err := workitemtracking.NewClient(commandContext, connection)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create Work Item Tracking client")
 	}
 
-	// Build the WIQL query to find work items assigned to the user
-	wiql := fmt.Sprintf("SELECT [System.Id], [System.Title], [System.WorkItemType], [System.State], [System.AssignedTo], [Microsoft.VSTS.Scheduling.CompletedWork] FROM WorkItems WHERE [System.AssignedTo] = '%s' ORDER BY [System.ChangedDate] DESC", username)
-
 	// Execute the WIQL query
 	wiqlArgs := workitemtracking.QueryByWiqlArgs{
 		Wiql: &workitemtracking.Wiql{
@@ -88,8 +114,11 @@ func getAssignedWorkItems(username string) ([]AssignedWorkItem, error) {
 		},
 		Project: &connectionDetails.Project,
 	}
+	if team != "" {
+		wiqlArgs.Team = &team
+	}
 
-	queryResult, err := client.QueryByWiql(context.Background(), wiqlArgs)
+	queryResult, err := client.QueryByWiql(commandContext, wiqlArgs)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to execute WIQL query")
 	}
@@ -110,7 +139,7 @@ func getAssignedWorkItems(username string) ([]AssignedWorkItem, error) {
 	for _, workItemID := range workItemIDs {
 		// Get the work item
 		workItem, err := client.GetWorkItem(
-			context.Background(),
+			commandContext,
 			workitemtracking.GetWorkItemArgs{
 				Id:      &workItemID,
 				Project: &connectionDetails.Project,
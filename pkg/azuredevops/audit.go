@@ -0,0 +1,255 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/audit"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// auditBatchSize is the number of audit log entries requested per page
+const auditBatchSize = 200
+
+// AuditEvent represents an entry of the organization audit log
+type AuditEvent struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Actor     string    `json:"actor"`
+	ActorID   string    `json:"actorId,omitempty"`
+	Action    string    `json:"action"`
+	Area      string    `json:"area"`
+	Category  string    `json:"category"`
+	Scope     string    `json:"scope"`
+	IPAddress string    `json:"ipAddress,omitempty"`
+	Details   string    `json:"details"`
+}
+
+// queryAuditCommand lists the audit log events of the organization
+func queryAuditCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Querying audit log")
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the period to query
+	sinceValue, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return handleError("Failed to get since flag", err)
+	}
+	period, err := parseSincePeriod(sinceValue)
+	if err != nil {
+		return handleError("Invalid since flag", err)
+	}
+
+	// Get the actor to filter by
+	actor, err := cmd.Flags().GetString("actor")
+	if err != nil {
+		return handleError("Failed to get actor flag", err)
+	}
+
+	// Get the events
+	events, err := queryAuditLog(time.Now().Add(-period), actor)
+	if err != nil {
+		return handleError("Failed to query audit log", err)
+	}
+
+	// Check if table output is requested
+	tableOutput, err := useTableOutput(cmd)
+	if err != nil {
+		return handleError("Failed to get table flag", err)
+	}
+
+	// Print the events
+	switch {
+	case jsonOutput:
+		printAuditEventsAsJSON(events)
+	case tableOutput:
+		printAuditEventsAsTable(events)
+	default:
+		printAuditEventsAsText(events)
+	}
+
+	logger.Info("Audit log queried successfully", "events", len(events))
+	return nil
+}
+
+// createAuditClient creates a client for the Audit API
+func createAuditClient(connectionDetails *ConnectionDetails) (audit.Client, error) {
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	// Create a client for the Audit API
+	client, err := audit.NewClient(commandContext, connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Audit client")
+	}
+
+	return client, nil
+}
+
+// resolveAuditActor resolves an actor filter to an identity ID, or returns an empty ID when it does not resolve,
+// so that actors who have since left the organization can still be found by display name
+func resolveAuditActor(connectionDetails *ConnectionDetails, actor string) string {
+	client, err := createIdentityClient(connectionDetails)
+	if err == nil {
+		var id string
+		if id, err = resolveIdentityID(client, actor); err == nil {
+			return id
+		}
+	}
+
+	logger.Warn("Could not resolve actor to an identity, matching by display name only", "actor", actor, "error", err)
+	return ""
+}
+
+// queryAuditLog gets the audit log events since the given time, newest first, optionally only those of one actor
+func queryAuditLog(since time.Time, actor string) ([]AuditEvent, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Audit API
+	client, err := createAuditClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	actorID := ""
+	if actor != "" {
+		actorID = resolveAuditActor(connectionDetails, actor)
+	}
+
+	result := []AuditEvent{}
+	startTime := azuredevops.Time{Time: since.UTC()}
+	batchSize := auditBatchSize
+	var continuationToken *string
+
+	for {
+		response, err := client.QueryLog(commandContext, audit.QueryLogArgs{
+			StartTime:         &startTime,
+			BatchSize:         &batchSize,
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query audit log")
+		}
+
+		if response.DecoratedAuditLogEntries != nil {
+			for _, entry := range *response.DecoratedAuditLogEntries {
+				event := convertAuditEvent(entry)
+				if actor == "" || matchesAuditActor(event, actor, actorID) {
+					result = append(result, event)
+				}
+			}
+		}
+
+		if !boolValue(response.HasMore) || stringValue(response.ContinuationToken) == "" {
+			return result, nil
+		}
+		continuationToken = response.ContinuationToken
+	}
+}
+
+// convertAuditEvent converts an Azure DevOps audit log entry to our model
+func convertAuditEvent(entry audit.DecoratedAuditLogEntry) AuditEvent {
+	result := AuditEvent{
+		ID:        stringValue(entry.Id),
+		Actor:     stringValue(entry.ActorDisplayName),
+		Action:    stringValue(entry.ActionId),
+		Area:      stringValue(entry.Area),
+		Category:  stringValue(entry.CategoryDisplayName),
+		Scope:     stringValue(entry.ScopeDisplayName),
+		IPAddress: stringValue(entry.IpAddress),
+		Details:   stringValue(entry.Details),
+	}
+	if entry.Timestamp != nil {
+		result.Timestamp = entry.Timestamp.Time
+	}
+	if entry.ActorUserId != nil {
+		result.ActorID = entry.ActorUserId.String()
+	}
+	return result
+}
+
+// matchesAuditActor checks if an event was initiated by the actor, given by display name or resolved identity ID
+func matchesAuditActor(event AuditEvent, actor string, actorID string) bool {
+	if actorID != "" && strings.EqualFold(event.ActorID, actorID) {
+		return true
+	}
+	return strings.EqualFold(event.Actor, actor) || strings.EqualFold(event.ActorID, actor)
+}
+
+// printAuditEventsAsText prints audit events in a human-readable format
+func printAuditEventsAsText(events []AuditEvent) {
+	if len(events) == 0 {
+		fmt.Println("No audit events found.")
+		return
+	}
+
+	fmt.Printf("Found %d audit events:\n\n", len(events))
+
+	for _, event := range events {
+		fmt.Printf("Time: %s\n", event.Timestamp.Format(time.RFC3339))
+		fmt.Printf("Actor: %s\n", event.Actor)
+		fmt.Printf("Action: %s\n", event.Action)
+		fmt.Printf("Scope: %s\n", event.Scope)
+		if event.IPAddress != "" {
+			fmt.Printf("IP Address: %s\n", event.IPAddress)
+		}
+		fmt.Printf("Details: %s\n", event.Details)
+		fmt.Println()
+	}
+}
+
+// printAuditEventsAsTable prints audit events as a table with one row per event
+func printAuditEventsAsTable(events []AuditEvent) {
+	if len(events) == 0 {
+		fmt.Println("No audit events found.")
+		return
+	}
+
+	writeAuditEventTable(os.Stdout, events)
+}
+
+// writeAuditEventTable writes audit events as a table
+func writeAuditEventTable(output io.Writer, events []AuditEvent) {
+	writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "TIME\tACTOR\tACTION\tSCOPE\tDETAILS")
+	for _, event := range events {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			event.Timestamp.Format(time.RFC3339),
+			event.Actor,
+			event.Action,
+			event.Scope,
+			truncateText(event.Details, maxTableTitleWidth),
+		)
+	}
+	writer.Flush()
+}
+
+// printAuditEventsAsJSON prints audit events in JSON format
+func printAuditEventsAsJSON(events []AuditEvent) {
+	// Marshal the events to JSON with indentation
+	jsonData, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal audit events to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal audit events to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
@@ -0,0 +1,95 @@
+package azuredevops
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/audit"
+)
+
+func TestConvertAuditEvent(t *testing.T) {
+	id, actor, action := "evt-1", "Jane Doe", "Git.RepositoryCreated"
+	area, category, scope := "Git", "Create", "myorg (Organization)"
+	ip, details := "10.0.0.1", "Created repository api"
+	actorID := uuid.MustParse("6f2b5a8e-1c4d-4e3f-9a7b-2d8c0e1f3a5b")
+	timestamp := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+
+	got := convertAuditEvent(audit.DecoratedAuditLogEntry{
+		Id:                  &id,
+		ActorDisplayName:    &actor,
+		ActorUserId:         &actorID,
+		ActionId:            &action,
+		Area:                &area,
+		CategoryDisplayName: &category,
+		ScopeDisplayName:    &scope,
+		IpAddress:           &ip,
+		Details:             &details,
+		Timestamp:           &azuredevops.Time{Time: timestamp},
+	})
+
+	want := AuditEvent{
+		ID:        id,
+		Timestamp: timestamp,
+		Actor:     actor,
+		ActorID:   actorID.String(),
+		Action:    action,
+		Area:      area,
+		Category:  category,
+		Scope:     scope,
+		IPAddress: ip,
+		Details:   details,
+	}
+	if got != want {
+		t.Errorf("convertAuditEvent() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMatchesAuditActor(t *testing.T) {
+	event := AuditEvent{Actor: "Jane Doe", ActorID: "6f2b5a8e-1c4d-4e3f-9a7b-2d8c0e1f3a5b"}
+
+	tests := []struct {
+		actor   string
+		actorID string
+		want    bool
+	}{
+		{"jane@example.com", "6F2B5A8E-1C4D-4E3F-9A7B-2D8C0E1F3A5B", true},
+		{"jane doe", "", true},
+		{"6f2b5a8e-1c4d-4e3f-9a7b-2d8c0e1f3a5b", "", true},
+		{"john@example.com", "00000000-0000-0000-0000-000000000001", false},
+		{"John Doe", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchesAuditActor(event, tt.actor, tt.actorID); got != tt.want {
+			t.Errorf("matchesAuditActor(%s, %s) = %v, want %v", tt.actor, tt.actorID, got, tt.want)
+		}
+	}
+}
+
+func TestWriteAuditEventTable(t *testing.T) {
+	var output bytes.Buffer
+	writeAuditEventTable(&output, []AuditEvent{{
+		Timestamp: time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC),
+		Actor:     "Jane Doe",
+		Action:    "Git.RepositoryCreated",
+		Scope:     "myorg",
+		Details:   "Created repository api",
+	}})
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("writeAuditEventTable() wrote %d lines, want 2:\n%s", len(lines), output.String())
+	}
+	if !strings.HasPrefix(lines[0], "TIME") {
+		t.Errorf("header = %q, want it to start with TIME", lines[0])
+	}
+	for _, value := range []string{"2024-06-03T10:00:00Z", "Jane Doe", "Git.RepositoryCreated", "Created repository api"} {
+		if !strings.Contains(lines[1], value) {
+			t.Errorf("row %q does not contain %q", lines[1], value)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package azuredevops
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/oscarrieken/master-mold/pkg/keyring"
+	"github.com/oscarrieken/master-mold/pkg/terminal"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// loginCommand prompts for a PAT, or signs in to Azure AD with --aad, and stores the credential in the system keyring
+func loginCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Logging in")
+
+	// Get the organization to log in to
+	org, err := getLoginOrganization()
+	if err != nil {
+		return handleError("Failed to get organization", err)
+	}
+
+	// Check if Azure AD sign-in is requested
+	aad, err := cmd.Flags().GetBool("aad")
+	if err != nil {
+		return handleError("Failed to get aad flag", err)
+	}
+	if aad {
+		return loginWithAAD(cmd, org)
+	}
+
+	// Read the token
+	token, err := promptToken(fmt.Sprintf("Personal Access Token for %s: ", org))
+	if err != nil {
+		return handleError("Failed to read token", err)
+	}
+
+	// Check the token before storing it
+	user, err := getAuthenticatedUser(&ConnectionDetails{Token: token, Organization: org})
+	if err != nil {
+		return handleError("Failed to authenticate with the token", err)
+	}
+
+	if err := keyring.Set(keyringService, org, token); err != nil {
+		return handleError("Failed to store token in the keyring", err)
+	}
+
+	fmt.Printf("Logged in to %s as %s; the token is stored in the system keyring\n", org, stringValue(user.ProviderDisplayName))
+
+	logger.Info("Logged in successfully", "organization", org)
+	return nil
+}
+
+// logoutCommand removes the PAT of an organization from the system keyring
+func logoutCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Logging out")
+
+	// Get the organization to log out of
+	org, err := getLoginOrganization()
+	if err != nil {
+		return handleError("Failed to get organization", err)
+	}
+
+	if err := keyring.Delete(keyringService, org); err != nil {
+		if errors.Is(err, keyring.ErrNotFound) {
+			fmt.Printf("No token stored for %s\n", org)
+			return nil
+		}
+		return handleError("Failed to remove token from the keyring", err)
+	}
+
+	fmt.Printf("Removed the token of %s from the system keyring\n", org)
+
+	logger.Info("Logged out successfully", "organization", org)
+	return nil
+}
+
+// loginWithAAD signs in to Azure AD with the device-code flow and stores the issued tokens in the system keyring
+func loginWithAAD(cmd *cobra.Command, org string) error {
+	// Get the Azure AD tenant and client
+	tenant, err := cmd.Flags().GetString("tenant")
+	if err != nil {
+		return handleError("Failed to get tenant flag", err)
+	}
+	clientID, err := cmd.Flags().GetString("client-id")
+	if err != nil {
+		return handleError("Failed to get client-id flag", err)
+	}
+
+	// Start the device-code flow
+	code, err := requestDeviceCode(tenant, clientID)
+	if err != nil {
+		return handleError("Failed to start Azure AD sign-in", err)
+	}
+	fmt.Fprintln(os.Stderr, code.Message)
+
+	// Wait for the user to sign in
+	credential, err := pollDeviceCodeToken(tenant, clientID, code)
+	if err != nil {
+		return handleError("Failed to sign in to Azure AD", err)
+	}
+
+	// Check the access token before storing it
+	user, err := getAuthenticatedUser(&ConnectionDetails{Token: credential.AccessToken, Organization: org, Bearer: true})
+	if err != nil {
+		return handleError("Failed to authenticate with the Azure AD token", err)
+	}
+
+	if err := storeAADCredential(org, credential); err != nil {
+		return handleError("Failed to store token in the keyring", err)
+	}
+
+	fmt.Printf("Logged in to %s as %s with Azure AD; the tokens are stored in the system keyring\n", org, stringValue(user.ProviderDisplayName))
+
+	logger.Info("Logged in with Azure AD successfully", "organization", org)
+	return nil
+}
+
+// getLoginOrganization gets the organization given with --org, or the configured organization
+func getLoginOrganization() (string, error) {
+	config, err := loadConnectionConfig()
+	if err != nil {
+		return "", err
+	}
+	return resolveOrganization(config, selectedProfileName(), env.Getenv)
+}
+
+// promptToken reads a token from standard input, prompting without echo when it is a terminal
+func promptToken(prompt string) (string, error) {
+	if terminal.IsTerminal(os.Stdin) {
+		fmt.Fprint(os.Stderr, prompt)
+		if restore := disableEcho(); restore != nil {
+			defer func() {
+				restore()
+				fmt.Fprintln(os.Stderr)
+			}()
+		}
+	}
+
+	return readTokenLine(os.Stdin)
+}
+
+// disableEcho turns off terminal echo with stty, returning a function that turns it back on, or nil when it is not possible
+func disableEcho() func() {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	stty := func(arg string) error {
+		cmd := exec.Command("stty", arg)
+		cmd.Stdin = os.Stdin
+		return cmd.Run()
+	}
+	if err := stty("-echo"); err != nil {
+		return nil
+	}
+	return func() {
+		stty("echo")
+	}
+}
+
+// readTokenLine reads a token from the first line of a reader
+func readTokenLine(reader io.Reader) (string, error) {
+	line, err := bufio.NewReader(reader).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", errors.Wrap(err, "failed to read token")
+	}
+
+	token := strings.TrimSpace(line)
+	if token == "" {
+		return "", errors.New("no token given")
+	}
+	return token, nil
+}
@@ -0,0 +1,31 @@
+package azuredevops
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadTokenLine(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"abc123\n", "abc123", false},
+		{"  abc123  \r\nignored\n", "abc123", false},
+		{"abc123", "abc123", false},
+		{"\n", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := readTokenLine(strings.NewReader(tt.input))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("readTokenLine(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("readTokenLine(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package azuredevops
+
+import (
+	"bytes"
+	"encoding/json"
+	"os/exec"
+	"strings"
+
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/pkg/errors"
+)
+
+// tokenSourceAzureCLI is the token source of tokens obtained from the Azure CLI
+const tokenSourceAzureCLI = "azure-cli"
+
+// azureCLIAccessToken is the output of 'az account get-access-token'
+type azureCLIAccessToken struct {
+	AccessToken string `json:"accessToken"`
+	ExpiresOn   int64  `json:"expires_on"`
+}
+
+// azureCLIToken gets an Azure DevOps access token from the Azure CLI, for users signed in with 'az login'
+func azureCLIToken() (string, error) {
+	path, err := exec.LookPath("az")
+	if err != nil {
+		return "", errors.New("the Azure CLI is not installed")
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(commandContext, path, "account", "get-access-token", "--resource", azureDevOpsResource, "--output", "json")
+	cmd.Env = env.Environ()
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", errors.Errorf("az account get-access-token failed: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return parseAzureCLIToken(output)
+}
+
+// parseAzureCLIToken gets the access token from the output of 'az account get-access-token'
+func parseAzureCLIToken(output []byte) (string, error) {
+	var token azureCLIAccessToken
+	if err := json.Unmarshal(output, &token); err != nil {
+		return "", errors.Wrap(err, "failed to parse Azure CLI token")
+	}
+	if token.AccessToken == "" {
+		return "", errors.New("the Azure CLI returned no access token")
+	}
+	return token.AccessToken, nil
+}
@@ -0,0 +1,26 @@
+package azuredevops
+
+import "testing"
+
+func TestParseAzureCLIToken(t *testing.T) {
+	output := []byte(`{
+  "accessToken": "eyJ0eXAi",
+  "expiresOn": "2024-05-01 13:00:00.000000",
+  "expires_on": 1714568400,
+  "subscription": "00000000-0000-0000-0000-000000000000",
+  "tenant": "11111111-1111-1111-1111-111111111111",
+  "tokenType": "Bearer"
+}`)
+
+	got, err := parseAzureCLIToken(output)
+	if err != nil || got != "eyJ0eXAi" {
+		t.Errorf("parseAzureCLIToken() = %q, %v, want eyJ0eXAi", got, err)
+	}
+
+	if _, err := parseAzureCLIToken([]byte(`{}`)); err == nil {
+		t.Error("parseAzureCLIToken() without token error = nil, want an error")
+	}
+	if _, err := parseAzureCLIToken([]byte(`ERROR: Please run 'az login'`)); err == nil {
+		t.Error("parseAzureCLIToken() with invalid output error = nil, want an error")
+	}
+}
@@ -0,0 +1,526 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// Well-known IDs of the branch policy types managed by the policies commands
+var (
+	minimumReviewersPolicyTypeID = uuid.MustParse("fa4e907d-c16b-4a4c-9dfa-4906e5d171dd")
+	buildPolicyTypeID            = uuid.MustParse("0609b952-1397-4640-95ec-e00a01b2c241")
+	workItemLinkingPolicyTypeID  = uuid.MustParse("40e92b44-2fe1-4dd6-b3d8-74a9c21d0c6e")
+)
+
+// Match kinds of a policy scope
+const (
+	policyMatchExact  = "exact"
+	policyMatchPrefix = "prefix"
+)
+
+// BranchPolicy represents a branch policy configuration
+type BranchPolicy struct {
+	ID       int    `json:"id"`
+	Type     string `json:"type"`
+	Enabled  bool   `json:"enabled"`
+	Blocking bool   `json:"blocking"`
+	Details  string `json:"details,omitempty"`
+}
+
+// BranchPolicyChanges holds the policy changes requested by the set command
+type BranchPolicyChanges struct {
+	MinReviewers      *int
+	BuildDefinitionID *int
+	RequireWorkItems  *bool
+	Blocking          bool
+}
+
+// listBranchPoliciesCommand lists the policies that apply to a branch of a repository
+func listBranchPoliciesCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing branch policies", "repository", args[0], "branch", args[1])
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the policies
+	policies, err := getBranchPolicies(args[0], args[1])
+	if err != nil {
+		return handleError("Failed to get branch policies", err)
+	}
+
+	// Print the policies
+	if jsonOutput {
+		printBranchPoliciesAsJSON(policies)
+	} else {
+		printBranchPoliciesAsText(policies)
+	}
+
+	logger.Info("Branch policies listed successfully", "repository", args[0], "branch", args[1])
+	return nil
+}
+
+// setBranchPoliciesCommand creates, updates or removes policies on a branch of a repository
+func setBranchPoliciesCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Setting branch policies", "repository", args[0], "branch", args[1])
+
+	// Get the requested changes
+	changes, err := getBranchPolicyChanges(cmd)
+	if err != nil {
+		return handleError("Invalid policy flags", err)
+	}
+
+	// Apply the changes
+	if err := setBranchPolicies(args[0], args[1], changes); err != nil {
+		return handleError("Failed to set branch policies", err)
+	}
+
+	logger.Info("Branch policies set successfully", "repository", args[0], "branch", args[1])
+	return nil
+}
+
+// getBranchPolicyChanges reads the policy flags from the command; only flags that were given are changed
+func getBranchPolicyChanges(cmd *cobra.Command) (BranchPolicyChanges, error) {
+	var changes BranchPolicyChanges
+
+	if cmd.Flags().Changed("min-reviewers") {
+		minReviewers, err := cmd.Flags().GetInt("min-reviewers")
+		if err != nil {
+			return changes, errors.Wrap(err, "failed to get min-reviewers flag")
+		}
+		if minReviewers < 0 {
+			return changes, errors.Errorf("min-reviewers must not be negative, got %d", minReviewers)
+		}
+		changes.MinReviewers = &minReviewers
+	}
+
+	if cmd.Flags().Changed("build") {
+		buildDefinitionID, err := cmd.Flags().GetInt("build")
+		if err != nil {
+			return changes, errors.Wrap(err, "failed to get build flag")
+		}
+		if buildDefinitionID <= 0 {
+			return changes, errors.Errorf("build must be a build definition ID, got %d", buildDefinitionID)
+		}
+		changes.BuildDefinitionID = &buildDefinitionID
+	}
+
+	if cmd.Flags().Changed("work-items") {
+		requireWorkItems, err := cmd.Flags().GetBool("work-items")
+		if err != nil {
+			return changes, errors.Wrap(err, "failed to get work-items flag")
+		}
+		changes.RequireWorkItems = &requireWorkItems
+	}
+
+	if changes.MinReviewers == nil && changes.BuildDefinitionID == nil && changes.RequireWorkItems == nil {
+		return changes, errors.New("nothing to set; use --min-reviewers, --build or --work-items")
+	}
+
+	optional, err := cmd.Flags().GetBool("optional")
+	if err != nil {
+		return changes, errors.Wrap(err, "failed to get optional flag")
+	}
+	changes.Blocking = !optional
+
+	return changes, nil
+}
+
+// createPolicyClient creates a client for the Policy API
+func createPolicyClient(connectionDetails *ConnectionDetails) (policy.Client, error) {
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	// Create a client for the Policy API
+	client, err := policy.NewClient(commandContext, connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Policy client")
+	}
+
+	return client, nil
+}
+
+// getRepositoryID looks up the ID of a repository by name
+func getRepositoryID(connectionDetails *ConnectionDetails, repository string) (string, error) {
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return "", err
+	}
+
+	repo, err := client.GetRepository(commandContext, git.GetRepositoryArgs{
+		RepositoryId: &repository,
+		Project:      &connectionDetails.Project,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get repository '%s'", repository)
+	}
+	if repo.Id == nil {
+		return "", errors.Errorf("repository '%s' has no ID", repository)
+	}
+
+	return repo.Id.String(), nil
+}
+
+// getPolicyConfigurations gets all policy configurations of a project, following continuation tokens
+func getPolicyConfigurations(client policy.Client, project string) ([]policy.PolicyConfiguration, error) {
+	var result []policy.PolicyConfiguration
+	var continuationToken *string
+
+	for {
+		response, err := client.GetPolicyConfigurations(commandContext, policy.GetPolicyConfigurationsArgs{
+			Project:           &project,
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get policy configurations")
+		}
+
+		result = append(result, response.Value...)
+
+		if response.ContinuationToken == "" {
+			return result, nil
+		}
+		token := response.ContinuationToken
+		continuationToken = &token
+	}
+}
+
+// getBranchPolicies gets the policies that apply to a branch of a repository
+func getBranchPolicies(repository, branch string) ([]BranchPolicy, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	repositoryID, err := getRepositoryID(connectionDetails, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Policy API
+	client, err := createPolicyClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	configurations, err := getPolicyConfigurations(client, connectionDetails.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	refName := normalizeBranchName(branch)
+	result := []BranchPolicy{}
+	for _, configuration := range configurations {
+		if configuration.IsDeleted != nil && *configuration.IsDeleted {
+			continue
+		}
+		if policyAppliesTo(configuration, repositoryID, refName) {
+			result = append(result, convertBranchPolicy(configuration))
+		}
+	}
+	return result, nil
+}
+
+// policyScopes gets the scope entries from the settings of a policy configuration
+func policyScopes(configuration policy.PolicyConfiguration) []map[string]interface{} {
+	settings, ok := configuration.Settings.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	scopes, ok := settings["scope"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var result []map[string]interface{}
+	for _, scope := range scopes {
+		if entry, ok := scope.(map[string]interface{}); ok {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// policyAppliesTo checks if any scope of a policy covers the branch of the repository
+func policyAppliesTo(configuration policy.PolicyConfiguration, repositoryID, refName string) bool {
+	for _, scope := range policyScopes(configuration) {
+		// A scope without a repository applies to every repository of the project
+		if scopeRepository, ok := scope["repositoryId"].(string); ok && !strings.EqualFold(scopeRepository, repositoryID) {
+			continue
+		}
+
+		scopeRef, ok := scope["refName"].(string)
+		if !ok {
+			return true
+		}
+		if matchKind, _ := scope["matchKind"].(string); strings.EqualFold(matchKind, policyMatchPrefix) {
+			if strings.HasPrefix(refName, scopeRef) {
+				return true
+			}
+		} else if scopeRef == refName {
+			return true
+		}
+	}
+	return false
+}
+
+// isExactBranchPolicy checks if a policy is scoped to exactly one branch of one repository
+func isExactBranchPolicy(configuration policy.PolicyConfiguration, repositoryID, refName string) bool {
+	scopes := policyScopes(configuration)
+	if len(scopes) != 1 {
+		return false
+	}
+
+	scopeRepository, _ := scopes[0]["repositoryId"].(string)
+	scopeRef, _ := scopes[0]["refName"].(string)
+	matchKind, _ := scopes[0]["matchKind"].(string)
+	return strings.EqualFold(scopeRepository, repositoryID) && scopeRef == refName && strings.EqualFold(matchKind, policyMatchExact)
+}
+
+// policyTypeID gets the type ID of a policy configuration
+func policyTypeID(configuration policy.PolicyConfiguration) uuid.UUID {
+	if configuration.Type == nil || configuration.Type.Id == nil {
+		return uuid.Nil
+	}
+	return *configuration.Type.Id
+}
+
+// policySettingInt gets an integer setting of a policy configuration
+func policySettingInt(configuration policy.PolicyConfiguration, name string) (int, bool) {
+	settings, ok := configuration.Settings.(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+	value, ok := settings[name].(float64)
+	return int(value), ok
+}
+
+// convertBranchPolicy converts a policy configuration to our model
+func convertBranchPolicy(configuration policy.PolicyConfiguration) BranchPolicy {
+	result := BranchPolicy{
+		ID:       intValue(configuration.Id),
+		Enabled:  configuration.IsEnabled != nil && *configuration.IsEnabled,
+		Blocking: configuration.IsBlocking != nil && *configuration.IsBlocking,
+	}
+	if configuration.Type != nil {
+		result.Type = stringValue(configuration.Type.DisplayName)
+	}
+
+	switch policyTypeID(configuration) {
+	case minimumReviewersPolicyTypeID:
+		if count, ok := policySettingInt(configuration, "minimumApproverCount"); ok {
+			result.Details = fmt.Sprintf("%d reviewers", count)
+		}
+	case buildPolicyTypeID:
+		if id, ok := policySettingInt(configuration, "buildDefinitionId"); ok {
+			result.Details = fmt.Sprintf("build definition %d", id)
+		}
+		if settings, ok := configuration.Settings.(map[string]interface{}); ok {
+			if displayName, ok := settings["displayName"].(string); ok && displayName != "" {
+				result.Details = fmt.Sprintf("%s (%s)", displayName, result.Details)
+			}
+		}
+	}
+
+	return result
+}
+
+// buildPolicyScope builds the settings scope for exactly one branch of one repository
+func buildPolicyScope(repositoryID, refName string) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"repositoryId": repositoryID,
+			"refName":      refName,
+			"matchKind":    policyMatchExact,
+		},
+	}
+}
+
+// buildPolicyConfiguration builds a policy configuration of a type with settings for a branch
+func buildPolicyConfiguration(typeID uuid.UUID, blocking bool, settings map[string]interface{}, repositoryID, refName string) *policy.PolicyConfiguration {
+	enabled := true
+	settings["scope"] = buildPolicyScope(repositoryID, refName)
+	return &policy.PolicyConfiguration{
+		Type:       &policy.PolicyTypeRef{Id: &typeID},
+		IsEnabled:  &enabled,
+		IsBlocking: &blocking,
+		Settings:   settings,
+	}
+}
+
+// setBranchPolicies applies the requested policy changes to a branch of a repository
+func setBranchPolicies(repository, branch string, changes BranchPolicyChanges) error {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return err
+	}
+
+	repositoryID, err := getRepositoryID(connectionDetails, repository)
+	if err != nil {
+		return err
+	}
+
+	// Create a client for the Policy API
+	client, err := createPolicyClient(connectionDetails)
+	if err != nil {
+		return err
+	}
+
+	configurations, err := getPolicyConfigurations(client, connectionDetails.Project)
+	if err != nil {
+		return err
+	}
+
+	// Only policies scoped to exactly this branch are changed; wider policies are left alone
+	refName := normalizeBranchName(branch)
+	var existing []policy.PolicyConfiguration
+	for _, configuration := range configurations {
+		if isExactBranchPolicy(configuration, repositoryID, refName) {
+			existing = append(existing, configuration)
+		}
+	}
+
+	apply := func(name string, typeID uuid.UUID, matches func(policy.PolicyConfiguration) bool, settings map[string]interface{}) error {
+		var current *policy.PolicyConfiguration
+		for i := range existing {
+			if policyTypeID(existing[i]) == typeID && matches(existing[i]) {
+				current = &existing[i]
+				break
+			}
+		}
+		return applyBranchPolicy(client, connectionDetails.Project, name, current, settings, changes.Blocking, typeID, repositoryID, refName)
+	}
+	anyPolicy := func(policy.PolicyConfiguration) bool { return true }
+
+	if changes.MinReviewers != nil {
+		var settings map[string]interface{}
+		if *changes.MinReviewers > 0 {
+			settings = map[string]interface{}{
+				"minimumApproverCount": *changes.MinReviewers,
+				"creatorVoteCounts":    false,
+			}
+		}
+		if err := apply("minimum reviewers", minimumReviewersPolicyTypeID, anyPolicy, settings); err != nil {
+			return err
+		}
+	}
+
+	if changes.BuildDefinitionID != nil {
+		buildDefinitionID := *changes.BuildDefinitionID
+		sameDefinition := func(configuration policy.PolicyConfiguration) bool {
+			id, ok := policySettingInt(configuration, "buildDefinitionId")
+			return ok && id == buildDefinitionID
+		}
+		settings := map[string]interface{}{
+			"buildDefinitionId":       buildDefinitionID,
+			"queueOnSourceUpdateOnly": true,
+			"manualQueueOnly":         false,
+			"validDuration":           720,
+		}
+		if err := apply("build validation", buildPolicyTypeID, sameDefinition, settings); err != nil {
+			return err
+		}
+	}
+
+	if changes.RequireWorkItems != nil {
+		var settings map[string]interface{}
+		if *changes.RequireWorkItems {
+			settings = map[string]interface{}{}
+		}
+		if err := apply("linked work items", workItemLinkingPolicyTypeID, anyPolicy, settings); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyBranchPolicy creates or updates a policy with the settings, or deletes it when the settings are nil
+func applyBranchPolicy(client policy.Client, project, name string, current *policy.PolicyConfiguration, settings map[string]interface{}, blocking bool, typeID uuid.UUID, repositoryID, refName string) error {
+	if settings == nil {
+		if current == nil {
+			fmt.Printf("No %s policy to remove\n", name)
+			return nil
+		}
+		err := client.DeletePolicyConfiguration(commandContext, policy.DeletePolicyConfigurationArgs{
+			Project:         &project,
+			ConfigurationId: current.Id,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to remove %s policy", name)
+		}
+		fmt.Printf("Removed %s policy %d\n", name, intValue(current.Id))
+		return nil
+	}
+
+	configuration := buildPolicyConfiguration(typeID, blocking, settings, repositoryID, refName)
+
+	if current == nil {
+		created, err := client.CreatePolicyConfiguration(commandContext, policy.CreatePolicyConfigurationArgs{
+			Configuration: configuration,
+			Project:       &project,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create %s policy", name)
+		}
+		fmt.Printf("Created %s policy %d\n", name, intValue(created.Id))
+		return nil
+	}
+
+	_, err := client.UpdatePolicyConfiguration(commandContext, policy.UpdatePolicyConfigurationArgs{
+		Configuration:   configuration,
+		Project:         &project,
+		ConfigurationId: current.Id,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update %s policy", name)
+	}
+	fmt.Printf("Updated %s policy %d\n", name, intValue(current.Id))
+	return nil
+}
+
+// printBranchPoliciesAsText prints branch policies in a human-readable format
+func printBranchPoliciesAsText(policies []BranchPolicy) {
+	if len(policies) == 0 {
+		fmt.Println("No branch policies found.")
+		return
+	}
+
+	fmt.Printf("Found %d branch policies:\n\n", len(policies))
+
+	for _, branchPolicy := range policies {
+		fmt.Printf("ID: %d\n", branchPolicy.ID)
+		fmt.Printf("Type: %s\n", branchPolicy.Type)
+		if branchPolicy.Details != "" {
+			fmt.Printf("Details: %s\n", branchPolicy.Details)
+		}
+		fmt.Printf("Enabled: %t\n", branchPolicy.Enabled)
+		fmt.Printf("Blocking: %t\n", branchPolicy.Blocking)
+		fmt.Println()
+	}
+}
+
+// printBranchPoliciesAsJSON prints branch policies in JSON format
+func printBranchPoliciesAsJSON(policies []BranchPolicy) {
+	// Marshal the branch policies to JSON with indentation
+	jsonData, err := json.MarshalIndent(policies, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal branch policies to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal branch policies to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
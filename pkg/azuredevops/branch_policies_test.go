@@ -0,0 +1,114 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/spf13/cobra"
+)
+
+const testRepositoryID = "6f1e6c1a-7d4e-4c7a-9a8e-2a1b3c4d5e6f"
+
+func testPolicy(typeID uuid.UUID, settings map[string]interface{}) policy.PolicyConfiguration {
+	id, displayName := 5, "Minimum number of reviewers"
+	enabled, blocking := true, false
+	return policy.PolicyConfiguration{
+		Id:         &id,
+		Type:       &policy.PolicyTypeRef{Id: &typeID, DisplayName: &displayName},
+		IsEnabled:  &enabled,
+		IsBlocking: &blocking,
+		Settings:   settings,
+	}
+}
+
+func scopeSettings(scopes ...map[string]interface{}) map[string]interface{} {
+	var list []interface{}
+	for _, scope := range scopes {
+		list = append(list, scope)
+	}
+	return map[string]interface{}{"scope": list}
+}
+
+func TestPolicyAppliesTo(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope map[string]interface{}
+		want  bool
+	}{
+		{"exact match", map[string]interface{}{"repositoryId": testRepositoryID, "refName": "refs/heads/main", "matchKind": "Exact"}, true},
+		{"other branch", map[string]interface{}{"repositoryId": testRepositoryID, "refName": "refs/heads/develop", "matchKind": "Exact"}, false},
+		{"other repository", map[string]interface{}{"repositoryId": uuid.NewString(), "refName": "refs/heads/main", "matchKind": "Exact"}, false},
+		{"prefix match", map[string]interface{}{"repositoryId": testRepositoryID, "refName": "refs/heads/", "matchKind": "Prefix"}, true},
+		{"all repositories", map[string]interface{}{"refName": "refs/heads/main", "matchKind": "Exact"}, true},
+		{"whole repository", map[string]interface{}{"repositoryId": testRepositoryID}, true},
+	}
+
+	for _, tt := range tests {
+		configuration := testPolicy(minimumReviewersPolicyTypeID, scopeSettings(tt.scope))
+		if got := policyAppliesTo(configuration, testRepositoryID, "refs/heads/main"); got != tt.want {
+			t.Errorf("policyAppliesTo() for %s = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsExactBranchPolicy(t *testing.T) {
+	exact := testPolicy(minimumReviewersPolicyTypeID, map[string]interface{}{"scope": buildPolicyScope(testRepositoryID, "refs/heads/main")})
+	if !isExactBranchPolicy(exact, testRepositoryID, "refs/heads/main") {
+		t.Error("isExactBranchPolicy() = false for a policy on exactly the branch")
+	}
+
+	prefix := testPolicy(minimumReviewersPolicyTypeID, scopeSettings(map[string]interface{}{"repositoryId": testRepositoryID, "refName": "refs/heads/main", "matchKind": "prefix"}))
+	if isExactBranchPolicy(prefix, testRepositoryID, "refs/heads/main") {
+		t.Error("isExactBranchPolicy() = true for a prefix policy")
+	}
+}
+
+func TestConvertBranchPolicy(t *testing.T) {
+	settings := map[string]interface{}{"minimumApproverCount": float64(2)}
+	got := convertBranchPolicy(testPolicy(minimumReviewersPolicyTypeID, settings))
+
+	want := BranchPolicy{ID: 5, Type: "Minimum number of reviewers", Enabled: true, Details: "2 reviewers"}
+	if got != want {
+		t.Errorf("convertBranchPolicy() = %+v, want %+v", got, want)
+	}
+
+	build := convertBranchPolicy(testPolicy(buildPolicyTypeID, map[string]interface{}{"buildDefinitionId": float64(12), "displayName": "CI"}))
+	if build.Details != "CI (build definition 12)" {
+		t.Errorf("convertBranchPolicy() details = %s, want 'CI (build definition 12)'", build.Details)
+	}
+}
+
+func TestGetBranchPolicyChanges(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("min-reviewers", 0, "")
+		cmd.Flags().Int("build", 0, "")
+		cmd.Flags().Bool("work-items", false, "")
+		cmd.Flags().Bool("optional", false, "")
+		return cmd
+	}
+
+	cmd := newCmd()
+	if _, err := getBranchPolicyChanges(cmd); err == nil {
+		t.Error("getBranchPolicyChanges() without flags error = nil, want an error")
+	}
+
+	cmd = newCmd()
+	cmd.Flags().Set("min-reviewers", "2")
+	cmd.Flags().Set("work-items", "false")
+	cmd.Flags().Set("optional", "true")
+	changes, err := getBranchPolicyChanges(cmd)
+	if err != nil {
+		t.Fatalf("getBranchPolicyChanges() error = %v", err)
+	}
+	if changes.MinReviewers == nil || *changes.MinReviewers != 2 {
+		t.Errorf("MinReviewers = %v, want 2", changes.MinReviewers)
+	}
+	if changes.RequireWorkItems == nil || *changes.RequireWorkItems {
+		t.Errorf("RequireWorkItems = %v, want false", changes.RequireWorkItems)
+	}
+	if changes.BuildDefinitionID != nil || changes.Blocking {
+		t.Errorf("changes = %+v, want no build and non-blocking", changes)
+	}
+}
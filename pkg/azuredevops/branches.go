@@ -0,0 +1,339 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// zeroObjectID is the object ID used in ref updates for a ref that does not exist
+const zeroObjectID = "0000000000000000000000000000000000000000"
+
+// branchRefFilter is the refs API filter matching all branches
+const branchRefFilter = "heads/"
+
+// commitIDPattern matches a full Git commit ID
+var commitIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+
+// Branch represents a branch of a Git repository
+type Branch struct {
+	Name      string `json:"name"`
+	CommitID  string `json:"commitId"`
+	Creator   string `json:"creator,omitempty"`
+	IsDefault bool   `json:"isDefault"`
+	Ahead     int    `json:"ahead"`
+	Behind    int    `json:"behind"`
+}
+
+// listBranchesCommand lists the branches of a repository with their ahead/behind counts
+func listBranchesCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing branches", "repository", args[0])
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the branches
+	branches, err := getBranches(args[0])
+	if err != nil {
+		return handleError("Failed to get branches", err)
+	}
+
+	// Print the branches
+	if jsonOutput {
+		printBranchesAsJSON(branches)
+	} else {
+		printBranchesAsText(branches)
+	}
+
+	logger.Info("Branches listed successfully", "repository", args[0])
+	return nil
+}
+
+// createBranchCommand creates a branch in a repository
+func createBranchCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Creating branch", "repository", args[0], "branch", args[1])
+
+	// Get the branch or commit to create the branch from
+	from, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return handleError("Failed to get from flag", err)
+	}
+
+	// Create the branch
+	commitID, err := createBranch(args[0], args[1], from)
+	if err != nil {
+		return handleError("Failed to create branch", err)
+	}
+
+	fmt.Printf("Created branch %s in %s at %s\n", args[1], args[0], shortCommitID(commitID))
+
+	logger.Info("Branch created successfully", "repository", args[0], "branch", args[1])
+	return nil
+}
+
+// deleteBranchCommand deletes a branch from a repository
+func deleteBranchCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Deleting branch", "repository", args[0], "branch", args[1])
+
+	// Delete the branch
+	if err := deleteBranch(args[0], args[1]); err != nil {
+		return handleError("Failed to delete branch", err)
+	}
+
+	fmt.Printf("Deleted branch %s from %s\n", args[1], args[0])
+
+	logger.Info("Branch deleted successfully", "repository", args[0], "branch", args[1])
+	return nil
+}
+
+// getBranches gets the branches of a repository, with their ahead/behind counts relative to the default branch
+func getBranches(repository string) ([]Branch, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Git API
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := getBranchRefs(client, connectionDetails.Project, repository, branchRefFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a base version, the branch stats are relative to the default branch
+	stats, err := client.GetBranches(commandContext, git.GetBranchesArgs{
+		RepositoryId: &repository,
+		Project:      &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get branch stats")
+	}
+
+	return mergeBranchStats(refs, *stats), nil
+}
+
+// getBranchRefs gets the refs of a repository matching the filter, following continuation tokens
+func getBranchRefs(client git.Client, project, repository, filter string) ([]git.GitRef, error) {
+	var result []git.GitRef
+	var continuationToken *string
+
+	for {
+		response, err := client.GetRefs(commandContext, git.GetRefsArgs{
+			RepositoryId:      &repository,
+			Project:           &project,
+			Filter:            &filter,
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get refs")
+		}
+
+		result = append(result, response.Value...)
+
+		if response.ContinuationToken == "" {
+			return result, nil
+		}
+		token := response.ContinuationToken
+		continuationToken = &token
+	}
+}
+
+// mergeBranchStats combines branch refs with their ahead/behind stats
+func mergeBranchStats(refs []git.GitRef, stats []git.GitBranchStats) []Branch {
+	statsByName := make(map[string]git.GitBranchStats, len(stats))
+	for _, stat := range stats {
+		statsByName[stringValue(stat.Name)] = stat
+	}
+
+	result := []Branch{}
+	for _, ref := range refs {
+		branch := Branch{
+			Name:     strings.TrimPrefix(stringValue(ref.Name), "refs/heads/"),
+			CommitID: stringValue(ref.ObjectId),
+		}
+		if ref.Creator != nil {
+			branch.Creator = stringValue(ref.Creator.DisplayName)
+		}
+		if stat, ok := statsByName[branch.Name]; ok {
+			branch.Ahead = intValue(stat.AheadCount)
+			branch.Behind = intValue(stat.BehindCount)
+			branch.IsDefault = stat.IsBaseVersion != nil && *stat.IsBaseVersion
+		}
+		result = append(result, branch)
+	}
+	return result
+}
+
+// findBranchRef finds the ref of a branch by its exact name
+func findBranchRef(refs []git.GitRef, branch string) (git.GitRef, error) {
+	refName := normalizeBranchName(branch)
+	for _, ref := range refs {
+		if stringValue(ref.Name) == refName {
+			return ref, nil
+		}
+	}
+	return git.GitRef{}, errors.Errorf("branch '%s' not found", branch)
+}
+
+// resolveBranchSource resolves the branch or commit to create a branch from to a commit ID
+func resolveBranchSource(client git.Client, project, repository, from string) (string, error) {
+	if commitIDPattern.MatchString(from) {
+		return strings.ToLower(from), nil
+	}
+
+	// Default to the default branch of the repository
+	if from == "" {
+		repo, err := client.GetRepository(commandContext, git.GetRepositoryArgs{
+			RepositoryId: &repository,
+			Project:      &project,
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get repository")
+		}
+		if repo.DefaultBranch == nil {
+			return "", errors.Errorf("repository '%s' has no default branch; use --from", repository)
+		}
+		from = *repo.DefaultBranch
+	}
+
+	refName := normalizeBranchName(from)
+	refs, err := getBranchRefs(client, project, repository, strings.TrimPrefix(refName, "refs/"))
+	if err != nil {
+		return "", err
+	}
+	ref, err := findBranchRef(refs, refName)
+	if err != nil {
+		return "", err
+	}
+	return stringValue(ref.ObjectId), nil
+}
+
+// createBranch creates a branch from another branch or a commit, returning the commit ID it points at
+func createBranch(repository, branch, from string) (string, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	// Create a client for the Git API
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return "", err
+	}
+
+	commitID, err := resolveBranchSource(client, connectionDetails.Project, repository, from)
+	if err != nil {
+		return "", err
+	}
+
+	if err := updateBranchRef(client, connectionDetails.Project, repository, branch, zeroObjectID, commitID); err != nil {
+		return "", err
+	}
+	return commitID, nil
+}
+
+// deleteBranch deletes a branch from a repository
+func deleteBranch(repository, branch string) error {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return err
+	}
+
+	// Create a client for the Git API
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return err
+	}
+
+	// Deleting a ref requires its current object ID
+	refName := normalizeBranchName(branch)
+	refs, err := getBranchRefs(client, connectionDetails.Project, repository, strings.TrimPrefix(refName, "refs/"))
+	if err != nil {
+		return err
+	}
+	ref, err := findBranchRef(refs, refName)
+	if err != nil {
+		return err
+	}
+
+	return updateBranchRef(client, connectionDetails.Project, repository, branch, stringValue(ref.ObjectId), zeroObjectID)
+}
+
+// updateBranchRef moves a branch ref from one object ID to another, checking the update result
+func updateBranchRef(client git.Client, project, repository, branch, oldObjectID, newObjectID string) error {
+	refName := normalizeBranchName(branch)
+	results, err := client.UpdateRefs(commandContext, git.UpdateRefsArgs{
+		RefUpdates: &[]git.GitRefUpdate{{
+			Name:        &refName,
+			OldObjectId: &oldObjectID,
+			NewObjectId: &newObjectID,
+		}},
+		RepositoryId: &repository,
+		Project:      &project,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update branch '%s'", branch)
+	}
+
+	for _, result := range *results {
+		if result.Success != nil && !*result.Success {
+			status := ""
+			if result.UpdateStatus != nil {
+				status = string(*result.UpdateStatus)
+			}
+			return errors.Errorf("update of branch '%s' was rejected: %s", branch, status)
+		}
+	}
+	return nil
+}
+
+// printBranchesAsText prints branches in a human-readable format
+func printBranchesAsText(branches []Branch) {
+	if len(branches) == 0 {
+		fmt.Println("No branches found.")
+		return
+	}
+
+	fmt.Printf("Found %d branches:\n\n", len(branches))
+
+	for _, branch := range branches {
+		name := branch.Name
+		if branch.IsDefault {
+			name += " (default)"
+		}
+		fmt.Printf("Name: %s\n", name)
+		fmt.Printf("Commit: %s\n", shortCommitID(branch.CommitID))
+		fmt.Printf("Creator: %s\n", branch.Creator)
+		fmt.Printf("Ahead/Behind: %d/%d\n", branch.Ahead, branch.Behind)
+		fmt.Println()
+	}
+}
+
+// printBranchesAsJSON prints branches in JSON format
+func printBranchesAsJSON(branches []Branch) {
+	// Marshal the branches to JSON with indentation
+	jsonData, err := json.MarshalIndent(branches, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal branches to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal branches to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
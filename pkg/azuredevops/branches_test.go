@@ -0,0 +1,64 @@
+package azuredevops
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/webapi"
+)
+
+func branchRef(name, objectID string) git.GitRef {
+	return git.GitRef{Name: &name, ObjectId: &objectID}
+}
+
+func TestMergeBranchStats(t *testing.T) {
+	creator := "Jane Doe"
+	mainRef := branchRef("refs/heads/main", "aaa")
+	featureRef := branchRef("refs/heads/feature/login", "bbb")
+	featureRef.Creator = &webapi.IdentityRef{DisplayName: &creator}
+
+	mainName, featureName := "main", "feature/login"
+	isBase := true
+	zero, ahead, behind := 0, 3, 5
+	stats := []git.GitBranchStats{
+		{Name: &mainName, IsBaseVersion: &isBase, AheadCount: &zero, BehindCount: &zero},
+		{Name: &featureName, AheadCount: &ahead, BehindCount: &behind},
+	}
+
+	got := mergeBranchStats([]git.GitRef{mainRef, featureRef, branchRef("refs/heads/orphan", "ccc")}, stats)
+	want := []Branch{
+		{Name: "main", CommitID: "aaa", IsDefault: true},
+		{Name: "feature/login", CommitID: "bbb", Creator: "Jane Doe", Ahead: 3, Behind: 5},
+		{Name: "orphan", CommitID: "ccc"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeBranchStats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFindBranchRef(t *testing.T) {
+	refs := []git.GitRef{
+		branchRef("refs/heads/feature", "aaa"),
+		branchRef("refs/heads/feature/login", "bbb"),
+	}
+
+	if got, err := findBranchRef(refs, "feature"); err != nil || *got.ObjectId != "aaa" {
+		t.Errorf("findBranchRef(\"feature\") = %v, want aaa", err)
+	}
+	if got, err := findBranchRef(refs, "refs/heads/feature/login"); err != nil || *got.ObjectId != "bbb" {
+		t.Errorf("findBranchRef(\"refs/heads/feature/login\") = %v, want bbb", err)
+	}
+	if _, err := findBranchRef(refs, "feat"); err == nil {
+		t.Error("findBranchRef(\"feat\") error = nil, want an error")
+	}
+}
+
+func TestCommitIDPattern(t *testing.T) {
+	if !commitIDPattern.MatchString("0123456789abcdef0123456789ABCDEF01234567") {
+		t.Error("commitIDPattern does not match a full commit ID")
+	}
+	if commitIDPattern.MatchString("main") || commitIDPattern.MatchString("0123456") {
+		t.Error("commitIDPattern matches a branch name or short commit ID")
+	}
+}
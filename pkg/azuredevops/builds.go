@@ -0,0 +1,216 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// QueuedBuild represents a build queued from a classic build definition
+type QueuedBuild struct {
+	ID           int    `json:"id"`
+	BuildNumber  string `json:"buildNumber"`
+	DefinitionID int    `json:"definitionId"`
+	Definition   string `json:"definition"`
+	SourceBranch string `json:"sourceBranch,omitempty"`
+	Status       string `json:"status"`
+	URL          string `json:"url"`
+}
+
+// queueBuildCommand queues a build of a classic build definition
+func queueBuildCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Queueing build", "definition", args[0])
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the queue flags
+	branch, err := cmd.Flags().GetString("branch")
+	if err != nil {
+		return handleError("Failed to get branch flag", err)
+	}
+	paramFlags, err := cmd.Flags().GetStringArray("param")
+	if err != nil {
+		return handleError("Failed to get param flag", err)
+	}
+	params, err := parseKeyValues(paramFlags)
+	if err != nil {
+		return handleError("Invalid param flag", err)
+	}
+
+	// Queue the build
+	queued, err := queueBuild(args[0], branch, params)
+	if err != nil {
+		return handleError("Failed to queue build", err)
+	}
+
+	// Print the queued build
+	if jsonOutput {
+		printQueuedBuildAsJSON(queued)
+	} else {
+		fmt.Printf("Queued build %d (%s) of definition %s\n", queued.ID, queued.BuildNumber, queued.Definition)
+		if queued.URL != "" {
+			fmt.Printf("URL: %s\n", queued.URL)
+		}
+	}
+
+	logger.Info("Build queued successfully", "definition", queued.Definition, "build", queued.ID)
+	return nil
+}
+
+// listBuildDefinitions gets all build definitions of a project, following continuation tokens
+func listBuildDefinitions(client build.Client, project string) ([]build.BuildDefinitionReference, error) {
+	var result []build.BuildDefinitionReference
+	var continuationToken *string
+
+	for {
+		response, err := client.GetDefinitions(commandContext, build.GetDefinitionsArgs{
+			Project:           &project,
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list build definitions")
+		}
+
+		result = append(result, response.Value...)
+
+		if response.ContinuationToken == "" {
+			return result, nil
+		}
+		token := response.ContinuationToken
+		continuationToken = &token
+	}
+}
+
+// findBuildDefinition finds a build definition by ID or by name, ignoring case
+func findBuildDefinition(definitions []build.BuildDefinitionReference, nameOrID string) (build.BuildDefinitionReference, error) {
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		for _, definition := range definitions {
+			if intValue(definition.Id) == id {
+				return definition, nil
+			}
+		}
+		return build.BuildDefinitionReference{}, errors.Errorf("no build definition with ID %d", id)
+	}
+
+	var matches []build.BuildDefinitionReference
+	for _, definition := range definitions {
+		if strings.EqualFold(stringValue(definition.Name), nameOrID) {
+			matches = append(matches, definition)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return build.BuildDefinitionReference{}, errors.Errorf("no build definition named '%s'", nameOrID)
+	case 1:
+		return matches[0], nil
+	default:
+		return build.BuildDefinitionReference{}, errors.Errorf("'%s' matches %d build definitions; use the definition ID", nameOrID, len(matches))
+	}
+}
+
+// buildQueueRequest builds the build to queue for a definition, branch and parameters
+func buildQueueRequest(definitionID int, branch string, params map[string]string) (*build.Build, error) {
+	request := &build.Build{
+		Definition: &build.DefinitionReference{Id: &definitionID},
+	}
+
+	if branch != "" {
+		sourceBranch := normalizeBranchName(branch)
+		request.SourceBranch = &sourceBranch
+	}
+
+	// Build parameters are sent as a JSON-encoded dictionary
+	if len(params) > 0 {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal build parameters")
+		}
+		parameters := string(data)
+		request.Parameters = &parameters
+	}
+
+	return request, nil
+}
+
+// queueBuild queues a build of a definition, given by ID or name
+func queueBuild(nameOrID string, branch string, params map[string]string) (*QueuedBuild, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Build API
+	client, err := createBuildClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the build definition
+	definitions, err := listBuildDefinitions(client, connectionDetails.Project)
+	if err != nil {
+		return nil, err
+	}
+	definition, err := findBuildDefinition(definitions, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := buildQueueRequest(intValue(definition.Id), branch, params)
+	if err != nil {
+		return nil, err
+	}
+
+	queued, err := client.QueueBuild(commandContext, build.QueueBuildArgs{
+		Build:   request,
+		Project: &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to queue build of %s", stringValue(definition.Name))
+	}
+
+	result := convertQueuedBuild(*queued)
+	return &result, nil
+}
+
+// convertQueuedBuild converts an Azure DevOps build to our model
+func convertQueuedBuild(queued build.Build) QueuedBuild {
+	result := QueuedBuild{
+		ID:           intValue(queued.Id),
+		BuildNumber:  stringValue(queued.BuildNumber),
+		SourceBranch: stringValue(queued.SourceBranch),
+		URL:          linkHref(queued.Links, "web"),
+	}
+	if queued.Definition != nil {
+		result.DefinitionID = intValue(queued.Definition.Id)
+		result.Definition = stringValue(queued.Definition.Name)
+	}
+	if queued.Status != nil {
+		result.Status = string(*queued.Status)
+	}
+	return result
+}
+
+// printQueuedBuildAsJSON prints a queued build in JSON format
+func printQueuedBuildAsJSON(queued *QueuedBuild) {
+	// Marshal the build to JSON with indentation
+	jsonData, err := json.MarshalIndent(queued, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal build to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal build to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
@@ -0,0 +1,84 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+)
+
+func TestFindBuildDefinition(t *testing.T) {
+	id1, id2, id3 := 1, 2, 3
+	name1, name2, name3 := "CI", "Nightly", "nightly"
+	definitions := []build.BuildDefinitionReference{
+		{Id: &id1, Name: &name1},
+		{Id: &id2, Name: &name2},
+		{Id: &id3, Name: &name3},
+	}
+
+	if got, err := findBuildDefinition(definitions, "ci"); err != nil || intValue(got.Id) != 1 {
+		t.Errorf("findBuildDefinition(ci) = %v, %v, want definition 1", intValue(got.Id), err)
+	}
+	if got, err := findBuildDefinition(definitions, "2"); err != nil || intValue(got.Id) != 2 {
+		t.Errorf("findBuildDefinition(2) = %v, %v, want definition 2", intValue(got.Id), err)
+	}
+	for _, nameOrID := range []string{"nightly", "missing", "42"} {
+		if _, err := findBuildDefinition(definitions, nameOrID); err == nil {
+			t.Errorf("findBuildDefinition(%s) error = nil, want an error", nameOrID)
+		}
+	}
+}
+
+func TestBuildQueueRequest(t *testing.T) {
+	request, err := buildQueueRequest(7, "main", map[string]string{"env": "prod"})
+	if err != nil {
+		t.Fatalf("buildQueueRequest() error = %v", err)
+	}
+	if intValue(request.Definition.Id) != 7 {
+		t.Errorf("Definition.Id = %d, want 7", intValue(request.Definition.Id))
+	}
+	if got := stringValue(request.SourceBranch); got != "refs/heads/main" {
+		t.Errorf("SourceBranch = %s, want refs/heads/main", got)
+	}
+	if got := stringValue(request.Parameters); got != `{"env":"prod"}` {
+		t.Errorf("Parameters = %s, want {\"env\":\"prod\"}", got)
+	}
+
+	request, err = buildQueueRequest(7, "", nil)
+	if err != nil {
+		t.Fatalf("buildQueueRequest() error = %v", err)
+	}
+	if request.SourceBranch != nil || request.Parameters != nil {
+		t.Errorf("buildQueueRequest() without branch and parameters = %+v, want them unset", request)
+	}
+}
+
+func TestConvertQueuedBuild(t *testing.T) {
+	id, definitionID := 1234, 7
+	number, definitionName, branch := "20240603.1", "CI", "refs/heads/main"
+	status := build.BuildStatusValues.NotStarted
+	links := map[string]interface{}{
+		"web": map[string]interface{}{"href": "https://dev.azure.com/org/project/_build/results?buildId=1234"},
+	}
+
+	got := convertQueuedBuild(build.Build{
+		Id:           &id,
+		BuildNumber:  &number,
+		Definition:   &build.DefinitionReference{Id: &definitionID, Name: &definitionName},
+		SourceBranch: &branch,
+		Status:       &status,
+		Links:        links,
+	})
+
+	want := QueuedBuild{
+		ID:           1234,
+		BuildNumber:  "20240603.1",
+		DefinitionID: 7,
+		Definition:   "CI",
+		SourceBranch: "refs/heads/main",
+		Status:       "notStarted",
+		URL:          "https://dev.azure.com/org/project/_build/results?buildId=1234",
+	}
+	if got != want {
+		t.Errorf("convertQueuedBuild() = %+v, want %+v", got, want)
+	}
+}
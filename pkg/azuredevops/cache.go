@@ -0,0 +1,178 @@
+package azuredevops
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// cachedResponse is a response stored in the cache, revalidated with its ETag
+type cachedResponse struct {
+	URL    string      `json:"url"`
+	ETag   string      `json:"etag"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// cacheTransport caches GET responses on disk and revalidates them with If-None-Match
+type cacheTransport struct {
+	next http.RoundTripper
+	dir  string
+}
+
+// newCacheTransport wraps a transport with a cache stored in the directory
+func newCacheTransport(next http.RoundTripper, dir string) *cacheTransport {
+	return &cacheTransport{next: next, dir: dir}
+}
+
+// azureDevOpsCacheDir gets the directory of the response cache, in the base directory master-mold passes as
+// MM_BASE_DIR, or else in the default base directory
+func azureDevOpsCacheDir() string {
+	baseDir := env.Getenv(env.BaseDir)
+	if baseDir == "" {
+		defaults := config.DefaultConfig()
+		baseDir = config.GetExpandedBaseDir(&defaults)
+	}
+	return filepath.Join(baseDir, "cache", "azure-devops")
+}
+
+// RoundTrip serves GET requests from the cache when the server reports that the cached response is still current
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	path := filepath.Join(t.dir, cacheKey(req)+".json")
+	cached := readCachedResponse(path)
+	if cached != nil {
+		// Clone the request, as a round tripper must not modify it
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		return cached.response(req), nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || etag == "" {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body")
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// A cache that cannot be written only costs speed, so the error is ignored
+	writeCachedResponse(path, &cachedResponse{
+		URL:    req.URL.Redacted(),
+		ETag:   etag,
+		Status: resp.StatusCode,
+		Header: resp.Header,
+		Body:   body,
+	})
+
+	return resp, nil
+}
+
+// cacheKey gets the cache key of a request; the credential is part of it, so accounts never share responses
+func cacheKey(req *http.Request) string {
+	hash := sha256.New()
+	io.WriteString(hash, req.URL.String())
+	io.WriteString(hash, "\n")
+	io.WriteString(hash, req.Header.Get("Authorization"))
+	io.WriteString(hash, "\n")
+	io.WriteString(hash, req.Header.Get("Accept"))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// readCachedResponse reads a cached response, or nil when there is none
+func readCachedResponse(path string) *cachedResponse {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var cached cachedResponse
+	if err := json.Unmarshal(data, &cached); err != nil || cached.ETag == "" {
+		return nil
+	}
+	return &cached
+}
+
+// writeCachedResponse stores a response in the cache
+func writeCachedResponse(path string, cached *cachedResponse) error {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	// Write to a temporary file of its own first, so concurrent invocations never read or write a partial entry
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// response rebuilds the HTTP response of a cached entry
+func (c *cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", c.Status, http.StatusText(c.Status)),
+		StatusCode:    c.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        c.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
+
+// clearCacheCommand removes all cached responses
+func clearCacheCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Clearing response cache")
+
+	dir := azureDevOpsCacheDir()
+	if err := os.RemoveAll(dir); err != nil {
+		return handleError("Failed to clear cache", err)
+	}
+
+	fmt.Printf("Cleared the response cache in %s\n", dir)
+
+	logger.Info("Response cache cleared successfully", "dir", dir)
+	return nil
+}
@@ -0,0 +1,125 @@
+package azuredevops
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/env"
+)
+
+func TestCacheTransport(t *testing.T) {
+	requests, notModified := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"count":1}`)
+	}))
+	defer server.Close()
+
+	transport := newCacheTransport(http.DefaultTransport, t.TempDir())
+	client := &http.Client{Transport: transport}
+
+	get := func(token string) string {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/_apis/projects", nil)
+		req.Header.Set("Authorization", token)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Get() status = %d, want 200", resp.StatusCode)
+		}
+		if resp.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Get() Content-Type = %q, want the cached header", resp.Header.Get("Content-Type"))
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	if body := get("Basic a"); body != `{"count":1}` {
+		t.Errorf("first Get() body = %q", body)
+	}
+	if body := get("Basic a"); body != `{"count":1}` {
+		t.Errorf("cached Get() body = %q", body)
+	}
+	if notModified != 1 {
+		t.Errorf("server answered %d requests with 304, want 1", notModified)
+	}
+
+	// Another credential does not share the cached response
+	get("Basic b")
+	if requests != 3 || notModified != 1 {
+		t.Errorf("requests = %d, not modified = %d, want 3 and 1", requests, notModified)
+	}
+}
+
+func TestCacheTransportSkipsOtherMethods(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			t.Errorf("%s request was revalidated", r.Method)
+		}
+		w.Header().Set("ETag", `"v1"`)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newCacheTransport(http.DefaultTransport, t.TempDir())}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Post(server.URL, "application/json", nil)
+		if err != nil {
+			t.Fatalf("Post() error = %v", err)
+		}
+		resp.Body.Close()
+	}
+}
+
+func TestAzureDevOpsCacheDir(t *testing.T) {
+	baseDir := t.TempDir()
+	t.Setenv(env.BaseDir, baseDir)
+	if got, want := azureDevOpsCacheDir(), filepath.Join(baseDir, "cache", "azure-devops"); got != want {
+		t.Errorf("azureDevOpsCacheDir() = %s, want %s in the base directory of master-mold", got, want)
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(env.BaseDir, "")
+	if got, want := azureDevOpsCacheDir(), filepath.Join(home, ".master-mold", "cache", "azure-devops"); got != want {
+		t.Errorf("azureDevOpsCacheDir() = %s, want %s in the default base directory", got, want)
+	}
+}
+
+func TestWriteCachedResponseConcurrently(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "entry.json")
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := writeCachedResponse(path, &cachedResponse{ETag: fmt.Sprintf(`"v%d"`, i), Status: http.StatusOK}); err != nil {
+				t.Errorf("writeCachedResponse() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if cached := readCachedResponse(path); cached == nil {
+		t.Error("readCachedResponse() = nil, want one of the written entries")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("cache directory has %d files, want the entry only", len(entries))
+	}
+}
@@ -0,0 +1,238 @@
+package azuredevops
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Cassette files given with the --record and --replay flags
+var (
+	recordCassette string
+	replayCassette string
+)
+
+// replayToken stands in for the credential when replaying, as no request reaches Azure DevOps
+const replayToken = "replay"
+
+// tokenSourceReplay is the token source while replaying a cassette
+const tokenSourceReplay = "replay"
+
+// cassette holds recorded HTTP interactions
+type cassette struct {
+	Interactions []interaction `json:"interactions"`
+}
+
+// interaction is a recorded request with its response
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+// recordedRequest is a recorded request; its headers are not kept, as they hold the credentials
+type recordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// recordedResponse is a recorded response
+type recordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+	// Base64 marks a binary body, stored base64 encoded
+	Base64 bool `json:"base64,omitempty"`
+}
+
+// recordTransport records the interactions of a transport to a cassette file, rewriting it after every interaction
+// so the recording survives a failing command
+type recordTransport struct {
+	next     http.RoundTripper
+	path     string
+	mu       sync.Mutex
+	cassette cassette
+}
+
+// newRecordTransport wraps a transport with a recorder writing to the cassette file
+func newRecordTransport(next http.RoundTripper, path string) *recordTransport {
+	return &recordTransport{next: next, path: path}
+}
+
+// RoundTrip sends the request and records it with its response
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isAADRequest(req) {
+		// Sign-in traffic carries tokens, so it is never recorded
+		return t.next.RoundTrip(req)
+	}
+
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body")
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	recorded := recordedResponse{Status: resp.StatusCode, Header: resp.Header.Clone()}
+	recorded.Header.Del("Set-Cookie")
+	if utf8.Valid(responseBody) {
+		recorded.Body = string(responseBody)
+	} else {
+		recorded.Body = base64.StdEncoding.EncodeToString(responseBody)
+		recorded.Base64 = true
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cassette.Interactions = append(t.cassette.Interactions, interaction{
+		Request:  recordedRequest{Method: req.Method, URL: req.URL.String(), Body: string(requestBody)},
+		Response: recorded,
+	})
+	if err := writeCassette(t.path, &t.cassette); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// replayTransport answers requests with the interactions of a cassette, without any network access
+type replayTransport struct {
+	mu           sync.Mutex
+	interactions []interaction
+	used         []bool
+}
+
+// newReplayTransport loads a cassette file to replay
+func newReplayTransport(path string) (*replayTransport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read cassette %s", path)
+	}
+
+	var recorded cassette
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse cassette %s", path)
+	}
+
+	return &replayTransport{interactions: recorded.Interactions, used: make([]bool, len(recorded.Interactions))}, nil
+}
+
+// RoundTrip answers the request with the first unused interaction recorded for it. Interactions match on the
+// method, URL and body, or else on the method and path, as query parameters may hold times that change between runs.
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	exact := func(recorded recordedRequest) bool {
+		return recorded.Method == req.Method && recorded.URL == req.URL.String() && recorded.Body == string(body)
+	}
+	samePath := func(recorded recordedRequest) bool {
+		recordedURL, err := url.Parse(recorded.URL)
+		return err == nil && recorded.Method == req.Method && recordedURL.Host == req.URL.Host && recordedURL.Path == req.URL.Path
+	}
+
+	for _, matches := range []func(recordedRequest) bool{exact, samePath} {
+		for i, recorded := range t.interactions {
+			if !t.used[i] && matches(recorded.Request) {
+				t.used[i] = true
+				return recorded.Response.response(req)
+			}
+		}
+	}
+
+	return nil, errors.Errorf("no recorded interaction for %s %s", req.Method, req.URL.Redacted())
+}
+
+// response rebuilds the HTTP response of a recorded response
+func (r recordedResponse) response(req *http.Request) (*http.Response, error) {
+	body := []byte(r.Body)
+	if r.Base64 {
+		decoded, err := base64.StdEncoding.DecodeString(r.Body)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid recorded response body")
+		}
+		body = decoded
+	}
+
+	header := r.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// readRequestBody reads the body of a request, leaving it readable for the transport
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read request body")
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
+
+// isAADRequest checks if a request goes to Azure AD
+func isAADRequest(req *http.Request) bool {
+	authority, err := url.Parse(aadAuthority)
+	return err == nil && req.URL.Host == authority.Host
+}
+
+// writeCassette writes recorded interactions to a cassette file
+func writeCassette(path string, recorded *cassette) error {
+	data, err := json.MarshalIndent(recorded, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode cassette")
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write cassette %s", path)
+	}
+	return nil
+}
+
+// replayTokenSources stand in for all credentials while replaying
+var replayTokenSources = tokenSources{
+	stored: func(string) (string, bool) { return replayToken, false },
+}
@@ -0,0 +1,244 @@
+package azuredevops
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// iterationDateLayout is the layout of the iteration start and finish date flags
+const iterationDateLayout = "2006-01-02"
+
+// Names of the root nodes of the classification trees, as they appear in node paths
+const (
+	iterationRootName = "Iteration"
+	areaRootName      = "Area"
+)
+
+// createIterationCommand creates an iteration, optionally under a parent iteration
+func createIterationCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Creating iteration", "name", args[0])
+
+	// Get the parent iteration
+	parent, err := cmd.Flags().GetString("parent")
+	if err != nil {
+		return handleError("Failed to get parent flag", err)
+	}
+
+	// Get the iteration dates
+	attributes, err := getIterationDates(cmd)
+	if err != nil {
+		return handleError("Invalid iteration dates", err)
+	}
+
+	// Create the iteration
+	node := &workitemtracking.WorkItemClassificationNode{
+		Name:       &args[0],
+		Attributes: attributes,
+	}
+	created, err := createClassificationNode(workitemtracking.TreeStructureGroupValues.Iterations, parent, node)
+	if err != nil {
+		return handleError("Failed to create iteration", err)
+	}
+
+	fmt.Printf("Created iteration %s\n", stringValue(created.Path))
+
+	logger.Info("Iteration created successfully", "path", stringValue(created.Path))
+	return nil
+}
+
+// updateIterationCommand renames an iteration or changes its dates
+func updateIterationCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Updating iteration", "path", args[0])
+
+	// Get the new name
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return handleError("Failed to get name flag", err)
+	}
+
+	// Get the iteration dates
+	attributes, err := getIterationDates(cmd)
+	if err != nil {
+		return handleError("Invalid iteration dates", err)
+	}
+
+	if name == "" && attributes == nil {
+		return handleError("Nothing to update", errors.New("use --name, or --start and --finish"))
+	}
+
+	// Update the iteration
+	node := &workitemtracking.WorkItemClassificationNode{
+		Attributes: attributes,
+	}
+	if name != "" {
+		node.Name = &name
+	}
+	updated, err := updateClassificationNode(workitemtracking.TreeStructureGroupValues.Iterations, args[0], node)
+	if err != nil {
+		return handleError("Failed to update iteration", err)
+	}
+
+	fmt.Printf("Updated iteration %s\n", stringValue(updated.Path))
+
+	logger.Info("Iteration updated successfully", "path", stringValue(updated.Path))
+	return nil
+}
+
+// createAreaCommand creates an area, optionally under a parent area
+func createAreaCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Creating area", "name", args[0])
+
+	// Get the parent area
+	parent, err := cmd.Flags().GetString("parent")
+	if err != nil {
+		return handleError("Failed to get parent flag", err)
+	}
+
+	// Create the area
+	node := &workitemtracking.WorkItemClassificationNode{
+		Name: &args[0],
+	}
+	created, err := createClassificationNode(workitemtracking.TreeStructureGroupValues.Areas, parent, node)
+	if err != nil {
+		return handleError("Failed to create area", err)
+	}
+
+	fmt.Printf("Created area %s\n", stringValue(created.Path))
+
+	logger.Info("Area created successfully", "path", stringValue(created.Path))
+	return nil
+}
+
+// getIterationDates reads the start and finish date flags into node attributes, or nil when neither is given
+func getIterationDates(cmd *cobra.Command) (*map[string]interface{}, error) {
+	start, err := cmd.Flags().GetString("start")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get start flag")
+	}
+	finish, err := cmd.Flags().GetString("finish")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get finish flag")
+	}
+
+	return buildIterationAttributes(start, finish)
+}
+
+// buildIterationAttributes builds the start and finish date attributes of an iteration
+func buildIterationAttributes(start, finish string) (*map[string]interface{}, error) {
+	if start == "" && finish == "" {
+		return nil, nil
+	}
+	if start == "" || finish == "" {
+		return nil, errors.New("--start and --finish must be given together")
+	}
+
+	startDate, err := time.Parse(iterationDateLayout, start)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid start date '%s', expected YYYY-MM-DD", start)
+	}
+	finishDate, err := time.Parse(iterationDateLayout, finish)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid finish date '%s', expected YYYY-MM-DD", finish)
+	}
+	if finishDate.Before(startDate) {
+		return nil, errors.Errorf("finish date %s is before start date %s", finish, start)
+	}
+
+	return &map[string]interface{}{
+		"startDate":  startDate.Format(time.RFC3339),
+		"finishDate": finishDate.Format(time.RFC3339),
+	}, nil
+}
+
+// classificationRootName gets the name of the root node of a classification tree
+func classificationRootName(structureGroup workitemtracking.TreeStructureGroup) string {
+	if structureGroup == workitemtracking.TreeStructureGroupValues.Areas {
+		return areaRootName
+	}
+	return iterationRootName
+}
+
+// normalizeClassificationPath converts a node path to a path relative to the root of its tree.
+// Both "Sprint 1" and "\MyProject\Iteration\Sprint 1", as shown by Azure DevOps, are accepted.
+func normalizeClassificationPath(project string, structureGroup workitemtracking.TreeStructureGroup, path string) string {
+	segments := strings.FieldsFunc(path, func(r rune) bool {
+		return r == '\\' || r == '/'
+	})
+
+	if len(segments) > 0 && strings.EqualFold(segments[0], project) {
+		segments = segments[1:]
+		if len(segments) > 0 && strings.EqualFold(segments[0], classificationRootName(structureGroup)) {
+			segments = segments[1:]
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// createClassificationNode creates an area or iteration under the parent path, or under the root when it is empty
+func createClassificationNode(structureGroup workitemtracking.TreeStructureGroup, parent string, node *workitemtracking.WorkItemClassificationNode) (*workitemtracking.WorkItemClassificationNode, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Work Item Tracking API
+	client, err := createAzureDevOpsClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	args := workitemtracking.CreateOrUpdateClassificationNodeArgs{
+		PostedNode:     node,
+		Project:        &connectionDetails.Project,
+		StructureGroup: &structureGroup,
+	}
+	if path := normalizeClassificationPath(connectionDetails.Project, structureGroup, parent); path != "" {
+		args.Path = &path
+	}
+
+	created, err := client.CreateOrUpdateClassificationNode(commandContext, args)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create '%s'", stringValue(node.Name))
+	}
+
+	return created, nil
+}
+
+// updateClassificationNode updates the area or iteration at the path
+func updateClassificationNode(structureGroup workitemtracking.TreeStructureGroup, path string, node *workitemtracking.WorkItemClassificationNode) (*workitemtracking.WorkItemClassificationNode, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Work Item Tracking API
+	client, err := createAzureDevOpsClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	nodePath := normalizeClassificationPath(connectionDetails.Project, structureGroup, path)
+	if nodePath == "" {
+		return nil, errors.New("the root node cannot be updated")
+	}
+
+	updated, err := client.UpdateClassificationNode(commandContext, workitemtracking.UpdateClassificationNodeArgs{
+		PostedNode:     node,
+		Project:        &connectionDetails.Project,
+		StructureGroup: &structureGroup,
+		Path:           &nodePath,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update '%s'", nodePath)
+	}
+
+	return updated, nil
+}
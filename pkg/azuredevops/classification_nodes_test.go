@@ -0,0 +1,57 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func TestNormalizeClassificationPath(t *testing.T) {
+	iterations := workitemtracking.TreeStructureGroupValues.Iterations
+	areas := workitemtracking.TreeStructureGroupValues.Areas
+
+	tests := []struct {
+		structureGroup workitemtracking.TreeStructureGroup
+		path           string
+		want           string
+	}{
+		{iterations, "", ""},
+		{iterations, "Sprint 1", "Sprint 1"},
+		{iterations, "Release 1/Sprint 1", "Release 1/Sprint 1"},
+		{iterations, `\MyProject\Iteration\Release 1\Sprint 1`, "Release 1/Sprint 1"},
+		{iterations, `MyProject\Release 1`, "Release 1"},
+		{areas, `\MyProject\Area\Team A`, "Team A"},
+		{areas, "/Team A/Backend/", "Team A/Backend"},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeClassificationPath("MyProject", tt.structureGroup, tt.path); got != tt.want {
+			t.Errorf("normalizeClassificationPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestBuildIterationAttributes(t *testing.T) {
+	attributes, err := buildIterationAttributes("", "")
+	if err != nil || attributes != nil {
+		t.Errorf("buildIterationAttributes() without dates = %v, %v, want nil", attributes, err)
+	}
+
+	attributes, err = buildIterationAttributes("2024-01-08", "2024-01-19")
+	if err != nil {
+		t.Fatalf("buildIterationAttributes() error = %v", err)
+	}
+	if (*attributes)["startDate"] != "2024-01-08T00:00:00Z" || (*attributes)["finishDate"] != "2024-01-19T00:00:00Z" {
+		t.Errorf("buildIterationAttributes() = %v", *attributes)
+	}
+
+	for _, dates := range [][2]string{
+		{"2024-01-08", ""},
+		{"08/01/2024", "2024-01-19"},
+		{"2024-01-19", "2024-01-08"},
+	} {
+		if _, err := buildIterationAttributes(dates[0], dates[1]); err == nil {
+			t.Errorf("buildIterationAttributes(%s, %s) error = nil, want an error", dates[0], dates[1])
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// commitPageSize is the number of commits requested per page
+const commitPageSize = 100
+
+// Commit represents a commit of a Git repository
+type Commit struct {
+	ID        string    `json:"id"`
+	Author    string    `json:"author"`
+	Email     string    `json:"email"`
+	Date      time.Time `json:"date"`
+	Message   string    `json:"message"`
+	WorkItems []int     `json:"workItems"`
+	URL       string    `json:"url"`
+}
+
+// listCommitsCommand lists the recent commits of a repository branch
+func listCommitsCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing commits", "repository", args[0])
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the branch to list commits of
+	branch, err := cmd.Flags().GetString("branch")
+	if err != nil {
+		return handleError("Failed to get branch flag", err)
+	}
+
+	// Get the period to list commits for
+	sinceValue, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return handleError("Failed to get since flag", err)
+	}
+	period, err := parseSincePeriod(sinceValue)
+	if err != nil {
+		return handleError("Invalid since flag", err)
+	}
+
+	// Get the commits
+	commits, err := getCommits(args[0], branch, time.Now().Add(-period))
+	if err != nil {
+		return handleError("Failed to get commits", err)
+	}
+
+	// Print the commits
+	if jsonOutput {
+		printCommitsAsJSON(commits)
+	} else {
+		printCommitsAsText(commits)
+	}
+
+	logger.Info("Commits listed successfully", "repository", args[0])
+	return nil
+}
+
+// getCommits gets the commits of a branch made since the given time, newest first
+func getCommits(repository, branch string, since time.Time) ([]Commit, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Git API
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	fromDate := since.UTC().Format(time.RFC3339)
+	includeWorkItems := true
+	searchCriteria := git.GitQueryCommitsCriteria{
+		FromDate:         &fromDate,
+		IncludeWorkItems: &includeWorkItems,
+	}
+
+	// Without a branch the default branch is used
+	if branch != "" {
+		branchName := strings.TrimPrefix(branch, "refs/heads/")
+		versionType := git.GitVersionTypeValues.Branch
+		searchCriteria.ItemVersion = &git.GitVersionDescriptor{
+			Version:     &branchName,
+			VersionType: &versionType,
+		}
+	}
+
+	result := []Commit{}
+	top := commitPageSize
+	for skip := 0; ; skip += top {
+		searchCriteria.Top = &top
+		searchCriteria.Skip = &skip
+		commits, err := client.GetCommits(commandContext, git.GetCommitsArgs{
+			RepositoryId:   &repository,
+			SearchCriteria: &searchCriteria,
+			Project:        &connectionDetails.Project,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get commits of %s", repository)
+		}
+
+		for _, commit := range *commits {
+			result = append(result, convertRepositoryCommit(commit))
+		}
+
+		if len(*commits) < top {
+			return result, nil
+		}
+	}
+}
+
+// convertRepositoryCommit converts an Azure DevOps commit to our model
+func convertRepositoryCommit(commit git.GitCommitRef) Commit {
+	result := Commit{
+		ID:        stringValue(commit.CommitId),
+		Message:   stringValue(commit.Comment),
+		WorkItems: []int{},
+		URL:       stringValue(commit.RemoteUrl),
+	}
+	if commit.Author != nil {
+		result.Author = stringValue(commit.Author.Name)
+		result.Email = stringValue(commit.Author.Email)
+		if commit.Author.Date != nil {
+			result.Date = commit.Author.Date.Time
+		}
+	}
+	if commit.WorkItems != nil {
+		for _, workItem := range *commit.WorkItems {
+			if id, err := strconv.Atoi(stringValue(workItem.Id)); err == nil {
+				result.WorkItems = append(result.WorkItems, id)
+			}
+		}
+	}
+	return result
+}
+
+// formatWorkItemIDs formats work item IDs as a comma-separated list of #IDs
+func formatWorkItemIDs(ids []int) string {
+	var parts []string
+	for _, id := range ids {
+		parts = append(parts, fmt.Sprintf("#%d", id))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printCommitsAsText prints commits in a human-readable format
+func printCommitsAsText(commits []Commit) {
+	if len(commits) == 0 {
+		fmt.Println("No commits found.")
+		return
+	}
+
+	fmt.Printf("Found %d commits:\n\n", len(commits))
+
+	for _, commit := range commits {
+		fmt.Printf("Commit: %s\n", shortCommitID(commit.ID))
+		fmt.Printf("Author: %s <%s>\n", commit.Author, commit.Email)
+		fmt.Printf("Date: %s\n", commit.Date.Format(time.RFC3339))
+		fmt.Printf("Message: %s\n", firstLine(commit.Message))
+		if len(commit.WorkItems) > 0 {
+			fmt.Printf("Work Items: %s\n", formatWorkItemIDs(commit.WorkItems))
+		}
+		fmt.Println()
+	}
+}
+
+// printCommitsAsJSON prints commits in JSON format
+func printCommitsAsJSON(commits []Commit) {
+	// Marshal the commits to JSON with indentation
+	jsonData, err := json.MarshalIndent(commits, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal commits to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal commits to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
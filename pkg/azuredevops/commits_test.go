@@ -0,0 +1,46 @@
+package azuredevops
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/webapi"
+)
+
+func TestConvertRepositoryCommit(t *testing.T) {
+	commitID, comment := "0123456789abcdef0123456789abcdef01234567", "Fix login\n\nDetails"
+	name, email := "Jane Doe", "jane@example.com"
+	date := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+	first, second, invalid := "42", "7", "not-a-number"
+
+	got := convertRepositoryCommit(git.GitCommitRef{
+		CommitId:  &commitID,
+		Comment:   &comment,
+		Author:    &git.GitUserDate{Name: &name, Email: &email, Date: &azuredevops.Time{Time: date}},
+		WorkItems: &[]webapi.ResourceRef{{Id: &first}, {Id: &second}, {Id: &invalid}},
+	})
+
+	want := Commit{
+		ID:        commitID,
+		Author:    name,
+		Email:     email,
+		Date:      date,
+		Message:   comment,
+		WorkItems: []int{42, 7},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("convertRepositoryCommit() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFormatWorkItemIDs(t *testing.T) {
+	if got := formatWorkItemIDs([]int{42, 7}); got != "#42, #7" {
+		t.Errorf("formatWorkItemIDs() = %s, want '#42, #7'", got)
+	}
+	if got := formatWorkItemIDs(nil); got != "" {
+		t.Errorf("formatWorkItemIDs(nil) = %s, want empty", got)
+	}
+}
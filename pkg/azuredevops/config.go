@@ -0,0 +1,378 @@
+package azuredevops
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	mmconfig "github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/describe"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// Environment variables that select the configuration
+const (
+	EnvAzureDevOpsConfig  = "AZURE_DEVOPS_CONFIG"
+	EnvAzureDevOpsProfile = "AZURE_DEVOPS_PROFILE"
+)
+
+// envVars are the environment variables the subcommand reads, as it describes them to master-mold. The connection
+// details can also come from the configuration file, but are required in the environment without one.
+var envVars = []describe.EnvVar{
+	{Name: EnvAzureDevOpsToken, Description: "Personal access token, unless set in azure-devops.toml or the keyring", Required: true},
+	{Name: EnvAzureDevOpsOrg, Description: "Organization name, unless set in azure-devops.toml", Required: true},
+	{Name: EnvAzureDevOpsProject, Description: "Project name, unless set in azure-devops.toml", Required: true},
+	{Name: EnvAzureDevOpsAPIVersion, Description: "REST API version, " + DefaultAzureDevOpsAPIVersion + " by default"},
+	{Name: EnvAzureDevOpsConfig, Description: "Configuration file, instead of ~/.master-mold/azure-devops.toml"},
+	{Name: EnvAzureDevOpsProfile, Description: "Configuration profile, instead of the default one"},
+}
+
+// Token sources of tokens read from the system keyring and of tokens set in the configuration file
+const (
+	tokenSourceKeyring = "keyring"
+	tokenSourceConfig  = "config"
+)
+
+// defaultConfigFile is the configuration file name, relative to the master-mold base directory
+const defaultConfigFile = "azure-devops.toml"
+
+// profileName is the profile selected with the --profile flag
+var profileName string
+
+// flagSettings holds the organization and project given with the --org and --project flags
+var flagSettings ConnectionSettings
+
+// ConnectionSettings holds connection settings of the configuration file or of one of its profiles
+type ConnectionSettings struct {
+	Organization string `mapstructure:"organization"`
+	Project      string `mapstructure:"project"`
+	APIVersion   string `mapstructure:"api_version"`
+	// TokenEnv names the environment variable holding the PAT, so the token itself is not stored in the file
+	TokenEnv string `mapstructure:"token_env"`
+	// Token is the PAT, given as a keyring: or age: secret so it is not stored in plaintext
+	Token string `mapstructure:"token"`
+	// TenantID and ClientID select a service principal, which authenticates with the secret held by the
+	// ClientSecretEnv environment variable or with the ClientCertificate PEM file
+	TenantID          string `mapstructure:"tenant_id"`
+	ClientID          string `mapstructure:"client_id"`
+	ClientSecretEnv   string `mapstructure:"client_secret_env"`
+	ClientCertificate string `mapstructure:"client_certificate"`
+}
+
+// tokenSources look up tokens that are not given as a PAT in the environment
+type tokenSources struct {
+	// stored gets the token stored in the keyring for an organization, and whether it is a bearer token
+	stored func(organization string) (string, bool)
+	// servicePrincipal gets an access token for a service principal
+	servicePrincipal func(servicePrincipal) (string, error)
+	// azureCLI gets an access token from the Azure CLI
+	azureCLI func() (string, error)
+}
+
+// defaultTokenSources look up tokens in the system keyring, Azure AD and the Azure CLI
+var defaultTokenSources = tokenSources{
+	stored:           lookupStoredToken,
+	servicePrincipal: servicePrincipalToken,
+	azureCLI:         azureCLIToken,
+}
+
+// AzureDevOpsConfig holds the settings read from the configuration file
+type AzureDevOpsConfig struct {
+	ConnectionSettings `mapstructure:",squash"`
+	DefaultProfile     string                        `mapstructure:"default_profile"`
+	Profiles           map[string]ConnectionSettings `mapstructure:"profiles"`
+	// ProxyURL is the proxy for API calls; without it the standard proxy environment variables are honored
+	ProxyURL string `mapstructure:"proxy_url"`
+	// CABundle is a PEM file with extra certificate authorities to trust, such as a corporate TLS inspection CA
+	CABundle string `mapstructure:"ca_bundle"`
+	// RetryMax is the number of times rate limited and failed API calls are retried
+	RetryMax *int `mapstructure:"retry_max"`
+	// Cache enables the local response cache, revalidated with ETags
+	Cache bool `mapstructure:"cache"`
+	// PATExpiryWarningDays is how many days before its expiry a PAT is warned about; 0 disables the warning
+	PATExpiryWarningDays *int `mapstructure:"pat_expiry_warning_days"`
+
+	path string
+	// overrides are settings given on the command line, taking precedence over all others
+	overrides ConnectionSettings
+}
+
+// azureDevOpsConfigPath gets the path of the configuration file
+func azureDevOpsConfigPath() string {
+	if path := env.Getenv(EnvAzureDevOpsConfig); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".master-mold", defaultConfigFile)
+	}
+	return filepath.Join(home, ".master-mold", defaultConfigFile)
+}
+
+// loadAzureDevOpsConfig loads the configuration file over the [plugins.azure-devops] table of the master-mold
+// configuration; without either, the configuration is empty
+func loadAzureDevOpsConfig(path string) (*AzureDevOpsConfig, error) {
+	config := &AzureDevOpsConfig{path: path}
+
+	v := viper.New()
+	var pluginSettings map[string]interface{}
+	found, err := env.DecodePluginConfig(&pluginSettings)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		if err := v.MergeConfigMap(pluginSettings); err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", env.PluginConfig)
+		}
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if !found {
+			return config, nil
+		}
+	} else {
+		v.SetConfigFile(path)
+		v.SetConfigType("toml")
+		if err := v.MergeInConfig(); err != nil {
+			return nil, errors.Wrapf(err, "failed to read config file %s", path)
+		}
+	}
+	if _, err := mmconfig.ResolveSecrets(v); err != nil {
+		return nil, errors.Wrapf(err, "failed to read config file %s", path)
+	}
+
+	if err := v.Unmarshal(config); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal config file %s", path)
+	}
+
+	return config, nil
+}
+
+// resolvedConnectionDetails caches the connection details of the running command, so the configuration file,
+// the keyring and the token endpoints are only consulted once
+var resolvedConnectionDetails struct {
+	sync.Mutex
+	details *ConnectionDetails
+}
+
+// getAzureDevOpsConnectionDetails gets the Azure DevOps connection details from the configuration file,
+// with environment variables taking precedence
+func getAzureDevOpsConnectionDetails() (*ConnectionDetails, error) {
+	resolvedConnectionDetails.Lock()
+	defer resolvedConnectionDetails.Unlock()
+
+	if resolvedConnectionDetails.details == nil {
+		config, err := loadConnectionConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		sources := defaultTokenSources
+		if replayCassette != "" {
+			sources = replayTokenSources
+		}
+
+		details, err := resolveConnectionDetails(config, selectedProfileName(), env.Getenv, sources)
+		if err != nil {
+			return nil, err
+		}
+		if replayCassette != "" {
+			details.TokenSource = tokenSourceReplay
+		}
+		resolvedConnectionDetails.details = details
+	}
+
+	// Return a copy, so callers cannot change the shared details
+	details := *resolvedConnectionDetails.details
+	return &details, nil
+}
+
+// loadConnectionConfig loads the configuration file with the --org and --project flags as overrides
+func loadConnectionConfig() (*AzureDevOpsConfig, error) {
+	config, err := loadAzureDevOpsConfig(azureDevOpsConfigPath())
+	if err != nil {
+		return nil, err
+	}
+
+	config.overrides = flagSettings
+	return config, nil
+}
+
+// selectedProfileName gets the profile selected with the --profile flag or the environment
+func selectedProfileName() string {
+	return firstNonEmpty(profileName, env.Getenv(EnvAzureDevOpsProfile))
+}
+
+// findProfile finds a profile of the configuration by name, ignoring case
+func findProfile(config *AzureDevOpsConfig, name string) (ConnectionSettings, error) {
+	for profile, settings := range config.Profiles {
+		if strings.EqualFold(profile, name) {
+			return settings, nil
+		}
+	}
+
+	var names []string
+	for profile := range config.Profiles {
+		names = append(names, profile)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return ConnectionSettings{}, errors.Errorf("profile %s not found: %s defines no profiles", name, config.path)
+	}
+	return ConnectionSettings{}, errors.Errorf("profile %s not found in %s (available: %s)", name, config.path, strings.Join(names, ", "))
+}
+
+// connectionLayers orders the sources of connection settings from highest to lowest precedence: the overrides,
+// the explicitly selected profile, the environment variables, the default profile and the top-level settings
+func connectionLayers(config *AzureDevOpsConfig, selectedProfile string, getenv func(string) string) ([]ConnectionSettings, error) {
+	layers := []ConnectionSettings{config.overrides}
+
+	if selectedProfile != "" {
+		profile, err := findProfile(config, selectedProfile)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, profile)
+	}
+
+	layers = append(layers, ConnectionSettings{
+		Organization:      getenv(EnvAzureDevOpsOrg),
+		Project:           getenv(EnvAzureDevOpsProject),
+		APIVersion:        getenv(EnvAzureDevOpsAPIVersion),
+		TokenEnv:          EnvAzureDevOpsToken,
+		TenantID:          getenv(EnvAzureTenantID),
+		ClientID:          getenv(EnvAzureClientID),
+		ClientSecretEnv:   EnvAzureClientSecret,
+		ClientCertificate: getenv(EnvAzureClientCertificate),
+	})
+
+	if selectedProfile == "" && config.DefaultProfile != "" {
+		profile, err := findProfile(config, config.DefaultProfile)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, profile)
+	}
+
+	return append(layers, config.ConnectionSettings), nil
+}
+
+// mergeConnectionLayers merges connection layers given from highest to lowest precedence, reading tokens with getenv.
+// It also returns the environment variables the token was looked up in.
+func mergeConnectionLayers(layers []ConnectionSettings, getenv func(string) string) (*ConnectionDetails, []string) {
+	details := &ConnectionDetails{APIVersion: DefaultAzureDevOpsAPIVersion}
+	var tokenEnvs []string
+	for i := len(layers) - 1; i >= 0; i-- {
+		layer := layers[i]
+		details.Organization = firstNonEmpty(layer.Organization, details.Organization)
+		details.Project = firstNonEmpty(layer.Project, details.Project)
+		details.APIVersion = firstNonEmpty(layer.APIVersion, details.APIVersion)
+		if layer.TokenEnv != "" {
+			if token := getenv(layer.TokenEnv); token != "" {
+				details.Token = token
+				details.TokenSource = layer.TokenEnv
+			}
+			tokenEnvs = append([]string{layer.TokenEnv}, tokenEnvs...)
+		}
+		if layer.Token != "" {
+			details.Token = layer.Token
+			details.TokenSource = tokenSourceConfig
+		}
+	}
+	return details, tokenEnvs
+}
+
+// mergeServicePrincipal merges the service principal settings of connection layers given from highest to lowest
+// precedence, reading the client secret with getenv
+func mergeServicePrincipal(layers []ConnectionSettings, getenv func(string) string) servicePrincipal {
+	var sp servicePrincipal
+	for i := len(layers) - 1; i >= 0; i-- {
+		layer := layers[i]
+		sp.TenantID = firstNonEmpty(layer.TenantID, sp.TenantID)
+		sp.ClientID = firstNonEmpty(layer.ClientID, sp.ClientID)
+		sp.ClientCertificate = firstNonEmpty(layer.ClientCertificate, sp.ClientCertificate)
+		if layer.ClientSecretEnv != "" {
+			sp.ClientSecret = firstNonEmpty(getenv(layer.ClientSecretEnv), sp.ClientSecret)
+		}
+	}
+	return sp
+}
+
+// resolveOrganization gets the organization from the configuration, the selected profile and the environment variables
+func resolveOrganization(config *AzureDevOpsConfig, selectedProfile string, getenv func(string) string) (string, error) {
+	layers, err := connectionLayers(config, selectedProfile, getenv)
+	if err != nil {
+		return "", err
+	}
+
+	details, _ := mergeConnectionLayers(layers, getenv)
+	if details.Organization == "" {
+		return "", fmt.Errorf("Azure DevOps Organization not found. Use --org, set the %s environment variable or organization in %s", EnvAzureDevOpsOrg, config.path)
+	}
+	return details.Organization, nil
+}
+
+// resolveConnectionDetails combines the configuration, the selected profile and the environment variables read by getenv.
+// A token stored in the keyring for the organization is used before the environment variables; without either,
+// a token is requested for the configured service principal, or else from the Azure CLI.
+func resolveConnectionDetails(config *AzureDevOpsConfig, selectedProfile string, getenv func(string) string, sources tokenSources) (*ConnectionDetails, error) {
+	layers, err := connectionLayers(config, selectedProfile, getenv)
+	if err != nil {
+		return nil, err
+	}
+	details, tokenEnvs := mergeConnectionLayers(layers, getenv)
+
+	// Get the organization
+	if details.Organization == "" {
+		return nil, fmt.Errorf("Azure DevOps Organization not found. Use --org, set the %s environment variable or organization in %s", EnvAzureDevOpsOrg, config.path)
+	}
+
+	// Get the token, from the keyring or through the environment variables
+	if token, bearer := sources.stored(details.Organization); token != "" {
+		details.Token = token
+		details.Bearer = bearer
+		details.TokenSource = tokenSourceKeyring
+	}
+	if sp := mergeServicePrincipal(layers, getenv); details.Token == "" && sp.configured() {
+		token, err := sources.servicePrincipal(sp)
+		if err != nil {
+			return nil, err
+		}
+		details.Token = token
+		details.Bearer = true
+		details.TokenSource = tokenSourceServicePrincipal
+	}
+	if details.Token == "" {
+		if token, err := sources.azureCLI(); err == nil {
+			details.Token = token
+			details.Bearer = true
+			details.TokenSource = tokenSourceAzureCLI
+		}
+	}
+	if details.Token == "" {
+		return nil, fmt.Errorf("Azure DevOps Personal Access Token not found. Run 'azure-devops auth login' or 'az login', or set the %s environment variable", strings.Join(tokenEnvs, " or "))
+	}
+
+	// Get the project
+	if details.Project == "" {
+		return nil, fmt.Errorf("Azure DevOps Project not found. Use --project, set the %s environment variable or project in %s", EnvAzureDevOpsProject, config.path)
+	}
+
+	return details, nil
+}
+
+// firstNonEmpty returns the first value that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,335 @@
+package azuredevops
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/pkg/errors"
+)
+
+// noStoredToken is a keyring lookup that finds no token
+func noStoredToken(string) (string, bool) {
+	return "", false
+}
+
+// noAzureCLIToken is an Azure CLI lookup that finds no token
+func noAzureCLIToken() (string, error) {
+	return "", errors.New("the Azure CLI is not installed")
+}
+
+// noTokenSources finds no token outside the environment
+var noTokenSources = tokenSources{stored: noStoredToken, azureCLI: noAzureCLIToken}
+
+func TestLoadAzureDevOpsConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "azure-devops.toml")
+	content := `organization = "myorg"
+project = "web"
+api_version = "7.1"
+token_env = "WORK_ADO_PAT"
+default_profile = "work"
+
+[profiles.oss]
+organization = "ossorg"
+token_env = "OSS_ADO_PAT"
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	config, err := loadAzureDevOpsConfig(path)
+	if err != nil {
+		t.Fatalf("loadAzureDevOpsConfig() error = %v", err)
+	}
+	if config.Organization != "myorg" || config.Project != "web" || config.APIVersion != "7.1" || config.TokenEnv != "WORK_ADO_PAT" {
+		t.Errorf("loadAzureDevOpsConfig() = %+v", config)
+	}
+	if config.DefaultProfile != "work" {
+		t.Errorf("DefaultProfile = %s, want work", config.DefaultProfile)
+	}
+	if profile := config.Profiles["oss"]; profile.Organization != "ossorg" || profile.TokenEnv != "OSS_ADO_PAT" {
+		t.Errorf("Profiles[oss] = %+v", profile)
+	}
+}
+
+func TestLoadAzureDevOpsConfigMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.toml")
+
+	config, err := loadAzureDevOpsConfig(path)
+	if err != nil {
+		t.Fatalf("loadAzureDevOpsConfig() error = %v", err)
+	}
+	if config.Organization != "" || config.path != path {
+		t.Errorf("loadAzureDevOpsConfig() = %+v, want an empty config for %s", config, path)
+	}
+}
+
+func TestLoadAzureDevOpsConfigPluginSettings(t *testing.T) {
+	t.Setenv(env.PluginConfig, `{"organization":"pluginorg","project":"web","profiles":{"oss":{"organization":"ossorg"}}}`)
+
+	// The plugin settings of master-mold stand in for a missing file
+	config, err := loadAzureDevOpsConfig(filepath.Join(t.TempDir(), "missing.toml"))
+	if err != nil {
+		t.Fatalf("loadAzureDevOpsConfig() error = %v", err)
+	}
+	if config.Organization != "pluginorg" || config.Project != "web" || config.Profiles["oss"].Organization != "ossorg" {
+		t.Errorf("loadAzureDevOpsConfig() = %+v, want the plugin settings", config)
+	}
+
+	// The file wins over them
+	path := filepath.Join(t.TempDir(), "azure-devops.toml")
+	if err := os.WriteFile(path, []byte("project = \"api\"\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	config, err = loadAzureDevOpsConfig(path)
+	if err != nil {
+		t.Fatalf("loadAzureDevOpsConfig() error = %v", err)
+	}
+	if config.Organization != "pluginorg" || config.Project != "api" {
+		t.Errorf("loadAzureDevOpsConfig() = %+v, want the project of the file", config)
+	}
+}
+
+func TestResolveConnectionDetails(t *testing.T) {
+	config := &AzureDevOpsConfig{
+		ConnectionSettings: ConnectionSettings{Organization: "myorg", Project: "web", TokenEnv: "WORK_ADO_PAT"},
+		path:               "azure-devops.toml",
+	}
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    ConnectionDetails
+		wantErr string
+	}{
+		{
+			name: "config with token reference",
+			env:  map[string]string{"WORK_ADO_PAT": "ref-token"},
+			want: ConnectionDetails{Token: "ref-token", TokenSource: "WORK_ADO_PAT", Organization: "myorg", Project: "web", APIVersion: DefaultAzureDevOpsAPIVersion},
+		},
+		{
+			name: "environment overrides config",
+			env: map[string]string{
+				EnvAzureDevOpsToken:      "env-token",
+				EnvAzureDevOpsOrg:        "otherorg",
+				EnvAzureDevOpsProject:    "api",
+				EnvAzureDevOpsAPIVersion: "6.0",
+				"WORK_ADO_PAT":           "ref-token",
+			},
+			want: ConnectionDetails{Token: "env-token", TokenSource: EnvAzureDevOpsToken, Organization: "otherorg", Project: "api", APIVersion: "6.0"},
+		},
+		{
+			name:    "missing token",
+			env:     map[string]string{},
+			wantErr: "WORK_ADO_PAT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveConnectionDetails(config, "", func(key string) string { return tt.env[key] }, noTokenSources)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("resolveConnectionDetails() error = %v, want it to mention %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveConnectionDetails() error = %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("resolveConnectionDetails() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveConnectionDetailsConfigToken(t *testing.T) {
+	config := &AzureDevOpsConfig{
+		ConnectionSettings: ConnectionSettings{Organization: "myorg", Project: "web", Token: "config-token"},
+		Profiles:           map[string]ConnectionSettings{"oss": {Token: "oss-token"}},
+		path:               "azure-devops.toml",
+	}
+	env := map[string]string{}
+	getenv := func(key string) string { return env[key] }
+
+	got, err := resolveConnectionDetails(config, "", getenv, noTokenSources)
+	if err != nil || got.Token != "config-token" || got.TokenSource != tokenSourceConfig {
+		t.Errorf("resolveConnectionDetails() = %+v, %v, want the token of the config file", got, err)
+	}
+
+	// The environment and the selected profile take precedence over the top-level token
+	env[EnvAzureDevOpsToken] = "env-token"
+	if got, err = resolveConnectionDetails(config, "", getenv, noTokenSources); err != nil || got.Token != "env-token" {
+		t.Errorf("resolveConnectionDetails() = %+v, %v, want the token of the environment", got, err)
+	}
+	if got, err = resolveConnectionDetails(config, "oss", getenv, noTokenSources); err != nil || got.Token != "oss-token" {
+		t.Errorf("resolveConnectionDetails(oss) = %+v, %v, want the token of the profile", got, err)
+	}
+}
+
+func TestResolveConnectionDetailsMissingProject(t *testing.T) {
+	config := &AzureDevOpsConfig{ConnectionSettings: ConnectionSettings{Organization: "myorg"}, path: "azure-devops.toml"}
+	env := map[string]string{EnvAzureDevOpsToken: "token"}
+
+	_, err := resolveConnectionDetails(config, "", func(key string) string { return env[key] }, noTokenSources)
+	if err == nil || !strings.Contains(err.Error(), "project in azure-devops.toml") {
+		t.Errorf("resolveConnectionDetails() error = %v, want it to mention the config file", err)
+	}
+}
+
+func TestResolveConnectionDetailsProfiles(t *testing.T) {
+	config := &AzureDevOpsConfig{
+		ConnectionSettings: ConnectionSettings{Project: "web", TokenEnv: "DEFAULT_ADO_PAT"},
+		DefaultProfile:     "work",
+		Profiles: map[string]ConnectionSettings{
+			"work": {Organization: "workorg", TokenEnv: "WORK_ADO_PAT"},
+			"oss":  {Organization: "ossorg", Project: "tools", TokenEnv: "OSS_ADO_PAT"},
+		},
+		path: "azure-devops.toml",
+	}
+	env := map[string]string{
+		EnvAzureDevOpsOrg: "envorg",
+		"WORK_ADO_PAT":    "work-token",
+		"OSS_ADO_PAT":     "oss-token",
+	}
+	getenv := func(key string) string { return env[key] }
+
+	// An explicitly selected profile takes precedence over the environment
+	got, err := resolveConnectionDetails(config, "OSS", getenv, noTokenSources)
+	if err != nil {
+		t.Fatalf("resolveConnectionDetails(oss) error = %v", err)
+	}
+	want := ConnectionDetails{Token: "oss-token", TokenSource: "OSS_ADO_PAT", Organization: "ossorg", Project: "tools", APIVersion: DefaultAzureDevOpsAPIVersion}
+	if *got != want {
+		t.Errorf("resolveConnectionDetails(oss) = %+v, want %+v", *got, want)
+	}
+
+	// The default profile is overridden by the environment and falls back to the top-level settings
+	got, err = resolveConnectionDetails(config, "", getenv, noTokenSources)
+	if err != nil {
+		t.Fatalf("resolveConnectionDetails() error = %v", err)
+	}
+	want = ConnectionDetails{Token: "work-token", TokenSource: "WORK_ADO_PAT", Organization: "envorg", Project: "web", APIVersion: DefaultAzureDevOpsAPIVersion}
+	if *got != want {
+		t.Errorf("resolveConnectionDetails() = %+v, want %+v", *got, want)
+	}
+
+	// The --org and --project flags override even a selected profile
+	config.overrides = ConnectionSettings{Organization: "flagorg", Project: "flagproject"}
+	got, err = resolveConnectionDetails(config, "oss", getenv, noTokenSources)
+	if err != nil {
+		t.Fatalf("resolveConnectionDetails() with overrides error = %v", err)
+	}
+	want = ConnectionDetails{Token: "oss-token", TokenSource: "OSS_ADO_PAT", Organization: "flagorg", Project: "flagproject", APIVersion: DefaultAzureDevOpsAPIVersion}
+	if *got != want {
+		t.Errorf("resolveConnectionDetails() with overrides = %+v, want %+v", *got, want)
+	}
+	config.overrides = ConnectionSettings{}
+
+	if _, err := resolveConnectionDetails(config, "missing", getenv, noTokenSources); err == nil || !strings.Contains(err.Error(), "available: oss, work") {
+		t.Errorf("resolveConnectionDetails(missing) error = %v, want it to list the profiles", err)
+	}
+}
+
+func TestResolveConnectionDetailsStoredToken(t *testing.T) {
+	config := &AzureDevOpsConfig{ConnectionSettings: ConnectionSettings{Organization: "myorg", Project: "web"}}
+	env := map[string]string{EnvAzureDevOpsToken: "env-token"}
+	storedToken := func(organization string) (string, bool) {
+		if organization == "myorg" {
+			return "stored-token", true
+		}
+		return "", false
+	}
+
+	got, err := resolveConnectionDetails(config, "", func(key string) string { return env[key] }, tokenSources{stored: storedToken, azureCLI: noAzureCLIToken})
+	if err != nil {
+		t.Fatalf("resolveConnectionDetails() error = %v", err)
+	}
+	if got.Token != "stored-token" || !got.Bearer || got.TokenSource != tokenSourceKeyring {
+		t.Errorf("resolveConnectionDetails() = %+v, want the stored bearer token", got)
+	}
+}
+
+func TestResolveConnectionDetailsAzureCLI(t *testing.T) {
+	config := &AzureDevOpsConfig{ConnectionSettings: ConnectionSettings{Organization: "myorg", Project: "web"}}
+	azureCLI := func() (string, error) { return "cli-token", nil }
+
+	// The Azure CLI is only used without a PAT
+	got, err := resolveConnectionDetails(config, "", func(string) string { return "" }, tokenSources{stored: noStoredToken, azureCLI: azureCLI})
+	if err != nil {
+		t.Fatalf("resolveConnectionDetails() error = %v", err)
+	}
+	if got.Token != "cli-token" || !got.Bearer || got.TokenSource != tokenSourceAzureCLI {
+		t.Errorf("resolveConnectionDetails() = %+v, want the Azure CLI bearer token", got)
+	}
+
+	env := map[string]string{EnvAzureDevOpsToken: "env-token"}
+	got, err = resolveConnectionDetails(config, "", func(key string) string { return env[key] }, tokenSources{stored: noStoredToken, azureCLI: azureCLI})
+	if err != nil {
+		t.Fatalf("resolveConnectionDetails() error = %v", err)
+	}
+	if got.Token != "env-token" || got.Bearer {
+		t.Errorf("resolveConnectionDetails() = %+v, want the PAT", got)
+	}
+}
+
+func TestResolveConnectionDetailsServicePrincipal(t *testing.T) {
+	config := &AzureDevOpsConfig{ConnectionSettings: ConnectionSettings{
+		Organization:    "myorg",
+		Project:         "web",
+		TenantID:        "file-tenant",
+		ClientID:        "file-client",
+		ClientSecretEnv: "BUILD_SP_SECRET",
+	}}
+	env := map[string]string{
+		EnvAzureClientID:  "env-client",
+		"BUILD_SP_SECRET": "file-secret",
+	}
+	var requested servicePrincipal
+	sources := noTokenSources
+	sources.servicePrincipal = func(sp servicePrincipal) (string, error) {
+		requested = sp
+		return "sp-token", nil
+	}
+
+	got, err := resolveConnectionDetails(config, "", func(key string) string { return env[key] }, sources)
+	if err != nil {
+		t.Fatalf("resolveConnectionDetails() error = %v", err)
+	}
+	if got.Token != "sp-token" || !got.Bearer || got.TokenSource != tokenSourceServicePrincipal {
+		t.Errorf("resolveConnectionDetails() = %+v, want the service principal token", got)
+	}
+	want := servicePrincipal{TenantID: "file-tenant", ClientID: "env-client", ClientSecret: "file-secret"}
+	if requested != want {
+		t.Errorf("requested service principal = %+v, want %+v", requested, want)
+	}
+
+	// A PAT is used before a service principal
+	env[EnvAzureDevOpsToken] = "env-token"
+	requested = servicePrincipal{}
+	got, err = resolveConnectionDetails(config, "", func(key string) string { return env[key] }, sources)
+	if err != nil || got.Token != "env-token" || requested.ClientID != "" {
+		t.Errorf("resolveConnectionDetails() with a PAT = %+v, %v, want the PAT", got, err)
+	}
+}
+
+func TestResolveOrganization(t *testing.T) {
+	config := &AzureDevOpsConfig{
+		ConnectionSettings: ConnectionSettings{Organization: "myorg"},
+		Profiles:           map[string]ConnectionSettings{"oss": {Organization: "ossorg"}},
+	}
+	getenv := func(string) string { return "" }
+
+	if got, err := resolveOrganization(config, "", getenv); err != nil || got != "myorg" {
+		t.Errorf("resolveOrganization() = %s, %v, want myorg", got, err)
+	}
+	if got, err := resolveOrganization(config, "oss", getenv); err != nil || got != "ossorg" {
+		t.Errorf("resolveOrganization(oss) = %s, %v, want ossorg", got, err)
+	}
+	if _, err := resolveOrganization(&AzureDevOpsConfig{}, "", getenv); err == nil {
+		t.Error("resolveOrganization() without organization error = nil, want an error")
+	}
+}
@@ -0,0 +1,67 @@
+package azuredevops
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+)
+
+// connectionKey identifies the connections that can be shared
+type connectionKey struct {
+	organization string
+	token        string
+	bearer       bool
+}
+
+// connectionFactory shares one connection per organization and credential between all the API calls of a command.
+// A connection caches the API clients and resource locations it looked up, so sharing it saves a round trip per
+// client, and all clients send their requests through the pooled default transport.
+type connectionFactory struct {
+	mu          sync.Mutex
+	connections map[connectionKey]*azuredevops.Connection
+}
+
+// connections is the connection factory of the running command
+var connections = &connectionFactory{}
+
+// get gets the connection for the connection details, creating it on first use
+func (f *connectionFactory) get(connectionDetails *ConnectionDetails) *azuredevops.Connection {
+	key := connectionKey{
+		organization: connectionDetails.Organization,
+		token:        connectionDetails.Token,
+		bearer:       connectionDetails.Bearer,
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if connection, ok := f.connections[key]; ok {
+		return connection
+	}
+	if f.connections == nil {
+		f.connections = make(map[connectionKey]*azuredevops.Connection)
+	}
+
+	connection := createConnection(connectionDetails)
+	f.connections[key] = connection
+	return connection
+}
+
+// newConnection gets the shared connection to the organization of the connection details
+func newConnection(connectionDetails *ConnectionDetails) *azuredevops.Connection {
+	return connections.get(connectionDetails)
+}
+
+// createConnection creates a connection to the organization, authenticating with a PAT or an Azure AD access token
+func createConnection(connectionDetails *ConnectionDetails) *azuredevops.Connection {
+	organizationURL := fmt.Sprintf("https://dev.azure.com/%s", connectionDetails.Organization)
+
+	if connectionDetails.Bearer {
+		connection := azuredevops.NewAnonymousConnection(organizationURL)
+		connection.AuthorizationString = "Bearer " + connectionDetails.Token
+		return connection
+	}
+
+	return azuredevops.NewPatConnection(organizationURL, connectionDetails.Token)
+}
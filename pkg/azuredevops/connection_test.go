@@ -0,0 +1,24 @@
+package azuredevops
+
+import "testing"
+
+func TestConnectionFactory(t *testing.T) {
+	factory := &connectionFactory{}
+
+	first := factory.get(&ConnectionDetails{Organization: "myorg", Token: "token"})
+	if first.BaseUrl != "https://dev.azure.com/myorg" {
+		t.Errorf("connection BaseUrl = %s, want https://dev.azure.com/myorg", first.BaseUrl)
+	}
+	if again := factory.get(&ConnectionDetails{Organization: "myorg", Token: "token", Project: "other"}); again != first {
+		t.Error("get() created a second connection to the same organization")
+	}
+
+	if other := factory.get(&ConnectionDetails{Organization: "otherorg", Token: "token"}); other == first {
+		t.Error("get() shared the connection of another organization")
+	}
+
+	bearer := factory.get(&ConnectionDetails{Organization: "myorg", Token: "token", Bearer: true})
+	if bearer == first || bearer.AuthorizationString != "Bearer token" {
+		t.Errorf("get() with a bearer token = %q, want a separate bearer connection", bearer.AuthorizationString)
+	}
+}
@@ -0,0 +1,40 @@
+package azuredevops
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// commandContext is the context of every API call of the running command.
+// It is cancelled on Ctrl-C, and when the --timeout of the command expires.
+var commandContext = context.Background()
+
+// cancelCommandContext releases the resources of the command context
+var cancelCommandContext context.CancelFunc = func() {}
+
+// commandTimeout is the time limit of the command, set with --timeout; zero means no limit
+var commandTimeout time.Duration
+
+// setupContext derives the context of the API calls from the context of the command and its timeout
+func setupContext(cmd *cobra.Command) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if commandTimeout > 0 {
+		ctx, cancelCommandContext = context.WithTimeout(ctx, commandTimeout)
+	}
+	commandContext = ctx
+}
+
+// setupCommand prepares the context, the output and the HTTP transport before a command runs. Its arguments and
+// flags were valid, so its errors no longer print the usage.
+func setupCommand(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	setupContext(cmd)
+	applyOutputEnv(cmd)
+	return setupTransport(cmd, args)
+}
@@ -0,0 +1,67 @@
+package azuredevops
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func TestSetupContext(t *testing.T) {
+	oldContext, oldTimeout := commandContext, commandTimeout
+	defer func() {
+		cancelCommandContext()
+		commandContext, commandTimeout = oldContext, oldTimeout
+	}()
+
+	parent, cancel := context.WithCancel(context.Background())
+	cmd := &cobra.Command{}
+	cmd.SetContext(parent)
+
+	commandTimeout = 0
+	setupContext(cmd)
+	if _, ok := commandContext.Deadline(); ok {
+		t.Error("setupContext() without a timeout set a deadline")
+	}
+
+	commandTimeout = time.Minute
+	setupContext(cmd)
+	if deadline, ok := commandContext.Deadline(); !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("setupContext() deadline = %v, %v, want within a minute", deadline, ok)
+	}
+
+	// Cancelling the command context, as Ctrl-C does, cancels the API calls
+	cancel()
+	if commandContext.Err() == nil {
+		t.Error("command context was not cancelled with its parent")
+	}
+}
+
+func TestHandleError(t *testing.T) {
+	oldContext, oldLogger := commandContext, logger
+	defer func() { commandContext, logger = oldContext, oldLogger }()
+	logger = slog.New(slog.DiscardHandler)
+
+	commandContext = context.Background()
+	err := handleError("Failed to get pull requests", errors.New("401 Unauthorized"))
+	if err == nil || err.Error() != "Failed to get pull requests: 401 Unauthorized" {
+		t.Errorf("handleError() = %v, want the message and the error", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	commandContext = ctx
+	if err := handleError("Failed to get pull requests", ctx.Err()); !errors.Is(err, context.Canceled) {
+		t.Errorf("handleError() after Ctrl-C = %v, want context.Canceled", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	commandContext = ctx
+	if err := handleError("Failed to get pull requests", ctx.Err()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("handleError() after the timeout = %v, want context.DeadlineExceeded", err)
+	}
+}
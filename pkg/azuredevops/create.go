@@ -1,4 +1,4 @@
-package main
+package azuredevops
 
 import (
 	"context"
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/microsoft/azure-devops-go-api/azuredevops"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/webapi"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
 	"github.com/pkg/errors"
@@ -23,7 +22,7 @@ const (
 )
 
 // createWorkItems creates work items in Azure DevOps based on a JSON file
-func createWorkItems(cmd *cobra.Command, args []string) {
+func createWorkItems(cmd *cobra.Command, args []string) error {
 	logger.Info("Creating work items")
 
 	// Create a flag provider from the command
@@ -32,18 +31,17 @@ func createWorkItems(cmd *cobra.Command, args []string) {
 	// Get the JSON file path from the flag
 	jsonFilePath, err := getJSONFilePath(provider)
 	if err != nil {
-		handleError("Failed to get JSON file path", err)
-		return
+		return handleError("Failed to get JSON file path", err)
 	}
 
 	// Process the work items
 	err = processWorkItems(jsonFilePath)
 	if err != nil {
-		handleError("Failed to process work items", err)
-		return
+		return handleError("Failed to process work items", err)
 	}
 
 	logger.Info("Work items created successfully")
+	return nil
 }
 
 // FlagProvider is an interface for getting flag values
@@ -70,11 +68,20 @@ func getJSONFilePath(provider FlagProvider) (string, error) {
 	return jsonFilePath, nil
 }
 
-// handleError logs an error and exits the program
-func handleError(message string, err error) {
+// handleError logs an error and returns it with its message, for master-mold or the binary to exit with. An
+// interrupted command returns context.Canceled and one that ran out of time context.DeadlineExceeded.
+func handleError(message string, err error) error {
 	logger.Error(message, "error", err)
-	fmt.Printf("Error: %s: %v\n", message, err)
-	os.Exit(1)
+	switch commandContext.Err() {
+	case context.Canceled:
+		return errors.Wrap(context.Canceled, "interrupted")
+	case context.DeadlineExceeded:
+		if commandTimeout > 0 {
+			return errors.Wrapf(context.DeadlineExceeded, "%s: timed out after %s", message, commandTimeout)
+		}
+		return errors.Wrapf(context.DeadlineExceeded, "%s: timed out", message)
+	}
+	return errors.Wrap(err, message)
 }
 
 // processWorkItems reads work items from a file and creates them in Azure DevOps
@@ -117,40 +124,10 @@ type ConnectionDetails struct {
 	Organization string
 	Project     string
 	APIVersion  string
-}
-
-// getAzureDevOpsConnectionDetails gets the Azure DevOps connection details from environment variables
-func getAzureDevOpsConnectionDetails() (*ConnectionDetails, error) {
-	// Get the token
-	token := os.Getenv(EnvAzureDevOpsToken)
-	if token == "" {
-		return nil, fmt.Errorf("Azure DevOps Personal Access Token not found. Set the %s environment variable", EnvAzureDevOpsToken)
-	}
-
-	// Get the organization
-	org := os.Getenv(EnvAzureDevOpsOrg)
-	if org == "" {
-		return nil, fmt.Errorf("Azure DevOps Organization not found. Set the %s environment variable", EnvAzureDevOpsOrg)
-	}
-
-	// Get the project
-	project := os.Getenv(EnvAzureDevOpsProject)
-	if project == "" {
-		return nil, fmt.Errorf("Azure DevOps Project not found. Set the %s environment variable", EnvAzureDevOpsProject)
-	}
-
-	// Get the API version (optional)
-	apiVersion := os.Getenv(EnvAzureDevOpsAPIVersion)
-	if apiVersion == "" {
-		apiVersion = DefaultAzureDevOpsAPIVersion
-	}
-
-	return &ConnectionDetails{
-		Token:       token,
-		Organization: org,
-		Project:     project,
-		APIVersion:  apiVersion,
-	}, nil
+	// Bearer reports whether Token is an Azure AD access token rather than a PAT
+	Bearer      bool
+	// TokenSource is where the token was found: the keyring or the name of an environment variable
+	TokenSource string
 }
 
 // readWorkItemsFromFile reads work item fields from a JSON file
@@ -188,13 +165,10 @@ func createAzureDevOpsWorkItems(connectionDetails *ConnectionDetails, fields []W
 // createAzureDevOpsClient creates a client for the Azure DevOps API
 func createAzureDevOpsClient(connectionDetails *ConnectionDetails) (workitemtracking.Client, error) {
 	// Create a connection to Azure DevOps
-	connection := azuredevops.NewPatConnection(
-		fmt.Sprintf("https://dev.azure.com/%s", connectionDetails.Organization),
-		connectionDetails.Token,
-	)
+	connection := newConnection(connectionDetails)
 
 	// Create a client for the Work Item Tracking API
-	client, err := workitemtracking.NewClient(context.Background(), connection)
+	client, err := workitemtracking.NewClient(commandContext, connection)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create Azure DevOps client")
 	}
@@ -277,7 +251,7 @@ func convertFieldsToPatches(fields []WorkItemField) []webapi.JsonPatchOperation
 func createWorkItemWithPatches(client workitemtracking.Client, project string, workItemType string, patches []webapi.JsonPatchOperation) (*workitemtracking.WorkItem, error) {
 	// Create the work item
 	workItem, err := client.CreateWorkItem(
-		context.Background(),
+		commandContext,
 		workitemtracking.CreateWorkItemArgs{
 			Project:  &project,
 			Type:     &workItemType,
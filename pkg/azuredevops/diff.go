@@ -0,0 +1,176 @@
+package azuredevops
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContextLines is the number of unchanged lines shown around each change in a unified diff
+const diffContextLines = 3
+
+// maxDiffCells bounds the size of the table used to compute a line diff; larger files are shown as fully replaced
+const maxDiffCells = 4_000_000
+
+// Kinds of line-level diff operations
+const (
+	diffEqual  = ' '
+	diffDelete = '-'
+	diffInsert = '+'
+)
+
+// diffOp is a single line of a line-level diff
+type diffOp struct {
+	Kind byte
+	Text string
+}
+
+// splitLines splits file content into lines, ignoring the newline that terminates the last line
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
+
+// isBinaryContent checks if file content looks binary rather than text
+func isBinaryContent(content string) bool {
+	return strings.IndexByte(content, 0) >= 0
+}
+
+// diffLines computes a line diff from the old to the new lines using their longest common subsequence
+func diffLines(oldLines []string, newLines []string) []diffOp {
+	n, m := len(oldLines), len(newLines)
+
+	// Fall back to replacing the whole file when the table would be too large
+	if n*m > maxDiffCells {
+		ops := make([]diffOp, 0, n+m)
+		for _, line := range oldLines {
+			ops = append(ops, diffOp{Kind: diffDelete, Text: line})
+		}
+		for _, line := range newLines {
+			ops = append(ops, diffOp{Kind: diffInsert, Text: line})
+		}
+		return ops
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of oldLines[i:] and newLines[j:]
+	lcs := make([][]int32, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case oldLines[i] == newLines[j]:
+			ops = append(ops, diffOp{Kind: diffEqual, Text: oldLines[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{Kind: diffDelete, Text: oldLines[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{Kind: diffInsert, Text: newLines[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{Kind: diffDelete, Text: oldLines[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{Kind: diffInsert, Text: newLines[j]})
+	}
+
+	return ops
+}
+
+// countDiffChanges counts the inserted and deleted lines of a diff
+func countDiffChanges(ops []diffOp) (insertions int, deletions int) {
+	for _, op := range ops {
+		switch op.Kind {
+		case diffInsert:
+			insertions++
+		case diffDelete:
+			deletions++
+		}
+	}
+	return insertions, deletions
+}
+
+// formatUnifiedDiff renders a diff in unified format with the given file names
+func formatUnifiedDiff(oldName string, newName string, ops []diffOp) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "--- %s\n+++ %s\n", oldName, newName)
+
+	// Line numbers before each operation, used for the hunk headers
+	oldLine := make([]int, len(ops)+1)
+	newLine := make([]int, len(ops)+1)
+	for k, op := range ops {
+		oldLine[k+1], newLine[k+1] = oldLine[k], newLine[k]
+		if op.Kind != diffInsert {
+			oldLine[k+1]++
+		}
+		if op.Kind != diffDelete {
+			newLine[k+1]++
+		}
+	}
+
+	for start := 0; start < len(ops); {
+		// Find the next change
+		change := start
+		for change < len(ops) && ops[change].Kind == diffEqual {
+			change++
+		}
+		if change == len(ops) {
+			break
+		}
+
+		// Extend the hunk while changes are close enough for their context to overlap
+		last := change
+		for k := change; k < len(ops) && k-last <= 2*diffContextLines; k++ {
+			if ops[k].Kind != diffEqual {
+				last = k
+			}
+		}
+
+		hunkStart := max(change-diffContextLines, start)
+		hunkEnd := min(last+diffContextLines+1, len(ops))
+
+		builder.WriteString(formatHunkHeader(oldLine[hunkStart], oldLine[hunkEnd], newLine[hunkStart], newLine[hunkEnd]))
+		for _, op := range ops[hunkStart:hunkEnd] {
+			builder.WriteByte(op.Kind)
+			builder.WriteString(op.Text)
+			builder.WriteByte('\n')
+		}
+
+		start = hunkEnd
+	}
+
+	return builder.String()
+}
+
+// formatHunkHeader renders the header of a hunk covering the given old and new line ranges
+func formatHunkHeader(oldFrom int, oldTo int, newFrom int, newTo int) string {
+	return fmt.Sprintf("@@ -%s +%s @@\n", formatHunkRange(oldFrom, oldTo-oldFrom), formatHunkRange(newFrom, newTo-newFrom))
+}
+
+// formatHunkRange renders a line range of a hunk header; empty ranges refer to the line before them
+func formatHunkRange(from int, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", from)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", from+1)
+	}
+	return fmt.Sprintf("%d,%d", from+1, count)
+}
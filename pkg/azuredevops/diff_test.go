@@ -0,0 +1,91 @@
+package azuredevops
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitLines(t *testing.T) {
+	tests := []struct {
+		content string
+		want    []string
+	}{
+		{content: "", want: nil},
+		{content: "a\nb\n", want: []string{"a", "b"}},
+		{content: "a\nb", want: []string{"a", "b"}},
+		{content: "\n", want: []string{""}},
+	}
+
+	for _, tt := range tests {
+		if got := splitLines(tt.content); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitLines(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestIsBinaryContent(t *testing.T) {
+	if isBinaryContent("plain text\n") {
+		t.Error("isBinaryContent() = true for text, want false")
+	}
+	if !isBinaryContent("PNG\x00\x01") {
+		t.Error("isBinaryContent() = false for binary, want true")
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	ops := diffLines([]string{"a", "b", "c"}, []string{"a", "x", "c", "d"})
+
+	want := []diffOp{
+		{Kind: diffEqual, Text: "a"},
+		{Kind: diffDelete, Text: "b"},
+		{Kind: diffInsert, Text: "x"},
+		{Kind: diffEqual, Text: "c"},
+		{Kind: diffInsert, Text: "d"},
+	}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("diffLines() = %+v, want %+v", ops, want)
+	}
+
+	insertions, deletions := countDiffChanges(ops)
+	if insertions != 2 || deletions != 1 {
+		t.Errorf("countDiffChanges() = %d, %d, want 2, 1", insertions, deletions)
+	}
+}
+
+func TestFormatUnifiedDiff(t *testing.T) {
+	var oldLines, newLines []string
+	for i := 1; i <= 20; i++ {
+		line := strings.Repeat("x", i)
+		oldLines = append(oldLines, line)
+		if i == 2 {
+			newLines = append(newLines, "changed")
+			continue
+		}
+		newLines = append(newLines, line)
+	}
+	newLines = append(newLines, "appended")
+
+	got := formatUnifiedDiff("a/file", "b/file", diffLines(oldLines, newLines))
+	want := "--- a/file\n+++ b/file\n" +
+		"@@ -1,5 +1,5 @@\n x\n-xx\n+changed\n xxx\n xxxx\n xxxxx\n" +
+		"@@ -18,3 +18,4 @@\n " + strings.Repeat("x", 18) + "\n " + strings.Repeat("x", 19) + "\n " + strings.Repeat("x", 20) + "\n+appended\n"
+	if got != want {
+		t.Errorf("formatUnifiedDiff() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatUnifiedDiffNewFile(t *testing.T) {
+	got := formatUnifiedDiff("/dev/null", "b/file", diffLines(nil, []string{"one"}))
+	want := "--- /dev/null\n+++ b/file\n@@ -0,0 +1 @@\n+one\n"
+	if got != want {
+		t.Errorf("formatUnifiedDiff() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatUnifiedDiffNoChanges(t *testing.T) {
+	got := formatUnifiedDiff("a/file", "b/file", diffLines([]string{"same"}, []string{"same"}))
+	if got != "--- a/file\n+++ b/file\n" {
+		t.Errorf("formatUnifiedDiff() = %q, want only the file header", got)
+	}
+}
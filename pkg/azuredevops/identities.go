@@ -0,0 +1,109 @@
+package azuredevops
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/identity"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/location"
+	"github.com/pkg/errors"
+)
+
+// identitySearchFilter is the search filter used to find identities by name or email
+const identitySearchFilter = "General"
+
+// createIdentityClient creates a client for the Identity API
+func createIdentityClient(connectionDetails *ConnectionDetails) (identity.Client, error) {
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	// Create a client for the Identity API
+	client, err := identity.NewClient(commandContext, connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Identity client")
+	}
+
+	return client, nil
+}
+
+// getAuthenticatedUser gets the identity of the user the Personal Access Token belongs to
+func getAuthenticatedUser(connectionDetails *ConnectionDetails) (*identity.Identity, error) {
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	// Create a client for the Location API
+	client := location.NewClient(commandContext, connection)
+
+	// Get the connection data, which includes the authenticated user
+	connectionData, err := client.GetConnectionData(commandContext, location.GetConnectionDataArgs{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get connection data")
+	}
+	if connectionData.AuthenticatedUser == nil || connectionData.AuthenticatedUser.Id == nil {
+		return nil, errors.New("the connection data does not include an authenticated user")
+	}
+
+	return connectionData.AuthenticatedUser, nil
+}
+
+// resolveIdentityID resolves a user name, email address or ID to an identity ID
+func resolveIdentityID(client identity.Client, user string) (string, error) {
+	// IDs can be used as-is
+	if _, err := uuid.Parse(user); err == nil {
+		return user, nil
+	}
+
+	searchFilter := identitySearchFilter
+	identities, err := client.ReadIdentities(commandContext, identity.ReadIdentitiesArgs{
+		SearchFilter: &searchFilter,
+		FilterValue:  &user,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to search for identity '%s'", user)
+	}
+
+	return selectIdentity(user, *identities)
+}
+
+// resolveIdentityUUID resolves a user name, email address or ID to an identity UUID
+func resolveIdentityUUID(client identity.Client, user string) (*uuid.UUID, error) {
+	id, err := resolveIdentityID(client, user)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid identity ID '%s'", id)
+	}
+	return &parsed, nil
+}
+
+// selectIdentity picks the single identity matching a user from search results
+func selectIdentity(user string, identities []identity.Identity) (string, error) {
+	var matches []identity.Identity
+	for _, candidate := range identities {
+		if candidate.Id != nil {
+			matches = append(matches, candidate)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", errors.Errorf("no identity found for '%s'", user)
+	case 1:
+		return matches[0].Id.String(), nil
+	}
+
+	// Prefer an exact display name match when the search is ambiguous
+	var names []string
+	for _, candidate := range matches {
+		name := stringValue(candidate.ProviderDisplayName)
+		if strings.EqualFold(name, user) {
+			return candidate.Id.String(), nil
+		}
+		names = append(names, name)
+	}
+
+	return "", errors.Errorf("'%s' matches multiple identities (%s); use an email address or ID", user, strings.Join(names, ", "))
+}
@@ -0,0 +1,45 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/identity"
+)
+
+func TestSelectIdentity(t *testing.T) {
+	janeID := uuid.New()
+	johnID := uuid.New()
+	jane := "Jane Smith"
+	john := "John Smith"
+
+	// A single match is returned
+	got, err := selectIdentity("jane@example.com", []identity.Identity{{Id: &janeID, ProviderDisplayName: &jane}})
+	if err != nil {
+		t.Fatalf("selectIdentity() returned an error: %v", err)
+	}
+	if got != janeID.String() {
+		t.Errorf("selectIdentity() = %s, want %s", got, janeID)
+	}
+
+	// An exact display name match resolves ambiguous results
+	candidates := []identity.Identity{
+		{Id: &janeID, ProviderDisplayName: &jane},
+		{Id: &johnID, ProviderDisplayName: &john},
+	}
+	got, err = selectIdentity("john smith", candidates)
+	if err != nil {
+		t.Fatalf("selectIdentity() returned an error: %v", err)
+	}
+	if got != johnID.String() {
+		t.Errorf("selectIdentity() = %s, want %s", got, johnID)
+	}
+
+	// Ambiguous and empty results are errors
+	if _, err := selectIdentity("Smith", candidates); err == nil {
+		t.Errorf("selectIdentity() did not return an error for an ambiguous search")
+	}
+	if _, err := selectIdentity("nobody", nil); err == nil {
+		t.Errorf("selectIdentity() did not return an error when nothing matched")
+	}
+}
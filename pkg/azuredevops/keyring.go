@@ -0,0 +1,42 @@
+package azuredevops
+
+import (
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/keyring"
+	"github.com/pkg/errors"
+)
+
+// keyringService is the service name credentials are stored under in the system keyring, with the organization as account
+const keyringService = "master-mold-azure-devops"
+
+// lookupStoredToken gets the token stored in the keyring for an organization, or an empty string when there is none.
+// It reports whether the token is an Azure AD access token, which is refreshed first when it has expired.
+func lookupStoredToken(organization string) (string, bool) {
+	secret, err := keyring.Get(keyringService, organization)
+	if err != nil {
+		if !errors.Is(err, keyring.ErrNotFound) {
+			logger.Debug("Could not read the keyring", "organization", organization, "error", err)
+		}
+		return "", false
+	}
+
+	credential, ok := parseAADCredential(secret)
+	if !ok {
+		return secret, false
+	}
+
+	if credential.needsRefresh(time.Now()) {
+		refreshed, err := refreshAADCredential(credential)
+		if err != nil {
+			logger.Warn("Could not refresh the Azure AD token; run 'azure-devops auth login --aad' again", "organization", organization, "error", err)
+			return "", false
+		}
+		if err := storeAADCredential(organization, refreshed); err != nil {
+			logger.Warn("Could not store the refreshed Azure AD token", "organization", organization, "error", err)
+		}
+		credential = refreshed
+	}
+
+	return credential.AccessToken, true
+}
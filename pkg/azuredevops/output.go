@@ -0,0 +1,61 @@
+package azuredevops
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/oscarrieken/master-mold/pkg/terminal"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// maxTableTitleWidth is the maximum width of titles in table output
+const maxTableTitleWidth = 50
+
+// useTableOutput checks if table output is requested; without an explicit --table flag, tables are used on a terminal
+func useTableOutput(cmd *cobra.Command) (bool, error) {
+	table, err := cmd.Flags().GetBool("table")
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get table flag")
+	}
+	if cmd.Flags().Changed("table") {
+		return table, nil
+	}
+	return terminal.IsTerminal(os.Stdout), nil
+}
+
+// applyOutputEnv turns on the --json flag of the command when master-mold was run with its global --json flag
+func applyOutputEnv(cmd *cobra.Command) {
+	if !env.JSONOutput() {
+		return
+	}
+	if flag := cmd.Flags().Lookup("json"); flag != nil && !flag.Changed {
+		flag.Value.Set("true")
+	}
+}
+
+// truncateText shortens text to at most width characters, marking truncation with an ellipsis
+func truncateText(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+// formatAge formats a duration as a compact age such as 45m, 5h or 3d
+func formatAge(age time.Duration) string {
+	switch {
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	}
+}
@@ -0,0 +1,77 @@
+package azuredevops
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/spf13/cobra"
+)
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  string
+	}{
+		{text: "short", width: 10, want: "short"},
+		{text: "exactly10!", width: 10, want: "exactly10!"},
+		{text: "a much longer title", width: 10, want: "a much ..."},
+		{text: "héllo wörld", width: 8, want: "héllo..."},
+		{text: "abcdef", width: 2, want: "ab"},
+	}
+
+	for _, tt := range tests {
+		if got := truncateText(tt.text, tt.width); got != tt.want {
+			t.Errorf("truncateText(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{age: 45 * time.Minute, want: "45m"},
+		{age: 5 * time.Hour, want: "5h"},
+		{age: 80 * time.Hour, want: "3d"},
+	}
+
+	for _, tt := range tests {
+		if got := formatAge(tt.age); got != tt.want {
+			t.Errorf("formatAge(%v) = %s, want %s", tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestApplyOutputEnv(t *testing.T) {
+	newCommand := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("json", false, "")
+		return cmd
+	}
+
+	t.Setenv(env.Output, "")
+	cmd := newCommand()
+	applyOutputEnv(cmd)
+	if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+		t.Error("applyOutputEnv() turned on --json without master-mold --json")
+	}
+
+	t.Setenv(env.Output, env.OutputJSON)
+	cmd = newCommand()
+	applyOutputEnv(cmd)
+	if jsonOutput, _ := cmd.Flags().GetBool("json"); !jsonOutput {
+		t.Error("applyOutputEnv() did not turn on --json with master-mold --json")
+	}
+
+	// An explicit --json=false wins, and commands without --json are left alone
+	cmd = newCommand()
+	cmd.Flags().Set("json", "false")
+	applyOutputEnv(cmd)
+	if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+		t.Error("applyOutputEnv() overrode an explicit --json=false")
+	}
+	applyOutputEnv(&cobra.Command{})
+}
@@ -0,0 +1,157 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// Settings of the PAT expiry warning
+const (
+	// defaultPATExpiryWarningDays is how many days before its expiry a PAT is warned about
+	defaultPATExpiryWarningDays = 7
+	// patExpiryCheckInterval is how often the expiry of the PATs of an organization is checked
+	patExpiryCheckInterval = 24 * time.Hour
+	// patTokensAPIVersion is the REST API version of the PAT lifecycle API
+	patTokensAPIVersion = "7.1-preview.1"
+)
+
+// patToken is a PAT as listed by the PAT lifecycle API, which never returns the token itself
+type patToken struct {
+	DisplayName string    `json:"displayName"`
+	ValidTo     time.Time `json:"validTo"`
+}
+
+// patTokenList is a page of PATs
+type patTokenList struct {
+	PatTokens         []patToken `json:"patTokens"`
+	ContinuationToken string     `json:"continuationToken"`
+}
+
+// warnPATExpiry warns, after a command succeeded, about PATs of the organization that are about to expire
+func warnPATExpiry(cmd *cobra.Command, args []string) {
+	resolvedConnectionDetails.Lock()
+	details := resolvedConnectionDetails.details
+	resolvedConnectionDetails.Unlock()
+
+	// Only check when the command called the API with a PAT
+	if details == nil || details.Bearer || replayCassette != "" {
+		return
+	}
+
+	config, err := loadAzureDevOpsConfig(azureDevOpsConfigPath())
+	if err != nil {
+		return
+	}
+	days := defaultPATExpiryWarningDays
+	if config.PATExpiryWarningDays != nil {
+		days = *config.PATExpiryWarningDays
+	}
+	if days <= 0 {
+		return
+	}
+
+	statePath := filepath.Join(azureDevOpsCacheDir(), "pat-expiry-checks.json")
+	checks := readPATExpiryChecks(statePath)
+	now := time.Now()
+	if last, ok := checks[details.Organization]; ok && now.Sub(last) < patExpiryCheckInterval {
+		return
+	}
+
+	// The check is best effort: the API may reject PATs without the permission to list tokens
+	tokens, err := listPATs(details)
+	if err != nil {
+		logger.Debug("Failed to check PAT expiry", "organization", details.Organization, "error", err)
+		return
+	}
+	checks[details.Organization] = now
+	writePATExpiryChecks(statePath, checks)
+
+	for _, token := range expiringPATs(tokens, now, days) {
+		fmt.Fprintf(os.Stderr, "Warning: the PAT '%s' expires on %s. Renew it at https://dev.azure.com/%s/_usersSettings/tokens\n",
+			token.DisplayName, token.ValidTo.Local().Format("2006-01-02"), details.Organization)
+	}
+}
+
+// expiringPATs selects the PATs that are still valid but expire within the number of days, soonest first
+func expiringPATs(tokens []patToken, now time.Time, days int) []patToken {
+	limit := now.AddDate(0, 0, days)
+
+	var expiring []patToken
+	for _, token := range tokens {
+		if token.ValidTo.After(now) && token.ValidTo.Before(limit) {
+			expiring = append(expiring, token)
+		}
+	}
+
+	sort.Slice(expiring, func(i, j int) bool {
+		return expiring[i].ValidTo.Before(expiring[j].ValidTo)
+	})
+	return expiring
+}
+
+// listPATs lists the active PATs of the user in the organization
+func listPATs(connectionDetails *ConnectionDetails) ([]patToken, error) {
+	connection := newConnection(connectionDetails)
+	baseURL := fmt.Sprintf("https://vssps.dev.azure.com/%s", connectionDetails.Organization)
+	client := connection.GetClientByUrl(baseURL)
+
+	var tokens []patToken
+	continuationToken := ""
+	for {
+		query := url.Values{"displayFilterOption": {"active"}}
+		if continuationToken != "" {
+			query.Set("continuationToken", continuationToken)
+		}
+
+		request, err := client.CreateRequestMessage(commandContext, http.MethodGet, baseURL+"/_apis/tokens/pats?"+query.Encode(), patTokensAPIVersion, nil, "", "application/json", nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create PAT request")
+		}
+
+		response, err := client.SendRequest(request)
+		if err != nil {
+			return nil, err
+		}
+
+		var page patTokenList
+		if err := client.UnmarshalBody(response, &page); err != nil {
+			return nil, errors.Wrap(err, "failed to read PATs")
+		}
+
+		tokens = append(tokens, page.PatTokens...)
+		if page.ContinuationToken == "" {
+			return tokens, nil
+		}
+		continuationToken = page.ContinuationToken
+	}
+}
+
+// readPATExpiryChecks reads when the PATs of each organization were last checked
+func readPATExpiryChecks(path string) map[string]time.Time {
+	checks := make(map[string]time.Time)
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &checks)
+	}
+	return checks
+}
+
+// writePATExpiryChecks records when the PATs of each organization were last checked
+func writePATExpiryChecks(path string, checks map[string]time.Time) error {
+	data, err := json.Marshal(checks)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
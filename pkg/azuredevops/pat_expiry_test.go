@@ -0,0 +1,41 @@
+package azuredevops
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExpiringPATs(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tokens := []patToken{
+		{DisplayName: "ci", ValidTo: now.AddDate(0, 0, 5)},
+		{DisplayName: "laptop", ValidTo: now.AddDate(0, 0, 30)},
+		{DisplayName: "expired", ValidTo: now.AddDate(0, 0, -1)},
+		{DisplayName: "release", ValidTo: now.AddDate(0, 0, 1)},
+	}
+
+	got := expiringPATs(tokens, now, 7)
+	if len(got) != 2 || got[0].DisplayName != "release" || got[1].DisplayName != "ci" {
+		t.Errorf("expiringPATs() = %+v, want release and ci", got)
+	}
+
+	if got := expiringPATs(tokens, now, 0); len(got) != 0 {
+		t.Errorf("expiringPATs() with 0 days = %+v, want none", got)
+	}
+}
+
+func TestPATExpiryChecks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "pat-expiry-checks.json")
+	if checks := readPATExpiryChecks(path); len(checks) != 0 {
+		t.Errorf("readPATExpiryChecks() without a file = %v, want none", checks)
+	}
+
+	checked := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := writePATExpiryChecks(path, map[string]time.Time{"myorg": checked}); err != nil {
+		t.Fatalf("writePATExpiryChecks() error = %v", err)
+	}
+	if got := readPATExpiryChecks(path)["myorg"]; !got.Equal(checked) {
+		t.Errorf("readPATExpiryChecks()[myorg] = %v, want %v", got, checked)
+	}
+}
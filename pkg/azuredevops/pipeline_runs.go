@@ -0,0 +1,412 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// logPollInterval is how often the timeline and logs of a run are polled when following its logs
+const logPollInterval = 5 * time.Second
+
+// Timeline record types shown in the run status
+const (
+	timelineRecordStage = "Stage"
+	timelineRecordJob   = "Job"
+)
+
+// RunStep represents a stage or job in the timeline of a run
+type RunStep struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	State  string `json:"state"`
+	Result string `json:"result,omitempty"`
+	Depth  int    `json:"depth"`
+}
+
+// RunStatus represents the status of a pipeline run and its stages and jobs
+type RunStatus struct {
+	ID           int        `json:"id"`
+	BuildNumber  string     `json:"buildNumber"`
+	Pipeline     string     `json:"pipeline"`
+	Status       string     `json:"status"`
+	Result       string     `json:"result,omitempty"`
+	Branch       string     `json:"branch"`
+	RequestedFor string     `json:"requestedFor"`
+	Queued       *time.Time `json:"queued,omitempty"`
+	Started      *time.Time `json:"started,omitempty"`
+	Finished     *time.Time `json:"finished,omitempty"`
+	URL          string     `json:"url"`
+	Steps        []RunStep  `json:"steps"`
+}
+
+// pipelineStatusCommand shows the status of a pipeline run
+func pipelineStatusCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Showing pipeline run status")
+
+	// Parse the run ID
+	runID, err := parseRunID(args[0])
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the run status
+	status, err := getRunStatus(runID)
+	if err != nil {
+		return handleError("Failed to get pipeline run status", err)
+	}
+
+	// Print the run status
+	if jsonOutput {
+		printRunStatusAsJSON(status)
+	} else {
+		printRunStatusAsText(status)
+	}
+
+	logger.Info("Pipeline run status shown successfully")
+	return nil
+}
+
+// pipelineLogsCommand prints the logs of a pipeline run, optionally following them until the run completes
+func pipelineLogsCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Showing pipeline run logs")
+
+	// Parse the run ID
+	runID, err := parseRunID(args[0])
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Check if the logs should be followed
+	follow, err := cmd.Flags().GetBool("follow")
+	if err != nil {
+		return handleError("Failed to get follow flag", err)
+	}
+
+	// Print the logs
+	if err := streamRunLogs(runID, follow); err != nil {
+		return handleError("Failed to get pipeline run logs", err)
+	}
+
+	logger.Info("Pipeline run logs shown successfully")
+	return nil
+}
+
+// parseRunID parses a pipeline run ID argument
+func parseRunID(value string) (int, error) {
+	runID, err := strconv.Atoi(value)
+	if err != nil || runID <= 0 {
+		return 0, errors.Errorf("'%s' is not a valid run ID", value)
+	}
+	return runID, nil
+}
+
+// createBuildClient creates a client for the Build API
+func createBuildClient(connectionDetails *ConnectionDetails) (build.Client, error) {
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	// Create a client for the Build API
+	client, err := build.NewClient(commandContext, connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Build client")
+	}
+
+	return client, nil
+}
+
+// getRunStatus gets the status of a pipeline run and the stages and jobs in its timeline
+func getRunStatus(runID int) (*RunStatus, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Build API
+	client, err := createBuildClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pipeline runs are builds, so the run ID is the build ID
+	run, err := client.GetBuild(commandContext, build.GetBuildArgs{
+		Project: &connectionDetails.Project,
+		BuildId: &runID,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get run %d", runID)
+	}
+
+	records, err := getTimelineRecords(client, connectionDetails.Project, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := convertRunStatus(*run)
+	for _, record := range orderTimelineRecords(records) {
+		recordType := stringValue(record.Type)
+		if recordType != timelineRecordStage && recordType != timelineRecordJob {
+			continue
+		}
+		status.Steps = append(status.Steps, convertRunStep(record))
+	}
+
+	return &status, nil
+}
+
+// getTimelineRecords gets the records of the timeline of a run; runs that have not started have none
+func getTimelineRecords(client build.Client, project string, runID int) ([]build.TimelineRecord, error) {
+	timeline, err := client.GetBuildTimeline(commandContext, build.GetBuildTimelineArgs{
+		Project: &project,
+		BuildId: &runID,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get timeline of run %d", runID)
+	}
+	if timeline == nil || timeline.Records == nil {
+		return nil, nil
+	}
+	return *timeline.Records, nil
+}
+
+// convertRunStatus converts a build to our run status model
+func convertRunStatus(run build.Build) RunStatus {
+	status := RunStatus{
+		ID:          intValue(run.Id),
+		BuildNumber: stringValue(run.BuildNumber),
+		Branch:      stringValue(run.SourceBranch),
+		URL:         linkHref(run.Links, "web"),
+		Steps:       []RunStep{},
+	}
+	if run.Definition != nil {
+		status.Pipeline = stringValue(run.Definition.Name)
+	}
+	if run.Status != nil {
+		status.Status = string(*run.Status)
+	}
+	if run.Result != nil {
+		status.Result = string(*run.Result)
+	}
+	if run.RequestedFor != nil {
+		status.RequestedFor = stringValue(run.RequestedFor.DisplayName)
+	}
+	status.Queued = timeValue(run.QueueTime)
+	status.Started = timeValue(run.StartTime)
+	status.Finished = timeValue(run.FinishTime)
+	return status
+}
+
+// timeValue converts an optional Azure DevOps time to an optional time
+func timeValue(value *azuredevops.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	result := value.Time
+	return &result
+}
+
+// orderTimelineRecords orders timeline records depth-first, so each stage is followed by its jobs and each job by its tasks
+func orderTimelineRecords(records []build.TimelineRecord) []build.TimelineRecord {
+	children := make(map[uuid.UUID][]build.TimelineRecord)
+	var roots []build.TimelineRecord
+	for _, record := range records {
+		if record.ParentId == nil {
+			roots = append(roots, record)
+			continue
+		}
+		children[*record.ParentId] = append(children[*record.ParentId], record)
+	}
+
+	byOrder := func(list []build.TimelineRecord) {
+		sort.SliceStable(list, func(i, j int) bool {
+			return intValue(list[i].Order) < intValue(list[j].Order)
+		})
+	}
+
+	var result []build.TimelineRecord
+	var visit func(list []build.TimelineRecord)
+	visit = func(list []build.TimelineRecord) {
+		byOrder(list)
+		for _, record := range list {
+			result = append(result, record)
+			if record.Id != nil {
+				visit(children[*record.Id])
+			}
+		}
+	}
+	visit(roots)
+
+	return result
+}
+
+// convertRunStep converts a stage or job timeline record to our run step model
+func convertRunStep(record build.TimelineRecord) RunStep {
+	step := RunStep{
+		Name: stringValue(record.Name),
+		Type: stringValue(record.Type),
+	}
+	if step.Type == timelineRecordJob {
+		step.Depth = 1
+	}
+	if record.State != nil {
+		step.State = string(*record.State)
+	}
+	if record.Result != nil {
+		step.Result = string(*record.Result)
+	}
+	return step
+}
+
+// formatStepState formats the state of a run or step with its result once it has one
+func formatStepState(state string, result string) string {
+	if result == "" {
+		return state
+	}
+	return fmt.Sprintf("%s: %s", state, result)
+}
+
+// streamRunLogs prints the logs of a run in timeline order; when following, it polls until the run completes
+func streamRunLogs(runID int, follow bool) error {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return err
+	}
+
+	// Create a client for the Build API
+	client, err := createBuildClient(connectionDetails)
+	if err != nil {
+		return err
+	}
+
+	// The number of lines already printed per log
+	printed := make(map[int]int)
+	headerPrinted := make(map[int]bool)
+
+	for {
+		// Check the run status before reading the logs, so the final pass sees every line
+		run, err := client.GetBuild(commandContext, build.GetBuildArgs{
+			Project: &connectionDetails.Project,
+			BuildId: &runID,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get run %d", runID)
+		}
+		completed := run.Status != nil && *run.Status == build.BuildStatusValues.Completed
+
+		records, err := getTimelineRecords(client, connectionDetails.Project, runID)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range orderTimelineRecords(records) {
+			if record.Log == nil || record.Log.Id == nil {
+				continue
+			}
+			logID := *record.Log.Id
+
+			lines, err := client.GetBuildLogLines(commandContext, build.GetBuildLogLinesArgs{
+				Project: &connectionDetails.Project,
+				BuildId: &runID,
+				LogId:   &logID,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "failed to get log %d of run %d", logID, runID)
+			}
+
+			newLines := unseenLogLines(*lines, printed[logID])
+			if len(newLines) == 0 {
+				continue
+			}
+			if !headerPrinted[logID] {
+				fmt.Printf("==> %s <==\n", stringValue(record.Name))
+				headerPrinted[logID] = true
+			}
+			for _, line := range newLines {
+				fmt.Println(line)
+			}
+			printed[logID] += len(newLines)
+		}
+
+		if !follow || completed {
+			if completed {
+				result := ""
+				if run.Result != nil {
+					result = string(*run.Result)
+				}
+				fmt.Printf("\nRun %d %s\n", runID, formatStepState(string(*run.Status), result))
+			}
+			return nil
+		}
+
+		if err := sleepContext(commandContext, logPollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+// unseenLogLines returns the lines of a log after the ones already printed
+func unseenLogLines(lines []string, printed int) []string {
+	if printed >= len(lines) {
+		return nil
+	}
+	return lines[printed:]
+}
+
+// printRunStatusAsText prints the status of a run in a human-readable format
+func printRunStatusAsText(status *RunStatus) {
+	fmt.Printf("Run %d (%s) of %s\n", status.ID, status.BuildNumber, status.Pipeline)
+	fmt.Printf("Status: %s\n", formatStepState(status.Status, status.Result))
+	fmt.Printf("Branch: %s\n", status.Branch)
+	fmt.Printf("Requested For: %s\n", status.RequestedFor)
+	if status.Queued != nil {
+		fmt.Printf("Queued: %s\n", status.Queued.Format(time.RFC3339))
+	}
+	if status.Started != nil {
+		fmt.Printf("Started: %s\n", status.Started.Format(time.RFC3339))
+	}
+	if status.Finished != nil {
+		fmt.Printf("Finished: %s\n", status.Finished.Format(time.RFC3339))
+	}
+	fmt.Printf("URL: %s\n", status.URL)
+
+	if len(status.Steps) == 0 {
+		return
+	}
+
+	fmt.Println("\nStages and Jobs:")
+	for _, step := range status.Steps {
+		indent := strings.Repeat("  ", step.Depth+1)
+		fmt.Printf("%s- %s [%s]\n", indent, step.Name, formatStepState(step.State, step.Result))
+	}
+}
+
+// printRunStatusAsJSON prints the status of a run in JSON format
+func printRunStatusAsJSON(status *RunStatus) {
+	// Marshal the run status to JSON with indentation
+	jsonData, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal run status to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal run status to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
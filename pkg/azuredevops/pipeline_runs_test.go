@@ -0,0 +1,87 @@
+package azuredevops
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+)
+
+func timelineRecord(id uuid.UUID, parent *uuid.UUID, name string, recordType string, order int) build.TimelineRecord {
+	return build.TimelineRecord{
+		Id:       &id,
+		ParentId: parent,
+		Name:     &name,
+		Type:     &recordType,
+		Order:    &order,
+	}
+}
+
+func TestOrderTimelineRecords(t *testing.T) {
+	stageA, stageB := uuid.New(), uuid.New()
+	jobA := uuid.New()
+
+	records := []build.TimelineRecord{
+		timelineRecord(uuid.New(), &jobA, "Test", "Task", 2),
+		timelineRecord(stageB, nil, "Deploy", "Stage", 2),
+		timelineRecord(jobA, &stageA, "Build job", "Job", 1),
+		timelineRecord(uuid.New(), &jobA, "Checkout", "Task", 1),
+		timelineRecord(stageA, nil, "Build", "Stage", 1),
+	}
+
+	var names []string
+	for _, record := range orderTimelineRecords(records) {
+		names = append(names, *record.Name)
+	}
+
+	want := []string{"Build", "Build job", "Checkout", "Test", "Deploy"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("orderTimelineRecords() = %v, want %v", names, want)
+	}
+}
+
+func TestConvertRunStep(t *testing.T) {
+	state := build.TimelineRecordStateValues.Completed
+	result := build.TaskResultValues.Failed
+	record := timelineRecord(uuid.New(), nil, "Build job", timelineRecordJob, 1)
+	record.State = &state
+	record.Result = &result
+
+	step := convertRunStep(record)
+	want := RunStep{Name: "Build job", Type: timelineRecordJob, State: "completed", Result: "failed", Depth: 1}
+	if step != want {
+		t.Errorf("convertRunStep() = %+v, want %+v", step, want)
+	}
+}
+
+func TestFormatStepState(t *testing.T) {
+	if got := formatStepState("inProgress", ""); got != "inProgress" {
+		t.Errorf("formatStepState() = %s, want inProgress", got)
+	}
+	if got := formatStepState("completed", "succeeded"); got != "completed: succeeded" {
+		t.Errorf("formatStepState() = %s, want 'completed: succeeded'", got)
+	}
+}
+
+func TestUnseenLogLines(t *testing.T) {
+	lines := []string{"one", "two", "three"}
+
+	if got := unseenLogLines(lines, 1); !reflect.DeepEqual(got, []string{"two", "three"}) {
+		t.Errorf("unseenLogLines(1) = %v", got)
+	}
+	if got := unseenLogLines(lines, 3); got != nil {
+		t.Errorf("unseenLogLines(3) = %v, want nil", got)
+	}
+}
+
+func TestParseRunID(t *testing.T) {
+	if got, err := parseRunID("1234"); err != nil || got != 1234 {
+		t.Errorf("parseRunID(\"1234\") = %d, %v, want 1234", got, err)
+	}
+	for _, value := range []string{"0", "-1", "abc"} {
+		if _, err := parseRunID(value); err == nil {
+			t.Errorf("parseRunID(%q) error = nil, want an error", value)
+		}
+	}
+}
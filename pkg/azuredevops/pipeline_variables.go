@@ -0,0 +1,360 @@
+package azuredevops
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// PipelineVariable represents a variable of a pipeline or variable group
+type PipelineVariable struct {
+	Name          string `json:"name"`
+	Value         string `json:"value"`
+	IsSecret      bool   `json:"isSecret"`
+	AllowOverride bool   `json:"allowOverride"`
+}
+
+// getPipelineVariableCommand prints the value of a pipeline or variable group variable
+func getPipelineVariableCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Getting pipeline variable", "source", args[0], "name", args[1])
+
+	// Check if the variable belongs to a variable group
+	group, err := cmd.Flags().GetBool("group")
+	if err != nil {
+		return handleError("Failed to get group flag", err)
+	}
+
+	// Get the variable
+	var variable *PipelineVariable
+	if group {
+		variable, err = getGroupVariable(args[0], args[1])
+	} else {
+		variable, err = getDefinitionVariable(args[0], args[1])
+	}
+	if err != nil {
+		return handleError("Failed to get variable", err)
+	}
+
+	if variable.IsSecret {
+		return handleError("Failed to get variable", errors.Errorf("%s is a secret, its value cannot be read", variable.Name))
+	}
+
+	fmt.Println(variable.Value)
+
+	logger.Info("Pipeline variable retrieved successfully", "source", args[0], "name", variable.Name)
+	return nil
+}
+
+// setPipelineVariableCommand creates or updates a pipeline or variable group variable
+func setPipelineVariableCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Setting pipeline variable", "source", args[0], "name", args[1])
+
+	// Check if the variable belongs to a variable group
+	group, err := cmd.Flags().GetBool("group")
+	if err != nil {
+		return handleError("Failed to get group flag", err)
+	}
+
+	// Check if the variable is a secret
+	secret, err := cmd.Flags().GetBool("secret")
+	if err != nil {
+		return handleError("Failed to get secret flag", err)
+	}
+
+	// Check if the variable can be overridden at queue time, which only applies to pipelines
+	var allowOverride *bool
+	if cmd.Flags().Changed("allow-override") {
+		if group {
+			return handleError("Invalid allow-override flag", errors.New("--allow-override does not apply to variable groups"))
+		}
+		value, err := cmd.Flags().GetBool("allow-override")
+		if err != nil {
+			return handleError("Failed to get allow-override flag", err)
+		}
+		allowOverride = &value
+	}
+
+	// Get the value, reading it from standard input when it is not given so secrets stay out of the shell history
+	value, err := readVariableValue(args[2:], os.Stdin)
+	if err != nil {
+		return handleError("Failed to read variable value", err)
+	}
+
+	// Set the variable
+	if group {
+		err = setGroupVariable(args[0], args[1], value, secret)
+	} else {
+		err = setDefinitionVariable(args[0], args[1], value, secret, allowOverride)
+	}
+	if err != nil {
+		return handleError("Failed to set variable", err)
+	}
+
+	fmt.Printf("Set variable %s of %s\n", args[1], args[0])
+
+	logger.Info("Pipeline variable set successfully", "source", args[0], "name", args[1])
+	return nil
+}
+
+// readVariableValue gets a variable value from the remaining arguments, or from the reader when there are none
+func readVariableValue(args []string, reader io.Reader) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read standard input")
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// findVariableName finds the name under which a variable is stored; variable names are case-insensitive
+func findVariableName(names []string, name string) (string, bool) {
+	for _, existing := range names {
+		if strings.EqualFold(existing, name) {
+			return existing, true
+		}
+	}
+	return name, false
+}
+
+// getPipelineDefinition gets the build definition behind a pipeline, given by ID or name
+func getPipelineDefinition(connectionDetails *ConnectionDetails, nameOrID string) (build.Client, *build.BuildDefinition, error) {
+	// Find the pipeline, which shares its ID with its build definition
+	pipeline, err := resolvePipeline(createPipelinesClient(connectionDetails), connectionDetails.Project, nameOrID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Create a client for the Build API
+	client, err := createBuildClient(connectionDetails)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	definition, err := client.GetDefinition(commandContext, build.GetDefinitionArgs{
+		Project:      &connectionDetails.Project,
+		DefinitionId: &pipeline.ID,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get definition of pipeline %s", pipeline.Name)
+	}
+
+	return client, definition, nil
+}
+
+// definitionVariableNames gets the names of the variables of a build definition
+func definitionVariableNames(definition *build.BuildDefinition) []string {
+	var names []string
+	if definition.Variables != nil {
+		for name := range *definition.Variables {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// getDefinitionVariable gets a variable of a pipeline
+func getDefinitionVariable(nameOrID string, name string) (*PipelineVariable, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	_, definition, err := getPipelineDefinition(connectionDetails, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, ok := findVariableName(definitionVariableNames(definition), name)
+	if !ok {
+		return nil, errors.Errorf("pipeline %s has no variable %s", stringValue(definition.Name), name)
+	}
+
+	variable := (*definition.Variables)[name]
+	return &PipelineVariable{
+		Name:          name,
+		Value:         stringValue(variable.Value),
+		IsSecret:      boolValue(variable.IsSecret),
+		AllowOverride: boolValue(variable.AllowOverride),
+	}, nil
+}
+
+// setDefinitionVariable creates or updates a variable of a pipeline.
+// An existing secret stays secret, and allowOverride keeps its current setting when it is nil.
+func setDefinitionVariable(nameOrID string, name string, value string, secret bool, allowOverride *bool) error {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return err
+	}
+
+	client, definition, err := getPipelineDefinition(connectionDetails, nameOrID)
+	if err != nil {
+		return err
+	}
+
+	if definition.Variables == nil {
+		definition.Variables = &map[string]build.BuildDefinitionVariable{}
+	}
+	name, _ = findVariableName(definitionVariableNames(definition), name)
+	variables := *definition.Variables
+	variables[name] = updateDefinitionVariable(variables[name], value, secret, allowOverride)
+
+	_, err = client.UpdateDefinition(commandContext, build.UpdateDefinitionArgs{
+		Definition:   definition,
+		Project:      &connectionDetails.Project,
+		DefinitionId: definition.Id,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update definition of pipeline %s", stringValue(definition.Name))
+	}
+
+	return nil
+}
+
+// updateDefinitionVariable applies a new value to a build definition variable
+func updateDefinitionVariable(variable build.BuildDefinitionVariable, value string, secret bool, allowOverride *bool) build.BuildDefinitionVariable {
+	isSecret := secret || boolValue(variable.IsSecret)
+	variable.Value = &value
+	variable.IsSecret = &isSecret
+	if allowOverride != nil {
+		variable.AllowOverride = allowOverride
+	}
+	return variable
+}
+
+// createTaskAgentClient creates a client for the Task Agent API, which manages variable groups
+func createTaskAgentClient(connectionDetails *ConnectionDetails) (taskagent.Client, error) {
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	// Create a client for the Task Agent API
+	client, err := taskagent.NewClient(commandContext, connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Task Agent client")
+	}
+
+	return client, nil
+}
+
+// getVariableGroup gets a variable group by name
+func getVariableGroup(client taskagent.Client, project string, name string) (*taskagent.VariableGroup, error) {
+	groups, err := client.GetVariableGroups(commandContext, taskagent.GetVariableGroupsArgs{
+		Project:   &project,
+		GroupName: &name,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get variable group %s", name)
+	}
+
+	for _, group := range *groups {
+		if strings.EqualFold(stringValue(group.Name), name) {
+			return &group, nil
+		}
+	}
+	return nil, errors.Errorf("variable group %s not found", name)
+}
+
+// groupVariableNames gets the names of the variables of a variable group
+func groupVariableNames(group *taskagent.VariableGroup) []string {
+	var names []string
+	if group.Variables != nil {
+		for name := range *group.Variables {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// convertGroupVariable converts a variable group variable, which the SDK leaves as a generic map, to our model
+func convertGroupVariable(name string, value interface{}) PipelineVariable {
+	result := PipelineVariable{Name: name}
+	if fields, ok := value.(map[string]interface{}); ok {
+		result.Value, _ = fields["value"].(string)
+		result.IsSecret, _ = fields["isSecret"].(bool)
+	}
+	return result
+}
+
+// getGroupVariable gets a variable of a variable group
+func getGroupVariable(groupName string, name string) (*PipelineVariable, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := createTaskAgentClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := getVariableGroup(client, connectionDetails.Project, groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	name, ok := findVariableName(groupVariableNames(group), name)
+	if !ok {
+		return nil, errors.Errorf("variable group %s has no variable %s", stringValue(group.Name), name)
+	}
+
+	variable := convertGroupVariable(name, (*group.Variables)[name])
+	return &variable, nil
+}
+
+// setGroupVariable creates or updates a variable of a variable group; an existing secret stays secret
+func setGroupVariable(groupName string, name string, value string, secret bool) error {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return err
+	}
+
+	client, err := createTaskAgentClient(connectionDetails)
+	if err != nil {
+		return err
+	}
+
+	group, err := getVariableGroup(client, connectionDetails.Project, groupName)
+	if err != nil {
+		return err
+	}
+
+	if group.Variables == nil {
+		group.Variables = &map[string]interface{}{}
+	}
+	name, _ = findVariableName(groupVariableNames(group), name)
+	variables := *group.Variables
+	isSecret := secret || convertGroupVariable(name, variables[name]).IsSecret
+	variables[name] = taskagent.VariableValue{
+		Value:    &value,
+		IsSecret: &isSecret,
+	}
+
+	_, err = client.UpdateVariableGroup(commandContext, taskagent.UpdateVariableGroupArgs{
+		Group: &taskagent.VariableGroupParameters{
+			Description:  group.Description,
+			Name:         group.Name,
+			ProviderData: group.ProviderData,
+			Type:         group.Type,
+			Variables:    group.Variables,
+		},
+		Project: &connectionDetails.Project,
+		GroupId: group.Id,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update variable group %s", stringValue(group.Name))
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package azuredevops
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+)
+
+func TestReadVariableValue(t *testing.T) {
+	got, err := readVariableValue([]string{"from-args"}, strings.NewReader("ignored"))
+	if err != nil || got != "from-args" {
+		t.Errorf("readVariableValue(args) = %q, %v, want from-args", got, err)
+	}
+
+	got, err = readVariableValue(nil, strings.NewReader("s3cr3t\n"))
+	if err != nil || got != "s3cr3t" {
+		t.Errorf("readVariableValue(stdin) = %q, %v, want s3cr3t", got, err)
+	}
+}
+
+func TestFindVariableName(t *testing.T) {
+	names := []string{"ApiUrl", "Token"}
+
+	if got, ok := findVariableName(names, "apiurl"); !ok || got != "ApiUrl" {
+		t.Errorf("findVariableName(apiurl) = %s, %v, want ApiUrl, true", got, ok)
+	}
+	if got, ok := findVariableName(names, "NewVar"); ok || got != "NewVar" {
+		t.Errorf("findVariableName(NewVar) = %s, %v, want NewVar, false", got, ok)
+	}
+}
+
+func TestUpdateDefinitionVariable(t *testing.T) {
+	secret, override := true, true
+	existing := build.BuildDefinitionVariable{IsSecret: &secret, AllowOverride: &override}
+
+	got := updateDefinitionVariable(existing, "value", false, nil)
+	if stringValue(got.Value) != "value" || !boolValue(got.IsSecret) || !boolValue(got.AllowOverride) {
+		t.Errorf("updateDefinitionVariable() should keep the secret and override settings, got %+v", got)
+	}
+
+	noOverride := false
+	got = updateDefinitionVariable(build.BuildDefinitionVariable{}, "value", true, &noOverride)
+	if !boolValue(got.IsSecret) || got.AllowOverride == nil || *got.AllowOverride {
+		t.Errorf("updateDefinitionVariable() should apply the secret and override flags, got %+v", got)
+	}
+}
+
+func TestConvertGroupVariable(t *testing.T) {
+	got := convertGroupVariable("ApiUrl", map[string]interface{}{"value": "https://example.com", "isSecret": false})
+	if got != (PipelineVariable{Name: "ApiUrl", Value: "https://example.com"}) {
+		t.Errorf("convertGroupVariable() = %+v", got)
+	}
+
+	got = convertGroupVariable("Token", map[string]interface{}{"value": nil, "isSecret": true})
+	if got != (PipelineVariable{Name: "Token", IsSecret: true}) {
+		t.Errorf("convertGroupVariable(secret) = %+v", got)
+	}
+}
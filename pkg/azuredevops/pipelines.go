@@ -0,0 +1,344 @@
+package azuredevops
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelines"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// runPipelineLocationID is the REST location used to run a pipeline
+var runPipelineLocationID = uuid.MustParse("7859261e-d2e9-4a68-b820-a5d84cc5bb3d")
+
+// runPipelineAPIVersion is the REST API version used to run a pipeline
+const runPipelineAPIVersion = "5.1-preview.1"
+
+// selfRepositoryName is the name of the repository a YAML pipeline is defined in
+const selfRepositoryName = "self"
+
+// Pipeline represents a pipeline definition in Azure DevOps
+type Pipeline struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Folder string `json:"folder"`
+	URL    string `json:"url"`
+}
+
+// PipelineRun represents a run of a pipeline
+type PipelineRun struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	PipelineID int        `json:"pipelineId"`
+	Pipeline   string     `json:"pipeline"`
+	State      string     `json:"state"`
+	Result     string     `json:"result,omitempty"`
+	Created    time.Time  `json:"created"`
+	Finished   *time.Time `json:"finished,omitempty"`
+	URL        string     `json:"url"`
+}
+
+// runPipelineParameters extends the SDK run parameters with the template parameters it does not support
+type runPipelineParameters struct {
+	pipelines.RunPipelineParameters
+	TemplateParameters map[string]string `json:"templateParameters,omitempty"`
+}
+
+// listPipelinesCommand lists the pipeline definitions of the project
+func listPipelinesCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing pipelines")
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the pipelines
+	pipelineList, err := getPipelines()
+	if err != nil {
+		return handleError("Failed to get pipelines", err)
+	}
+
+	// Print the pipelines
+	if jsonOutput {
+		printPipelinesAsJSON(pipelineList)
+	} else {
+		printPipelinesAsText(pipelineList)
+	}
+
+	logger.Info("Pipelines listed successfully")
+	return nil
+}
+
+// runPipelineCommand queues a run of a pipeline
+func runPipelineCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Running pipeline", "pipeline", args[0])
+
+	// Get the run flags
+	branch, err := cmd.Flags().GetString("branch")
+	if err != nil {
+		return handleError("Failed to get branch flag", err)
+	}
+	paramFlags, err := cmd.Flags().GetStringArray("param")
+	if err != nil {
+		return handleError("Failed to get param flag", err)
+	}
+	params, err := parseKeyValues(paramFlags)
+	if err != nil {
+		return handleError("Invalid param flag", err)
+	}
+	varFlags, err := cmd.Flags().GetStringArray("var")
+	if err != nil {
+		return handleError("Failed to get var flag", err)
+	}
+	variables, err := parseKeyValues(varFlags)
+	if err != nil {
+		return handleError("Invalid var flag", err)
+	}
+
+	// Run the pipeline
+	run, err := runPipeline(args[0], buildRunPipelineParameters(branch, params, variables))
+	if err != nil {
+		return handleError("Failed to run pipeline", err)
+	}
+
+	fmt.Printf("Queued run %d (%s) of pipeline %s\n", run.ID, run.Name, run.Pipeline)
+	if run.URL != "" {
+		fmt.Printf("URL: %s\n", run.URL)
+	}
+
+	logger.Info("Pipeline run queued successfully", "pipeline", run.Pipeline, "run", run.ID)
+	return nil
+}
+
+// createPipelinesClient creates a client for the Pipelines API
+func createPipelinesClient(connectionDetails *ConnectionDetails) pipelines.Client {
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	return pipelines.NewClient(commandContext, connection)
+}
+
+// getPipelines gets all pipeline definitions of the project
+func getPipelines() ([]Pipeline, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	return listProjectPipelines(createPipelinesClient(connectionDetails), connectionDetails.Project)
+}
+
+// listProjectPipelines gets all pipeline definitions of a project, following continuation tokens
+func listProjectPipelines(client pipelines.Client, project string) ([]Pipeline, error) {
+	result := []Pipeline{}
+	var continuationToken *string
+
+	for {
+		response, err := client.ListPipelines(commandContext, pipelines.ListPipelinesArgs{
+			Project:           &project,
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list pipelines")
+		}
+
+		for _, pipeline := range response.Value {
+			result = append(result, convertPipeline(pipeline))
+		}
+
+		if response.ContinuationToken == "" {
+			return result, nil
+		}
+		token := response.ContinuationToken
+		continuationToken = &token
+	}
+}
+
+// convertPipeline converts an Azure DevOps pipeline to our model
+func convertPipeline(pipeline pipelines.Pipeline) Pipeline {
+	result := Pipeline{
+		ID:     intValue(pipeline.Id),
+		Name:   stringValue(pipeline.Name),
+		Folder: stringValue(pipeline.Folder),
+		URL:    linkHref(pipeline.Links, "web"),
+	}
+	if result.URL == "" {
+		result.URL = stringValue(pipeline.Url)
+	}
+	return result
+}
+
+// findPipeline finds a pipeline by ID or by name, ignoring case
+func findPipeline(pipelineList []Pipeline, nameOrID string) (Pipeline, error) {
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		for _, pipeline := range pipelineList {
+			if pipeline.ID == id {
+				return pipeline, nil
+			}
+		}
+		return Pipeline{}, errors.Errorf("no pipeline with ID %d", id)
+	}
+
+	var matches []Pipeline
+	for _, pipeline := range pipelineList {
+		if strings.EqualFold(pipeline.Name, nameOrID) {
+			matches = append(matches, pipeline)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Pipeline{}, errors.Errorf("no pipeline named '%s'", nameOrID)
+	case 1:
+		return matches[0], nil
+	default:
+		return Pipeline{}, errors.Errorf("'%s' matches %d pipelines; use the pipeline ID", nameOrID, len(matches))
+	}
+}
+
+// resolvePipeline looks up a pipeline of the project by ID or name
+func resolvePipeline(client pipelines.Client, project string, nameOrID string) (Pipeline, error) {
+	pipelineList, err := listProjectPipelines(client, project)
+	if err != nil {
+		return Pipeline{}, err
+	}
+	return findPipeline(pipelineList, nameOrID)
+}
+
+// buildRunPipelineParameters builds the parameters of a pipeline run for a branch, template parameters and variables
+func buildRunPipelineParameters(branch string, params map[string]string, variables map[string]string) runPipelineParameters {
+	parameters := runPipelineParameters{}
+
+	if branch != "" {
+		refName := normalizeBranchName(branch)
+		parameters.Resources = &pipelines.RunResourcesParameters{
+			Repositories: &map[string]pipelines.RepositoryResourceParameters{
+				selfRepositoryName: {RefName: &refName},
+			},
+		}
+	}
+
+	if len(params) > 0 {
+		parameters.TemplateParameters = params
+	}
+
+	if len(variables) > 0 {
+		runVariables := make(map[string]pipelines.Variable, len(variables))
+		for name, value := range variables {
+			runVariables[name] = pipelines.Variable{Value: &value}
+		}
+		parameters.Variables = &runVariables
+	}
+
+	return parameters
+}
+
+// runPipeline queues a run of a pipeline, given by ID or name, with the given parameters
+func runPipeline(nameOrID string, parameters runPipelineParameters) (*PipelineRun, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	// Find the pipeline
+	pipeline, err := resolvePipeline(pipelines.NewClient(commandContext, connection), connectionDetails.Project, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The SDK does not support template parameters, so send the request ourselves
+	body, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal run parameters")
+	}
+	routeValues := map[string]string{
+		"project":    connectionDetails.Project,
+		"pipelineId": strconv.Itoa(pipeline.ID),
+	}
+	client := connection.GetClientByUrl(connection.BaseUrl)
+	response, err := client.Send(commandContext, http.MethodPost, runPipelineLocationID, runPipelineAPIVersion, routeValues, nil, bytes.NewReader(body), "application/json", "application/json", nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to run pipeline %s", pipeline.Name)
+	}
+
+	var run pipelines.Run
+	if err := client.UnmarshalBody(response, &run); err != nil {
+		return nil, errors.Wrap(err, "failed to read pipeline run")
+	}
+
+	result := convertPipelineRun(run)
+	return &result, nil
+}
+
+// convertPipelineRun converts an Azure DevOps pipeline run to our model
+func convertPipelineRun(run pipelines.Run) PipelineRun {
+	result := PipelineRun{
+		ID:   intValue(run.Id),
+		Name: stringValue(run.Name),
+		URL:  linkHref(run.Links, "web"),
+	}
+	if run.Pipeline != nil {
+		result.PipelineID = intValue(run.Pipeline.Id)
+		result.Pipeline = stringValue(run.Pipeline.Name)
+	}
+	if run.State != nil {
+		result.State = string(*run.State)
+	}
+	if run.Result != nil {
+		result.Result = string(*run.Result)
+	}
+	if run.CreatedDate != nil {
+		result.Created = run.CreatedDate.Time
+	}
+	if run.FinishedDate != nil {
+		finished := run.FinishedDate.Time
+		result.Finished = &finished
+	}
+	return result
+}
+
+// printPipelinesAsText prints pipelines in a human-readable format
+func printPipelinesAsText(pipelineList []Pipeline) {
+	if len(pipelineList) == 0 {
+		fmt.Println("No pipelines found.")
+		return
+	}
+
+	fmt.Printf("Found %d pipelines:\n\n", len(pipelineList))
+
+	for _, pipeline := range pipelineList {
+		fmt.Printf("ID: %d\n", pipeline.ID)
+		fmt.Printf("Name: %s\n", pipeline.Name)
+		fmt.Printf("Folder: %s\n", pipeline.Folder)
+		fmt.Printf("URL: %s\n", pipeline.URL)
+		fmt.Println()
+	}
+}
+
+// printPipelinesAsJSON prints pipelines in JSON format
+func printPipelinesAsJSON(pipelineList []Pipeline) {
+	// Marshal the pipelines to JSON with indentation
+	jsonData, err := json.MarshalIndent(pipelineList, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal pipelines to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal pipelines to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
@@ -0,0 +1,71 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFindPipeline(t *testing.T) {
+	pipelineList := []Pipeline{
+		{ID: 1, Name: "CI"},
+		{ID: 2, Name: "Deploy"},
+		{ID: 3, Name: "deploy", Folder: "\\legacy"},
+	}
+
+	if got, err := findPipeline(pipelineList, "1"); err != nil || got.Name != "CI" {
+		t.Errorf("findPipeline(\"1\") = %+v, %v, want CI", got, err)
+	}
+	if got, err := findPipeline(pipelineList, "ci"); err != nil || got.ID != 1 {
+		t.Errorf("findPipeline(\"ci\") = %+v, %v, want ID 1", got, err)
+	}
+	if _, err := findPipeline(pipelineList, "deploy"); err == nil {
+		t.Error("findPipeline(\"deploy\") error = nil, want an ambiguity error")
+	}
+	if _, err := findPipeline(pipelineList, "42"); err == nil {
+		t.Error("findPipeline(\"42\") error = nil, want an error")
+	}
+	if _, err := findPipeline(pipelineList, "missing"); err == nil {
+		t.Error("findPipeline(\"missing\") error = nil, want an error")
+	}
+}
+
+func TestBuildRunPipelineParameters(t *testing.T) {
+	parameters := buildRunPipelineParameters("main", map[string]string{"environment": "staging"}, map[string]string{"DEBUG": "true"})
+
+	data, err := json.Marshal(parameters)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"resources": map[string]interface{}{
+			"repositories": map[string]interface{}{
+				"self": map[string]interface{}{"refName": "refs/heads/main"},
+			},
+		},
+		"templateParameters": map[string]interface{}{"environment": "staging"},
+		"variables": map[string]interface{}{
+			"DEBUG": map[string]interface{}{"value": "true"},
+		},
+	}
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("run parameters = %s, want %s", gotJSON, wantJSON)
+	}
+}
+
+func TestBuildRunPipelineParametersEmpty(t *testing.T) {
+	data, err := json.Marshal(buildRunPipelineParameters("", nil, nil))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(data) != "{}" {
+		t.Errorf("run parameters = %s, want {}", data)
+	}
+}
@@ -0,0 +1,124 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
+	"github.com/spf13/cobra"
+)
+
+// Project represents a project in Azure DevOps
+type Project struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Visibility  string     `json:"visibility"`
+	State       string     `json:"state"`
+	LastUpdated *time.Time `json:"lastUpdated,omitempty"`
+}
+
+// listProjectsCommand lists the projects of the organization
+func listProjectsCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing projects")
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the projects
+	projects, err := getOrganizationProjects()
+	if err != nil {
+		return handleError("Failed to get projects", err)
+	}
+
+	// Print the projects
+	if jsonOutput {
+		printProjectsAsJSON(projects)
+	} else {
+		printProjectsAsText(projects)
+	}
+
+	logger.Info("Projects listed successfully")
+	return nil
+}
+
+// getOrganizationProjects gets all projects of the organization
+func getOrganizationProjects() ([]Project, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	projects, err := getProjects(connection)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []Project{}
+	for _, project := range projects {
+		result = append(result, convertProject(project))
+	}
+	return result, nil
+}
+
+// convertProject converts an Azure DevOps project reference to our model
+func convertProject(project core.TeamProjectReference) Project {
+	result := Project{
+		Name:        stringValue(project.Name),
+		Description: stringValue(project.Description),
+	}
+	if project.Id != nil {
+		result.ID = project.Id.String()
+	}
+	if project.Visibility != nil {
+		result.Visibility = string(*project.Visibility)
+	}
+	if project.State != nil {
+		result.State = string(*project.State)
+	}
+	if project.LastUpdateTime != nil {
+		lastUpdated := project.LastUpdateTime.Time
+		result.LastUpdated = &lastUpdated
+	}
+	return result
+}
+
+// printProjectsAsText prints projects in a human-readable format
+func printProjectsAsText(projects []Project) {
+	if len(projects) == 0 {
+		fmt.Println("No projects found.")
+		return
+	}
+
+	fmt.Printf("Found %d projects:\n\n", len(projects))
+
+	for _, project := range projects {
+		fmt.Printf("Name: %s\n", project.Name)
+		fmt.Printf("Description: %s\n", project.Description)
+		fmt.Printf("Visibility: %s\n", project.Visibility)
+		fmt.Printf("State: %s\n", project.State)
+		fmt.Println()
+	}
+}
+
+// printProjectsAsJSON prints projects in JSON format
+func printProjectsAsJSON(projects []Project) {
+	// Marshal the projects to JSON with indentation
+	jsonData, err := json.MarshalIndent(projects, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal projects to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal projects to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
@@ -0,0 +1,41 @@
+package azuredevops
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
+)
+
+func TestConvertProject(t *testing.T) {
+	id := uuid.New()
+	name, description := "MyProject", "The main product"
+	visibility := core.ProjectVisibilityValues.Private
+	state := core.ProjectStateValues.WellFormed
+	updated := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+
+	got := convertProject(core.TeamProjectReference{
+		Id:             &id,
+		Name:           &name,
+		Description:    &description,
+		Visibility:     &visibility,
+		State:          &state,
+		LastUpdateTime: &azuredevops.Time{Time: updated},
+	})
+
+	if got.ID != id.String() || got.Name != name || got.Description != description {
+		t.Errorf("convertProject() = %+v", got)
+	}
+	if got.Visibility != "private" || got.State != "wellFormed" {
+		t.Errorf("convertProject() visibility = %s, state = %s, want private, wellFormed", got.Visibility, got.State)
+	}
+	if got.LastUpdated == nil || !got.LastUpdated.Equal(updated) {
+		t.Errorf("convertProject() last updated = %v, want %v", got.LastUpdated, updated)
+	}
+
+	if empty := convertProject(core.TeamProjectReference{}); empty.ID != "" || empty.LastUpdated != nil {
+		t.Errorf("convertProject() of an empty reference = %+v", empty)
+	}
+}
@@ -0,0 +1,98 @@
+package azuredevops
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/webapi"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// autoCompletePullRequestCommand enables or cancels auto-complete on a pull request
+func autoCompletePullRequestCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Setting pull request auto-complete")
+
+	// Parse the repository and pull request ID
+	repository, id, err := parsePullRequestArgs(args)
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Check if auto-complete should be cancelled instead
+	cancel, err := cmd.Flags().GetBool("cancel")
+	if err != nil {
+		return handleError("Failed to get cancel flag", err)
+	}
+
+	// Get the completion settings
+	settings, err := getCompletionSettings(cmd)
+	if err != nil {
+		return handleError("Failed to get completion flags", err)
+	}
+
+	// Update the auto-complete settings
+	if err := setPullRequestAutoComplete(repository, id, settings, cancel); err != nil {
+		return handleError("Failed to set pull request auto-complete", err)
+	}
+
+	if cancel {
+		fmt.Printf("Cancelled auto-complete of pull request %d in %s\n", id, repository)
+	} else {
+		fmt.Printf("Pull request %d in %s will be completed once all policies pass (merge strategy: %s)\n", id, repository, mergeStrategyFor(settings))
+	}
+
+	logger.Info("Pull request auto-complete set successfully", "repository", repository, "id", id, "cancel", cancel)
+	return nil
+}
+
+// setPullRequestAutoComplete sets the current user as the auto-complete user of a pull request, or clears it
+func setPullRequestAutoComplete(repository string, id int, settings CompletionSettings, cancel bool) error {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return err
+	}
+
+	// Auto-complete is set on behalf of the current user; the empty ID clears it
+	userID := uuid.Nil
+	if !cancel {
+		me, err := getAuthenticatedUser(connectionDetails)
+		if err != nil {
+			return err
+		}
+		userID = *me.Id
+	}
+
+	// Create a client for the Git API
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return err
+	}
+
+	// Update the pull request
+	_, err = client.UpdatePullRequest(commandContext, git.UpdatePullRequestArgs{
+		GitPullRequestToUpdate: buildAutoCompleteUpdate(userID, settings),
+		RepositoryId:           &repository,
+		PullRequestId:          &id,
+		Project:                &connectionDetails.Project,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update auto-complete of pull request %d", id)
+	}
+
+	return nil
+}
+
+// buildAutoCompleteUpdate builds the pull request update that sets or, for the nil user ID, clears auto-complete
+func buildAutoCompleteUpdate(userID uuid.UUID, settings CompletionSettings) *git.GitPullRequest {
+	id := userID.String()
+	update := &git.GitPullRequest{
+		AutoCompleteSetBy: &webapi.IdentityRef{Id: &id},
+	}
+	if userID != uuid.Nil {
+		update.CompletionOptions = buildCompletionOptions(settings)
+	}
+	return update
+}
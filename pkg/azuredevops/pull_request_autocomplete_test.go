@@ -0,0 +1,37 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+)
+
+func TestBuildAutoCompleteUpdate(t *testing.T) {
+	userID := uuid.MustParse("7b1e4b3a-1f2e-4c55-9d5b-0a3c2e1f4d6b")
+	settings := CompletionSettings{Squash: true, DeleteSourceBranch: true}
+
+	update := buildAutoCompleteUpdate(userID, settings)
+	if update.AutoCompleteSetBy == nil || *update.AutoCompleteSetBy.Id != userID.String() {
+		t.Fatalf("buildAutoCompleteUpdate().AutoCompleteSetBy = %+v, want user %s", update.AutoCompleteSetBy, userID)
+	}
+	if update.CompletionOptions == nil {
+		t.Fatal("buildAutoCompleteUpdate().CompletionOptions = nil, want options")
+	}
+	if *update.CompletionOptions.MergeStrategy != git.GitPullRequestMergeStrategyValues.Squash {
+		t.Errorf("MergeStrategy = %s, want squash", *update.CompletionOptions.MergeStrategy)
+	}
+	if !*update.CompletionOptions.DeleteSourceBranch {
+		t.Error("DeleteSourceBranch = false, want true")
+	}
+}
+
+func TestBuildAutoCompleteUpdateCancel(t *testing.T) {
+	update := buildAutoCompleteUpdate(uuid.Nil, CompletionSettings{})
+	if update.AutoCompleteSetBy == nil || *update.AutoCompleteSetBy.Id != uuid.Nil.String() {
+		t.Fatalf("buildAutoCompleteUpdate().AutoCompleteSetBy = %+v, want the empty ID", update.AutoCompleteSetBy)
+	}
+	if update.CompletionOptions != nil {
+		t.Errorf("buildAutoCompleteUpdate().CompletionOptions = %+v, want nil", update.CompletionOptions)
+	}
+}
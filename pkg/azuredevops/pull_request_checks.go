@@ -0,0 +1,257 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// Check outcomes reported by the checks command
+const (
+	checkPassed        = "passed"
+	checkFailed        = "failed"
+	checkPending       = "pending"
+	checkNotApplicable = "not applicable"
+)
+
+// PullRequestCheck represents a branch policy evaluation or status check on a pull request
+type PullRequestCheck struct {
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	State       string `json:"state"`
+	Outcome     string `json:"outcome"`
+	Blocking    bool   `json:"blocking"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// listPullRequestChecksCommand lists the policy evaluations and status checks of a pull request
+func listPullRequestChecksCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing pull request checks")
+
+	// Parse the repository and pull request ID
+	repository, id, err := parsePullRequestArgs(args)
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the checks
+	checks, err := getPullRequestChecks(repository, id)
+	if err != nil {
+		return handleError("Failed to get pull request checks", err)
+	}
+
+	// Print the checks
+	if jsonOutput {
+		printPullRequestChecksAsJSON(checks)
+	} else {
+		printPullRequestChecksAsText(checks)
+	}
+
+	logger.Info("Pull request checks listed successfully")
+	return nil
+}
+
+// getPullRequestChecks gets the branch policy evaluations and status checks of a pull request
+func getPullRequestChecks(repository string, id int) ([]PullRequestCheck, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Git API
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the pull request, which tells us the ID of its project
+	pr, err := client.GetPullRequest(commandContext, git.GetPullRequestArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &id,
+		Project:       &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get pull request %d", id)
+	}
+	if pr.Repository == nil || pr.Repository.Project == nil || pr.Repository.Project.Id == nil {
+		return nil, errors.Errorf("pull request %d does not include its project ID", id)
+	}
+
+	// Create a client for the Policy API
+	policyClient, err := createPolicyClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the policy evaluations
+	artifactID := pullRequestArtifactID(pr.Repository.Project.Id.String(), id)
+	evaluations, err := policyClient.GetPolicyEvaluations(commandContext, policy.GetPolicyEvaluationsArgs{
+		Project:    &connectionDetails.Project,
+		ArtifactId: &artifactID,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get policy evaluations of pull request %d", id)
+	}
+
+	result := []PullRequestCheck{}
+	for _, evaluation := range *evaluations {
+		result = append(result, convertPolicyEvaluation(evaluation))
+	}
+
+	// Get the status checks posted by external services
+	statuses, err := client.GetPullRequestStatuses(commandContext, git.GetPullRequestStatusesArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &id,
+		Project:       &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get statuses of pull request %d", id)
+	}
+
+	for _, status := range *statuses {
+		result = append(result, convertPullRequestStatus(status))
+	}
+
+	return result, nil
+}
+
+// pullRequestArtifactID builds the artifact ID that policy evaluations of a pull request are recorded against
+func pullRequestArtifactID(projectID string, id int) string {
+	return fmt.Sprintf("vstfs:///CodeReview/CodeReviewId/%s/%d", projectID, id)
+}
+
+// convertPolicyEvaluation converts a policy evaluation record to our model
+func convertPolicyEvaluation(evaluation policy.PolicyEvaluationRecord) PullRequestCheck {
+	result := PullRequestCheck{Kind: "policy"}
+
+	if evaluation.Configuration != nil {
+		if evaluation.Configuration.Type != nil {
+			result.Name = stringValue(evaluation.Configuration.Type.DisplayName)
+		}
+		result.Blocking = evaluation.Configuration.IsBlocking != nil && *evaluation.Configuration.IsBlocking
+
+		// Build policies carry a more specific name in their settings
+		if settings, ok := evaluation.Configuration.Settings.(map[string]interface{}); ok {
+			if displayName, ok := settings["displayName"].(string); ok && displayName != "" {
+				result.Name = fmt.Sprintf("%s (%s)", result.Name, displayName)
+			}
+		}
+	}
+
+	if evaluation.Status != nil {
+		result.State = string(*evaluation.Status)
+	}
+	result.Outcome = policyOutcome(result.State)
+
+	return result
+}
+
+// policyOutcome maps a policy evaluation status to a check outcome
+func policyOutcome(status string) string {
+	switch policy.PolicyEvaluationStatus(status) {
+	case policy.PolicyEvaluationStatusValues.Approved:
+		return checkPassed
+	case policy.PolicyEvaluationStatusValues.Rejected, policy.PolicyEvaluationStatusValues.Broken:
+		return checkFailed
+	case policy.PolicyEvaluationStatusValues.NotApplicable:
+		return checkNotApplicable
+	default:
+		return checkPending
+	}
+}
+
+// convertPullRequestStatus converts a pull request status to our model
+func convertPullRequestStatus(status git.GitPullRequestStatus) PullRequestCheck {
+	result := PullRequestCheck{
+		Kind:        "status",
+		Description: stringValue(status.Description),
+		URL:         stringValue(status.TargetUrl),
+	}
+
+	if status.Context != nil {
+		result.Name = stringValue(status.Context.Name)
+		if genre := stringValue(status.Context.Genre); genre != "" {
+			result.Name = genre + "/" + result.Name
+		}
+	}
+
+	if status.State != nil {
+		result.State = string(*status.State)
+	}
+	result.Outcome = statusOutcome(result.State)
+
+	return result
+}
+
+// statusOutcome maps a status check state to a check outcome
+func statusOutcome(state string) string {
+	switch git.GitStatusState(state) {
+	case git.GitStatusStateValues.Succeeded:
+		return checkPassed
+	case git.GitStatusStateValues.Failed, git.GitStatusStateValues.Error:
+		return checkFailed
+	case git.GitStatusStateValues.NotApplicable:
+		return checkNotApplicable
+	default:
+		return checkPending
+	}
+}
+
+// isBlockingCompletion checks if a check prevents the pull request from being completed
+func isBlockingCompletion(check PullRequestCheck) bool {
+	return check.Blocking && (check.Outcome == checkFailed || check.Outcome == checkPending)
+}
+
+// printPullRequestChecksAsText prints pull request checks in a human-readable format
+func printPullRequestChecksAsText(checks []PullRequestCheck) {
+	if len(checks) == 0 {
+		fmt.Println("No checks found.")
+		return
+	}
+
+	fmt.Printf("Found %d checks:\n\n", len(checks))
+
+	blocking := 0
+	for _, check := range checks {
+		marker := ""
+		if isBlockingCompletion(check) {
+			marker = " (blocking)"
+			blocking++
+		}
+		fmt.Printf("[%s] %s: %s%s\n", check.Kind, check.Name, check.Outcome, marker)
+		if check.Description != "" {
+			fmt.Printf("  %s\n", check.Description)
+		}
+		if check.URL != "" {
+			fmt.Printf("  %s\n", check.URL)
+		}
+	}
+
+	fmt.Printf("\n%d checks blocking completion\n", blocking)
+}
+
+// printPullRequestChecksAsJSON prints pull request checks in JSON format
+func printPullRequestChecksAsJSON(checks []PullRequestCheck) {
+	// Marshal the checks to JSON with indentation
+	jsonData, err := json.MarshalIndent(checks, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal checks to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal checks to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
@@ -0,0 +1,89 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+)
+
+func TestPullRequestArtifactID(t *testing.T) {
+	got := pullRequestArtifactID("a1b2", 42)
+	want := "vstfs:///CodeReview/CodeReviewId/a1b2/42"
+	if got != want {
+		t.Errorf("pullRequestArtifactID() = %s, want %s", got, want)
+	}
+}
+
+func TestConvertPolicyEvaluation(t *testing.T) {
+	typeName := "Build"
+	blocking := true
+	status := policy.PolicyEvaluationStatusValues.Rejected
+	evaluation := policy.PolicyEvaluationRecord{
+		Configuration: &policy.PolicyConfiguration{
+			Type:       &policy.PolicyTypeRef{DisplayName: &typeName},
+			IsBlocking: &blocking,
+			Settings:   map[string]interface{}{"displayName": "CI"},
+		},
+		Status: &status,
+	}
+
+	check := convertPolicyEvaluation(evaluation)
+	if check.Name != "Build (CI)" {
+		t.Errorf("convertPolicyEvaluation().Name = %s, want 'Build (CI)'", check.Name)
+	}
+	if check.Outcome != checkFailed || !check.Blocking {
+		t.Errorf("convertPolicyEvaluation() = %+v, want a blocking failed check", check)
+	}
+	if !isBlockingCompletion(check) {
+		t.Error("isBlockingCompletion() = false, want true")
+	}
+}
+
+func TestConvertPullRequestStatus(t *testing.T) {
+	genre := "ci"
+	name := "lint"
+	state := git.GitStatusStateValues.Succeeded
+	status := git.GitPullRequestStatus{
+		Context: &git.GitStatusContext{Genre: &genre, Name: &name},
+		State:   &state,
+	}
+
+	check := convertPullRequestStatus(status)
+	if check.Name != "ci/lint" || check.Outcome != checkPassed {
+		t.Errorf("convertPullRequestStatus() = %+v, want passed ci/lint", check)
+	}
+	if isBlockingCompletion(check) {
+		t.Error("isBlockingCompletion() = true, want false")
+	}
+}
+
+func TestOutcomes(t *testing.T) {
+	policyTests := map[string]string{
+		"approved":      checkPassed,
+		"rejected":      checkFailed,
+		"broken":        checkFailed,
+		"running":       checkPending,
+		"queued":        checkPending,
+		"notApplicable": checkNotApplicable,
+	}
+	for status, want := range policyTests {
+		if got := policyOutcome(status); got != want {
+			t.Errorf("policyOutcome(%s) = %s, want %s", status, got, want)
+		}
+	}
+
+	statusTests := map[string]string{
+		"succeeded":     checkPassed,
+		"failed":        checkFailed,
+		"error":         checkFailed,
+		"pending":       checkPending,
+		"notSet":        checkPending,
+		"notApplicable": checkNotApplicable,
+	}
+	for state, want := range statusTests {
+		if got := statusOutcome(state); got != want {
+			t.Errorf("statusOutcome(%s) = %s, want %s", state, got, want)
+		}
+	}
+}
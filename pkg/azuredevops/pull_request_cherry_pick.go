@@ -0,0 +1,187 @@
+package azuredevops
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// Polling settings used while waiting for a cherry-pick to finish
+const (
+	cherryPickPollInterval = 2 * time.Second
+	cherryPickTimeout      = 5 * time.Minute
+)
+
+// cherryPickPullRequestCommand cherry-picks a completed pull request onto another branch and opens a pull request for it
+func cherryPickPullRequestCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Cherry-picking pull request")
+
+	// Parse the repository and pull request ID
+	repository, id, err := parsePullRequestArgs(args)
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Get the branches
+	target, err := cmd.Flags().GetString("target")
+	if err != nil {
+		return handleError("Failed to get target flag", err)
+	}
+	branch, err := cmd.Flags().GetString("branch")
+	if err != nil {
+		return handleError("Failed to get branch flag", err)
+	}
+	if branch == "" {
+		branch = cherryPickBranchName(id, target)
+	}
+
+	// Cherry-pick the pull request
+	pullRequest, err := cherryPickPullRequest(repository, id, normalizeBranchName(target), normalizeBranchName(branch))
+	if err != nil {
+		return handleError("Failed to cherry-pick pull request", err)
+	}
+
+	fmt.Printf("Created pull request %d in %s to cherry-pick pull request %d onto %s\n", pullRequest.ID, pullRequest.Repository, id, target)
+
+	logger.Info("Pull request cherry-picked successfully", "repository", repository, "id", id, "target", target, "pullRequest", pullRequest.ID)
+	return nil
+}
+
+// cherryPickBranchName returns the default topic branch name for cherry-picking a pull request onto a target branch
+func cherryPickBranchName(id int, target string) string {
+	target = strings.TrimPrefix(target, "refs/heads/")
+	return fmt.Sprintf("cherry-pick/%d-%s", id, strings.ReplaceAll(target, "/", "-"))
+}
+
+// cherryPickTitle returns the title of the pull request that brings a cherry-pick into the target branch
+func cherryPickTitle(title string, target string) string {
+	return fmt.Sprintf("%s (cherry-pick to %s)", title, strings.TrimPrefix(target, "refs/heads/"))
+}
+
+// cherryPickPullRequest cherry-picks a completed pull request onto the target branch and opens a pull request from the topic branch
+func cherryPickPullRequest(repository string, id int, targetRef string, topicRef string) (*PullRequest, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Git API
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only completed pull requests have merged changes to cherry-pick
+	original, err := client.GetPullRequest(commandContext, git.GetPullRequestArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &id,
+		Project:       &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get pull request %d", id)
+	}
+	if original.Status == nil || *original.Status != git.PullRequestStatusValues.Completed {
+		return nil, errors.Errorf("pull request %d is not completed", id)
+	}
+
+	// Start the cherry-pick
+	cherryPick, err := client.CreateCherryPick(commandContext, git.CreateCherryPickArgs{
+		CherryPickToCreate: &git.GitAsyncRefOperationParameters{
+			GeneratedRefName: &topicRef,
+			OntoRefName:      &targetRef,
+			Repository:       original.Repository,
+			Source:           &git.GitAsyncRefOperationSource{PullRequestId: &id},
+		},
+		Project:      &connectionDetails.Project,
+		RepositoryId: &repository,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to start cherry-pick of pull request %d", id)
+	}
+
+	// Wait for the cherry-pick to finish
+	cherryPick, err = waitForCherryPick(client, connectionDetails.Project, repository, cherryPick)
+	if err != nil {
+		return nil, err
+	}
+
+	// Open a pull request from the topic branch into the target branch
+	title := cherryPickTitle(stringValue(original.Title), targetRef)
+	description := fmt.Sprintf("Cherry-pick of pull request !%d into %s.", id, strings.TrimPrefix(targetRef, "refs/heads/"))
+	if original.Description != nil && *original.Description != "" {
+		description += "\n\n" + *original.Description
+	}
+	created, err := client.CreatePullRequest(commandContext, git.CreatePullRequestArgs{
+		GitPullRequestToCreate: &git.GitPullRequest{
+			SourceRefName: &topicRef,
+			TargetRefName: &targetRef,
+			Title:         &title,
+			Description:   &description,
+		},
+		RepositoryId: &repository,
+		Project:      &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create pull request from %s", topicRef)
+	}
+
+	result := convertPullRequest(repository, *created)
+	return &result, nil
+}
+
+// waitForCherryPick polls a cherry-pick until it completes, fails or times out
+func waitForCherryPick(client git.Client, project string, repository string, cherryPick *git.GitCherryPick) (*git.GitCherryPick, error) {
+	deadline := time.Now().Add(cherryPickTimeout)
+	for {
+		if err := cherryPickError(cherryPick); err != nil {
+			return nil, err
+		}
+		if cherryPick.Status != nil && *cherryPick.Status == git.GitAsyncOperationStatusValues.Completed {
+			return cherryPick, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, errors.Errorf("cherry-pick %d did not finish within %s", intValue(cherryPick.CherryPickId), cherryPickTimeout)
+		}
+
+		if err := sleepContext(commandContext, cherryPickPollInterval); err != nil {
+			return nil, err
+		}
+
+		var err error
+		cherryPick, err = client.GetCherryPick(commandContext, git.GetCherryPickArgs{
+			Project:      &project,
+			CherryPickId: cherryPick.CherryPickId,
+			RepositoryId: &repository,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get cherry-pick status")
+		}
+	}
+}
+
+// cherryPickError returns an error describing why a cherry-pick failed, or nil if it has not failed
+func cherryPickError(cherryPick *git.GitCherryPick) error {
+	if cherryPick.Status == nil {
+		return nil
+	}
+	if *cherryPick.Status != git.GitAsyncOperationStatusValues.Failed && *cherryPick.Status != git.GitAsyncOperationStatusValues.Abandoned {
+		return nil
+	}
+
+	detail := cherryPick.DetailedStatus
+	switch {
+	case detail == nil:
+		return errors.Errorf("cherry-pick %s", *cherryPick.Status)
+	case detail.Conflict != nil && *detail.Conflict:
+		return errors.Errorf("cherry-pick failed with conflicts at commit %s; resolve them manually", shortCommitID(stringValue(detail.CurrentCommitId)))
+	case detail.FailureMessage != nil:
+		return errors.Errorf("cherry-pick failed: %s", *detail.FailureMessage)
+	default:
+		return errors.Errorf("cherry-pick %s", *cherryPick.Status)
+	}
+}
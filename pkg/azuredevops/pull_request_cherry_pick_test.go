@@ -0,0 +1,59 @@
+package azuredevops
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+)
+
+func TestCherryPickBranchName(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{target: "release/1.2", want: "cherry-pick/42-release-1.2"},
+		{target: "refs/heads/main", want: "cherry-pick/42-main"},
+	}
+
+	for _, tt := range tests {
+		if got := cherryPickBranchName(42, tt.target); got != tt.want {
+			t.Errorf("cherryPickBranchName(42, %q) = %s, want %s", tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestCherryPickTitle(t *testing.T) {
+	got := cherryPickTitle("Fix login", "refs/heads/release/1.2")
+	if got != "Fix login (cherry-pick to release/1.2)" {
+		t.Errorf("cherryPickTitle() = %s", got)
+	}
+}
+
+func TestCherryPickError(t *testing.T) {
+	inProgress := git.GitAsyncOperationStatusValues.InProgress
+	failed := git.GitAsyncOperationStatusValues.Failed
+	conflict := true
+	commit := "0123456789abcdef"
+	message := "target branch not found"
+
+	if err := cherryPickError(&git.GitCherryPick{Status: &inProgress}); err != nil {
+		t.Errorf("cherryPickError(inProgress) = %v, want nil", err)
+	}
+
+	err := cherryPickError(&git.GitCherryPick{
+		Status:         &failed,
+		DetailedStatus: &git.GitAsyncRefOperationDetail{Conflict: &conflict, CurrentCommitId: &commit},
+	})
+	if err == nil || !strings.Contains(err.Error(), "conflicts at commit 01234567") {
+		t.Errorf("cherryPickError(conflict) = %v, want a conflict error", err)
+	}
+
+	err = cherryPickError(&git.GitCherryPick{
+		Status:         &failed,
+		DetailedStatus: &git.GitAsyncRefOperationDetail{FailureMessage: &message},
+	})
+	if err == nil || !strings.Contains(err.Error(), message) {
+		t.Errorf("cherryPickError(failure) = %v, want the failure message", err)
+	}
+}
@@ -0,0 +1,300 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// PullRequestComment represents a comment in a pull request thread
+type PullRequestComment struct {
+	ID        int       `json:"id"`
+	Author    string    `json:"author"`
+	Content   string    `json:"content"`
+	Published time.Time `json:"published"`
+}
+
+// PullRequestThread represents a comment thread on a pull request
+type PullRequestThread struct {
+	ID       int                  `json:"id"`
+	Status   string               `json:"status"`
+	FilePath string               `json:"filePath,omitempty"`
+	Line     int                  `json:"line,omitempty"`
+	Comments []PullRequestComment `json:"comments"`
+}
+
+// listPullRequestCommentsCommand lists the comment threads of a pull request
+func listPullRequestCommentsCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing pull request comment threads")
+
+	// Parse the repository and pull request ID
+	repository, id, err := parsePullRequestArgs(args)
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the comment threads
+	threads, err := getPullRequestThreads(repository, id)
+	if err != nil {
+		return handleError("Failed to get pull request comment threads", err)
+	}
+
+	// Print the comment threads
+	if jsonOutput {
+		printPullRequestThreadsAsJSON(threads)
+	} else {
+		printPullRequestThreadsAsText(threads)
+	}
+
+	logger.Info("Pull request comment threads listed successfully")
+	return nil
+}
+
+// addPullRequestCommentCommand adds a comment thread to a pull request
+func addPullRequestCommentCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Adding pull request comment")
+
+	// Parse the repository and pull request ID
+	repository, id, err := parsePullRequestArgs(args)
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Get the comment flags
+	message, err := cmd.Flags().GetString("message")
+	if err != nil {
+		return handleError("Failed to get message flag", err)
+	}
+	filePath, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return handleError("Failed to get file flag", err)
+	}
+	line, err := cmd.Flags().GetInt("line")
+	if err != nil {
+		return handleError("Failed to get line flag", err)
+	}
+
+	// Build the comment thread
+	thread, err := buildCommentThread(message, filePath, line)
+	if err != nil {
+		return handleError("Invalid comment", err)
+	}
+
+	// Create the comment thread
+	created, err := createPullRequestThread(repository, id, thread)
+	if err != nil {
+		return handleError("Failed to add pull request comment", err)
+	}
+
+	fmt.Printf("Added comment thread %d to pull request %d in %s\n", created.ID, id, repository)
+
+	logger.Info("Pull request comment added successfully", "repository", repository, "id", id, "thread", created.ID)
+	return nil
+}
+
+// buildCommentThread builds a new active comment thread, optionally anchored to a file and line
+func buildCommentThread(message string, filePath string, line int) (*git.GitPullRequestCommentThread, error) {
+	if strings.TrimSpace(message) == "" {
+		return nil, errors.New("a comment message is required")
+	}
+	if line != 0 && filePath == "" {
+		return nil, errors.New("--line requires --file")
+	}
+	if line < 0 {
+		return nil, errors.Errorf("invalid line number %d", line)
+	}
+
+	commentType := git.CommentTypeValues.Text
+	status := git.CommentThreadStatusValues.Active
+	thread := &git.GitPullRequestCommentThread{
+		Comments: &[]git.Comment{
+			{
+				Content:     &message,
+				CommentType: &commentType,
+			},
+		},
+		Status: &status,
+	}
+
+	if filePath != "" {
+		if !strings.HasPrefix(filePath, "/") {
+			filePath = "/" + filePath
+		}
+		threadContext := &git.CommentThreadContext{FilePath: &filePath}
+		if line > 0 {
+			offset := 1
+			threadContext.RightFileStart = &git.CommentPosition{Line: &line, Offset: &offset}
+			threadContext.RightFileEnd = &git.CommentPosition{Line: &line, Offset: &offset}
+		}
+		thread.ThreadContext = threadContext
+	}
+
+	return thread, nil
+}
+
+// getPullRequestThreads gets the comment threads of a pull request
+func getPullRequestThreads(repository string, id int) ([]PullRequestThread, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Git API
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the comment threads
+	threads, err := client.GetThreads(commandContext, git.GetThreadsArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &id,
+		Project:       &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get threads of pull request %d", id)
+	}
+
+	// Convert the threads to our model, skipping deleted and system-only threads
+	var result []PullRequestThread
+	for _, thread := range *threads {
+		if thread.IsDeleted != nil && *thread.IsDeleted {
+			continue
+		}
+		converted := convertPullRequestThread(thread)
+		if len(converted.Comments) == 0 {
+			continue
+		}
+		result = append(result, converted)
+	}
+
+	return result, nil
+}
+
+// createPullRequestThread creates a comment thread on a pull request
+func createPullRequestThread(repository string, id int, thread *git.GitPullRequestCommentThread) (*PullRequestThread, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Git API
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the comment thread
+	created, err := client.CreateThread(commandContext, git.CreateThreadArgs{
+		CommentThread: thread,
+		RepositoryId:  &repository,
+		PullRequestId: &id,
+		Project:       &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create thread on pull request %d", id)
+	}
+
+	result := convertPullRequestThread(*created)
+	return &result, nil
+}
+
+// convertPullRequestThread converts an Azure DevOps comment thread to our model, dropping system comments
+func convertPullRequestThread(thread git.GitPullRequestCommentThread) PullRequestThread {
+	result := PullRequestThread{
+		ID:       intValue(thread.Id),
+		Comments: []PullRequestComment{},
+	}
+	if thread.Status != nil {
+		result.Status = string(*thread.Status)
+	}
+	if thread.ThreadContext != nil {
+		result.FilePath = stringValue(thread.ThreadContext.FilePath)
+		if thread.ThreadContext.RightFileStart != nil {
+			result.Line = intValue(thread.ThreadContext.RightFileStart.Line)
+		}
+	}
+
+	if thread.Comments == nil {
+		return result
+	}
+
+	for _, comment := range *thread.Comments {
+		if comment.IsDeleted != nil && *comment.IsDeleted {
+			continue
+		}
+		if comment.CommentType != nil && *comment.CommentType == git.CommentTypeValues.System {
+			continue
+		}
+
+		converted := PullRequestComment{
+			ID:      intValue(comment.Id),
+			Content: stringValue(comment.Content),
+		}
+		if comment.Author != nil {
+			converted.Author = stringValue(comment.Author.DisplayName)
+		}
+		if comment.PublishedDate != nil {
+			converted.Published = comment.PublishedDate.Time
+		}
+		result.Comments = append(result.Comments, converted)
+	}
+
+	return result
+}
+
+// printPullRequestThreadsAsText prints comment threads in a human-readable format
+func printPullRequestThreadsAsText(threads []PullRequestThread) {
+	if len(threads) == 0 {
+		fmt.Println("No comment threads found.")
+		return
+	}
+
+	fmt.Printf("Found %d comment threads:\n\n", len(threads))
+
+	for _, thread := range threads {
+		fmt.Printf("Thread %d [%s]", thread.ID, thread.Status)
+		if thread.FilePath != "" {
+			fmt.Printf(" %s", thread.FilePath)
+			if thread.Line > 0 {
+				fmt.Printf(":%d", thread.Line)
+			}
+		}
+		fmt.Println()
+
+		for _, comment := range thread.Comments {
+			fmt.Printf("  %s (%s):\n", comment.Author, comment.Published.Format(time.RFC3339))
+			for _, line := range strings.Split(comment.Content, "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+		fmt.Println()
+	}
+}
+
+// printPullRequestThreadsAsJSON prints comment threads in JSON format
+func printPullRequestThreadsAsJSON(threads []PullRequestThread) {
+	// Marshal the threads to JSON with indentation
+	jsonData, err := json.MarshalIndent(threads, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal comment threads to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal comment threads to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
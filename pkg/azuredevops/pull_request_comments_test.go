@@ -0,0 +1,84 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/webapi"
+)
+
+func TestBuildCommentThread(t *testing.T) {
+	// A general comment has no thread context
+	thread, err := buildCommentThread("Looks good", "", 0)
+	if err != nil {
+		t.Fatalf("buildCommentThread() returned an error: %v", err)
+	}
+	if thread.ThreadContext != nil {
+		t.Errorf("buildCommentThread() set a thread context for a general comment")
+	}
+	if len(*thread.Comments) != 1 || *(*thread.Comments)[0].Content != "Looks good" {
+		t.Errorf("buildCommentThread() did not set the comment content")
+	}
+
+	// A file comment is anchored to the right side of the diff
+	thread, err = buildCommentThread("Fix this", "src/main.go", 42)
+	if err != nil {
+		t.Fatalf("buildCommentThread() returned an error: %v", err)
+	}
+	if thread.ThreadContext == nil {
+		t.Fatalf("buildCommentThread() did not set a thread context for a file comment")
+	}
+	if *thread.ThreadContext.FilePath != "/src/main.go" {
+		t.Errorf("FilePath = %s, want /src/main.go", *thread.ThreadContext.FilePath)
+	}
+	if *thread.ThreadContext.RightFileStart.Line != 42 {
+		t.Errorf("RightFileStart.Line = %d, want 42", *thread.ThreadContext.RightFileStart.Line)
+	}
+
+	// Invalid combinations are rejected
+	if _, err := buildCommentThread(" ", "", 0); err == nil {
+		t.Errorf("buildCommentThread() did not return an error for an empty message")
+	}
+	if _, err := buildCommentThread("Fix this", "", 10); err == nil {
+		t.Errorf("buildCommentThread() did not return an error for --line without --file")
+	}
+}
+
+func TestConvertPullRequestThread(t *testing.T) {
+	id := 7
+	status := git.CommentThreadStatusValues.Fixed
+	filePath := "/src/main.go"
+	line := 3
+	author := "Jane Smith"
+	userContent := "Please rename this"
+	systemContent := "Jane Smith voted 10"
+	textType := git.CommentTypeValues.Text
+	systemType := git.CommentTypeValues.System
+
+	thread := git.GitPullRequestCommentThread{
+		Id:     &id,
+		Status: &status,
+		ThreadContext: &git.CommentThreadContext{
+			FilePath:       &filePath,
+			RightFileStart: &git.CommentPosition{Line: &line},
+		},
+		Comments: &[]git.Comment{
+			{Content: &userContent, CommentType: &textType, Author: &webapi.IdentityRef{DisplayName: &author}},
+			{Content: &systemContent, CommentType: &systemType},
+		},
+	}
+
+	got := convertPullRequestThread(thread)
+	if got.ID != id || got.Status != "fixed" {
+		t.Errorf("convertPullRequestThread() = (%d, %s), want (7, fixed)", got.ID, got.Status)
+	}
+	if got.FilePath != filePath || got.Line != line {
+		t.Errorf("convertPullRequestThread() location = %s:%d, want %s:%d", got.FilePath, got.Line, filePath, line)
+	}
+	if len(got.Comments) != 1 {
+		t.Fatalf("convertPullRequestThread() kept %d comments, want 1", len(got.Comments))
+	}
+	if got.Comments[0].Author != author || got.Comments[0].Content != userContent {
+		t.Errorf("convertPullRequestThread() comment = %+v", got.Comments[0])
+	}
+}
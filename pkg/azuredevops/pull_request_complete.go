@@ -0,0 +1,152 @@
+package azuredevops
+
+import (
+	"fmt"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// CompletionSettings holds the options used when completing a pull request
+type CompletionSettings struct {
+	Squash              bool
+	Rebase              bool
+	DeleteSourceBranch  bool
+	TransitionWorkItems bool
+	MergeCommitMessage  string
+}
+
+// completePullRequestCommand completes (merges) a pull request
+func completePullRequestCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Completing pull request")
+
+	// Parse the repository and pull request ID
+	repository, id, err := parsePullRequestArgs(args)
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Get the completion settings
+	settings, err := getCompletionSettings(cmd)
+	if err != nil {
+		return handleError("Failed to get completion flags", err)
+	}
+
+	// Complete the pull request
+	pullRequest, err := completePullRequest(repository, id, settings)
+	if err != nil {
+		return handleError("Failed to complete pull request", err)
+	}
+
+	fmt.Printf("Pull request %d in %s is now %s (merge strategy: %s)\n", pullRequest.ID, pullRequest.Repository, pullRequest.Status, mergeStrategyFor(settings))
+
+	logger.Info("Pull request completed successfully", "repository", repository, "id", id)
+	return nil
+}
+
+// getCompletionSettings reads the completion flags from the command
+func getCompletionSettings(cmd *cobra.Command) (CompletionSettings, error) {
+	var settings CompletionSettings
+	var err error
+
+	if settings.Squash, err = cmd.Flags().GetBool("squash"); err != nil {
+		return settings, errors.Wrap(err, "failed to get squash flag")
+	}
+	if settings.Rebase, err = cmd.Flags().GetBool("rebase"); err != nil {
+		return settings, errors.Wrap(err, "failed to get rebase flag")
+	}
+	if settings.DeleteSourceBranch, err = cmd.Flags().GetBool("delete-source-branch"); err != nil {
+		return settings, errors.Wrap(err, "failed to get delete-source-branch flag")
+	}
+	if settings.TransitionWorkItems, err = cmd.Flags().GetBool("transition-work-items"); err != nil {
+		return settings, errors.Wrap(err, "failed to get transition-work-items flag")
+	}
+	if settings.MergeCommitMessage, err = cmd.Flags().GetString("message"); err != nil {
+		return settings, errors.Wrap(err, "failed to get message flag")
+	}
+
+	if settings.Squash && settings.Rebase {
+		return settings, errors.New("--squash and --rebase cannot be used together")
+	}
+
+	return settings, nil
+}
+
+// mergeStrategyFor returns the merge strategy selected by the completion settings
+func mergeStrategyFor(settings CompletionSettings) git.GitPullRequestMergeStrategy {
+	switch {
+	case settings.Squash:
+		return git.GitPullRequestMergeStrategyValues.Squash
+	case settings.Rebase:
+		return git.GitPullRequestMergeStrategyValues.Rebase
+	default:
+		return git.GitPullRequestMergeStrategyValues.NoFastForward
+	}
+}
+
+// buildCompletionOptions converts completion settings to Azure DevOps completion options
+func buildCompletionOptions(settings CompletionSettings) *git.GitPullRequestCompletionOptions {
+	strategy := mergeStrategyFor(settings)
+	deleteSourceBranch := settings.DeleteSourceBranch
+	transitionWorkItems := settings.TransitionWorkItems
+
+	options := &git.GitPullRequestCompletionOptions{
+		MergeStrategy:       &strategy,
+		DeleteSourceBranch:  &deleteSourceBranch,
+		TransitionWorkItems: &transitionWorkItems,
+	}
+	if settings.MergeCommitMessage != "" {
+		message := settings.MergeCommitMessage
+		options.MergeCommitMessage = &message
+	}
+
+	return options
+}
+
+// completePullRequest completes a pull request with the given settings
+func completePullRequest(repository string, id int, settings CompletionSettings) (*PullRequest, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Git API
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the pull request to find the last merge source commit
+	existing, err := client.GetPullRequest(commandContext, git.GetPullRequestArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &id,
+		Project:       &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get pull request %d", id)
+	}
+	if existing.LastMergeSourceCommit == nil {
+		return nil, errors.Errorf("pull request %d has no source commit to merge", id)
+	}
+
+	// Complete the pull request
+	status := git.PullRequestStatusValues.Completed
+	updated, err := client.UpdatePullRequest(commandContext, git.UpdatePullRequestArgs{
+		GitPullRequestToUpdate: &git.GitPullRequest{
+			Status:                &status,
+			LastMergeSourceCommit: existing.LastMergeSourceCommit,
+			CompletionOptions:     buildCompletionOptions(settings),
+		},
+		RepositoryId:  &repository,
+		PullRequestId: &id,
+		Project:       &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to complete pull request %d", id)
+	}
+
+	result := convertPullRequest(repository, *updated)
+	return &result, nil
+}
@@ -0,0 +1,71 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+)
+
+func TestMergeStrategyFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings CompletionSettings
+		want     git.GitPullRequestMergeStrategy
+	}{
+		{name: "default", settings: CompletionSettings{}, want: git.GitPullRequestMergeStrategyValues.NoFastForward},
+		{name: "squash", settings: CompletionSettings{Squash: true}, want: git.GitPullRequestMergeStrategyValues.Squash},
+		{name: "rebase", settings: CompletionSettings{Rebase: true}, want: git.GitPullRequestMergeStrategyValues.Rebase},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeStrategyFor(tt.settings); got != tt.want {
+				t.Errorf("mergeStrategyFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildCompletionOptions(t *testing.T) {
+	options := buildCompletionOptions(CompletionSettings{
+		Squash:              true,
+		DeleteSourceBranch:  true,
+		TransitionWorkItems: true,
+		MergeCommitMessage:  "Merged PR 123",
+	})
+
+	if *options.MergeStrategy != git.GitPullRequestMergeStrategyValues.Squash {
+		t.Errorf("MergeStrategy = %v, want squash", *options.MergeStrategy)
+	}
+	if !*options.DeleteSourceBranch {
+		t.Errorf("DeleteSourceBranch = false, want true")
+	}
+	if !*options.TransitionWorkItems {
+		t.Errorf("TransitionWorkItems = false, want true")
+	}
+	if options.MergeCommitMessage == nil || *options.MergeCommitMessage != "Merged PR 123" {
+		t.Errorf("MergeCommitMessage = %v, want 'Merged PR 123'", options.MergeCommitMessage)
+	}
+
+	// No message should leave the merge commit message unset
+	if options := buildCompletionOptions(CompletionSettings{}); options.MergeCommitMessage != nil {
+		t.Errorf("MergeCommitMessage = %v, want nil", *options.MergeCommitMessage)
+	}
+}
+
+func TestParsePullRequestArgs(t *testing.T) {
+	repository, id, err := parsePullRequestArgs([]string{"MyRepo", "123"})
+	if err != nil {
+		t.Fatalf("parsePullRequestArgs() returned an error: %v", err)
+	}
+	if repository != "MyRepo" || id != 123 {
+		t.Errorf("parsePullRequestArgs() = (%s, %d), want (MyRepo, 123)", repository, id)
+	}
+
+	if _, _, err := parsePullRequestArgs([]string{"MyRepo", "abc"}); err == nil {
+		t.Errorf("parsePullRequestArgs() did not return an error for an invalid ID")
+	}
+	if _, _, err := parsePullRequestArgs([]string{"MyRepo"}); err == nil {
+		t.Errorf("parsePullRequestArgs() did not return an error for missing arguments")
+	}
+}
@@ -0,0 +1,188 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// pullRequestConflictsLocationID is the REST location of pull request conflicts, which the Git client does not expose
+var pullRequestConflictsLocationID = uuid.MustParse("d840fb74-bbef-42d3-b250-564604c054a4")
+
+// pullRequestConflictsAPIVersion is the REST API version used to list pull request conflicts
+const pullRequestConflictsAPIVersion = "5.1-preview.1"
+
+// MergeConflict represents a file that conflicts when merging a pull request
+type MergeConflict struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+}
+
+// PullRequestMergeStatus represents the merge status of a pull request and its conflicting files
+type PullRequestMergeStatus struct {
+	ID             int             `json:"id"`
+	Repository     string          `json:"repository"`
+	MergeStatus    string          `json:"mergeStatus"`
+	FailureMessage string          `json:"failureMessage,omitempty"`
+	Conflicts      []MergeConflict `json:"conflicts"`
+}
+
+// listPullRequestConflictsCommand shows the merge status of a pull request and lists its conflicting files
+func listPullRequestConflictsCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Checking pull request conflicts")
+
+	// Parse the repository and pull request ID
+	repository, id, err := parsePullRequestArgs(args)
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the merge status
+	status, err := getPullRequestMergeStatus(repository, id)
+	if err != nil {
+		return handleError("Failed to get pull request conflicts", err)
+	}
+
+	// Print the merge status
+	if jsonOutput {
+		printMergeStatusAsJSON(status)
+	} else {
+		printMergeStatusAsText(status)
+	}
+
+	logger.Info("Pull request conflicts checked successfully")
+	return nil
+}
+
+// getPullRequestMergeStatus gets the merge status of a pull request and, if the merge is blocked by conflicts, the conflicting files
+func getPullRequestMergeStatus(repository string, id int) (*PullRequestMergeStatus, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	// Create a client for the Git API
+	client, err := git.NewClient(commandContext, connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Git client")
+	}
+
+	// Get the pull request
+	pr, err := client.GetPullRequest(commandContext, git.GetPullRequestArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &id,
+		Project:       &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get pull request %d", id)
+	}
+
+	result := &PullRequestMergeStatus{
+		ID:             id,
+		Repository:     repository,
+		FailureMessage: stringValue(pr.MergeFailureMessage),
+		Conflicts:      []MergeConflict{},
+	}
+	if pr.MergeStatus != nil {
+		result.MergeStatus = string(*pr.MergeStatus)
+	}
+
+	if pr.MergeStatus == nil || *pr.MergeStatus != git.PullRequestAsyncStatusValues.Conflicts {
+		return result, nil
+	}
+
+	// List the conflicting files
+	conflicts, err := getPullRequestConflicts(connection, connectionDetails.Project, repository, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, conflict := range conflicts {
+		result.Conflicts = append(result.Conflicts, convertMergeConflict(conflict))
+	}
+
+	return result, nil
+}
+
+// getPullRequestConflicts lists the merge conflicts of a pull request through the REST API
+func getPullRequestConflicts(connection *azuredevops.Connection, project string, repository string, id int) ([]git.GitConflict, error) {
+	client, err := connection.GetClientByResourceAreaId(commandContext, git.ResourceAreaId)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Git REST client")
+	}
+
+	routeValues := map[string]string{
+		"project":       project,
+		"repositoryId":  repository,
+		"pullRequestId": strconv.Itoa(id),
+	}
+	response, err := client.Send(commandContext, http.MethodGet, pullRequestConflictsLocationID, pullRequestConflictsAPIVersion, routeValues, nil, nil, "", "application/json", nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get conflicts of pull request %d", id)
+	}
+
+	var conflicts []git.GitConflict
+	if err := client.UnmarshalCollectionBody(response, &conflicts); err != nil {
+		return nil, errors.Wrapf(err, "failed to read conflicts of pull request %d", id)
+	}
+	return conflicts, nil
+}
+
+// convertMergeConflict converts a Git conflict to our model
+func convertMergeConflict(conflict git.GitConflict) MergeConflict {
+	result := MergeConflict{Path: stringValue(conflict.ConflictPath)}
+	if conflict.ConflictType != nil {
+		result.Type = string(*conflict.ConflictType)
+	}
+	return result
+}
+
+// printMergeStatusAsText prints the merge status of a pull request in a human-readable format
+func printMergeStatusAsText(status *PullRequestMergeStatus) {
+	fmt.Printf("Pull request %d in %s: merge status %s\n", status.ID, status.Repository, status.MergeStatus)
+	if status.FailureMessage != "" {
+		fmt.Printf("  %s\n", status.FailureMessage)
+	}
+
+	if len(status.Conflicts) == 0 {
+		if status.MergeStatus == string(git.PullRequestAsyncStatusValues.Conflicts) {
+			fmt.Println("\nThe merge is blocked by conflicts, but no conflicting files were reported.")
+		}
+		return
+	}
+
+	fmt.Printf("\nFound %d conflicting files:\n\n", len(status.Conflicts))
+	for _, conflict := range status.Conflicts {
+		fmt.Printf("  [%s] %s\n", conflict.Type, conflict.Path)
+	}
+}
+
+// printMergeStatusAsJSON prints the merge status of a pull request in JSON format
+func printMergeStatusAsJSON(status *PullRequestMergeStatus) {
+	// Marshal the merge status to JSON with indentation
+	jsonData, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal merge status to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal merge status to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
@@ -0,0 +1,21 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+)
+
+func TestConvertMergeConflict(t *testing.T) {
+	path := "/src/main.go"
+	conflictType := git.GitConflictTypeValues.EditEdit
+
+	got := convertMergeConflict(git.GitConflict{ConflictPath: &path, ConflictType: &conflictType})
+	if got.Path != path || got.Type != "editEdit" {
+		t.Errorf("convertMergeConflict() = %+v, want editEdit of %s", got, path)
+	}
+
+	if got := convertMergeConflict(git.GitConflict{}); got != (MergeConflict{}) {
+		t.Errorf("convertMergeConflict(empty) = %+v, want zero value", got)
+	}
+}
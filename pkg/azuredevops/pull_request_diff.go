@@ -0,0 +1,235 @@
+package azuredevops
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// maxStatBarWidth is the maximum number of +/- characters shown per file with --stat
+const maxStatBarWidth = 40
+
+// PullRequestFileDiff represents the line diff of a file changed by a pull request
+type PullRequestFileDiff struct {
+	Change PullRequestFileChange
+	Binary bool
+	Ops    []diffOp
+}
+
+// diffPullRequestCommand prints the diff of a pull request
+func diffPullRequestCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Showing pull request diff")
+
+	// Parse the repository and pull request ID
+	repository, id, err := parsePullRequestArgs(args)
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Check if only a summary is requested
+	stat, err := cmd.Flags().GetBool("stat")
+	if err != nil {
+		return handleError("Failed to get stat flag", err)
+	}
+
+	// Get the file diffs
+	diffs, err := getPullRequestDiff(repository, id)
+	if err != nil {
+		return handleError("Failed to get pull request diff", err)
+	}
+
+	// Print the diff
+	if stat {
+		printDiffStat(diffs)
+	} else {
+		printUnifiedDiff(diffs)
+	}
+
+	logger.Info("Pull request diff shown successfully")
+	return nil
+}
+
+// getPullRequestDiff computes the line diffs of the files changed by the latest iteration of a pull request
+func getPullRequestDiff(repository string, id int) ([]PullRequestFileDiff, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Git API
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// The latest iteration tells us which commits to compare
+	latest, err := getLatestPullRequestIteration(client, connectionDetails.Project, repository, id)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	if latest.CommonRefCommit == nil || latest.SourceRefCommit == nil {
+		return nil, errors.Errorf("the latest iteration of pull request %d has no commits to compare", id)
+	}
+	baseCommit := stringValue(latest.CommonRefCommit.CommitId)
+	headCommit := stringValue(latest.SourceRefCommit.CommitId)
+
+	changes, err := getIterationChanges(client, connectionDetails.Project, repository, id, intValue(latest.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []PullRequestFileDiff
+	for _, change := range changes {
+		if change.IsFolder {
+			continue
+		}
+
+		// Get the content of the file before and after the change
+		var oldContent, newContent string
+		if !hasChangeType(change, git.VersionControlChangeTypeValues.Add) {
+			oldPath := change.Path
+			if change.OriginalPath != "" {
+				oldPath = change.OriginalPath
+			}
+			if oldContent, err = getFileContent(client, connectionDetails.Project, repository, oldPath, baseCommit); err != nil {
+				return nil, err
+			}
+		}
+		if !hasChangeType(change, git.VersionControlChangeTypeValues.Delete) {
+			if newContent, err = getFileContent(client, connectionDetails.Project, repository, change.Path, headCommit); err != nil {
+				return nil, err
+			}
+		}
+
+		diff := PullRequestFileDiff{Change: change}
+		if isBinaryContent(oldContent) || isBinaryContent(newContent) {
+			diff.Binary = true
+		} else {
+			diff.Ops = diffLines(splitLines(oldContent), splitLines(newContent))
+		}
+		result = append(result, diff)
+	}
+
+	return result, nil
+}
+
+// hasChangeType checks if a change includes the given change type; Azure DevOps combines types such as "edit, rename"
+func hasChangeType(change PullRequestFileChange, changeType git.VersionControlChangeType) bool {
+	for _, part := range strings.Split(change.ChangeType, ",") {
+		if strings.TrimSpace(part) == string(changeType) {
+			return true
+		}
+	}
+	return false
+}
+
+// getFileContent gets the content of a file at a commit
+func getFileContent(client git.Client, project string, repository string, path string, commit string) (string, error) {
+	versionType := git.GitVersionTypeValues.Commit
+	reader, err := client.GetItemContent(commandContext, git.GetItemContentArgs{
+		RepositoryId: &repository,
+		Path:         &path,
+		Project:      &project,
+		VersionDescriptor: &git.GitVersionDescriptor{
+			Version:     &commit,
+			VersionType: &versionType,
+		},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get content of %s at %s", path, shortCommitID(commit))
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read content of %s at %s", path, shortCommitID(commit))
+	}
+	return string(content), nil
+}
+
+// diffFileNames returns the old and new file names shown in the header of a unified diff
+func diffFileNames(change PullRequestFileChange) (string, string) {
+	oldName := "a" + change.Path
+	if change.OriginalPath != "" {
+		oldName = "a" + change.OriginalPath
+	}
+	newName := "b" + change.Path
+
+	if hasChangeType(change, git.VersionControlChangeTypeValues.Add) {
+		oldName = "/dev/null"
+	}
+	if hasChangeType(change, git.VersionControlChangeTypeValues.Delete) {
+		newName = "/dev/null"
+	}
+	return oldName, newName
+}
+
+// printUnifiedDiff prints file diffs in unified format
+func printUnifiedDiff(diffs []PullRequestFileDiff) {
+	if len(diffs) == 0 {
+		fmt.Println("No changes found.")
+		return
+	}
+
+	for _, diff := range diffs {
+		oldName, newName := diffFileNames(diff.Change)
+		if diff.Binary {
+			fmt.Printf("Binary files %s and %s differ\n", oldName, newName)
+			continue
+		}
+		fmt.Print(formatUnifiedDiff(oldName, newName, diff.Ops))
+	}
+}
+
+// printDiffStat prints a per-file summary of inserted and deleted lines
+func printDiffStat(diffs []PullRequestFileDiff) {
+	if len(diffs) == 0 {
+		fmt.Println("No changes found.")
+		return
+	}
+
+	fmt.Print(formatDiffStat(diffs))
+}
+
+// formatDiffStat renders a per-file summary of inserted and deleted lines, like git diff --stat
+func formatDiffStat(diffs []PullRequestFileDiff) string {
+	width := 0
+	largest := 0
+	for _, diff := range diffs {
+		width = max(width, len(diff.Change.Path))
+		insertions, deletions := countDiffChanges(diff.Ops)
+		largest = max(largest, insertions+deletions)
+	}
+
+	var builder strings.Builder
+	totalInsertions, totalDeletions := 0, 0
+	for _, diff := range diffs {
+		if diff.Binary {
+			fmt.Fprintf(&builder, " %-*s | Bin\n", width, diff.Change.Path)
+			continue
+		}
+
+		insertions, deletions := countDiffChanges(diff.Ops)
+		totalInsertions += insertions
+		totalDeletions += deletions
+
+		// Scale the bar down when the largest change does not fit
+		plus, minus := insertions, deletions
+		if largest > maxStatBarWidth {
+			plus = insertions * maxStatBarWidth / largest
+			minus = deletions * maxStatBarWidth / largest
+		}
+		fmt.Fprintf(&builder, " %-*s | %d %s%s\n", width, diff.Change.Path, insertions+deletions, strings.Repeat("+", plus), strings.Repeat("-", minus))
+	}
+
+	fmt.Fprintf(&builder, " %d files changed, %d insertions(+), %d deletions(-)\n", len(diffs), totalInsertions, totalDeletions)
+	return builder.String()
+}
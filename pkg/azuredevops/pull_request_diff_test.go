@@ -0,0 +1,62 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+)
+
+func TestHasChangeType(t *testing.T) {
+	change := PullRequestFileChange{Path: "/b.go", OriginalPath: "/a.go", ChangeType: "edit, rename"}
+
+	if !hasChangeType(change, git.VersionControlChangeTypeValues.Rename) {
+		t.Error("hasChangeType(rename) = false, want true")
+	}
+	if !hasChangeType(change, git.VersionControlChangeTypeValues.Edit) {
+		t.Error("hasChangeType(edit) = false, want true")
+	}
+	if hasChangeType(change, git.VersionControlChangeTypeValues.Add) {
+		t.Error("hasChangeType(add) = true, want false")
+	}
+}
+
+func TestDiffFileNames(t *testing.T) {
+	tests := []struct {
+		change  PullRequestFileChange
+		wantOld string
+		wantNew string
+	}{
+		{change: PullRequestFileChange{Path: "/a.go", ChangeType: "edit"}, wantOld: "a/a.go", wantNew: "b/a.go"},
+		{change: PullRequestFileChange{Path: "/a.go", ChangeType: "add"}, wantOld: "/dev/null", wantNew: "b/a.go"},
+		{change: PullRequestFileChange{Path: "/a.go", ChangeType: "delete"}, wantOld: "a/a.go", wantNew: "/dev/null"},
+		{change: PullRequestFileChange{Path: "/b.go", OriginalPath: "/a.go", ChangeType: "rename"}, wantOld: "a/a.go", wantNew: "b/b.go"},
+	}
+
+	for _, tt := range tests {
+		gotOld, gotNew := diffFileNames(tt.change)
+		if gotOld != tt.wantOld || gotNew != tt.wantNew {
+			t.Errorf("diffFileNames(%+v) = %s, %s, want %s, %s", tt.change, gotOld, gotNew, tt.wantOld, tt.wantNew)
+		}
+	}
+}
+
+func TestFormatDiffStat(t *testing.T) {
+	diffs := []PullRequestFileDiff{
+		{
+			Change: PullRequestFileChange{Path: "/main.go", ChangeType: "edit"},
+			Ops:    diffLines([]string{"a", "b"}, []string{"a", "c", "d"}),
+		},
+		{
+			Change: PullRequestFileChange{Path: "/logo.png", ChangeType: "add"},
+			Binary: true,
+		},
+	}
+
+	got := formatDiffStat(diffs)
+	want := " /main.go  | 3 ++-\n" +
+		" /logo.png | Bin\n" +
+		" 2 files changed, 2 insertions(+), 1 deletions(-)\n"
+	if got != want {
+		t.Errorf("formatDiffStat() =\n%s\nwant\n%s", got, want)
+	}
+}
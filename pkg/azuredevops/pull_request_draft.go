@@ -0,0 +1,86 @@
+package azuredevops
+
+import (
+	"fmt"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// draftPullRequestCommand marks a pull request as draft or publishes it
+func draftPullRequestCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Changing pull request draft status")
+
+	// Parse the repository and pull request ID
+	repository, id, err := parsePullRequestArgs(args)
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Get the requested draft status
+	on, err := cmd.Flags().GetBool("on")
+	if err != nil {
+		return handleError("Failed to get on flag", err)
+	}
+	off, err := cmd.Flags().GetBool("off")
+	if err != nil {
+		return handleError("Failed to get off flag", err)
+	}
+	isDraft, err := draftStatusFromFlags(on, off)
+	if err != nil {
+		return handleError("Invalid flags", err)
+	}
+
+	// Update the draft status
+	if err := setPullRequestDraft(repository, id, isDraft); err != nil {
+		return handleError("Failed to change pull request draft status", err)
+	}
+
+	if isDraft {
+		fmt.Printf("Pull request %d in %s is now a draft\n", id, repository)
+	} else {
+		fmt.Printf("Pull request %d in %s is now published\n", id, repository)
+	}
+
+	logger.Info("Pull request draft status changed successfully", "repository", repository, "id", id, "draft", isDraft)
+	return nil
+}
+
+// draftStatusFromFlags returns the draft status selected by the --on and --off flags
+func draftStatusFromFlags(on bool, off bool) (bool, error) {
+	if on == off {
+		return false, errors.New("exactly one of --on or --off is required")
+	}
+	return on, nil
+}
+
+// setPullRequestDraft marks a pull request as draft or publishes it
+func setPullRequestDraft(repository string, id int, isDraft bool) error {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return err
+	}
+
+	// Create a client for the Git API
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return err
+	}
+
+	// Update the draft status
+	_, err = client.UpdatePullRequest(commandContext, git.UpdatePullRequestArgs{
+		GitPullRequestToUpdate: &git.GitPullRequest{
+			IsDraft: &isDraft,
+		},
+		RepositoryId:  &repository,
+		PullRequestId: &id,
+		Project:       &connectionDetails.Project,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update draft status of pull request %d", id)
+	}
+
+	return nil
+}
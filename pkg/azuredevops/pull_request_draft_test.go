@@ -0,0 +1,30 @@
+package azuredevops
+
+import "testing"
+
+func TestDraftStatusFromFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		on      bool
+		off     bool
+		want    bool
+		wantErr bool
+	}{
+		{name: "on", on: true, want: true},
+		{name: "off", off: true, want: false},
+		{name: "neither", wantErr: true},
+		{name: "both", on: true, off: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := draftStatusFromFlags(tt.on, tt.off)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("draftStatusFromFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("draftStatusFromFlags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
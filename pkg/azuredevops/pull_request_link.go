@@ -0,0 +1,120 @@
+package azuredevops
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/webapi"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// pullRequestLinkName is the artifact link type Azure DevOps uses for pull requests
+const pullRequestLinkName = "Pull Request"
+
+// linkPullRequestCommand links a pull request to work items
+func linkPullRequestCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Linking pull request to work items")
+
+	// Parse the repository and pull request ID
+	repository, id, err := parsePullRequestArgs(args)
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Get the work items to link
+	workItemIDs, err := cmd.Flags().GetIntSlice("work-item")
+	if err != nil {
+		return handleError("Failed to get work-item flag", err)
+	}
+	if len(workItemIDs) == 0 {
+		return handleError("Invalid flags", errors.New("at least one --work-item is required"))
+	}
+
+	// Link the work items
+	if err := linkPullRequestToWorkItems(repository, id, workItemIDs); err != nil {
+		return handleError("Failed to link pull request to work items", err)
+	}
+
+	fmt.Printf("Linked pull request %d in %s to %d work items\n", id, repository, len(workItemIDs))
+
+	logger.Info("Pull request linked successfully", "repository", repository, "id", id, "workItems", workItemIDs)
+	return nil
+}
+
+// linkPullRequestToWorkItems adds an artifact link to the pull request on each of the given work items
+func linkPullRequestToWorkItems(repository string, id int, workItemIDs []int) error {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return err
+	}
+
+	// Create a client for the Git API
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return err
+	}
+
+	// Get the pull request, which tells us the IDs of its project and repository
+	pr, err := client.GetPullRequest(commandContext, git.GetPullRequestArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &id,
+		Project:       &connectionDetails.Project,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get pull request %d", id)
+	}
+	if pr.Repository == nil || pr.Repository.Id == nil || pr.Repository.Project == nil || pr.Repository.Project.Id == nil {
+		return errors.Errorf("pull request %d does not include its project and repository IDs", id)
+	}
+	artifactURL := pullRequestArtifactURL(pr.Repository.Project.Id.String(), pr.Repository.Id.String(), id)
+
+	// Create a client for the Work Item Tracking API
+	witClient, err := createAzureDevOpsClient(connectionDetails)
+	if err != nil {
+		return err
+	}
+
+	for _, workItemID := range workItemIDs {
+		patches := buildArtifactLinkPatches(artifactURL, pullRequestLinkName)
+		_, err := witClient.UpdateWorkItem(commandContext, workitemtracking.UpdateWorkItemArgs{
+			Document: &patches,
+			Id:       &workItemID,
+			Project:  &connectionDetails.Project,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to link work item %d", workItemID)
+		}
+
+		logger.Info("Linked work item", "workItem", workItemID, "artifact", artifactURL)
+	}
+
+	return nil
+}
+
+// pullRequestArtifactURL builds the artifact URL that identifies a pull request in work item links
+func pullRequestArtifactURL(projectID string, repositoryID string, id int) string {
+	return "vstfs:///Git/PullRequestId/" + url.PathEscape(fmt.Sprintf("%s/%s/%d", projectID, repositoryID, id))
+}
+
+// buildArtifactLinkPatches builds the JSON patch operations that add an artifact link to a work item
+func buildArtifactLinkPatches(artifactURL string, name string) []webapi.JsonPatchOperation {
+	op := webapi.OperationValues.Add
+	path := "/relations/-"
+	return []webapi.JsonPatchOperation{
+		{
+			Op:   &op,
+			Path: &path,
+			Value: map[string]interface{}{
+				"rel": "ArtifactLink",
+				"url": artifactURL,
+				"attributes": map[string]interface{}{
+					"name": name,
+				},
+			},
+		},
+	}
+}
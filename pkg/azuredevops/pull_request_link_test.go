@@ -0,0 +1,39 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/webapi"
+)
+
+func TestPullRequestArtifactURL(t *testing.T) {
+	got := pullRequestArtifactURL("project-id", "repo-id", 7)
+	want := "vstfs:///Git/PullRequestId/project-id%2Frepo-id%2F7"
+	if got != want {
+		t.Errorf("pullRequestArtifactURL() = %s, want %s", got, want)
+	}
+}
+
+func TestBuildArtifactLinkPatches(t *testing.T) {
+	patches := buildArtifactLinkPatches("vstfs:///Git/PullRequestId/x", pullRequestLinkName)
+
+	if len(patches) != 1 {
+		t.Fatalf("buildArtifactLinkPatches() returned %d patches, want 1", len(patches))
+	}
+	patch := patches[0]
+	if *patch.Op != webapi.OperationValues.Add || *patch.Path != "/relations/-" {
+		t.Errorf("patch = %s %s, want add /relations/-", *patch.Op, *patch.Path)
+	}
+
+	value, ok := patch.Value.(map[string]interface{})
+	if !ok {
+		t.Fatalf("patch value has type %T, want a map", patch.Value)
+	}
+	if value["rel"] != "ArtifactLink" || value["url"] != "vstfs:///Git/PullRequestId/x" {
+		t.Errorf("patch value = %v, want an ArtifactLink to the pull request", value)
+	}
+	attributes, _ := value["attributes"].(map[string]interface{})
+	if attributes["name"] != pullRequestLinkName {
+		t.Errorf("link name = %v, want %s", attributes["name"], pullRequestLinkName)
+	}
+}
@@ -0,0 +1,146 @@
+package azuredevops
+
+import (
+	"fmt"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// addPullRequestReviewersCommand adds reviewers to a pull request
+func addPullRequestReviewersCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Adding pull request reviewers")
+
+	// Parse the repository and pull request ID
+	repository, id, err := parsePullRequestArgs(args)
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Check if the reviewers are required
+	required, err := cmd.Flags().GetBool("required")
+	if err != nil {
+		return handleError("Failed to get required flag", err)
+	}
+
+	// Add the reviewers
+	users := args[2:]
+	if err := addPullRequestReviewers(repository, id, users, required); err != nil {
+		return handleError("Failed to add pull request reviewers", err)
+	}
+
+	fmt.Printf("Added %d reviewers to pull request %d in %s\n", len(users), id, repository)
+
+	logger.Info("Pull request reviewers added successfully", "repository", repository, "id", id)
+	return nil
+}
+
+// removePullRequestReviewersCommand removes reviewers from a pull request
+func removePullRequestReviewersCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Removing pull request reviewers")
+
+	// Parse the repository and pull request ID
+	repository, id, err := parsePullRequestArgs(args)
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Remove the reviewers
+	users := args[2:]
+	if err := removePullRequestReviewers(repository, id, users); err != nil {
+		return handleError("Failed to remove pull request reviewers", err)
+	}
+
+	fmt.Printf("Removed %d reviewers from pull request %d in %s\n", len(users), id, repository)
+
+	logger.Info("Pull request reviewers removed successfully", "repository", repository, "id", id)
+	return nil
+}
+
+// addPullRequestReviewers adds the given users as reviewers of a pull request
+func addPullRequestReviewers(repository string, id int, users []string, required bool) error {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return err
+	}
+
+	// Create clients for the Git and Identity APIs
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return err
+	}
+	identityClient, err := createIdentityClient(connectionDetails)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		reviewerID, err := resolveIdentityID(identityClient, user)
+		if err != nil {
+			return err
+		}
+
+		// Reviewers added on behalf of someone else must start without a vote
+		vote := 0
+		isRequired := required
+		_, err = client.CreatePullRequestReviewer(commandContext, git.CreatePullRequestReviewerArgs{
+			Reviewer: &git.IdentityRefWithVote{
+				Vote:       &vote,
+				IsRequired: &isRequired,
+			},
+			RepositoryId:  &repository,
+			PullRequestId: &id,
+			ReviewerId:    &reviewerID,
+			Project:       &connectionDetails.Project,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to add reviewer '%s'", user)
+		}
+
+		logger.Info("Added reviewer", "user", user, "required", required)
+	}
+
+	return nil
+}
+
+// removePullRequestReviewers removes the given users from the reviewers of a pull request
+func removePullRequestReviewers(repository string, id int, users []string) error {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return err
+	}
+
+	// Create clients for the Git and Identity APIs
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return err
+	}
+	identityClient, err := createIdentityClient(connectionDetails)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		reviewerID, err := resolveIdentityID(identityClient, user)
+		if err != nil {
+			return err
+		}
+
+		err = client.DeletePullRequestReviewer(commandContext, git.DeletePullRequestReviewerArgs{
+			RepositoryId:  &repository,
+			PullRequestId: &id,
+			ReviewerId:    &reviewerID,
+			Project:       &connectionDetails.Project,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to remove reviewer '%s'", user)
+		}
+
+		logger.Info("Removed reviewer", "user", user)
+	}
+
+	return nil
+}
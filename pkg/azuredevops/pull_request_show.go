@@ -0,0 +1,449 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// maxIterationChangesPerRequest is the maximum number of changes returned by one iteration changes request
+const maxIterationChangesPerRequest = 2000
+
+// PullRequestReviewer represents a reviewer of a pull request and their vote
+type PullRequestReviewer struct {
+	Name     string `json:"name"`
+	Vote     int    `json:"vote"`
+	Status   string `json:"status"`
+	Required bool   `json:"required"`
+}
+
+// LinkedWorkItem represents a work item linked to a pull request
+type LinkedWorkItem struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	State string `json:"state"`
+}
+
+// PullRequestCommit represents a commit in a pull request
+type PullRequestCommit struct {
+	ID      string    `json:"id"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+	Message string    `json:"message"`
+}
+
+// PullRequestFileChange represents a file changed by a pull request
+type PullRequestFileChange struct {
+	Path         string `json:"path"`
+	OriginalPath string `json:"originalPath,omitempty"`
+	ChangeType   string `json:"changeType"`
+	IsFolder     bool   `json:"-"`
+}
+
+// PullRequestDetails represents a pull request with its reviewers, work items, commits and changes
+type PullRequestDetails struct {
+	PullRequest
+	Description  string                  `json:"description"`
+	SourceBranch string                  `json:"sourceBranch"`
+	Reviewers    []PullRequestReviewer   `json:"reviewers"`
+	WorkItems    []LinkedWorkItem        `json:"workItems"`
+	Commits      []PullRequestCommit     `json:"commits"`
+	Changes      []PullRequestFileChange `json:"changes"`
+}
+
+// showPullRequestCommand shows the details of a pull request
+func showPullRequestCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Showing pull request")
+
+	// Parse the repository and pull request ID
+	repository, id, err := parsePullRequestArgs(args)
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the pull request details
+	details, err := getPullRequestDetails(repository, id)
+	if err != nil {
+		return handleError("Failed to get pull request details", err)
+	}
+
+	// Print the pull request details
+	if jsonOutput {
+		printPullRequestDetailsAsJSON(details)
+	} else {
+		printPullRequestDetailsAsText(details)
+	}
+
+	logger.Info("Pull request shown successfully")
+	return nil
+}
+
+// getPullRequestDetails gets a pull request with its reviewers, work items, commits and changes
+func getPullRequestDetails(repository string, id int) (*PullRequestDetails, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Git API
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the pull request
+	pr, err := client.GetPullRequest(commandContext, git.GetPullRequestArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &id,
+		Project:       &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get pull request %d", id)
+	}
+
+	details := &PullRequestDetails{
+		PullRequest:  convertPullRequest(repository, *pr),
+		Description:  stringValue(pr.Description),
+		SourceBranch: stringValue(pr.SourceRefName),
+		Reviewers:    convertReviewers(pr.Reviewers),
+	}
+
+	// Get the linked work items
+	details.WorkItems, err = getLinkedWorkItems(client, connectionDetails, repository, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the commits
+	details.Commits, err = getPullRequestCommits(client, connectionDetails.Project, repository, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the changed files
+	details.Changes, err = getPullRequestChanges(client, connectionDetails.Project, repository, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// convertReviewers converts pull request reviewers to our model, required reviewers first
+func convertReviewers(reviewers *[]git.IdentityRefWithVote) []PullRequestReviewer {
+	result := []PullRequestReviewer{}
+	if reviewers == nil {
+		return result
+	}
+
+	for _, reviewer := range *reviewers {
+		vote := intValue(reviewer.Vote)
+		result = append(result, PullRequestReviewer{
+			Name:     stringValue(reviewer.DisplayName),
+			Vote:     vote,
+			Status:   voteLabel(vote),
+			Required: reviewer.IsRequired != nil && *reviewer.IsRequired,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Required && !result[j].Required
+	})
+
+	return result
+}
+
+// voteLabel returns a human-readable description of a reviewer vote
+func voteLabel(vote int) string {
+	switch {
+	case vote >= 10:
+		return "approved"
+	case vote >= 5:
+		return "approved with suggestions"
+	case vote <= -10:
+		return "rejected"
+	case vote <= -5:
+		return "waiting for author"
+	default:
+		return "no vote"
+	}
+}
+
+// getLinkedWorkItems gets the work items linked to a pull request
+func getLinkedWorkItems(client git.Client, connectionDetails *ConnectionDetails, repository string, id int) ([]LinkedWorkItem, error) {
+	refs, err := client.GetPullRequestWorkItemRefs(commandContext, git.GetPullRequestWorkItemRefsArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &id,
+		Project:       &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get work items of pull request %d", id)
+	}
+
+	var ids []int
+	for _, ref := range *refs {
+		if workItemID, err := strconv.Atoi(stringValue(ref.Id)); err == nil {
+			ids = append(ids, workItemID)
+		}
+	}
+
+	result := []LinkedWorkItem{}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	// Look up the titles and states of the work items
+	witClient, err := createAzureDevOpsClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []string{"System.Title", "System.State"}
+	workItems, err := witClient.GetWorkItems(commandContext, workitemtracking.GetWorkItemsArgs{
+		Ids:     &ids,
+		Fields:  &fields,
+		Project: &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get linked work items")
+	}
+
+	for _, workItem := range *workItems {
+		item := LinkedWorkItem{ID: intValue(workItem.Id)}
+		if workItem.Fields != nil {
+			item.Title = getFieldValue(*workItem.Fields, "System.Title", "Unknown")
+			item.State = getFieldValue(*workItem.Fields, "System.State", "Unknown")
+		}
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+// getPullRequestCommits gets the commits of a pull request
+func getPullRequestCommits(client git.Client, project string, repository string, id int) ([]PullRequestCommit, error) {
+	result := []PullRequestCommit{}
+	var continuationToken *string
+
+	for {
+		response, err := client.GetPullRequestCommits(commandContext, git.GetPullRequestCommitsArgs{
+			RepositoryId:      &repository,
+			PullRequestId:     &id,
+			Project:           &project,
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get commits of pull request %d", id)
+		}
+
+		for _, commit := range response.Value {
+			result = append(result, convertCommit(commit))
+		}
+
+		if response.ContinuationToken == "" {
+			return result, nil
+		}
+		token := response.ContinuationToken
+		continuationToken = &token
+	}
+}
+
+// convertCommit converts a commit reference to our model
+func convertCommit(commit git.GitCommitRef) PullRequestCommit {
+	result := PullRequestCommit{
+		ID:      stringValue(commit.CommitId),
+		Message: firstLine(stringValue(commit.Comment)),
+	}
+	if commit.Author != nil {
+		result.Author = stringValue(commit.Author.Name)
+		if commit.Author.Date != nil {
+			result.Date = commit.Author.Date.Time
+		}
+	}
+	return result
+}
+
+// firstLine returns the first line of a multi-line string
+func firstLine(value string) string {
+	if index := strings.IndexAny(value, "\r\n"); index >= 0 {
+		return value[:index]
+	}
+	return value
+}
+
+// shortCommitID abbreviates a commit ID for display
+func shortCommitID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+// getPullRequestChanges gets the files changed by the latest iteration of a pull request
+func getPullRequestChanges(client git.Client, project string, repository string, id int) ([]PullRequestFileChange, error) {
+	latest, err := getLatestPullRequestIteration(client, project, repository, id)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return []PullRequestFileChange{}, nil
+	}
+
+	return getIterationChanges(client, project, repository, id, intValue(latest.Id))
+}
+
+// getLatestPullRequestIteration gets the latest iteration of a pull request, or nil if it has none
+func getLatestPullRequestIteration(client git.Client, project string, repository string, id int) (*git.GitPullRequestIteration, error) {
+	iterations, err := client.GetPullRequestIterations(commandContext, git.GetPullRequestIterationsArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &id,
+		Project:       &project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get iterations of pull request %d", id)
+	}
+
+	if len(*iterations) == 0 {
+		return nil, nil
+	}
+	return &(*iterations)[len(*iterations)-1], nil
+}
+
+// getIterationChanges gets the files changed by an iteration of a pull request
+func getIterationChanges(client git.Client, project string, repository string, id int, iterationID int) ([]PullRequestFileChange, error) {
+	result := []PullRequestFileChange{}
+
+	// Compare the latest iteration against the common commit, one page at a time
+	skip := 0
+	top := maxIterationChangesPerRequest
+	for {
+		changes, err := client.GetPullRequestIterationChanges(commandContext, git.GetPullRequestIterationChangesArgs{
+			RepositoryId:  &repository,
+			PullRequestId: &id,
+			IterationId:   &iterationID,
+			Project:       &project,
+			Top:           &top,
+			Skip:          &skip,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get changes of pull request %d", id)
+		}
+
+		if changes.ChangeEntries != nil {
+			for _, change := range *changes.ChangeEntries {
+				result = append(result, convertFileChange(change))
+			}
+		}
+
+		if intValue(changes.NextTop) == 0 {
+			return result, nil
+		}
+		skip = intValue(changes.NextSkip)
+	}
+}
+
+// convertFileChange converts a pull request change entry to our model
+func convertFileChange(change git.GitPullRequestChange) PullRequestFileChange {
+	result := PullRequestFileChange{}
+	if change.ChangeType != nil {
+		result.ChangeType = string(*change.ChangeType)
+	}
+	if item, ok := change.Item.(map[string]interface{}); ok {
+		if path, ok := item["path"].(string); ok {
+			result.Path = path
+		}
+		if isFolder, ok := item["isFolder"].(bool); ok {
+			result.IsFolder = isFolder
+		}
+	}
+	result.OriginalPath = stringValue(change.OriginalPath)
+	return result
+}
+
+// summarizeChanges counts changed files by change type
+func summarizeChanges(changes []PullRequestFileChange) map[string]int {
+	summary := make(map[string]int)
+	for _, change := range changes {
+		summary[change.ChangeType]++
+	}
+	return summary
+}
+
+// printPullRequestDetailsAsText prints pull request details in a human-readable format
+func printPullRequestDetailsAsText(details *PullRequestDetails) {
+	fmt.Printf("Pull Request %d: %s\n", details.ID, details.Title)
+	fmt.Printf("Repository: %s\n", details.Repository)
+	fmt.Printf("Creator: %s\n", details.Creator)
+	fmt.Printf("Created: %s\n", details.Created.Format(time.RFC3339))
+	fmt.Printf("Status: %s\n", details.Status)
+	fmt.Printf("Branches: %s -> %s\n", details.SourceBranch, details.TargetBranch)
+
+	if details.Description != "" {
+		fmt.Println("\nDescription:")
+		for _, line := range strings.Split(details.Description, "\n") {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+
+	fmt.Printf("\nReviewers (%d):\n", len(details.Reviewers))
+	for _, reviewer := range details.Reviewers {
+		required := ""
+		if reviewer.Required {
+			required = " (required)"
+		}
+		fmt.Printf("  - %s%s: %s\n", reviewer.Name, required, reviewer.Status)
+	}
+
+	fmt.Printf("\nWork Items (%d):\n", len(details.WorkItems))
+	for _, workItem := range details.WorkItems {
+		fmt.Printf("  - #%d %s [%s]\n", workItem.ID, workItem.Title, workItem.State)
+	}
+
+	fmt.Printf("\nCommits (%d):\n", len(details.Commits))
+	for _, commit := range details.Commits {
+		fmt.Printf("  - %s %s (%s)\n", shortCommitID(commit.ID), commit.Message, commit.Author)
+	}
+
+	fmt.Printf("\nChanged Files (%d):\n", len(details.Changes))
+	summary := summarizeChanges(details.Changes)
+	changeTypes := make([]string, 0, len(summary))
+	for changeType := range summary {
+		changeTypes = append(changeTypes, changeType)
+	}
+	sort.Strings(changeTypes)
+	for _, changeType := range changeTypes {
+		fmt.Printf("  %s: %d\n", changeType, summary[changeType])
+	}
+	for _, change := range details.Changes {
+		fmt.Printf("  - [%s] %s\n", change.ChangeType, change.Path)
+	}
+}
+
+// printPullRequestDetailsAsJSON prints pull request details in JSON format
+func printPullRequestDetailsAsJSON(details *PullRequestDetails) {
+	// Marshal the details to JSON with indentation
+	jsonData, err := json.MarshalIndent(details, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal pull request details to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal pull request details to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
@@ -0,0 +1,89 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+)
+
+func TestVoteLabel(t *testing.T) {
+	tests := []struct {
+		vote int
+		want string
+	}{
+		{vote: 10, want: "approved"},
+		{vote: 5, want: "approved with suggestions"},
+		{vote: 0, want: "no vote"},
+		{vote: -5, want: "waiting for author"},
+		{vote: -10, want: "rejected"},
+	}
+
+	for _, tt := range tests {
+		if got := voteLabel(tt.vote); got != tt.want {
+			t.Errorf("voteLabel(%d) = %s, want %s", tt.vote, got, tt.want)
+		}
+	}
+}
+
+func TestConvertReviewers(t *testing.T) {
+	optional := "Optional Reviewer"
+	required := "Required Reviewer"
+	isRequired := true
+	approved := 10
+
+	reviewers := convertReviewers(&[]git.IdentityRefWithVote{
+		{DisplayName: &optional},
+		{DisplayName: &required, IsRequired: &isRequired, Vote: &approved},
+	})
+
+	if len(reviewers) != 2 {
+		t.Fatalf("convertReviewers() returned %d reviewers, want 2", len(reviewers))
+	}
+	if reviewers[0].Name != required || !reviewers[0].Required || reviewers[0].Status != "approved" {
+		t.Errorf("convertReviewers()[0] = %+v, want the required reviewer first", reviewers[0])
+	}
+	if reviewers[1].Status != "no vote" {
+		t.Errorf("convertReviewers()[1].Status = %s, want 'no vote'", reviewers[1].Status)
+	}
+
+	if reviewers := convertReviewers(nil); len(reviewers) != 0 {
+		t.Errorf("convertReviewers(nil) returned %d reviewers, want 0", len(reviewers))
+	}
+}
+
+func TestConvertFileChange(t *testing.T) {
+	changeType := git.VersionControlChangeType("edit")
+	change := git.GitPullRequestChange{
+		ChangeType: &changeType,
+		Item:       map[string]interface{}{"path": "/src/main.go"},
+	}
+
+	got := convertFileChange(change)
+	if got.Path != "/src/main.go" || got.ChangeType != "edit" {
+		t.Errorf("convertFileChange() = %+v, want edit of /src/main.go", got)
+	}
+}
+
+func TestSummarizeChanges(t *testing.T) {
+	summary := summarizeChanges([]PullRequestFileChange{
+		{Path: "/a", ChangeType: "edit"},
+		{Path: "/b", ChangeType: "edit"},
+		{Path: "/c", ChangeType: "add"},
+	})
+
+	if summary["edit"] != 2 || summary["add"] != 1 {
+		t.Errorf("summarizeChanges() = %v, want 2 edits and 1 add", summary)
+	}
+}
+
+func TestFirstLineAndShortCommitID(t *testing.T) {
+	if got := firstLine("Fix bug\n\nLonger description"); got != "Fix bug" {
+		t.Errorf("firstLine() = %q, want %q", got, "Fix bug")
+	}
+	if got := shortCommitID("0123456789abcdef"); got != "01234567" {
+		t.Errorf("shortCommitID() = %q, want %q", got, "01234567")
+	}
+	if got := shortCommitID("abc"); got != "abc" {
+		t.Errorf("shortCommitID() = %q, want %q", got, "abc")
+	}
+}
@@ -0,0 +1,431 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// voteUpdateThreadType is the thread type Azure DevOps uses for the system comment recorded when a reviewer votes
+const voteUpdateThreadType = "VoteUpdate"
+
+// RepositoryPullRequestCount represents the number of pull requests created in a repository
+type RepositoryPullRequestCount struct {
+	Repository string `json:"repository"`
+	Count      int    `json:"count"`
+}
+
+// ReviewerParticipation represents how often a reviewer voted on the pull requests they were assigned
+type ReviewerParticipation struct {
+	Name     string `json:"name"`
+	Assigned int    `json:"assigned"`
+	Voted    int    `json:"voted"`
+}
+
+// PullRequestStats represents review and merge statistics for a set of pull requests
+type PullRequestStats struct {
+	Since                     time.Time                    `json:"since"`
+	Total                     int                          `json:"total"`
+	Completed                 int                          `json:"completed"`
+	AverageHoursToFirstReview float64                      `json:"averageTimeToFirstReviewHours"`
+	MedianHoursToFirstReview  float64                      `json:"medianTimeToFirstReviewHours"`
+	AverageHoursToMerge       float64                      `json:"averageTimeToMergeHours"`
+	MedianHoursToMerge        float64                      `json:"medianTimeToMergeHours"`
+	Repositories              []RepositoryPullRequestCount `json:"repositories"`
+	Reviewers                 []ReviewerParticipation      `json:"reviewers"`
+}
+
+// pullRequestSample holds the data of a single pull request that the statistics are computed from
+type pullRequestSample struct {
+	Repository  string
+	Created     time.Time
+	Merged      *time.Time
+	FirstReview *time.Time
+	Reviewers   map[string]bool
+}
+
+// pullRequestStatsCommand computes review and merge statistics for recent pull requests
+func pullRequestStatsCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Computing pull request statistics")
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the period to compute statistics for
+	sinceValue, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return handleError("Failed to get since flag", err)
+	}
+	period, err := parseSincePeriod(sinceValue)
+	if err != nil {
+		return handleError("Invalid since flag", err)
+	}
+
+	// Get the project to limit the statistics to
+	project, err := cmd.Flags().GetString("project")
+	if err != nil {
+		return handleError("Failed to get project flag", err)
+	}
+
+	// Collect the pull requests
+	since := time.Now().Add(-period)
+	samples, err := getPullRequestSamples(project, since)
+	if err != nil {
+		return handleError("Failed to get pull requests", err)
+	}
+
+	// Compute and print the statistics
+	stats := computePullRequestStats(samples, since)
+	if jsonOutput {
+		printPullRequestStatsAsJSON(stats)
+	} else {
+		printPullRequestStatsAsText(stats)
+	}
+
+	logger.Info("Pull request statistics computed successfully")
+	return nil
+}
+
+// parseSincePeriod parses a period such as 90d, 2w or 36h
+func parseSincePeriod(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, errors.New("period must not be empty")
+	}
+
+	unit := value[len(value)-1]
+	if unit == 'd' || unit == 'w' {
+		count, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil || count <= 0 {
+			return 0, errors.Errorf("invalid period '%s': use a positive number of days (90d), weeks (2w) or a Go duration (36h)", value)
+		}
+		days := count
+		if unit == 'w' {
+			days *= 7
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	period, err := time.ParseDuration(value)
+	if err != nil || period <= 0 {
+		return 0, errors.Errorf("invalid period '%s': use a positive number of days (90d), weeks (2w) or a Go duration (36h)", value)
+	}
+	return period, nil
+}
+
+// getPullRequestSamples collects the pull requests created since the given time in one or all projects
+func getPullRequestSamples(project string, since time.Time) ([]pullRequestSample, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	// Create a client for the Git API
+	client, err := git.NewClient(commandContext, connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Git client")
+	}
+
+	// Get the projects to scan
+	projectNames, err := getProjectNames(connection, project)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get projects")
+	}
+
+	var samples []pullRequestSample
+	for _, projectName := range projectNames {
+		pullRequests, err := getPullRequestsCreatedSince(client, projectName, since)
+		if err != nil {
+			logger.Warn("Failed to get pull requests for project", "project", projectName, "error", err)
+			continue
+		}
+
+		for _, pr := range pullRequests {
+			sample := newPullRequestSample(pr)
+
+			// The first review is the first comment or vote by someone other than the creator
+			repositoryID := pr.Repository.Id.String()
+			threads, err := client.GetThreads(commandContext, git.GetThreadsArgs{
+				RepositoryId:  &repositoryID,
+				PullRequestId: pr.PullRequestId,
+				Project:       &projectName,
+			})
+			if err != nil {
+				logger.Warn("Failed to get threads of pull request", "id", intValue(pr.PullRequestId), "error", err)
+			} else {
+				sample.FirstReview = findFirstReview(*threads, creatorID(pr))
+			}
+
+			samples = append(samples, sample)
+		}
+	}
+
+	return samples, nil
+}
+
+// getPullRequestsCreatedSince gets the pull requests of a project in any state created since the given time
+func getPullRequestsCreatedSince(client git.Client, project string, since time.Time) ([]git.GitPullRequest, error) {
+	status := git.PullRequestStatusValues.All
+	searchCriteria := git.GitPullRequestSearchCriteria{
+		Status: &status,
+	}
+
+	// Pull requests are returned newest first, so stop at the first page that reaches past the period
+	var result []git.GitPullRequest
+	top := pullRequestPageSize
+	for skip := 0; ; skip += top {
+		page, err := client.GetPullRequestsByProject(commandContext, git.GetPullRequestsByProjectArgs{
+			Project:        &project,
+			SearchCriteria: &searchCriteria,
+			Top:            &top,
+			Skip:           &skip,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get pull requests for project %s", project)
+		}
+
+		reachedEnd := len(*page) < top
+		for _, pr := range *page {
+			if pr.CreationDate == nil || pr.CreationDate.Time.Before(since) {
+				reachedEnd = true
+				continue
+			}
+			if pr.Repository == nil || pr.Repository.Id == nil {
+				continue
+			}
+			result = append(result, pr)
+		}
+
+		if reachedEnd {
+			return result, nil
+		}
+	}
+}
+
+// creatorID returns the ID of the creator of a pull request
+func creatorID(pr git.GitPullRequest) string {
+	if pr.CreatedBy == nil {
+		return ""
+	}
+	return stringValue(pr.CreatedBy.Id)
+}
+
+// newPullRequestSample converts a pull request to a statistics sample
+func newPullRequestSample(pr git.GitPullRequest) pullRequestSample {
+	sample := pullRequestSample{
+		Reviewers: make(map[string]bool),
+	}
+	if pr.Repository != nil {
+		sample.Repository = stringValue(pr.Repository.Name)
+	}
+	if pr.CreationDate != nil {
+		sample.Created = pr.CreationDate.Time
+	}
+	if pr.Status != nil && *pr.Status == git.PullRequestStatusValues.Completed && pr.ClosedDate != nil {
+		merged := pr.ClosedDate.Time
+		sample.Merged = &merged
+	}
+	if pr.Reviewers != nil {
+		for _, reviewer := range *pr.Reviewers {
+			// Groups are assigned automatically by policies and do not vote themselves
+			if reviewer.IsContainer != nil && *reviewer.IsContainer {
+				continue
+			}
+			sample.Reviewers[stringValue(reviewer.DisplayName)] = intValue(reviewer.Vote) != 0
+		}
+	}
+	return sample
+}
+
+// findFirstReview finds the time of the first comment or vote by someone other than the creator
+func findFirstReview(threads []git.GitPullRequestCommentThread, creator string) *time.Time {
+	var first *time.Time
+	for _, thread := range threads {
+		if thread.Comments == nil {
+			continue
+		}
+		isVote := threadType(thread) == voteUpdateThreadType
+
+		for _, comment := range *thread.Comments {
+			if comment.PublishedDate == nil || comment.Author == nil {
+				continue
+			}
+			if strings.EqualFold(stringValue(comment.Author.Id), creator) {
+				continue
+			}
+			isText := comment.CommentType != nil && *comment.CommentType == git.CommentTypeValues.Text
+			if !isText && !isVote {
+				continue
+			}
+
+			published := comment.PublishedDate.Time
+			if first == nil || published.Before(*first) {
+				first = &published
+			}
+		}
+	}
+	return first
+}
+
+// threadType returns the code review thread type stored in the properties of a thread
+func threadType(thread git.GitPullRequestCommentThread) string {
+	properties, ok := thread.Properties.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	property, ok := properties["CodeReviewThreadType"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	value, _ := property["$value"].(string)
+	return value
+}
+
+// computePullRequestStats computes review and merge statistics from pull request samples
+func computePullRequestStats(samples []pullRequestSample, since time.Time) PullRequestStats {
+	stats := PullRequestStats{
+		Since:        since,
+		Total:        len(samples),
+		Repositories: []RepositoryPullRequestCount{},
+		Reviewers:    []ReviewerParticipation{},
+	}
+
+	var timesToFirstReview, timesToMerge []time.Duration
+	repositories := make(map[string]int)
+	reviewers := make(map[string]*ReviewerParticipation)
+
+	for _, sample := range samples {
+		repositories[sample.Repository]++
+
+		if sample.FirstReview != nil {
+			timesToFirstReview = append(timesToFirstReview, sample.FirstReview.Sub(sample.Created))
+		}
+		if sample.Merged != nil {
+			stats.Completed++
+			timesToMerge = append(timesToMerge, sample.Merged.Sub(sample.Created))
+		}
+
+		for name, voted := range sample.Reviewers {
+			participation, ok := reviewers[name]
+			if !ok {
+				participation = &ReviewerParticipation{Name: name}
+				reviewers[name] = participation
+			}
+			participation.Assigned++
+			if voted {
+				participation.Voted++
+			}
+		}
+	}
+
+	stats.AverageHoursToFirstReview = averageDuration(timesToFirstReview).Hours()
+	stats.MedianHoursToFirstReview = medianDuration(timesToFirstReview).Hours()
+	stats.AverageHoursToMerge = averageDuration(timesToMerge).Hours()
+	stats.MedianHoursToMerge = medianDuration(timesToMerge).Hours()
+
+	for repository, count := range repositories {
+		stats.Repositories = append(stats.Repositories, RepositoryPullRequestCount{Repository: repository, Count: count})
+	}
+	sort.Slice(stats.Repositories, func(i, j int) bool {
+		if stats.Repositories[i].Count != stats.Repositories[j].Count {
+			return stats.Repositories[i].Count > stats.Repositories[j].Count
+		}
+		return stats.Repositories[i].Repository < stats.Repositories[j].Repository
+	})
+
+	for _, participation := range reviewers {
+		stats.Reviewers = append(stats.Reviewers, *participation)
+	}
+	sort.Slice(stats.Reviewers, func(i, j int) bool {
+		if stats.Reviewers[i].Voted != stats.Reviewers[j].Voted {
+			return stats.Reviewers[i].Voted > stats.Reviewers[j].Voted
+		}
+		return stats.Reviewers[i].Name < stats.Reviewers[j].Name
+	})
+
+	return stats
+}
+
+// averageDuration returns the mean of a set of durations, or zero if there are none
+func averageDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, duration := range durations {
+		total += duration
+	}
+	return total / time.Duration(len(durations))
+}
+
+// medianDuration returns the median of a set of durations, or zero if there are none
+func medianDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// printPullRequestStatsAsText prints pull request statistics as text tables
+func printPullRequestStatsAsText(stats PullRequestStats) {
+	fmt.Printf("Pull requests since %s: %d (%d completed)\n\n", stats.Since.Format(wiqlDateLayout), stats.Total, stats.Completed)
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "METRIC\tAVERAGE (h)\tMEDIAN (h)")
+	fmt.Fprintf(writer, "Time to first review\t%.1f\t%.1f\n", stats.AverageHoursToFirstReview, stats.MedianHoursToFirstReview)
+	fmt.Fprintf(writer, "Time to merge\t%.1f\t%.1f\n", stats.AverageHoursToMerge, stats.MedianHoursToMerge)
+	writer.Flush()
+	fmt.Println()
+
+	writer = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "REPOSITORY\tPULL REQUESTS")
+	for _, repository := range stats.Repositories {
+		fmt.Fprintf(writer, "%s\t%d\n", repository.Repository, repository.Count)
+	}
+	writer.Flush()
+	fmt.Println()
+
+	writer = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "REVIEWER\tASSIGNED\tVOTED")
+	for _, reviewer := range stats.Reviewers {
+		fmt.Fprintf(writer, "%s\t%d\t%d\n", reviewer.Name, reviewer.Assigned, reviewer.Voted)
+	}
+	writer.Flush()
+}
+
+// printPullRequestStatsAsJSON prints pull request statistics in JSON format
+func printPullRequestStatsAsJSON(stats PullRequestStats) {
+	// Marshal the statistics to JSON with indentation
+	jsonData, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal pull request statistics to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal pull request statistics to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
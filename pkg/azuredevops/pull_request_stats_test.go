@@ -0,0 +1,151 @@
+package azuredevops
+
+import (
+	"testing"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/webapi"
+)
+
+func TestParseSincePeriod(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "90d", want: 90 * 24 * time.Hour},
+		{value: "2w", want: 14 * 24 * time.Hour},
+		{value: "36h", want: 36 * time.Hour},
+		{value: "", wantErr: true},
+		{value: "0d", wantErr: true},
+		{value: "xd", wantErr: true},
+		{value: "-5h", wantErr: true},
+		{value: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSincePeriod(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSincePeriod(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseSincePeriod(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestAverageAndMedianDuration(t *testing.T) {
+	durations := []time.Duration{4 * time.Hour, time.Hour, 10 * time.Hour}
+
+	if got := averageDuration(durations); got != 5*time.Hour {
+		t.Errorf("averageDuration() = %v, want 5h", got)
+	}
+	if got := medianDuration(durations); got != 4*time.Hour {
+		t.Errorf("medianDuration() = %v, want 4h", got)
+	}
+	if got := medianDuration(durations[:2]); got != 150*time.Minute {
+		t.Errorf("medianDuration() of an even set = %v, want 2h30m", got)
+	}
+	if got := averageDuration(nil); got != 0 {
+		t.Errorf("averageDuration(nil) = %v, want 0", got)
+	}
+	if got := medianDuration(nil); got != 0 {
+		t.Errorf("medianDuration(nil) = %v, want 0", got)
+	}
+}
+
+func TestFindFirstReview(t *testing.T) {
+	creator := "creator-id"
+	reviewer := "reviewer-id"
+	text := git.CommentTypeValues.Text
+	system := git.CommentTypeValues.System
+	early := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	late := early.Add(2 * time.Hour)
+	voteTime := early.Add(time.Hour)
+
+	threads := []git.GitPullRequestCommentThread{
+		{
+			// The creator's own comment does not count as a review
+			Comments: &[]git.Comment{
+				{Author: &webapi.IdentityRef{Id: &creator}, CommentType: &text, PublishedDate: &azuredevops.Time{Time: early}},
+				{Author: &webapi.IdentityRef{Id: &reviewer}, CommentType: &text, PublishedDate: &azuredevops.Time{Time: late}},
+			},
+		},
+		{
+			Properties: map[string]interface{}{
+				"CodeReviewThreadType": map[string]interface{}{"$value": voteUpdateThreadType},
+			},
+			Comments: &[]git.Comment{
+				{Author: &webapi.IdentityRef{Id: &reviewer}, CommentType: &system, PublishedDate: &azuredevops.Time{Time: voteTime}},
+			},
+		},
+		{
+			// Other system comments, such as policy updates, are ignored
+			Comments: &[]git.Comment{
+				{Author: &webapi.IdentityRef{Id: &reviewer}, CommentType: &system, PublishedDate: &azuredevops.Time{Time: early}},
+			},
+		},
+	}
+
+	got := findFirstReview(threads, creator)
+	if got == nil || !got.Equal(voteTime) {
+		t.Errorf("findFirstReview() = %v, want %v", got, voteTime)
+	}
+
+	if got := findFirstReview(nil, creator); got != nil {
+		t.Errorf("findFirstReview(nil) = %v, want nil", got)
+	}
+}
+
+func TestComputePullRequestStats(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	reviewed := created.Add(2 * time.Hour)
+	merged := created.Add(24 * time.Hour)
+
+	samples := []pullRequestSample{
+		{
+			Repository:  "api",
+			Created:     created,
+			Merged:      &merged,
+			FirstReview: &reviewed,
+			Reviewers:   map[string]bool{"Alice": true, "Bob": false},
+		},
+		{
+			Repository: "api",
+			Created:    created,
+			Reviewers:  map[string]bool{"Alice": true},
+		},
+		{
+			Repository: "web",
+			Created:    created,
+			Reviewers:  map[string]bool{},
+		},
+	}
+
+	stats := computePullRequestStats(samples, created)
+
+	if stats.Total != 3 || stats.Completed != 1 {
+		t.Errorf("computePullRequestStats() total/completed = %d/%d, want 3/1", stats.Total, stats.Completed)
+	}
+	if stats.AverageHoursToFirstReview != 2 || stats.MedianHoursToMerge != 24 {
+		t.Errorf("computePullRequestStats() = %+v, want 2h to first review and 24h to merge", stats)
+	}
+	if len(stats.Repositories) != 2 || stats.Repositories[0] != (RepositoryPullRequestCount{Repository: "api", Count: 2}) {
+		t.Errorf("computePullRequestStats().Repositories = %+v, want api first with 2", stats.Repositories)
+	}
+	wantReviewers := []ReviewerParticipation{
+		{Name: "Alice", Assigned: 2, Voted: 2},
+		{Name: "Bob", Assigned: 1, Voted: 0},
+	}
+	if len(stats.Reviewers) != len(wantReviewers) {
+		t.Fatalf("computePullRequestStats().Reviewers = %+v, want %+v", stats.Reviewers, wantReviewers)
+	}
+	for i, want := range wantReviewers {
+		if stats.Reviewers[i] != want {
+			t.Errorf("computePullRequestStats().Reviewers[%d] = %+v, want %+v", i, stats.Reviewers[i], want)
+		}
+	}
+}
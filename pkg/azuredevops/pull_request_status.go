@@ -0,0 +1,72 @@
+package azuredevops
+
+import (
+	"fmt"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// abandonPullRequestCommand abandons an active pull request
+func abandonPullRequestCommand(cmd *cobra.Command, args []string) error {
+	return changePullRequestStatusCommand(args, git.PullRequestStatusValues.Abandoned)
+}
+
+// reactivatePullRequestCommand reactivates an abandoned pull request
+func reactivatePullRequestCommand(cmd *cobra.Command, args []string) error {
+	return changePullRequestStatusCommand(args, git.PullRequestStatusValues.Active)
+}
+
+// changePullRequestStatusCommand changes the status of the pull request given in the arguments
+func changePullRequestStatusCommand(args []string, status git.PullRequestStatus) error {
+	logger.Info("Changing pull request status", "status", status)
+
+	// Parse the repository and pull request ID
+	repository, id, err := parsePullRequestArgs(args)
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Update the pull request status
+	pullRequest, err := setPullRequestStatus(repository, id, status)
+	if err != nil {
+		return handleError("Failed to change pull request status", err)
+	}
+
+	fmt.Printf("Pull request %d in %s is now %s\n", pullRequest.ID, pullRequest.Repository, pullRequest.Status)
+
+	logger.Info("Pull request status changed successfully", "repository", repository, "id", id, "status", status)
+	return nil
+}
+
+// setPullRequestStatus sets the status of a pull request
+func setPullRequestStatus(repository string, id int, status git.PullRequestStatus) (*PullRequest, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Git API
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update the pull request status
+	updated, err := client.UpdatePullRequest(commandContext, git.UpdatePullRequestArgs{
+		GitPullRequestToUpdate: &git.GitPullRequest{
+			Status: &status,
+		},
+		RepositoryId:  &repository,
+		PullRequestId: &id,
+		Project:       &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to set status of pull request %d to %s", id, status)
+	}
+
+	result := convertPullRequest(repository, *updated)
+	return &result, nil
+}
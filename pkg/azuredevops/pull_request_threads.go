@@ -0,0 +1,118 @@
+package azuredevops
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// resolvedThreadStatuses are the thread statuses that close out a review conversation
+var resolvedThreadStatuses = []git.CommentThreadStatus{
+	git.CommentThreadStatusValues.Fixed,
+	git.CommentThreadStatusValues.WontFix,
+	git.CommentThreadStatusValues.Closed,
+	git.CommentThreadStatusValues.ByDesign,
+}
+
+// resolvePullRequestThreadCommand resolves a comment thread of a pull request
+func resolvePullRequestThreadCommand(cmd *cobra.Command, args []string) error {
+	// Get the resolution status
+	value, err := cmd.Flags().GetString("status")
+	if err != nil {
+		return handleError("Failed to get status flag", err)
+	}
+	status, err := parseResolvedThreadStatus(value)
+	if err != nil {
+		return handleError("Invalid status flag", err)
+	}
+
+	return changePullRequestThreadStatusCommand(args, status)
+}
+
+// reactivatePullRequestThreadCommand reactivates a resolved comment thread of a pull request
+func reactivatePullRequestThreadCommand(cmd *cobra.Command, args []string) error {
+	return changePullRequestThreadStatusCommand(args, git.CommentThreadStatusValues.Active)
+}
+
+// changePullRequestThreadStatusCommand changes the status of the comment thread given in the arguments
+func changePullRequestThreadStatusCommand(args []string, status git.CommentThreadStatus) error {
+	logger.Info("Changing pull request thread status", "status", status)
+
+	// Parse the repository, pull request ID and thread ID
+	repository, id, err := parsePullRequestArgs(args)
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+	threadID, err := parseThreadID(args[2])
+	if err != nil {
+		return handleError("Invalid arguments", err)
+	}
+
+	// Update the thread status
+	thread, err := setPullRequestThreadStatus(repository, id, threadID, status)
+	if err != nil {
+		return handleError("Failed to change thread status", err)
+	}
+
+	fmt.Printf("Thread %d of pull request %d in %s is now %s\n", thread.ID, id, repository, thread.Status)
+
+	logger.Info("Pull request thread status changed successfully", "repository", repository, "id", id, "thread", threadID, "status", status)
+	return nil
+}
+
+// parseThreadID parses a comment thread ID argument
+func parseThreadID(value string) (int, error) {
+	threadID, err := strconv.Atoi(value)
+	if err != nil || threadID <= 0 {
+		return 0, errors.Errorf("'%s' is not a valid thread ID", value)
+	}
+	return threadID, nil
+}
+
+// parseResolvedThreadStatus parses the status used to resolve a thread, ignoring case
+func parseResolvedThreadStatus(value string) (git.CommentThreadStatus, error) {
+	var names []string
+	for _, status := range resolvedThreadStatuses {
+		if strings.EqualFold(value, string(status)) {
+			return status, nil
+		}
+		names = append(names, string(status))
+	}
+	return "", errors.Errorf("invalid status '%s': use one of %s", value, strings.Join(names, ", "))
+}
+
+// setPullRequestThreadStatus sets the status of a comment thread of a pull request
+func setPullRequestThreadStatus(repository string, id int, threadID int, status git.CommentThreadStatus) (*PullRequestThread, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Git API
+	client, err := createGitClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update the thread status
+	updated, err := client.UpdateThread(commandContext, git.UpdateThreadArgs{
+		CommentThread: &git.GitPullRequestCommentThread{
+			Status: &status,
+		},
+		RepositoryId:  &repository,
+		PullRequestId: &id,
+		ThreadId:      &threadID,
+		Project:       &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to set status of thread %d to %s", threadID, status)
+	}
+
+	result := convertPullRequestThread(*updated)
+	return &result, nil
+}
@@ -0,0 +1,39 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+)
+
+func TestParseThreadID(t *testing.T) {
+	if got, err := parseThreadID("12"); err != nil || got != 12 {
+		t.Errorf("parseThreadID(\"12\") = %d, %v, want 12, nil", got, err)
+	}
+	for _, value := range []string{"", "0", "-3", "abc"} {
+		if _, err := parseThreadID(value); err == nil {
+			t.Errorf("parseThreadID(%q) error = nil, want an error", value)
+		}
+	}
+}
+
+func TestParseResolvedThreadStatus(t *testing.T) {
+	tests := map[string]git.CommentThreadStatus{
+		"fixed":    git.CommentThreadStatusValues.Fixed,
+		"wontfix":  git.CommentThreadStatusValues.WontFix,
+		"closed":   git.CommentThreadStatusValues.Closed,
+		"ByDesign": git.CommentThreadStatusValues.ByDesign,
+	}
+	for value, want := range tests {
+		got, err := parseResolvedThreadStatus(value)
+		if err != nil || got != want {
+			t.Errorf("parseResolvedThreadStatus(%q) = %s, %v, want %s", value, got, err, want)
+		}
+	}
+
+	for _, value := range []string{"active", "pending", ""} {
+		if _, err := parseResolvedThreadStatus(value); err == nil {
+			t.Errorf("parseResolvedThreadStatus(%q) error = nil, want an error", value)
+		}
+	}
+}
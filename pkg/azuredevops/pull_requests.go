@@ -0,0 +1,420 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// pullRequestPageSize is the number of pull requests requested per page
+const pullRequestPageSize = 100
+
+// PullRequest represents a pull request in Azure DevOps
+type PullRequest struct {
+	Repository   string    `json:"repository"`
+	ID           int       `json:"id"`
+	Title        string    `json:"title"`
+	Creator      string    `json:"creator"`
+	Created      time.Time `json:"created"`
+	Status       string    `json:"status"`
+	TargetBranch string    `json:"targetBranch"`
+}
+
+// listOpenPullRequests lists all open pull requests for all repositories in the organization
+func listOpenPullRequests(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing open pull requests")
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the filters
+	filters, err := getPullRequestFilters(cmd)
+	if err != nil {
+		return handleError("Failed to get filter flags", err)
+	}
+
+	// Get the pull requests
+	pullRequests, err := getAllOpenPullRequests(filters)
+	if err != nil {
+		return handleError("Failed to get open pull requests", err)
+	}
+
+	// Check if table output is requested
+	tableOutput, err := useTableOutput(cmd)
+	if err != nil {
+		return handleError("Failed to get table flag", err)
+	}
+
+	// Print the pull requests
+	switch {
+	case jsonOutput:
+		printPullRequestsAsJSON(pullRequests)
+	case tableOutput:
+		printPullRequestsAsTable(pullRequests)
+	default:
+		printPullRequestsAsText(pullRequests)
+	}
+
+	logger.Info("Pull requests listed successfully")
+	return nil
+}
+
+// PullRequestFilters holds the optional filters for listing pull requests
+type PullRequestFilters struct {
+	Project      string
+	Repository   string
+	Creator      string
+	Reviewer     string
+	TargetBranch string
+}
+
+// getPullRequestFilters reads the pull request filter flags from the command
+func getPullRequestFilters(cmd *cobra.Command) (PullRequestFilters, error) {
+	var filters PullRequestFilters
+	var err error
+
+	if filters.Project, err = cmd.Flags().GetString("project"); err != nil {
+		return filters, errors.Wrap(err, "failed to get project flag")
+	}
+	if filters.Repository, err = cmd.Flags().GetString("repo"); err != nil {
+		return filters, errors.Wrap(err, "failed to get repo flag")
+	}
+	if filters.Creator, err = cmd.Flags().GetString("creator"); err != nil {
+		return filters, errors.Wrap(err, "failed to get creator flag")
+	}
+	if filters.Reviewer, err = cmd.Flags().GetString("reviewer"); err != nil {
+		return filters, errors.Wrap(err, "failed to get reviewer flag")
+	}
+	if filters.TargetBranch, err = cmd.Flags().GetString("target-branch"); err != nil {
+		return filters, errors.Wrap(err, "failed to get target-branch flag")
+	}
+
+	return filters, nil
+}
+
+// normalizeBranchName converts a short branch name to a full ref name
+func normalizeBranchName(branch string) string {
+	if branch == "" || strings.HasPrefix(branch, "refs/") {
+		return branch
+	}
+	return "refs/heads/" + branch
+}
+
+// buildPullRequestSearchCriteria builds the search criteria for open pull requests, resolving users to identities
+func buildPullRequestSearchCriteria(connectionDetails *ConnectionDetails, filters PullRequestFilters) (*git.GitPullRequestSearchCriteria, error) {
+	status := git.PullRequestStatusValues.Active
+	searchCriteria := &git.GitPullRequestSearchCriteria{
+		Status: &status,
+	}
+
+	if filters.TargetBranch != "" {
+		targetRefName := normalizeBranchName(filters.TargetBranch)
+		searchCriteria.TargetRefName = &targetRefName
+	}
+
+	if filters.Creator == "" && filters.Reviewer == "" {
+		return searchCriteria, nil
+	}
+
+	identityClient, err := createIdentityClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	if filters.Creator != "" {
+		creatorID, err := resolveIdentityUUID(identityClient, filters.Creator)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to resolve creator")
+		}
+		searchCriteria.CreatorId = creatorID
+	}
+
+	if filters.Reviewer != "" {
+		reviewerID, err := resolveIdentityUUID(identityClient, filters.Reviewer)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to resolve reviewer")
+		}
+		searchCriteria.ReviewerId = reviewerID
+	}
+
+	return searchCriteria, nil
+}
+
+// getAllOpenPullRequests gets all open pull requests matching the filters for the repositories in the organization
+func getAllOpenPullRequests(filters PullRequestFilters) ([]PullRequest, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	// Build the search criteria
+	searchCriteria, err := buildPullRequestSearchCriteria(connectionDetails, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the projects to scan
+	projectNames, err := getProjectNames(connection, filters.Project)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get projects")
+	}
+
+	// Get all repositories and pull requests
+	var allPullRequests []PullRequest
+	for _, projectName := range projectNames {
+		// Get all repositories for the project
+		repositories, err := getRepositories(connection, projectName)
+		if err != nil {
+			logger.Warn("Failed to get repositories for project", "project", projectName, "error", err)
+			continue
+		}
+
+		// Get all pull requests for each repository
+		for _, repo := range repositories {
+			if filters.Repository != "" && !strings.EqualFold(*repo.Name, filters.Repository) {
+				continue
+			}
+
+			pullRequests, err := getPullRequests(connection, projectName, *repo.Name, searchCriteria)
+			if err != nil {
+				logger.Warn("Failed to get pull requests for repository", "repository", *repo.Name, "error", err)
+				continue
+			}
+
+			allPullRequests = append(allPullRequests, pullRequests...)
+		}
+	}
+
+	return allPullRequests, nil
+}
+
+// getProjectNames gets the names of the projects to scan, limited to a single project if one is given
+func getProjectNames(connection *azuredevops.Connection, project string) ([]string, error) {
+	if project != "" {
+		return []string{project}, nil
+	}
+
+	projects, err := getProjects(connection)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, project := range projects {
+		names = append(names, *project.Name)
+	}
+	return names, nil
+}
+
+// getProjects gets all projects in the organization
+func getProjects(connection *azuredevops.Connection) ([]core.TeamProjectReference, error) {
+	// Create a client for the Core API
+	client, err := core.NewClient(commandContext, connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Core client")
+	}
+
+	// Get all projects, following continuation tokens
+	var result []core.TeamProjectReference
+	var continuationToken *string
+	for {
+		projects, err := client.GetProjects(commandContext, core.GetProjectsArgs{
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get projects")
+		}
+
+		result = append(result, projects.Value...)
+
+		if projects.ContinuationToken == "" {
+			return result, nil
+		}
+		token := projects.ContinuationToken
+		continuationToken = &token
+	}
+}
+
+// getRepositories gets all repositories for a project
+func getRepositories(connection *azuredevops.Connection, projectName string) ([]git.GitRepository, error) {
+	// Create a client for the Git API
+	client, err := git.NewClient(commandContext, connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Git client")
+	}
+
+	// Get all repositories for the project
+	repositories, err := client.GetRepositories(commandContext, git.GetRepositoriesArgs{
+		Project: &projectName,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get repositories")
+	}
+
+	return *repositories, nil
+}
+
+// getPullRequests gets all pull requests for a repository matching the search criteria
+func getPullRequests(connection *azuredevops.Connection, projectName, repositoryName string, searchCriteria *git.GitPullRequestSearchCriteria) ([]PullRequest, error) {
+	// Create a client for the Git API
+	client, err := git.NewClient(commandContext, connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Git client")
+	}
+
+	// Get all pull requests for the repository, one page at a time
+	var result []PullRequest
+	top := pullRequestPageSize
+	for skip := 0; ; skip += top {
+		pullRequests, err := client.GetPullRequests(commandContext, git.GetPullRequestsArgs{
+			Project:        &projectName,
+			RepositoryId:   &repositoryName,
+			SearchCriteria: searchCriteria,
+			Top:            &top,
+			Skip:           &skip,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get pull requests")
+		}
+
+		// Convert the pull requests to our model
+		for _, pr := range *pullRequests {
+			result = append(result, convertPullRequest(repositoryName, pr))
+		}
+
+		// A short page means there are no more pull requests
+		if len(*pullRequests) < top {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// createGitClient creates a client for the Git API
+func createGitClient(connectionDetails *ConnectionDetails) (git.Client, error) {
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	// Create a client for the Git API
+	client, err := git.NewClient(commandContext, connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Git client")
+	}
+
+	return client, nil
+}
+
+// convertPullRequest converts an Azure DevOps pull request to our model
+func convertPullRequest(repositoryName string, pr git.GitPullRequest) PullRequest {
+	result := PullRequest{
+		Repository:   repositoryName,
+		ID:           intValue(pr.PullRequestId),
+		Title:        stringValue(pr.Title),
+		TargetBranch: stringValue(pr.TargetRefName),
+	}
+	if pr.CreatedBy != nil {
+		result.Creator = stringValue(pr.CreatedBy.DisplayName)
+	}
+	if pr.CreationDate != nil {
+		result.Created = pr.CreationDate.Time
+	}
+	if pr.Status != nil {
+		result.Status = string(*pr.Status)
+	}
+	return result
+}
+
+// parsePullRequestArgs parses the repository and pull request ID command arguments
+func parsePullRequestArgs(args []string) (string, int, error) {
+	if len(args) < 2 {
+		return "", 0, errors.New("a repository and a pull request ID are required")
+	}
+
+	id, err := strconv.Atoi(args[1])
+	if err != nil || id <= 0 {
+		return "", 0, errors.Errorf("'%s' is not a valid pull request ID", args[1])
+	}
+
+	return args[0], id, nil
+}
+
+// printPullRequestsAsText prints pull requests in a human-readable format
+func printPullRequestsAsText(pullRequests []PullRequest) {
+	if len(pullRequests) == 0 {
+		fmt.Println("No open pull requests found.")
+		return
+	}
+
+	fmt.Printf("Found %d open pull requests:\n\n", len(pullRequests))
+
+	for _, pr := range pullRequests {
+		fmt.Printf("Repository: %s\n", pr.Repository)
+		fmt.Printf("ID: %d\n", pr.ID)
+		fmt.Printf("Title: %s\n", pr.Title)
+		fmt.Printf("Creator: %s\n", pr.Creator)
+		fmt.Printf("Created: %s\n", pr.Created.Format(time.RFC3339))
+		fmt.Printf("Status: %s\n", pr.Status)
+		fmt.Printf("Target Branch: %s\n", pr.TargetBranch)
+		fmt.Println()
+	}
+}
+
+// printPullRequestsAsTable prints pull requests as a table with one row per pull request
+func printPullRequestsAsTable(pullRequests []PullRequest) {
+	if len(pullRequests) == 0 {
+		fmt.Println("No open pull requests found.")
+		return
+	}
+
+	writePullRequestTable(os.Stdout, pullRequests, time.Now())
+}
+
+// writePullRequestTable writes pull requests as a table, showing their age relative to now
+func writePullRequestTable(output io.Writer, pullRequests []PullRequest, now time.Time) {
+	writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "REPOSITORY\tID\tTITLE\tCREATOR\tAGE\tTARGET BRANCH")
+	for _, pr := range pullRequests {
+		fmt.Fprintf(writer, "%s\t%d\t%s\t%s\t%s\t%s\n",
+			pr.Repository,
+			pr.ID,
+			truncateText(pr.Title, maxTableTitleWidth),
+			pr.Creator,
+			formatAge(now.Sub(pr.Created)),
+			strings.TrimPrefix(pr.TargetBranch, "refs/heads/"),
+		)
+	}
+	writer.Flush()
+}
+
+// printPullRequestsAsJSON prints pull requests in JSON format
+func printPullRequestsAsJSON(pullRequests []PullRequest) {
+	// Marshal the pull requests to JSON with indentation
+	jsonData, err := json.MarshalIndent(pullRequests, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal pull requests to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal pull requests to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
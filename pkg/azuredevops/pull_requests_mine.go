@@ -0,0 +1,150 @@
+package azuredevops
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// listMyPullRequests lists the open pull requests created by, or awaiting review from, the current user
+func listMyPullRequests(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing my pull requests")
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Check if pull requests awaiting my review are requested
+	asReviewer, err := cmd.Flags().GetBool("as-reviewer")
+	if err != nil {
+		return handleError("Failed to get as-reviewer flag", err)
+	}
+
+	// Get the project to limit the search to
+	project, err := cmd.Flags().GetString("project")
+	if err != nil {
+		return handleError("Failed to get project flag", err)
+	}
+
+	// Get the pull requests
+	pullRequests, err := getMyPullRequests(project, asReviewer)
+	if err != nil {
+		return handleError("Failed to get my pull requests", err)
+	}
+
+	// Check if table output is requested
+	tableOutput, err := useTableOutput(cmd)
+	if err != nil {
+		return handleError("Failed to get table flag", err)
+	}
+
+	// Print the pull requests
+	switch {
+	case jsonOutput:
+		printPullRequestsAsJSON(pullRequests)
+	case tableOutput:
+		printPullRequestsAsTable(pullRequests)
+	default:
+		printPullRequestsAsText(pullRequests)
+	}
+
+	logger.Info("My pull requests listed successfully")
+	return nil
+}
+
+// getMyPullRequests gets the open pull requests created by the current user, or awaiting their vote when asReviewer is set
+func getMyPullRequests(project string, asReviewer bool) ([]PullRequest, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Find out who the current user is
+	me, err := getAuthenticatedUser(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	// Create a client for the Git API
+	client, err := git.NewClient(commandContext, connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Git client")
+	}
+
+	// Set up the pull request search criteria
+	status := git.PullRequestStatusValues.Active
+	searchCriteria := git.GitPullRequestSearchCriteria{
+		Status: &status,
+	}
+	if asReviewer {
+		searchCriteria.ReviewerId = me.Id
+	} else {
+		searchCriteria.CreatorId = me.Id
+	}
+
+	// Get the projects to scan
+	projectNames, err := getProjectNames(connection, project)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get projects")
+	}
+
+	var result []PullRequest
+	for _, projectName := range projectNames {
+		pullRequests, err := client.GetPullRequestsByProject(commandContext, git.GetPullRequestsByProjectArgs{
+			Project:        &projectName,
+			SearchCriteria: &searchCriteria,
+		})
+		if err != nil {
+			logger.Warn("Failed to get pull requests for project", "project", projectName, "error", err)
+			continue
+		}
+
+		for _, pr := range *pullRequests {
+			if asReviewer && !isAwaitingVote(pr, *me.Id) {
+				continue
+			}
+
+			repositoryName := ""
+			if pr.Repository != nil {
+				repositoryName = stringValue(pr.Repository.Name)
+			}
+			result = append(result, convertPullRequest(repositoryName, pr))
+		}
+	}
+
+	sortPullRequestsByAge(result)
+	return result, nil
+}
+
+// isAwaitingVote checks if the user is a direct reviewer of the pull request who has not voted yet
+func isAwaitingVote(pr git.GitPullRequest, userID uuid.UUID) bool {
+	if pr.Reviewers == nil {
+		return false
+	}
+
+	for _, reviewer := range *pr.Reviewers {
+		if reviewer.Id == nil || !strings.EqualFold(*reviewer.Id, userID.String()) {
+			continue
+		}
+		return reviewer.Vote == nil || *reviewer.Vote == 0
+	}
+
+	return false
+}
+
+// sortPullRequestsByAge sorts pull requests from oldest to newest
+func sortPullRequestsByAge(pullRequests []PullRequest) {
+	sort.SliceStable(pullRequests, func(i, j int) bool {
+		return pullRequests[i].Created.Before(pullRequests[j].Created)
+	})
+}
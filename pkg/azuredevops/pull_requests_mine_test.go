@@ -0,0 +1,55 @@
+package azuredevops
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+)
+
+func TestIsAwaitingVote(t *testing.T) {
+	me := uuid.New()
+	myID := me.String()
+	otherID := uuid.New().String()
+	noVote := 0
+	approved := 10
+
+	tests := []struct {
+		name      string
+		reviewers *[]git.IdentityRefWithVote
+		want      bool
+	}{
+		{name: "no reviewers", reviewers: nil, want: false},
+		{name: "not a reviewer", reviewers: &[]git.IdentityRefWithVote{{Id: &otherID, Vote: &noVote}}, want: false},
+		{name: "not voted", reviewers: &[]git.IdentityRefWithVote{{Id: &otherID, Vote: &approved}, {Id: &myID, Vote: &noVote}}, want: true},
+		{name: "already voted", reviewers: &[]git.IdentityRefWithVote{{Id: &myID, Vote: &approved}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := git.GitPullRequest{Reviewers: tt.reviewers}
+			if got := isAwaitingVote(pr, me); got != tt.want {
+				t.Errorf("isAwaitingVote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortPullRequestsByAge(t *testing.T) {
+	now := time.Now()
+	pullRequests := []PullRequest{
+		{ID: 1, Created: now},
+		{ID: 2, Created: now.Add(-48 * time.Hour)},
+		{ID: 3, Created: now.Add(-time.Hour)},
+	}
+
+	sortPullRequestsByAge(pullRequests)
+
+	wantOrder := []int{2, 3, 1}
+	for i, id := range wantOrder {
+		if pullRequests[i].ID != id {
+			t.Errorf("sortPullRequestsByAge()[%d].ID = %d, want %d", i, pullRequests[i].ID, id)
+		}
+	}
+}
@@ -1,7 +1,9 @@
-package main
+package azuredevops
 
 import (
+	"bytes"
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 )
@@ -106,4 +108,71 @@ func TestPullRequestJSONSerialization(t *testing.T) {
 // Note: Testing the functions that interact with the Azure DevOps API would require
 // mocking the API clients, which is beyond the scope of this implementation.
 // In a real-world scenario, we would use a mocking framework to create mock
-// implementations of the Azure DevOps clients and test the functions that use them.
\ No newline at end of file
+// implementations of the Azure DevOps clients and test the functions that use them.
+func TestNormalizeBranchName(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{branch: "main", want: "refs/heads/main"},
+		{branch: "release/1.2", want: "refs/heads/release/1.2"},
+		{branch: "refs/heads/develop", want: "refs/heads/develop"},
+		{branch: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeBranchName(tt.branch); got != tt.want {
+			t.Errorf("normalizeBranchName(%q) = %q, want %q", tt.branch, got, tt.want)
+		}
+	}
+}
+
+func TestBuildPullRequestSearchCriteria(t *testing.T) {
+	// Without user filters no identity lookups are needed
+	criteria, err := buildPullRequestSearchCriteria(&ConnectionDetails{}, PullRequestFilters{TargetBranch: "main"})
+	if err != nil {
+		t.Fatalf("buildPullRequestSearchCriteria() returned an error: %v", err)
+	}
+	if criteria.Status == nil || *criteria.Status != "active" {
+		t.Errorf("buildPullRequestSearchCriteria() status = %v, want active", criteria.Status)
+	}
+	if criteria.TargetRefName == nil || *criteria.TargetRefName != "refs/heads/main" {
+		t.Errorf("buildPullRequestSearchCriteria() target = %v, want refs/heads/main", criteria.TargetRefName)
+	}
+	if criteria.CreatorId != nil || criteria.ReviewerId != nil {
+		t.Errorf("buildPullRequestSearchCriteria() set identity filters without user filters")
+	}
+}
+
+func TestWritePullRequestTable(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	pullRequests := []PullRequest{
+		{
+			Repository:   "api",
+			ID:           42,
+			Title:        "Add pagination to the pull request listing so large repositories work",
+			Creator:      "Jane Doe",
+			Created:      now.Add(-72 * time.Hour),
+			TargetBranch: "refs/heads/main",
+		},
+	}
+
+	var output bytes.Buffer
+	writePullRequestTable(&output, pullRequests, now)
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("writePullRequestTable() wrote %d lines, want 2:\n%s", len(lines), output.String())
+	}
+	if !strings.HasPrefix(lines[0], "REPOSITORY") {
+		t.Errorf("header = %q, want it to start with REPOSITORY", lines[0])
+	}
+	for _, want := range []string{"api", "42", "Add pagination to the pull request listing so l...", "Jane Doe", "3d", "main"} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("row %q does not contain %q", lines[1], want)
+		}
+	}
+	if strings.Contains(lines[1], "refs/heads/") {
+		t.Errorf("row %q should show the short branch name", lines[1])
+	}
+}
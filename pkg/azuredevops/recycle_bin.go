@@ -0,0 +1,230 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// maxDeletedWorkItemsPerRequest is the maximum number of IDs accepted by the deleted work items API
+const maxDeletedWorkItemsPerRequest = 200
+
+// DeletedWorkItem represents a work item in the recycle bin
+type DeletedWorkItem struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Type        string `json:"type"`
+	Project     string `json:"project"`
+	DeletedBy   string `json:"deletedBy"`
+	DeletedDate string `json:"deletedDate"`
+}
+
+// listRecycleBinWorkItems lists the work items in the recycle bin
+func listRecycleBinWorkItems(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing recycle bin work items")
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the deleted work items
+	workItems, err := getDeletedWorkItems()
+	if err != nil {
+		return handleError("Failed to get recycle bin work items", err)
+	}
+
+	// Print the deleted work items
+	if jsonOutput {
+		printDeletedWorkItemsAsJSON(workItems)
+	} else {
+		printDeletedWorkItemsAsText(workItems)
+	}
+
+	logger.Info("Recycle bin work items listed successfully")
+	return nil
+}
+
+// restoreDeletedWorkItem restores a work item from the recycle bin
+func restoreDeletedWorkItem(cmd *cobra.Command, args []string) error {
+	logger.Info("Restoring work item")
+
+	// Parse the work item ID
+	id, err := parseWorkItemID(args[0])
+	if err != nil {
+		return handleError("Invalid work item ID", err)
+	}
+
+	// Restore the work item
+	restored, err := restoreWorkItem(id)
+	if err != nil {
+		return handleError("Failed to restore work item", err)
+	}
+
+	fmt.Printf("Restored work item %d: %s\n", restored.ID, restored.Title)
+
+	logger.Info("Work item restored successfully", "id", id)
+	return nil
+}
+
+// parseWorkItemID parses a work item ID from a command argument
+func parseWorkItemID(value string) (int, error) {
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return 0, errors.Errorf("'%s' is not a valid work item ID", value)
+	}
+	return id, nil
+}
+
+// getDeletedWorkItems gets all work items in the recycle bin of the project
+func getDeletedWorkItems() ([]DeletedWorkItem, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Work Item Tracking API
+	client, err := createAzureDevOpsClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the IDs of the deleted work items
+	references, err := client.GetDeletedWorkItemShallowReferences(commandContext, workitemtracking.GetDeletedWorkItemShallowReferencesArgs{
+		Project: &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get recycle bin references")
+	}
+	// An empty recycle bin has no references
+	if references == nil {
+		return nil, nil
+	}
+
+	var ids []int
+	for _, reference := range *references {
+		if reference.Id != nil {
+			ids = append(ids, *reference.Id)
+		}
+	}
+
+	// Get the details of the deleted work items in batches
+	var result []DeletedWorkItem
+	for _, batch := range chunkIDs(ids, maxDeletedWorkItemsPerRequest) {
+		deleted, err := client.GetDeletedWorkItems(commandContext, workitemtracking.GetDeletedWorkItemsArgs{
+			Ids:     &batch,
+			Project: &connectionDetails.Project,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get recycle bin work items")
+		}
+		if deleted == nil {
+			continue
+		}
+
+		for _, item := range *deleted {
+			result = append(result, convertDeletedWorkItem(item))
+		}
+	}
+
+	return result, nil
+}
+
+// restoreWorkItem restores a work item from the recycle bin
+func restoreWorkItem(id int) (*DeletedWorkItem, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Work Item Tracking API
+	client, err := createAzureDevOpsClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// Clear the deleted flag on the work item
+	isDeleted := false
+	restored, err := client.RestoreWorkItem(commandContext, workitemtracking.RestoreWorkItemArgs{
+		Payload: &workitemtracking.WorkItemDeleteUpdate{IsDeleted: &isDeleted},
+		Id:      &id,
+		Project: &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to restore work item %d", id)
+	}
+
+	return &DeletedWorkItem{
+		ID:          id,
+		Title:       stringValue(restored.Name),
+		Type:        stringValue(restored.Type),
+		Project:     stringValue(restored.Project),
+		DeletedBy:   stringValue(restored.DeletedBy),
+		DeletedDate: stringValue(restored.DeletedDate),
+	}, nil
+}
+
+// convertDeletedWorkItem converts a deleted work item reference to our model
+func convertDeletedWorkItem(item workitemtracking.WorkItemDeleteReference) DeletedWorkItem {
+	return DeletedWorkItem{
+		ID:          intValue(item.Id),
+		Title:       stringValue(item.Name),
+		Type:        stringValue(item.Type),
+		Project:     stringValue(item.Project),
+		DeletedBy:   stringValue(item.DeletedBy),
+		DeletedDate: stringValue(item.DeletedDate),
+	}
+}
+
+// chunkIDs splits a list of IDs into batches of at most size elements
+func chunkIDs(ids []int, size int) [][]int {
+	var chunks [][]int
+	for start := 0; start < len(ids); start += size {
+		end := start + size
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunks = append(chunks, ids[start:end])
+	}
+	return chunks
+}
+
+// printDeletedWorkItemsAsText prints deleted work items in a human-readable format
+func printDeletedWorkItemsAsText(workItems []DeletedWorkItem) {
+	if len(workItems) == 0 {
+		fmt.Println("The recycle bin is empty.")
+		return
+	}
+
+	fmt.Printf("Found %d deleted work items:\n\n", len(workItems))
+
+	for _, item := range workItems {
+		fmt.Printf("ID: %d\n", item.ID)
+		fmt.Printf("Title: %s\n", item.Title)
+		fmt.Printf("Type: %s\n", item.Type)
+		fmt.Printf("Deleted By: %s\n", item.DeletedBy)
+		fmt.Printf("Deleted Date: %s\n", item.DeletedDate)
+		fmt.Println()
+	}
+}
+
+// printDeletedWorkItemsAsJSON prints deleted work items in JSON format
+func printDeletedWorkItemsAsJSON(workItems []DeletedWorkItem) {
+	// Marshal the work items to JSON with indentation
+	jsonData, err := json.MarshalIndent(workItems, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal deleted work items to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal deleted work items to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
@@ -0,0 +1,74 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func TestParseWorkItemID(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		want      int
+		wantError bool
+	}{
+		{name: "valid id", value: "123", want: 123},
+		{name: "not a number", value: "abc", wantError: true},
+		{name: "zero", value: "0", wantError: true},
+		{name: "negative", value: "-5", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWorkItemID(tt.value)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("parseWorkItemID() error = %v, wantError %v", err, tt.wantError)
+			}
+			if got != tt.want {
+				t.Errorf("parseWorkItemID() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChunkIDs(t *testing.T) {
+	ids := []int{1, 2, 3, 4, 5}
+
+	chunks := chunkIDs(ids, 2)
+	if len(chunks) != 3 {
+		t.Fatalf("chunkIDs() returned %d chunks, want 3", len(chunks))
+	}
+	if len(chunks[2]) != 1 || chunks[2][0] != 5 {
+		t.Errorf("chunkIDs() last chunk = %v, want [5]", chunks[2])
+	}
+
+	if chunks := chunkIDs(nil, 2); len(chunks) != 0 {
+		t.Errorf("chunkIDs() returned %d chunks for an empty list, want 0", len(chunks))
+	}
+}
+
+func TestConvertDeletedWorkItem(t *testing.T) {
+	id := 42
+	name := "Deleted Task"
+	deletedBy := "John Doe"
+	item := workitemtracking.WorkItemDeleteReference{
+		Id:        &id,
+		Name:      &name,
+		DeletedBy: &deletedBy,
+	}
+
+	got := convertDeletedWorkItem(item)
+	if got.ID != id {
+		t.Errorf("convertDeletedWorkItem().ID = %d, want %d", got.ID, id)
+	}
+	if got.Title != name {
+		t.Errorf("convertDeletedWorkItem().Title = %s, want %s", got.Title, name)
+	}
+	if got.DeletedBy != deletedBy {
+		t.Errorf("convertDeletedWorkItem().DeletedBy = %s, want %s", got.DeletedBy, deletedBy)
+	}
+	if got.Type != "" {
+		t.Errorf("convertDeletedWorkItem().Type = %s, want empty string for a missing field", got.Type)
+	}
+}
@@ -0,0 +1,566 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/release"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// defaultReleaseCount is the number of releases listed when no --top flag is given
+const defaultReleaseCount = 25
+
+// ReleaseDefinition represents a classic release definition in Azure DevOps
+type ReleaseDefinition struct {
+	ID     int      `json:"id"`
+	Name   string   `json:"name"`
+	Path   string   `json:"path"`
+	Stages []string `json:"stages"`
+	URL    string   `json:"url"`
+}
+
+// ReleaseStage represents the deployment of a release to one of its stages
+type ReleaseStage struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// Release represents a classic release in Azure DevOps
+type Release struct {
+	ID         int            `json:"id"`
+	Name       string         `json:"name"`
+	Definition string         `json:"definition"`
+	Status     string         `json:"status"`
+	CreatedBy  string         `json:"createdBy"`
+	Created    time.Time      `json:"created"`
+	Stages     []ReleaseStage `json:"stages"`
+	URL        string         `json:"url"`
+}
+
+// listReleaseDefinitionsCommand lists the release definitions of the project
+func listReleaseDefinitionsCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing release definitions")
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the release definitions
+	definitions, err := getReleaseDefinitions()
+	if err != nil {
+		return handleError("Failed to get release definitions", err)
+	}
+
+	// Print the release definitions
+	if jsonOutput {
+		printReleaseDefinitionsAsJSON(definitions)
+	} else {
+		printReleaseDefinitionsAsText(definitions)
+	}
+
+	logger.Info("Release definitions listed successfully")
+	return nil
+}
+
+// listReleasesCommand lists the most recent releases of the project
+func listReleasesCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing releases")
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the release definition to limit the listing to
+	definition, err := cmd.Flags().GetString("definition")
+	if err != nil {
+		return handleError("Failed to get definition flag", err)
+	}
+
+	// Get the maximum number of releases to list
+	top, err := cmd.Flags().GetInt("top")
+	if err != nil {
+		return handleError("Failed to get top flag", err)
+	}
+	if top <= 0 {
+		return handleError("Invalid top flag", errors.Errorf("top must be positive, got %d", top))
+	}
+
+	// Get the releases
+	releases, err := getReleases(definition, top)
+	if err != nil {
+		return handleError("Failed to get releases", err)
+	}
+
+	// Print the releases
+	if jsonOutput {
+		printReleasesAsJSON(releases)
+	} else {
+		printReleasesAsText(releases)
+	}
+
+	logger.Info("Releases listed successfully")
+	return nil
+}
+
+// createReleaseCommand creates a release of a release definition
+func createReleaseCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Creating release", "definition", args[0])
+
+	// Get the release flags
+	description, err := cmd.Flags().GetString("description")
+	if err != nil {
+		return handleError("Failed to get description flag", err)
+	}
+	manualStages, err := cmd.Flags().GetStringArray("manual-stage")
+	if err != nil {
+		return handleError("Failed to get manual-stage flag", err)
+	}
+	varFlags, err := cmd.Flags().GetStringArray("var")
+	if err != nil {
+		return handleError("Failed to get var flag", err)
+	}
+	variables, err := parseKeyValues(varFlags)
+	if err != nil {
+		return handleError("Invalid var flag", err)
+	}
+	stageVarFlags, err := cmd.Flags().GetStringArray("stage-var")
+	if err != nil {
+		return handleError("Failed to get stage-var flag", err)
+	}
+	stageVariables, err := parseStageVariables(stageVarFlags)
+	if err != nil {
+		return handleError("Invalid stage-var flag", err)
+	}
+
+	// Create the release
+	created, err := createRelease(args[0], description, manualStages, variables, stageVariables)
+	if err != nil {
+		return handleError("Failed to create release", err)
+	}
+
+	fmt.Printf("Created release %d (%s) of %s\n", created.ID, created.Name, created.Definition)
+	if created.URL != "" {
+		fmt.Printf("URL: %s\n", created.URL)
+	}
+
+	logger.Info("Release created successfully", "definition", created.Definition, "release", created.ID)
+	return nil
+}
+
+// createReleaseClient creates a client for the Release API
+func createReleaseClient(connectionDetails *ConnectionDetails) (release.Client, error) {
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	// Create a client for the Release API
+	client, err := release.NewClient(commandContext, connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Release client")
+	}
+
+	return client, nil
+}
+
+// getReleaseDefinitions gets all release definitions of the project
+func getReleaseDefinitions() ([]ReleaseDefinition, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Release API
+	client, err := createReleaseClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions, err := listProjectReleaseDefinitions(client, connectionDetails.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []ReleaseDefinition{}
+	for _, definition := range definitions {
+		result = append(result, convertReleaseDefinition(definition))
+	}
+	return result, nil
+}
+
+// listProjectReleaseDefinitions gets all release definitions of a project with their stages, following continuation tokens
+func listProjectReleaseDefinitions(client release.Client, project string) ([]release.ReleaseDefinition, error) {
+	var result []release.ReleaseDefinition
+	expand := release.ReleaseDefinitionExpandsValues.Environments
+	var continuationToken *string
+
+	for {
+		response, err := client.GetReleaseDefinitions(commandContext, release.GetReleaseDefinitionsArgs{
+			Project:           &project,
+			Expand:            &expand,
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list release definitions")
+		}
+
+		result = append(result, response.Value...)
+
+		if response.ContinuationToken == "" {
+			return result, nil
+		}
+		token := response.ContinuationToken
+		continuationToken = &token
+	}
+}
+
+// findReleaseDefinition finds a release definition by ID or by name, ignoring case
+func findReleaseDefinition(definitions []release.ReleaseDefinition, nameOrID string) (release.ReleaseDefinition, error) {
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		for _, definition := range definitions {
+			if intValue(definition.Id) == id {
+				return definition, nil
+			}
+		}
+		return release.ReleaseDefinition{}, errors.Errorf("no release definition with ID %d", id)
+	}
+
+	var matches []release.ReleaseDefinition
+	for _, definition := range definitions {
+		if strings.EqualFold(stringValue(definition.Name), nameOrID) {
+			matches = append(matches, definition)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return release.ReleaseDefinition{}, errors.Errorf("no release definition named '%s'", nameOrID)
+	case 1:
+		return matches[0], nil
+	default:
+		return release.ReleaseDefinition{}, errors.Errorf("'%s' matches %d release definitions; use the definition ID", nameOrID, len(matches))
+	}
+}
+
+// getReleases gets the most recent releases of the project, optionally of a single release definition
+func getReleases(definition string, top int) ([]Release, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Release API
+	client, err := createReleaseClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	expand := release.ReleaseExpandsValues.Environments
+	args := release.GetReleasesArgs{
+		Project: &connectionDetails.Project,
+		Expand:  &expand,
+		Top:     &top,
+	}
+
+	// Resolve the release definition when one is given
+	if definition != "" {
+		definitions, err := listProjectReleaseDefinitions(client, connectionDetails.Project)
+		if err != nil {
+			return nil, err
+		}
+		found, err := findReleaseDefinition(definitions, definition)
+		if err != nil {
+			return nil, err
+		}
+		args.DefinitionId = found.Id
+	}
+
+	response, err := client.GetReleases(commandContext, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list releases")
+	}
+
+	result := []Release{}
+	for _, r := range response.Value {
+		result = append(result, convertRelease(r))
+	}
+	return result, nil
+}
+
+// parseStageVariables parses stage:name=value pairs into variables per stage
+func parseStageVariables(pairs []string) (map[string]map[string]string, error) {
+	result := make(map[string]map[string]string)
+	for _, pair := range pairs {
+		stage, variable, found := strings.Cut(pair, ":")
+		if !found || strings.TrimSpace(stage) == "" {
+			return nil, errors.Errorf("invalid stage variable '%s', expected stage:name=value", pair)
+		}
+
+		variables, err := parseKeyValues([]string{variable})
+		if err != nil {
+			return nil, err
+		}
+
+		stage = strings.TrimSpace(stage)
+		if result[stage] == nil {
+			result[stage] = make(map[string]string)
+		}
+		for name, value := range variables {
+			result[stage][name] = value
+		}
+	}
+	return result, nil
+}
+
+// findReleaseStage finds a stage of a release definition by name, ignoring case
+func findReleaseStage(definition release.ReleaseDefinition, name string) (release.ReleaseDefinitionEnvironment, error) {
+	if definition.Environments != nil {
+		for _, environment := range *definition.Environments {
+			if strings.EqualFold(stringValue(environment.Name), name) {
+				return environment, nil
+			}
+		}
+	}
+	return release.ReleaseDefinitionEnvironment{}, errors.Errorf("release definition '%s' has no stage named '%s'", stringValue(definition.Name), name)
+}
+
+// configurationVariables converts plain variables to release configuration variables
+func configurationVariables(variables map[string]string) *map[string]release.ConfigurationVariableValue {
+	if len(variables) == 0 {
+		return nil
+	}
+
+	result := make(map[string]release.ConfigurationVariableValue, len(variables))
+	for name, value := range variables {
+		result[name] = release.ConfigurationVariableValue{Value: &value}
+	}
+	return &result
+}
+
+// buildReleaseStartMetadata builds the request to start a release, resolving stage names against the definition
+func buildReleaseStartMetadata(definition release.ReleaseDefinition, description string, manualStages []string, variables map[string]string, stageVariables map[string]map[string]string) (*release.ReleaseStartMetadata, error) {
+	metadata := &release.ReleaseStartMetadata{
+		DefinitionId: definition.Id,
+		Variables:    configurationVariables(variables),
+	}
+	if description != "" {
+		metadata.Description = &description
+	}
+
+	// Manual stages are not deployed automatically when the release is created
+	if len(manualStages) > 0 {
+		var names []string
+		for _, name := range manualStages {
+			stage, err := findReleaseStage(definition, name)
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, stringValue(stage.Name))
+		}
+		metadata.ManualEnvironments = &names
+	}
+
+	if len(stageVariables) > 0 {
+		var environments []release.ReleaseStartEnvironmentMetadata
+		for name, stageVars := range stageVariables {
+			stage, err := findReleaseStage(definition, name)
+			if err != nil {
+				return nil, err
+			}
+			environments = append(environments, release.ReleaseStartEnvironmentMetadata{
+				DefinitionEnvironmentId: stage.Id,
+				Variables:               configurationVariables(stageVars),
+			})
+		}
+		metadata.EnvironmentsMetadata = &environments
+	}
+
+	return metadata, nil
+}
+
+// createRelease creates a release of a release definition, given by ID or name
+func createRelease(nameOrID string, description string, manualStages []string, variables map[string]string, stageVariables map[string]map[string]string) (*Release, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Release API
+	client, err := createReleaseClient(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the release definition
+	definitions, err := listProjectReleaseDefinitions(client, connectionDetails.Project)
+	if err != nil {
+		return nil, err
+	}
+	definition, err := findReleaseDefinition(definitions, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := buildReleaseStartMetadata(definition, description, manualStages, variables, stageVariables)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.CreateRelease(commandContext, release.CreateReleaseArgs{
+		ReleaseStartMetadata: metadata,
+		Project:              &connectionDetails.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create release of %s", stringValue(definition.Name))
+	}
+
+	result := convertRelease(*created)
+	return &result, nil
+}
+
+// convertReleaseDefinition converts an Azure DevOps release definition to our model
+func convertReleaseDefinition(definition release.ReleaseDefinition) ReleaseDefinition {
+	result := ReleaseDefinition{
+		ID:     intValue(definition.Id),
+		Name:   stringValue(definition.Name),
+		Path:   stringValue(definition.Path),
+		Stages: []string{},
+		URL:    linkHref(definition.Links, "web"),
+	}
+	if result.URL == "" {
+		result.URL = stringValue(definition.Url)
+	}
+	if definition.Environments != nil {
+		for _, environment := range *definition.Environments {
+			result.Stages = append(result.Stages, stringValue(environment.Name))
+		}
+	}
+	return result
+}
+
+// convertRelease converts an Azure DevOps release to our model
+func convertRelease(r release.Release) Release {
+	result := Release{
+		ID:     intValue(r.Id),
+		Name:   stringValue(r.Name),
+		Stages: []ReleaseStage{},
+		URL:    linkHref(r.Links, "web"),
+	}
+	if result.URL == "" {
+		result.URL = stringValue(r.Url)
+	}
+	if r.ReleaseDefinition != nil {
+		result.Definition = stringValue(r.ReleaseDefinition.Name)
+	}
+	if r.Status != nil {
+		result.Status = string(*r.Status)
+	}
+	if r.CreatedBy != nil {
+		result.CreatedBy = stringValue(r.CreatedBy.DisplayName)
+	}
+	if r.CreatedOn != nil {
+		result.Created = r.CreatedOn.Time
+	}
+	if r.Environments != nil {
+		for _, environment := range *r.Environments {
+			stage := ReleaseStage{
+				ID:   intValue(environment.Id),
+				Name: stringValue(environment.Name),
+			}
+			if environment.Status != nil {
+				stage.Status = string(*environment.Status)
+			}
+			result.Stages = append(result.Stages, stage)
+		}
+	}
+	return result
+}
+
+// formatReleaseStages formats the stages of a release as a single line
+func formatReleaseStages(stages []ReleaseStage) string {
+	var parts []string
+	for _, stage := range stages {
+		parts = append(parts, fmt.Sprintf("%s (%s)", stage.Name, stage.Status))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printReleaseDefinitionsAsText prints release definitions in a human-readable format
+func printReleaseDefinitionsAsText(definitions []ReleaseDefinition) {
+	if len(definitions) == 0 {
+		fmt.Println("No release definitions found.")
+		return
+	}
+
+	fmt.Printf("Found %d release definitions:\n\n", len(definitions))
+
+	for _, definition := range definitions {
+		fmt.Printf("ID: %d\n", definition.ID)
+		fmt.Printf("Name: %s\n", definition.Name)
+		fmt.Printf("Path: %s\n", definition.Path)
+		fmt.Printf("Stages: %s\n", strings.Join(definition.Stages, ", "))
+		fmt.Printf("URL: %s\n", definition.URL)
+		fmt.Println()
+	}
+}
+
+// printReleaseDefinitionsAsJSON prints release definitions in JSON format
+func printReleaseDefinitionsAsJSON(definitions []ReleaseDefinition) {
+	// Marshal the release definitions to JSON with indentation
+	jsonData, err := json.MarshalIndent(definitions, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal release definitions to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal release definitions to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
+
+// printReleasesAsText prints releases in a human-readable format
+func printReleasesAsText(releases []Release) {
+	if len(releases) == 0 {
+		fmt.Println("No releases found.")
+		return
+	}
+
+	fmt.Printf("Found %d releases:\n\n", len(releases))
+
+	for _, r := range releases {
+		fmt.Printf("ID: %d\n", r.ID)
+		fmt.Printf("Name: %s\n", r.Name)
+		fmt.Printf("Definition: %s\n", r.Definition)
+		fmt.Printf("Status: %s\n", r.Status)
+		fmt.Printf("Created By: %s\n", r.CreatedBy)
+		fmt.Printf("Created: %s\n", r.Created.Format(time.RFC3339))
+		fmt.Printf("Stages: %s\n", formatReleaseStages(r.Stages))
+		fmt.Printf("URL: %s\n", r.URL)
+		fmt.Println()
+	}
+}
+
+// printReleasesAsJSON prints releases in JSON format
+func printReleasesAsJSON(releases []Release) {
+	// Marshal the releases to JSON with indentation
+	jsonData, err := json.MarshalIndent(releases, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal releases to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal releases to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
@@ -0,0 +1,120 @@
+package azuredevops
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/release"
+)
+
+func testReleaseDefinition() release.ReleaseDefinition {
+	id, name := 7, "Web App"
+	devID, devName := 11, "Dev"
+	prodID, prodName := 12, "Production"
+	return release.ReleaseDefinition{
+		Id:   &id,
+		Name: &name,
+		Environments: &[]release.ReleaseDefinitionEnvironment{
+			{Id: &devID, Name: &devName},
+			{Id: &prodID, Name: &prodName},
+		},
+	}
+}
+
+func TestFindReleaseDefinition(t *testing.T) {
+	definitions := []release.ReleaseDefinition{testReleaseDefinition()}
+
+	if got, err := findReleaseDefinition(definitions, "7"); err != nil || *got.Name != "Web App" {
+		t.Errorf("findReleaseDefinition(\"7\") = %v, want Web App", err)
+	}
+	if got, err := findReleaseDefinition(definitions, "web app"); err != nil || *got.Id != 7 {
+		t.Errorf("findReleaseDefinition(\"web app\") = %v, want ID 7", err)
+	}
+	if _, err := findReleaseDefinition(definitions, "8"); err == nil {
+		t.Error("findReleaseDefinition(\"8\") error = nil, want an error")
+	}
+	if _, err := findReleaseDefinition(definitions, "missing"); err == nil {
+		t.Error("findReleaseDefinition(\"missing\") error = nil, want an error")
+	}
+}
+
+func TestParseStageVariables(t *testing.T) {
+	got, err := parseStageVariables([]string{"Dev:replicas=1", "Production:replicas=3", "Production:region=westeurope"})
+	if err != nil {
+		t.Fatalf("parseStageVariables() error = %v", err)
+	}
+
+	want := map[string]map[string]string{
+		"Dev":        {"replicas": "1"},
+		"Production": {"replicas": "3", "region": "westeurope"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseStageVariables() = %v, want %v", got, want)
+	}
+
+	for _, pair := range []string{"replicas=1", ":replicas=1", "Dev:replicas"} {
+		if _, err := parseStageVariables([]string{pair}); err == nil {
+			t.Errorf("parseStageVariables(%q) error = nil, want an error", pair)
+		}
+	}
+}
+
+func TestBuildReleaseStartMetadata(t *testing.T) {
+	definition := testReleaseDefinition()
+
+	metadata, err := buildReleaseStartMetadata(
+		definition,
+		"Hotfix",
+		[]string{"production"},
+		map[string]string{"version": "1.2.3"},
+		map[string]map[string]string{"dev": {"replicas": "1"}},
+	)
+	if err != nil {
+		t.Fatalf("buildReleaseStartMetadata() error = %v", err)
+	}
+
+	if *metadata.DefinitionId != 7 || *metadata.Description != "Hotfix" {
+		t.Errorf("buildReleaseStartMetadata() definition = %d, description = %s", *metadata.DefinitionId, *metadata.Description)
+	}
+	if !reflect.DeepEqual(*metadata.ManualEnvironments, []string{"Production"}) {
+		t.Errorf("ManualEnvironments = %v, want [Production]", *metadata.ManualEnvironments)
+	}
+	if value := (*metadata.Variables)["version"]; *value.Value != "1.2.3" {
+		t.Errorf("Variables[version] = %s, want 1.2.3", *value.Value)
+	}
+
+	environments := *metadata.EnvironmentsMetadata
+	if len(environments) != 1 || *environments[0].DefinitionEnvironmentId != 11 {
+		t.Fatalf("EnvironmentsMetadata = %+v, want the Dev stage", environments)
+	}
+	if value := (*environments[0].Variables)["replicas"]; *value.Value != "1" {
+		t.Errorf("stage variable replicas = %s, want 1", *value.Value)
+	}
+
+	if _, err := buildReleaseStartMetadata(definition, "", []string{"QA"}, nil, nil); err == nil {
+		t.Error("buildReleaseStartMetadata() with an unknown stage error = nil, want an error")
+	}
+}
+
+func TestConvertRelease(t *testing.T) {
+	id, name := 42, "Release-42"
+	definitionName := "Web App"
+	status := release.ReleaseStatusValues.Active
+	stageName := "Dev"
+	stageStatus := release.EnvironmentStatusValues.Succeeded
+
+	got := convertRelease(release.Release{
+		Id:                &id,
+		Name:              &name,
+		Status:            &status,
+		ReleaseDefinition: &release.ReleaseDefinitionShallowReference{Name: &definitionName},
+		Environments:      &[]release.ReleaseEnvironment{{Name: &stageName, Status: &stageStatus}},
+	})
+
+	if got.ID != 42 || got.Name != "Release-42" || got.Definition != "Web App" || got.Status != "active" {
+		t.Errorf("convertRelease() = %+v", got)
+	}
+	if formatted := formatReleaseStages(got.Stages); formatted != "Dev (succeeded)" {
+		t.Errorf("formatReleaseStages() = %s, want 'Dev (succeeded)'", formatted)
+	}
+}
@@ -0,0 +1,154 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// Repository represents a Git repository in Azure DevOps
+type Repository struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Project       string `json:"project"`
+	DefaultBranch string `json:"defaultBranch"`
+	Size          uint64 `json:"size"`
+	RemoteURL     string `json:"remoteUrl"`
+	WebURL        string `json:"webUrl"`
+}
+
+// listRepositoriesCommand lists the Git repositories of each project
+func listRepositoriesCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing repositories")
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the project to limit the listing to
+	project, err := cmd.Flags().GetString("project")
+	if err != nil {
+		return handleError("Failed to get project flag", err)
+	}
+
+	// Get the repositories
+	repositories, err := getAllRepositories(project)
+	if err != nil {
+		return handleError("Failed to get repositories", err)
+	}
+
+	// Print the repositories
+	if jsonOutput {
+		printRepositoriesAsJSON(repositories)
+	} else {
+		printRepositoriesAsText(repositories)
+	}
+
+	logger.Info("Repositories listed successfully")
+	return nil
+}
+
+// getAllRepositories gets the Git repositories of every project, or of a single project when one is given
+func getAllRepositories(project string) ([]Repository, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	// Get the projects to scan
+	projectNames, err := getProjectNames(connection, project)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get projects")
+	}
+
+	result := []Repository{}
+	for _, projectName := range projectNames {
+		repositories, err := getRepositories(connection, projectName)
+		if err != nil {
+			logger.Warn("Failed to get repositories for project", "project", projectName, "error", err)
+			continue
+		}
+
+		for _, repo := range repositories {
+			result = append(result, convertRepository(projectName, repo))
+		}
+	}
+
+	return result, nil
+}
+
+// convertRepository converts an Azure DevOps Git repository to our model
+func convertRepository(projectName string, repo git.GitRepository) Repository {
+	result := Repository{
+		Name:          stringValue(repo.Name),
+		Project:       projectName,
+		DefaultBranch: strings.TrimPrefix(stringValue(repo.DefaultBranch), "refs/heads/"),
+		RemoteURL:     stringValue(repo.RemoteUrl),
+		WebURL:        stringValue(repo.WebUrl),
+	}
+	if repo.Id != nil {
+		result.ID = repo.Id.String()
+	}
+	if repo.Size != nil {
+		result.Size = *repo.Size
+	}
+	return result
+}
+
+// formatSize formats a size in bytes for display
+func formatSize(size uint64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := uint64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// printRepositoriesAsText prints repositories in a human-readable format
+func printRepositoriesAsText(repositories []Repository) {
+	if len(repositories) == 0 {
+		fmt.Println("No repositories found.")
+		return
+	}
+
+	fmt.Printf("Found %d repositories:\n\n", len(repositories))
+
+	for _, repo := range repositories {
+		fmt.Printf("Name: %s\n", repo.Name)
+		fmt.Printf("Project: %s\n", repo.Project)
+		fmt.Printf("Default Branch: %s\n", repo.DefaultBranch)
+		fmt.Printf("Size: %s\n", formatSize(repo.Size))
+		fmt.Printf("URL: %s\n", repo.WebURL)
+		fmt.Println()
+	}
+}
+
+// printRepositoriesAsJSON prints repositories in JSON format
+func printRepositoriesAsJSON(repositories []Repository) {
+	// Marshal the repositories to JSON with indentation
+	jsonData, err := json.MarshalIndent(repositories, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal repositories to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal repositories to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
@@ -0,0 +1,55 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+)
+
+func TestConvertRepository(t *testing.T) {
+	id := uuid.New()
+	name, branch := "api", "refs/heads/main"
+	webURL := "https://dev.azure.com/org/Project/_git/api"
+	size := uint64(2048)
+
+	got := convertRepository("Project", git.GitRepository{
+		Id:            &id,
+		Name:          &name,
+		DefaultBranch: &branch,
+		WebUrl:        &webURL,
+		Size:          &size,
+	})
+
+	want := Repository{
+		ID:            id.String(),
+		Name:          "api",
+		Project:       "Project",
+		DefaultBranch: "main",
+		Size:          2048,
+		WebURL:        webURL,
+	}
+	if got != want {
+		t.Errorf("convertRepository() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		size uint64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{5 * 1024 * 1024, "5.0 MiB"},
+		{3 * 1024 * 1024 * 1024, "3.0 GiB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatSize(tt.size); got != tt.want {
+			t.Errorf("formatSize(%d) = %s, want %s", tt.size, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package azuredevops
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// cloneHost is the host the credential of clones is sent to, and only to it
+const cloneHost = "https://dev.azure.com/"
+
+// cloneRepositoryCommand clones one repository, or every repository of a project, with the Personal Access Token
+func cloneRepositoryCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Cloning repositories", "target", args[0])
+
+	// Check if every repository of the project should be cloned
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return handleError("Failed to get all flag", err)
+	}
+
+	directory := ""
+	if len(args) > 1 {
+		directory = args[1]
+	}
+
+	if all {
+		err = cloneProjectRepositories(args[0], directory)
+	} else {
+		err = cloneRepository(args[0], directory)
+	}
+	if err != nil {
+		return handleError("Failed to clone repositories", err)
+	}
+
+	logger.Info("Repositories cloned successfully", "target", args[0])
+	return nil
+}
+
+// parseRepositoryPath splits a project/repo argument into its project and repository names
+func parseRepositoryPath(path string) (string, string, error) {
+	project, repository, found := strings.Cut(path, "/")
+	if !found || project == "" || repository == "" || strings.Contains(repository, "/") {
+		return "", "", errors.Errorf("invalid repository '%s', expected <project>/<repo>", path)
+	}
+	return project, repository, nil
+}
+
+// buildCloneURL builds the HTTPS clone URL of a repository
+func buildCloneURL(organization, project, repository string) string {
+	cloneURL := url.URL{
+		Scheme: "https",
+		Host:   "dev.azure.com",
+		Path:   fmt.Sprintf("/%s/%s/_git/%s", organization, project, repository),
+	}
+	return cloneURL.String()
+}
+
+// gitCredentialEnv gets the variables that make git send the credential to Azure DevOps as an Authorization header,
+// basic for a Personal Access Token and bearer for an Azure AD access token. Unlike a URL with the token, they are
+// neither on the command line of git nor stored in .git/config. The configuration given to git in the same way by
+// the environment is kept.
+func gitCredentialEnv(connectionDetails *ConnectionDetails) []string {
+	header := "Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte(":"+connectionDetails.Token))
+	if connectionDetails.Bearer {
+		header = "Authorization: Bearer " + connectionDetails.Token
+	}
+
+	count, err := strconv.Atoi(env.Getenv("GIT_CONFIG_COUNT"))
+	if err != nil || count < 0 {
+		count = 0
+	}
+	return []string{
+		fmt.Sprintf("GIT_CONFIG_COUNT=%d", count+1),
+		fmt.Sprintf("GIT_CONFIG_KEY_%d=http.%s.extraheader", count, cloneHost),
+		fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", count, header),
+	}
+}
+
+// cloneRepository clones a project/repo into the directory, which defaults to the repository name
+func cloneRepository(path string, directory string) error {
+	project, repository, err := parseRepositoryPath(path)
+	if err != nil {
+		return err
+	}
+
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return err
+	}
+
+	if directory == "" {
+		directory = repository
+	}
+	return runGitClone(connectionDetails, project, repository, directory)
+}
+
+// cloneProjectRepositories clones every repository of a project into subdirectories of the directory
+func cloneProjectRepositories(project string, directory string) error {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return err
+	}
+
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	repositories, err := getRepositories(connection, project)
+	if err != nil {
+		return err
+	}
+
+	if directory == "" {
+		directory = "."
+	}
+
+	cloned, skipped := 0, 0
+	for _, repo := range repositories {
+		name := stringValue(repo.Name)
+		target := filepath.Join(directory, name)
+		if _, err := os.Stat(target); err == nil {
+			fmt.Printf("Skipping %s: %s already exists\n", name, target)
+			skipped++
+			continue
+		}
+
+		if err := runGitClone(connectionDetails, project, name, target); err != nil {
+			return err
+		}
+		cloned++
+	}
+
+	fmt.Printf("Cloned %d repositories of %s, skipped %d\n", cloned, project, skipped)
+	return nil
+}
+
+// runGitClone clones a repository with git, passing the credential in its environment. Later fetches go through the
+// user's credential helper.
+func runGitClone(connectionDetails *ConnectionDetails, project, repository, directory string) error {
+	fmt.Printf("Cloning %s/%s into %s\n", project, repository, directory)
+
+	cloneURL := buildCloneURL(connectionDetails.Organization, project, repository)
+	if err := runGit(gitCredentialEnv(connectionDetails), "clone", cloneURL, directory); err != nil {
+		return errors.Wrapf(err, "failed to clone %s/%s", project, repository)
+	}
+	return nil
+}
+
+// runGit runs git with the given arguments and variables added to its environment, passing its output through
+func runGit(variables []string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Env = append(env.Environ(), variables...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
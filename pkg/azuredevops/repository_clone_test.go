@@ -0,0 +1,42 @@
+package azuredevops
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRepositoryPath(t *testing.T) {
+	project, repository, err := parseRepositoryPath("MyProject/api")
+	if err != nil || project != "MyProject" || repository != "api" {
+		t.Errorf("parseRepositoryPath() = %s, %s, %v, want MyProject, api", project, repository, err)
+	}
+
+	for _, path := range []string{"api", "/api", "MyProject/", "MyProject/api/extra"} {
+		if _, _, err := parseRepositoryPath(path); err == nil {
+			t.Errorf("parseRepositoryPath(%q) error = nil, want an error", path)
+		}
+	}
+}
+
+func TestBuildCloneURL(t *testing.T) {
+	if got := buildCloneURL("org", "My Project", "api"); got != "https://dev.azure.com/org/My%20Project/_git/api" {
+		t.Errorf("buildCloneURL() = %s", got)
+	}
+}
+
+func TestGitCredentialEnv(t *testing.T) {
+	t.Setenv("GIT_CONFIG_COUNT", "")
+	got := gitCredentialEnv(&ConnectionDetails{Token: "secret"})
+	want := []string{"GIT_CONFIG_COUNT=1", "GIT_CONFIG_KEY_0=http.https://dev.azure.com/.extraheader", "GIT_CONFIG_VALUE_0=Authorization: Basic OnNlY3JldA=="}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("gitCredentialEnv() of a PAT = %q, want %q", got, want)
+	}
+
+	// An Azure AD access token is a bearer token, added after the configuration already in the environment
+	t.Setenv("GIT_CONFIG_COUNT", "2")
+	got = gitCredentialEnv(&ConnectionDetails{Token: "aad", Bearer: true})
+	want = []string{"GIT_CONFIG_COUNT=3", "GIT_CONFIG_KEY_2=http.https://dev.azure.com/.extraheader", "GIT_CONFIG_VALUE_2=Authorization: Bearer aad"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("gitCredentialEnv() of an Azure AD token = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,157 @@
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Backoff settings of the retry layer
+const (
+	defaultRetryMax = 3
+	retryBaseDelay  = time.Second
+	retryMaxDelay   = 30 * time.Second
+	// retryAfterLimit caps the delay requested by the server with Retry-After
+	retryAfterLimit = 5 * time.Minute
+)
+
+// retryTransport retries API calls that were rate limited or failed with a transient error
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	baseDelay  time.Duration
+}
+
+// newRetryTransport wraps a transport with a retry layer
+func newRetryTransport(next http.RoundTripper, maxRetries int) *retryTransport {
+	return &retryTransport{next: next, maxRetries: maxRetries, baseDelay: retryBaseDelay}
+}
+
+// RoundTrip sends the request, retrying 429 and transient 5xx responses with exponential backoff
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.maxRetries > 0 {
+		if err := rewindableBody(req); err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to rewind request body")
+			}
+			req.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt, resp)
+		if err != nil {
+			logger.Warn("Retrying request", "method", req.Method, "url", req.URL.Redacted(), "error", err, "attempt", attempt+1, "delay", delay)
+		} else {
+			logger.Warn("Retrying request", "method", req.Method, "url", req.URL.Redacted(), "status", resp.StatusCode, "attempt", attempt+1, "delay", delay)
+			// Drain the body so the connection can be reused
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := sleepContext(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff gets the delay before a retry, honoring the Retry-After header of the response
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return min(delay, retryAfterLimit)
+		}
+	}
+
+	// Exponential backoff with jitter, between half and the full delay
+	delay := min(t.baseDelay<<attempt, retryMaxDelay)
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// shouldRetry checks if a request failed in a way that is worth retrying.
+// Rate limited and unavailable responses were not processed, so they are retried for every method;
+// other server and connection errors may have been, so they are only retried for idempotent methods.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return req.Context().Err() == nil && isIdempotent(req.Method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	}
+	return false
+}
+
+// isIdempotent checks if sending a request with the method twice has the same effect as sending it once
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// rewindableBody makes sure the body of a request can be sent again
+func rewindableBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return errors.Wrap(err, "failed to read request body")
+	}
+
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	req.Body, _ = req.GetBody()
+	return nil
+}
+
+// sleepContext waits for the delay, or until the context is done
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,111 @@
+package azuredevops
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	oldLogger := logger
+	logger = slog.New(slog.DiscardHandler)
+	defer func() { logger = oldLogger }()
+
+	tests := []struct {
+		name       string
+		method     string
+		statuses   []int
+		maxRetries int
+		wantStatus int
+		wantCalls  int
+	}{
+		{"success", http.MethodGet, []int{200}, 3, 200, 1},
+		{"rate limited then success", http.MethodPost, []int{429, 429, 200}, 3, 200, 3},
+		{"gives up after max retries", http.MethodGet, []int{503, 503, 503}, 2, 503, 3},
+		{"retries disabled", http.MethodGet, []int{503, 200}, 0, 503, 1},
+		{"server error retried for GET", http.MethodGet, []int{502, 200}, 3, 200, 2},
+		{"server error not retried for POST", http.MethodPost, []int{502, 200}, 3, 502, 1},
+		{"client error not retried", http.MethodGet, []int{404, 200}, 3, 404, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if r.Method == http.MethodPost && string(body) != "payload" {
+					t.Errorf("call %d got body %q, want %q", calls+1, body, "payload")
+				}
+				w.WriteHeader(tt.statuses[calls])
+				calls++
+			}))
+			defer server.Close()
+
+			transport := newRetryTransport(http.DefaultTransport, tt.maxRetries)
+			transport.baseDelay = time.Millisecond
+
+			var body io.Reader
+			if tt.method == http.MethodPost {
+				// A reader without GetBody, so the retry layer has to buffer it
+				body = io.NopCloser(strings.NewReader("payload"))
+			}
+			req, _ := http.NewRequest(tt.method, server.URL, body)
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("RoundTrip() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("RoundTrip() made %d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"Wed, 01 May 2024 12:00:30 GMT", 30 * time.Second, true},
+		{"Wed, 01 May 2024 11:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	transport := newRetryTransport(http.DefaultTransport, 3)
+
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := transport.backoff(attempt, nil)
+		want := min(retryBaseDelay<<attempt, retryMaxDelay)
+		if delay < want/2 || delay > want {
+			t.Errorf("backoff(%d) = %v, want between %v and %v", attempt, delay, want/2, want)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	if delay := transport.backoff(0, resp); delay != 7*time.Second {
+		t.Errorf("backoff() with Retry-After = %v, want 7s", delay)
+	}
+}
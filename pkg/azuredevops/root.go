@@ -0,0 +1,1067 @@
+// Package azuredevops is the azure-devops subcommand, which manages work items, pull requests, pipelines and more
+// in Azure DevOps. It is built as its own binary from cmd/azure-devops, and bundled in master-mold, which runs it
+// in-process when the binary isn't installed.
+package azuredevops
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/oscarrieken/master-mold/pkg/describe"
+	"github.com/oscarrieken/master-mold/pkg/logging"
+	"github.com/spf13/cobra"
+	"log/slog"
+)
+
+var logger *slog.Logger
+
+// newLogger creates the logger from the global flags and log settings of master-mold
+func newLogger() *slog.Logger {
+	return logging.New()
+}
+
+// Version is the version of the subcommand, set at build time with
+// -ldflags "-X github.com/oscarrieken/master-mold/pkg/azuredevops.Version=<version>"
+var Version = "dev"
+
+// Execute runs the subcommand with its arguments, without the subcommand name. It describes the command tree when
+// master-mold asks for it, and Ctrl-C cancels the context of the command, aborting in-flight API calls.
+func Execute(args []string) error {
+	return ExecuteContext(context.Background(), args)
+}
+
+// ExecuteContext runs the subcommand like Execute, within a context, such as one with the timeout of master-mold
+// when it runs the subcommand in-process. The error of the command is returned rather than exiting, so that
+// master-mold can record it and exit with its exit code.
+func ExecuteContext(ctx context.Context, args []string) error {
+	// Initialize the logger
+	logger = newLogger()
+	logger.Info("Starting Azure DevOps subcommand")
+
+	rootCmd := NewRootCommand()
+
+	// Describe the command tree and the environment variables to master-mold
+	description := describe.FromCobra(rootCmd)
+	description.Env = envVars
+	if describe.Handle(args, description) {
+		return nil
+	}
+
+	// Execute the root command; cobra reads os.Args when the arguments are nil, which are master-mold's in-process
+	if args == nil {
+		args = []string{}
+	}
+	rootCmd.SetArgs(args)
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	defer cancelCommandContext()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		logger.Error("Error executing command", "error", err.Error())
+		return err
+	}
+
+	logger.Info("Azure DevOps subcommand completed successfully")
+	return nil
+}
+
+// NewRootCommand creates the command tree of the subcommand
+func NewRootCommand() *cobra.Command {
+	// Create the root command
+	var rootCmd = &cobra.Command{
+		Use:     "azure-devops",
+		Short:   "Manage Azure DevOps work items",
+		Long:    "Provides commands to create and manage work items in Azure DevOps.",
+		Aliases: []string{"ado"},
+		Version: Version,
+		// Set up the context and HTTP transport of the API calls
+		PersistentPreRunE: setupCommand,
+		// Warn about PATs that are about to expire once a command succeeded
+		PersistentPostRun: warnPATExpiry,
+	}
+
+	// Create the work-items subcommand
+	var workItemsCmd = &cobra.Command{
+		Use:   "work-items",
+		Short: "Manage work items in Azure DevOps",
+		Long:  "Create and manage work items in Azure DevOps.",
+	}
+
+	// Create the create subcommand
+	var createCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Create work items from a JSON file",
+		Long:  "Creates work items in Azure DevOps based on data provided in a JSON file.",
+		RunE:  createWorkItems,
+	}
+
+	// Create the template subcommand
+	var templateCmd = &cobra.Command{
+		Use:   "template",
+		Short: "Generate a template JSON file for creating work items",
+		Long:  "Generates a template JSON file that can be used as a starting point for creating work items.",
+		RunE:  generateWorkItemTemplate,
+	}
+
+	// Create the assigned subcommand
+	var assignedCmd = &cobra.Command{
+		Use:   "assigned",
+		Short: "List work items assigned to a user",
+		Long:  "Lists all work items assigned to a user and displays the work item and time logged.",
+		RunE:  listAssignedWorkItems,
+	}
+
+	// Create the recycle-bin subcommand
+	var recycleBinCmd = &cobra.Command{
+		Use:   "recycle-bin",
+		Short: "Inspect deleted work items",
+		Long:  "Provides commands to inspect work items in the Azure DevOps recycle bin.",
+	}
+
+	// Create the recycle-bin list subcommand
+	var recycleBinListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List deleted work items",
+		Long:  "Lists all work items in the recycle bin of the project.",
+		RunE:  listRecycleBinWorkItems,
+	}
+
+	// Create the restore subcommand
+	var restoreCmd = &cobra.Command{
+		Use:   "restore <id>",
+		Short: "Restore a deleted work item",
+		Long:  "Restores a work item from the recycle bin of the project.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  restoreDeletedWorkItem,
+	}
+
+	// Create the pull-requests subcommand
+	var prCmd = &cobra.Command{
+		Use:   "pull-requests",
+		Short: "Manage pull requests",
+		Long:  "Provides commands to manage pull requests in Azure DevOps.",
+	}
+
+	// Create the list-open subcommand
+	var listOpenCmd = &cobra.Command{
+		Use:   "list-open",
+		Short: "List all open pull requests",
+		Long:  "Lists all open pull requests for all repositories in the organization.",
+		RunE:  listOpenPullRequests,
+	}
+
+	// Create the mine subcommand
+	var mineCmd = &cobra.Command{
+		Use:   "mine",
+		Short: "List my open pull requests",
+		Long:  "Lists the open pull requests created by the current user, or with --as-reviewer the ones awaiting the current user's vote, oldest first.",
+		RunE:  listMyPullRequests,
+	}
+
+	// Create the stats subcommand
+	var statsCmd = &cobra.Command{
+		Use:   "stats",
+		Short: "Show pull request review and merge statistics",
+		Long:  "Computes the average and median time to first review and time to merge, pull request counts per repository and reviewer participation for recent pull requests.",
+		RunE:  pullRequestStatsCommand,
+	}
+
+	// Create the show subcommand
+	var showCmd = &cobra.Command{
+		Use:   "show <repo> <id>",
+		Short: "Show the details of a pull request",
+		Long:  "Shows the description, reviewers and their votes, linked work items, commits and changed files of a pull request.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  showPullRequestCommand,
+	}
+
+	// Create the diff subcommand
+	var diffCmd = &cobra.Command{
+		Use:   "diff <repo> <id>",
+		Short: "Show the diff of a pull request",
+		Long:  "Prints a unified diff of the files changed by the latest iteration of a pull request, or a per-file change summary with --stat.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  diffPullRequestCommand,
+	}
+
+	// Create the checks subcommand
+	var checksCmd = &cobra.Command{
+		Use:   "checks <repo> <id>",
+		Short: "List the policy and status checks of a pull request",
+		Long:  "Lists the branch policy evaluations and status checks of a pull request with their pass/fail state, highlighting the ones blocking completion.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  listPullRequestChecksCommand,
+	}
+
+	// Create the conflicts subcommand
+	var conflictsCmd = &cobra.Command{
+		Use:   "conflicts <repo> <id>",
+		Short: "Check a pull request for merge conflicts",
+		Long:  "Shows the merge status of a pull request and lists the conflicting files if the merge is blocked by conflicts.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  listPullRequestConflictsCommand,
+	}
+
+	// Create the complete subcommand
+	var completeCmd = &cobra.Command{
+		Use:   "complete <repo> <id>",
+		Short: "Complete (merge) a pull request",
+		Long:  "Completes a pull request using the selected merge strategy, optionally deleting the source branch and transitioning linked work items.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  completePullRequestCommand,
+	}
+
+	// Create the draft subcommand
+	var draftCmd = &cobra.Command{
+		Use:   "draft <repo> <id>",
+		Short: "Mark a pull request as draft or publish it",
+		Long:  "Marks a pull request as draft with --on, or publishes a draft pull request with --off.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  draftPullRequestCommand,
+	}
+
+	// Create the autocomplete subcommand
+	var autoCompleteCmd = &cobra.Command{
+		Use:   "autocomplete <repo> <id>",
+		Short: "Enable auto-complete on a pull request",
+		Long:  "Sets you as the auto-complete user of a pull request so that it is completed with the selected merge strategy once all policies pass.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  autoCompletePullRequestCommand,
+	}
+
+	// Create the cherry-pick subcommand
+	var cherryPickCmd = &cobra.Command{
+		Use:   "cherry-pick <repo> <id>",
+		Short: "Cherry-pick a completed pull request onto another branch",
+		Long:  "Cherry-picks the changes of a completed pull request onto a topic branch created from the target branch, then opens a pull request into the target branch.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  cherryPickPullRequestCommand,
+	}
+
+	// Create the abandon subcommand
+	var abandonCmd = &cobra.Command{
+		Use:   "abandon <repo> <id>",
+		Short: "Abandon a pull request",
+		Long:  "Abandons an active pull request without merging it.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  abandonPullRequestCommand,
+	}
+
+	// Create the reactivate subcommand
+	var reactivateCmd = &cobra.Command{
+		Use:   "reactivate <repo> <id>",
+		Short: "Reactivate an abandoned pull request",
+		Long:  "Reactivates a previously abandoned pull request.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  reactivatePullRequestCommand,
+	}
+
+	// Create the link subcommand
+	var linkCmd = &cobra.Command{
+		Use:   "link <repo> <id>",
+		Short: "Link a pull request to work items",
+		Long:  "Adds an artifact link to the pull request on each work item, so the pull request shows up on the work item and can drive completion rules.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  linkPullRequestCommand,
+	}
+
+	// Create the comments subcommand
+	var commentsCmd = &cobra.Command{
+		Use:   "comments",
+		Short: "Manage pull request comments",
+		Long:  "Provides commands to list and add pull request comment threads.",
+	}
+
+	// Create the comments list subcommand
+	var commentsListCmd = &cobra.Command{
+		Use:   "list <repo> <id>",
+		Short: "List the comment threads of a pull request",
+		Long:  "Lists the comment threads of a pull request with their status.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  listPullRequestCommentsCommand,
+	}
+
+	// Create the comments add subcommand
+	var commentsAddCmd = &cobra.Command{
+		Use:   "add <repo> <id>",
+		Short: "Add a comment to a pull request",
+		Long:  "Adds a new comment thread to a pull request, optionally anchored to a file and line.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  addPullRequestCommentCommand,
+	}
+
+	// Create the threads subcommand
+	var threadsCmd = &cobra.Command{
+		Use:   "threads",
+		Short: "Manage pull request comment threads",
+		Long:  "Provides commands to resolve and reactivate pull request comment threads.",
+	}
+
+	// Create the threads resolve subcommand
+	var threadsResolveCmd = &cobra.Command{
+		Use:   "resolve <repo> <id> <thread-id>",
+		Short: "Resolve a comment thread",
+		Long:  "Resolves a comment thread of a pull request once the conversation has been addressed.",
+		Args:  cobra.ExactArgs(3),
+		RunE:  resolvePullRequestThreadCommand,
+	}
+
+	// Create the threads reactivate subcommand
+	var threadsReactivateCmd = &cobra.Command{
+		Use:   "reactivate <repo> <id> <thread-id>",
+		Short: "Reactivate a resolved comment thread",
+		Long:  "Reactivates a resolved comment thread of a pull request.",
+		Args:  cobra.ExactArgs(3),
+		RunE:  reactivatePullRequestThreadCommand,
+	}
+
+	// Create the reviewers subcommand
+	var reviewersCmd = &cobra.Command{
+		Use:   "reviewers",
+		Short: "Manage pull request reviewers",
+		Long:  "Provides commands to add and remove pull request reviewers.",
+	}
+
+	// Create the reviewers add subcommand
+	var reviewersAddCmd = &cobra.Command{
+		Use:   "add <repo> <id> <user>...",
+		Short: "Add reviewers to a pull request",
+		Long:  "Adds one or more users, identified by name, email address or ID, as reviewers of a pull request.",
+		Args:  cobra.MinimumNArgs(3),
+		RunE:  addPullRequestReviewersCommand,
+	}
+
+	// Create the reviewers remove subcommand
+	var reviewersRemoveCmd = &cobra.Command{
+		Use:   "remove <repo> <id> <user>...",
+		Short: "Remove reviewers from a pull request",
+		Long:  "Removes one or more users, identified by name, email address or ID, from the reviewers of a pull request.",
+		Args:  cobra.MinimumNArgs(3),
+		RunE:  removePullRequestReviewersCommand,
+	}
+
+	// Create the pipelines subcommand
+	var pipelinesCmd = &cobra.Command{
+		Use:   "pipelines",
+		Short: "Manage pipelines",
+		Long:  "Provides commands to list and run pipelines in Azure DevOps.",
+	}
+
+	// Create the pipelines list subcommand
+	var pipelinesListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List pipeline definitions",
+		Long:  "Lists the pipeline definitions of the project.",
+		RunE:  listPipelinesCommand,
+	}
+
+	// Create the pipelines run subcommand
+	var pipelinesRunCmd = &cobra.Command{
+		Use:   "run <pipeline>",
+		Short: "Run a pipeline",
+		Long:  "Queues a run of a pipeline, given by ID or name, optionally for a branch and with template parameters and variables.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runPipelineCommand,
+	}
+
+	// Create the pipelines status subcommand
+	var pipelinesStatusCmd = &cobra.Command{
+		Use:   "status <run-id>",
+		Short: "Show the status of a pipeline run",
+		Long:  "Shows the status and result of a pipeline run and of its stages and jobs.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  pipelineStatusCommand,
+	}
+
+	// Create the pipelines logs subcommand
+	var pipelinesLogsCmd = &cobra.Command{
+		Use:   "logs <run-id>",
+		Short: "Print the logs of a pipeline run",
+		Long:  "Prints the logs of a pipeline run in timeline order. With --follow, polls the run and streams new log lines until it completes.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  pipelineLogsCommand,
+	}
+
+	// Create the releases subcommand
+	var releasesCmd = &cobra.Command{
+		Use:   "releases",
+		Short: "Manage classic releases",
+		Long:  "Provides commands to list release definitions and releases, and to create releases in Azure DevOps.",
+	}
+
+	// Create the releases definitions subcommand
+	var releasesDefinitionsCmd = &cobra.Command{
+		Use:   "definitions",
+		Short: "List release definitions",
+		Long:  "Lists the release definitions of the project with their stages.",
+		RunE:  listReleaseDefinitionsCommand,
+	}
+
+	// Create the releases list subcommand
+	var releasesListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List releases",
+		Long:  "Lists the most recent releases of the project with the status of each stage.",
+		RunE:  listReleasesCommand,
+	}
+
+	// Create the releases create subcommand
+	var releasesCreateCmd = &cobra.Command{
+		Use:   "create <definition>",
+		Short: "Create a release",
+		Long:  "Creates a release of a release definition, given by ID or name. Stages can be left for manual deployment and given their own variable values.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  createReleaseCommand,
+	}
+
+	// Create the repos subcommand
+	var reposCmd = &cobra.Command{
+		Use:   "repos",
+		Short: "Manage Git repositories",
+		Long:  "Provides commands to work with Git repositories in Azure DevOps.",
+	}
+
+	// Create the repos list subcommand
+	var reposListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List Git repositories",
+		Long:  "Lists the Git repositories of every project with their default branch, size and web URL.",
+		RunE:  listRepositoriesCommand,
+	}
+
+	// Create the repos clone subcommand
+	var reposCloneCmd = &cobra.Command{
+		Use:   "clone <project>/<repo> [directory]",
+		Short: "Clone a Git repository",
+		Long:  "Clones a Git repository with git, authenticating with the Personal Access Token. With --all, the first argument is a project and every repository of the project is cloned into the directory.",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE:  cloneRepositoryCommand,
+	}
+
+	// Create the repos branches subcommand
+	var reposBranchesCmd = &cobra.Command{
+		Use:   "branches",
+		Short: "Manage branches",
+		Long:  "Provides commands to list, create and delete branches of a Git repository.",
+	}
+
+	// Create the repos branches list subcommand
+	var reposBranchesListCmd = &cobra.Command{
+		Use:   "list <repo>",
+		Short: "List branches",
+		Long:  "Lists the branches of a repository with how far each is ahead of and behind the default branch.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  listBranchesCommand,
+	}
+
+	// Create the repos branches create subcommand
+	var reposBranchesCreateCmd = &cobra.Command{
+		Use:   "create <repo> <branch>",
+		Short: "Create a branch",
+		Long:  "Creates a branch from another branch or a commit, defaulting to the default branch of the repository.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  createBranchCommand,
+	}
+
+	// Create the repos branches delete subcommand
+	var reposBranchesDeleteCmd = &cobra.Command{
+		Use:   "delete <repo> <branch>",
+		Short: "Delete a branch",
+		Long:  "Deletes a branch from a repository.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  deleteBranchCommand,
+	}
+
+	// Create the repos policies subcommand
+	var reposPoliciesCmd = &cobra.Command{
+		Use:   "policies",
+		Short: "Manage branch policies",
+		Long:  "Provides commands to view and configure the policies of a branch.",
+	}
+
+	// Create the repos policies list subcommand
+	var reposPoliciesListCmd = &cobra.Command{
+		Use:   "list <repo> <branch>",
+		Short: "List branch policies",
+		Long:  "Lists the policies that apply to a branch of a repository, including policies set for the whole project.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  listBranchPoliciesCommand,
+	}
+
+	// Create the repos policies set subcommand
+	var reposPoliciesSetCmd = &cobra.Command{
+		Use:   "set <repo> <branch>",
+		Short: "Configure branch policies",
+		Long:  "Creates or updates the minimum reviewers, build validation and linked work items policies of a branch. Only the given policies are changed; --min-reviewers 0 and --work-items=false remove the policy.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  setBranchPoliciesCommand,
+	}
+
+	// Create the artifacts subcommand
+	var artifactsCmd = &cobra.Command{
+		Use:   "artifacts",
+		Short: "Manage Azure Artifacts feeds",
+		Long:  "Provides commands to list Azure Artifacts feeds and packages, and to download packages.",
+	}
+
+	// Create the artifacts feeds subcommand
+	var artifactsFeedsCmd = &cobra.Command{
+		Use:   "feeds",
+		Short: "List feeds",
+		Long:  "Lists the organization-scoped feeds and the feeds of the project.",
+		RunE:  listFeedsCommand,
+	}
+
+	// Create the artifacts packages subcommand
+	var artifactsPackagesCmd = &cobra.Command{
+		Use:   "packages <feed>",
+		Short: "List packages",
+		Long:  "Lists the packages of a feed with their latest version.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  listPackagesCommand,
+	}
+
+	// Create the artifacts download subcommand
+	var artifactsDownloadCmd = &cobra.Command{
+		Use:   "download <feed> <package> <version>",
+		Short: "Download a package",
+		Long:  "Downloads a version of a NuGet or npm package from a feed.",
+		Args:  cobra.ExactArgs(3),
+		RunE:  downloadPackageCommand,
+	}
+
+	// Create the tests subcommand
+	var testsCmd = &cobra.Command{
+		Use:   "tests",
+		Short: "Show test plans and results",
+		Long:  "Provides commands to list test plans and suites, and to show the results of recent test runs.",
+	}
+
+	// Create the tests plans subcommand
+	var testsPlansCmd = &cobra.Command{
+		Use:   "plans",
+		Short: "List test plans",
+		Long:  "Lists the test plans of the project.",
+		RunE:  listTestPlansCommand,
+	}
+
+	// Create the tests suites subcommand
+	var testsSuitesCmd = &cobra.Command{
+		Use:   "suites <plan-id>",
+		Short: "List test suites",
+		Long:  "Lists the test suites of a test plan as a tree.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  listTestSuitesCommand,
+	}
+
+	// Create the tests runs subcommand
+	var testsRunsCmd = &cobra.Command{
+		Use:   "runs",
+		Short: "List recent test runs",
+		Long:  "Lists the most recent test runs of the project with their pass/fail counts.",
+		RunE:  listTestRunsCommand,
+	}
+
+	// Create the wiki subcommand
+	var wikiCmd = &cobra.Command{
+		Use:   "wiki",
+		Short: "Read and publish wiki pages",
+		Long:  "Provides commands to read and publish wiki pages, so documentation can be published from scripts and CI.",
+	}
+
+	// Create the wiki get subcommand
+	var wikiGetCmd = &cobra.Command{
+		Use:   "get <path>",
+		Short: "Print a wiki page",
+		Long:  "Prints the Markdown content of a wiki page.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  getWikiPageCommand,
+	}
+
+	// Create the wiki put subcommand
+	var wikiPutCmd = &cobra.Command{
+		Use:   "put <path>",
+		Short: "Create or update a wiki page",
+		Long:  "Creates a wiki page, or updates it when it exists, with the Markdown content of a file.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  putWikiPageCommand,
+	}
+
+	// Create the iterations subcommand
+	var iterationsCmd = &cobra.Command{
+		Use:   "iterations",
+		Short: "Manage iterations",
+		Long:  "Provides commands to provision the iterations (sprints) of the project.",
+	}
+
+	// Create the iterations create subcommand
+	var iterationsCreateCmd = &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create an iteration",
+		Long:  "Creates an iteration, optionally under a parent iteration and with start and finish dates.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  createIterationCommand,
+	}
+
+	// Create the iterations update subcommand
+	var iterationsUpdateCmd = &cobra.Command{
+		Use:   "update <path>",
+		Short: "Update an iteration",
+		Long:  "Renames an iteration or changes its start and finish dates.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  updateIterationCommand,
+	}
+
+	// Create the areas subcommand
+	var areasCmd = &cobra.Command{
+		Use:   "areas",
+		Short: "Manage areas",
+		Long:  "Provides commands to provision the area tree of the project.",
+	}
+
+	// Create the areas create subcommand
+	var areasCreateCmd = &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create an area",
+		Long:  "Creates an area, optionally under a parent area.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  createAreaCommand,
+	}
+
+	// Create the projects subcommand
+	var projectsCmd = &cobra.Command{
+		Use:   "projects",
+		Short: "Manage projects",
+		Long:  "Provides commands to work with the projects of the organization.",
+	}
+
+	// Create the projects list subcommand
+	var projectsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List projects",
+		Long:  "Lists the projects of the organization with their description and visibility.",
+		RunE:  listProjectsCommand,
+	}
+
+	// Create the repos commits subcommand
+	var reposCommitsCmd = &cobra.Command{
+		Use:   "commits <repo>",
+		Short: "List recent commits",
+		Long:  "Lists the recent commits of a branch with their author, date, message and linked work items.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  listCommitsCommand,
+	}
+
+	// Create the pipelines approvals subcommand
+	var pipelinesApprovalsCmd = &cobra.Command{
+		Use:   "approvals",
+		Short: "Manage pipeline approvals",
+		Long:  "Lists, approves and rejects the pending pipeline approvals assigned to you.",
+	}
+
+	// Create the pipelines approvals list subcommand
+	var pipelinesApprovalsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List pending approvals",
+		Long:  "Lists the pending environment and stage approvals assigned to you.",
+		RunE:  listApprovalsCommand,
+	}
+
+	// Create the pipelines approvals approve subcommand
+	var pipelinesApprovalsApproveCmd = &cobra.Command{
+		Use:   "approve <approval-id>",
+		Short: "Approve a pending approval",
+		Long:  "Approves a pending pipeline approval so the deployment can continue.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  approveApprovalCommand,
+	}
+
+	// Create the pipelines approvals reject subcommand
+	var pipelinesApprovalsRejectCmd = &cobra.Command{
+		Use:   "reject <approval-id>",
+		Short: "Reject a pending approval",
+		Long:  "Rejects a pending pipeline approval, which stops the deployment.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  rejectApprovalCommand,
+	}
+
+	// Create the pipelines vars subcommand
+	var pipelinesVarsCmd = &cobra.Command{
+		Use:   "vars",
+		Short: "Manage pipeline variables",
+		Long:  "Gets and sets the variables of a pipeline, or of a variable group with --group.",
+	}
+
+	// Create the pipelines vars get subcommand
+	var pipelinesVarsGetCmd = &cobra.Command{
+		Use:   "get <pipeline> <name>",
+		Short: "Get a variable",
+		Long:  "Prints the value of a pipeline variable. With --group the first argument is a variable group name. Secret values cannot be read.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  getPipelineVariableCommand,
+	}
+
+	// Create the pipelines vars set subcommand
+	var pipelinesVarsSetCmd = &cobra.Command{
+		Use:   "set <pipeline> <name> [value]",
+		Short: "Set a variable",
+		Long:  "Creates or updates a pipeline variable. With --group the first argument is a variable group name. The value is read from standard input when it is not given.",
+		Args:  cobra.RangeArgs(2, 3),
+		RunE:  setPipelineVariableCommand,
+	}
+
+	// Create the builds subcommand
+	var buildsCmd = &cobra.Command{
+		Use:   "builds",
+		Short: "Manage classic builds",
+		Long:  "Queues builds of classic build definitions.",
+	}
+
+	// Create the builds queue subcommand
+	var buildsQueueCmd = &cobra.Command{
+		Use:   "queue <definition>",
+		Short: "Queue a build",
+		Long:  "Queues a build of a classic build definition, given by ID or name, and prints the build ID and URL.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  queueBuildCommand,
+	}
+
+	// Create the audit subcommand
+	var auditCmd = &cobra.Command{
+		Use:   "audit",
+		Short: "Query the audit log",
+		Long:  "Queries the audit log of the organization for compliance reviews.",
+	}
+
+	// Create the audit query subcommand
+	var auditQueryCmd = &cobra.Command{
+		Use:   "query",
+		Short: "Query audit events",
+		Long:  "Lists the audit log events of the organization within a period, optionally only those of one actor.",
+		RunE:  queryAuditCommand,
+	}
+
+	// Create the auth subcommand
+	var authCmd = &cobra.Command{
+		Use:   "auth",
+		Short: "Manage authentication",
+		Long:  "Manages and checks the credentials used to connect to Azure DevOps.",
+	}
+
+	// Create the auth login subcommand
+	var authLoginCmd = &cobra.Command{
+		Use:   "login",
+		Short: "Store a Personal Access Token or Azure AD sign-in in the keyring",
+		Long:  "Prompts for a Personal Access Token, or signs in to Azure AD with a device code when --aad is given, checks the credential and stores it in the system keyring (Keychain, Credential Manager or libsecret). Commands read the token from the keyring before the environment variables.",
+		RunE:  loginCommand,
+	}
+
+	// Create the auth logout subcommand
+	var authLogoutCmd = &cobra.Command{
+		Use:   "logout",
+		Short: "Remove a Personal Access Token from the keyring",
+		Long:  "Removes the Personal Access Token of an organization from the system keyring.",
+		RunE:  logoutCommand,
+	}
+
+	// Create the auth whoami subcommand
+	var authWhoAmICmd = &cobra.Command{
+		Use:   "whoami",
+		Short: "Show the authenticated identity",
+		Long:  "Prints the authenticated identity, the organization and the credential in use, with the scopes and expiry of Azure AD tokens, to diagnose 401 and 403 errors.",
+		RunE:  whoAmICommand,
+	}
+
+	// Create the cache subcommand
+	var cacheCmd = &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the response cache",
+		Long:  "Manages the local cache of API responses, enabled with cache = true in the configuration file.",
+	}
+
+	// Create the cache clear subcommand
+	var cacheClearCmd = &cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached responses",
+		Long:  "Removes all API responses from the local cache.",
+		Args:  cobra.NoArgs,
+		RunE:  clearCacheCommand,
+	}
+
+	// Add flags to the commands
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Configuration profile to use (defaults to AZURE_DEVOPS_PROFILE)")
+	rootCmd.PersistentFlags().StringVar(&flagSettings.Organization, "org", "", "Organization to use, overriding the environment and configuration file")
+	rootCmd.PersistentFlags().StringVar(&flagSettings.Project, "project", "", "Project to use, overriding the environment and configuration file")
+	rootCmd.PersistentFlags().StringVar(&recordCassette, "record", "", "Record the API traffic to a cassette file")
+	rootCmd.PersistentFlags().StringVar(&replayCassette, "replay", "", "Replay the API traffic from a cassette file instead of calling Azure DevOps")
+	rootCmd.PersistentFlags().DurationVar(&commandTimeout, "timeout", 0, "Time limit of the command, such as 30s or 2m (no limit by default)")
+
+	createCmd.Flags().String("json", "", "Path to the JSON file containing work item definitions")
+	createCmd.MarkFlagRequired("json")
+
+	assignedCmd.Flags().String("user", "", "Username to filter work items by")
+	assignedCmd.MarkFlagRequired("user")
+	assignedCmd.Flags().Bool("json", false, "Output the results in JSON format")
+	assignedCmd.Flags().String("created-since", "", "Only include work items created on or after a date (YYYY-MM-DD, @Today or @Today-N)")
+	assignedCmd.Flags().String("changed-since", "", "Only include work items changed on or after a date (YYYY-MM-DD, @Today or @Today-N)")
+	assignedCmd.Flags().String("iteration", "", "Only include work items in an iteration path or @CurrentIteration[-N]")
+	assignedCmd.Flags().String("team", "", "Team used to resolve @CurrentIteration (defaults to the project's default team)")
+
+	recycleBinListCmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	listOpenCmd.Flags().Bool("json", false, "Output the results in JSON format")
+	listOpenCmd.Flags().Bool("table", false, "Output the results as a table (default when writing to a terminal)")
+	listOpenCmd.Flags().String("project", "", "Only list pull requests in this project")
+	listOpenCmd.Flags().String("repo", "", "Only list pull requests in this repository")
+	listOpenCmd.Flags().String("creator", "", "Only list pull requests created by this user (name, email or ID)")
+	listOpenCmd.Flags().String("reviewer", "", "Only list pull requests with this reviewer (name, email or ID)")
+	listOpenCmd.Flags().String("target-branch", "", "Only list pull requests targeting this branch")
+
+	commentsListCmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	commentsAddCmd.Flags().String("message", "", "The comment text")
+	commentsAddCmd.MarkFlagRequired("message")
+	commentsAddCmd.Flags().String("file", "", "Path of the file to comment on")
+	commentsAddCmd.Flags().Int("line", 0, "Line number in the file to comment on (requires --file)")
+
+	reviewersAddCmd.Flags().Bool("required", false, "Mark the reviewers as required")
+
+	mineCmd.Flags().Bool("as-reviewer", false, "List pull requests where I am a reviewer and have not voted yet")
+	mineCmd.Flags().String("project", "", "Only list pull requests in this project")
+	mineCmd.Flags().Bool("json", false, "Output the results in JSON format")
+	mineCmd.Flags().Bool("table", false, "Output the results as a table (default when writing to a terminal)")
+
+	statsCmd.Flags().String("since", "90d", "Only include pull requests created within this period (e.g. 90d, 2w, 36h)")
+	statsCmd.Flags().String("project", "", "Only include pull requests in this project")
+	statsCmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	showCmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	checksCmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	completeCmd.Flags().Bool("squash", false, "Squash the pull request commits into a single commit")
+	completeCmd.Flags().Bool("rebase", false, "Rebase the source branch onto the target branch and fast-forward")
+	completeCmd.Flags().Bool("delete-source-branch", false, "Delete the source branch after completion")
+	completeCmd.Flags().Bool("transition-work-items", false, "Transition linked work items to their next state")
+	completeCmd.Flags().String("message", "", "Commit message for the merge commit")
+
+	draftCmd.Flags().Bool("on", false, "Mark the pull request as draft")
+	draftCmd.Flags().Bool("off", false, "Publish the draft pull request")
+
+	autoCompleteCmd.Flags().Bool("squash", false, "Squash the pull request commits into a single commit")
+	autoCompleteCmd.Flags().Bool("rebase", false, "Rebase the source branch onto the target branch and fast-forward")
+	autoCompleteCmd.Flags().Bool("delete-source-branch", false, "Delete the source branch after completion")
+	autoCompleteCmd.Flags().Bool("transition-work-items", false, "Transition linked work items to their next state")
+	autoCompleteCmd.Flags().String("message", "", "Commit message for the merge commit")
+	autoCompleteCmd.Flags().Bool("cancel", false, "Cancel auto-complete instead of enabling it")
+
+	diffCmd.Flags().Bool("stat", false, "Only show a summary of the inserted and deleted lines per file")
+
+	linkCmd.Flags().IntSlice("work-item", nil, "ID of a work item to link (can be repeated or comma-separated)")
+
+	threadsResolveCmd.Flags().String("status", "fixed", "Resolution status: fixed, wontFix, closed or byDesign")
+
+	cherryPickCmd.Flags().String("target", "", "Branch to cherry-pick the pull request onto")
+	cherryPickCmd.MarkFlagRequired("target")
+	cherryPickCmd.Flags().String("branch", "", "Name of the topic branch to create (default cherry-pick/<id>-<target>)")
+
+	conflictsCmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	pipelinesListCmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	pipelinesRunCmd.Flags().String("branch", "", "Branch to run the pipeline for (default is the pipeline's default branch)")
+	pipelinesRunCmd.Flags().StringArray("param", nil, "Template parameter as key=value (can be repeated)")
+	pipelinesRunCmd.Flags().StringArray("var", nil, "Variable as key=value (can be repeated)")
+
+	pipelinesStatusCmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	pipelinesLogsCmd.Flags().BoolP("follow", "f", false, "Keep streaming new log lines until the run completes")
+
+	releasesDefinitionsCmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	releasesListCmd.Flags().Bool("json", false, "Output the results in JSON format")
+	releasesListCmd.Flags().String("definition", "", "Only list releases of this release definition (ID or name)")
+	releasesListCmd.Flags().Int("top", defaultReleaseCount, "Maximum number of releases to list")
+
+	releasesCreateCmd.Flags().String("description", "", "Description of the release")
+	releasesCreateCmd.Flags().StringArray("manual-stage", nil, "Stage to leave for manual deployment instead of deploying automatically (can be repeated)")
+	releasesCreateCmd.Flags().StringArray("var", nil, "Release variable as key=value (can be repeated)")
+	releasesCreateCmd.Flags().StringArray("stage-var", nil, "Stage variable as stage:key=value (can be repeated)")
+
+	reposListCmd.Flags().String("project", "", "Only list repositories in this project")
+	reposListCmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	reposCloneCmd.Flags().Bool("all", false, "Clone every repository of the project into the directory")
+
+	reposBranchesListCmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	reposBranchesCreateCmd.Flags().String("from", "", "Branch or full commit ID to create the branch from (default is the default branch)")
+
+	reposPoliciesListCmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	reposPoliciesSetCmd.Flags().Int("min-reviewers", 0, "Minimum number of reviewers (0 removes the policy)")
+	reposPoliciesSetCmd.Flags().Int("build", 0, "ID of a build definition that must succeed (build validation)")
+	reposPoliciesSetCmd.Flags().Bool("work-items", false, "Require linked work items (false removes the policy)")
+	reposPoliciesSetCmd.Flags().Bool("optional", false, "Make the policies optional instead of blocking")
+
+	artifactsFeedsCmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	artifactsPackagesCmd.Flags().Bool("json", false, "Output the results in JSON format")
+	artifactsPackagesCmd.Flags().String("query", "", "Only list packages whose name contains this text")
+	artifactsPackagesCmd.Flags().String("type", "", "Only list packages of this protocol type (e.g. NuGet, Npm)")
+
+	artifactsDownloadCmd.Flags().StringP("output", "o", "", "File to write the package to (default is the package file name)")
+
+	testsPlansCmd.Flags().Bool("json", false, "Output the results in JSON format")
+	testsPlansCmd.Flags().Bool("active", false, "Only list active test plans")
+
+	testsSuitesCmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	testsRunsCmd.Flags().Bool("json", false, "Output the results in JSON format")
+	testsRunsCmd.Flags().Int("plan", 0, "Only list runs of this test plan")
+	testsRunsCmd.Flags().Int("days", maxTestRunDays, "Number of days to look back (at most 7)")
+	testsRunsCmd.Flags().Int("top", defaultTestRunCount, "Maximum number of runs to list")
+
+	wikiGetCmd.Flags().String("wiki", "", "Name of the wiki (default is the project wiki)")
+
+	wikiPutCmd.Flags().String("wiki", "", "Name of the wiki (default is the project wiki)")
+	wikiPutCmd.Flags().StringP("file", "f", "", "Markdown file with the page content, or - for standard input")
+	wikiPutCmd.Flags().String("comment", "", "Comment for the wiki commit")
+	wikiPutCmd.MarkFlagRequired("file")
+
+	iterationsCreateCmd.Flags().String("parent", "", "Path of the parent iteration (default is the root)")
+	iterationsCreateCmd.Flags().String("start", "", "Start date as YYYY-MM-DD")
+	iterationsCreateCmd.Flags().String("finish", "", "Finish date as YYYY-MM-DD")
+
+	iterationsUpdateCmd.Flags().String("name", "", "New name of the iteration")
+	iterationsUpdateCmd.Flags().String("start", "", "Start date as YYYY-MM-DD")
+	iterationsUpdateCmd.Flags().String("finish", "", "Finish date as YYYY-MM-DD")
+
+	areasCreateCmd.Flags().String("parent", "", "Path of the parent area (default is the root)")
+
+	projectsListCmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	reposCommitsCmd.Flags().String("branch", "", "Branch to list commits of (default is the default branch)")
+	reposCommitsCmd.Flags().String("since", "7d", "Only list commits made within this period (e.g. 7d, 2w, 36h)")
+	reposCommitsCmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	pipelinesApprovalsListCmd.Flags().Bool("json", false, "Output the results in JSON format")
+	pipelinesApprovalsApproveCmd.Flags().String("comment", "", "Comment to record with the approval")
+	pipelinesApprovalsRejectCmd.Flags().String("comment", "", "Comment to record with the rejection")
+
+	pipelinesVarsGetCmd.Flags().Bool("group", false, "Get the variable from the variable group named by the first argument")
+	pipelinesVarsSetCmd.Flags().Bool("group", false, "Set the variable in the variable group named by the first argument")
+	pipelinesVarsSetCmd.Flags().Bool("secret", false, "Store the value as a secret")
+	pipelinesVarsSetCmd.Flags().Bool("allow-override", false, "Allow the value to be overridden when queueing a run (pipelines only)")
+
+	buildsQueueCmd.Flags().String("branch", "", "Branch to build (default is the definition's default branch)")
+	buildsQueueCmd.Flags().StringArray("param", nil, "Build parameter as key=value (can be repeated)")
+	buildsQueueCmd.Flags().Bool("json", false, "Output the queued build in JSON format")
+
+	auditQueryCmd.Flags().String("since", "7d", "Only list events within this period (e.g. 7d, 2w, 36h)")
+	auditQueryCmd.Flags().String("actor", "", "Only list events of this actor (display name, email or ID)")
+	auditQueryCmd.Flags().Bool("json", false, "Output the results in JSON format")
+	auditQueryCmd.Flags().Bool("table", false, "Output the results as a table (the default when writing to a terminal)")
+
+	authLoginCmd.Flags().Bool("aad", false, "Sign in to Azure AD with a device code instead of storing a PAT")
+	authLoginCmd.Flags().String("tenant", defaultAADTenant, "Azure AD tenant to sign in to (with --aad)")
+	authLoginCmd.Flags().String("client-id", defaultAADClientID, "Azure AD application to sign in with (with --aad)")
+
+	authWhoAmICmd.Flags().Bool("json", false, "Output the results in JSON format")
+
+	// Add subcommands to their parent commands
+	workItemsCmd.AddCommand(createCmd)
+	workItemsCmd.AddCommand(templateCmd)
+	workItemsCmd.AddCommand(assignedCmd)
+	workItemsCmd.AddCommand(recycleBinCmd)
+	workItemsCmd.AddCommand(restoreCmd)
+	recycleBinCmd.AddCommand(recycleBinListCmd)
+	prCmd.AddCommand(listOpenCmd)
+	prCmd.AddCommand(mineCmd)
+	prCmd.AddCommand(statsCmd)
+	prCmd.AddCommand(showCmd)
+	prCmd.AddCommand(checksCmd)
+	prCmd.AddCommand(completeCmd)
+	prCmd.AddCommand(abandonCmd)
+	prCmd.AddCommand(reactivateCmd)
+	prCmd.AddCommand(commentsCmd)
+	commentsCmd.AddCommand(commentsListCmd)
+	commentsCmd.AddCommand(commentsAddCmd)
+	prCmd.AddCommand(reviewersCmd)
+	reviewersCmd.AddCommand(reviewersAddCmd)
+	reviewersCmd.AddCommand(reviewersRemoveCmd)
+
+	prCmd.AddCommand(draftCmd)
+	prCmd.AddCommand(autoCompleteCmd)
+	prCmd.AddCommand(diffCmd)
+	prCmd.AddCommand(linkCmd)
+	prCmd.AddCommand(threadsCmd)
+	threadsCmd.AddCommand(threadsResolveCmd)
+	threadsCmd.AddCommand(threadsReactivateCmd)
+	prCmd.AddCommand(cherryPickCmd)
+	prCmd.AddCommand(conflictsCmd)
+	pipelinesCmd.AddCommand(pipelinesListCmd)
+	pipelinesCmd.AddCommand(pipelinesRunCmd)
+	pipelinesCmd.AddCommand(pipelinesStatusCmd)
+	pipelinesCmd.AddCommand(pipelinesLogsCmd)
+	releasesCmd.AddCommand(releasesDefinitionsCmd)
+	releasesCmd.AddCommand(releasesListCmd)
+	releasesCmd.AddCommand(releasesCreateCmd)
+	reposCmd.AddCommand(reposListCmd)
+	reposCmd.AddCommand(reposCloneCmd)
+	reposCmd.AddCommand(reposBranchesCmd)
+	reposBranchesCmd.AddCommand(reposBranchesListCmd)
+	reposBranchesCmd.AddCommand(reposBranchesCreateCmd)
+	reposBranchesCmd.AddCommand(reposBranchesDeleteCmd)
+	reposCmd.AddCommand(reposPoliciesCmd)
+	reposPoliciesCmd.AddCommand(reposPoliciesListCmd)
+	reposPoliciesCmd.AddCommand(reposPoliciesSetCmd)
+	artifactsCmd.AddCommand(artifactsFeedsCmd)
+	artifactsCmd.AddCommand(artifactsPackagesCmd)
+	artifactsCmd.AddCommand(artifactsDownloadCmd)
+	testsCmd.AddCommand(testsPlansCmd)
+	testsCmd.AddCommand(testsSuitesCmd)
+	testsCmd.AddCommand(testsRunsCmd)
+	wikiCmd.AddCommand(wikiGetCmd)
+	wikiCmd.AddCommand(wikiPutCmd)
+	iterationsCmd.AddCommand(iterationsCreateCmd)
+	iterationsCmd.AddCommand(iterationsUpdateCmd)
+	areasCmd.AddCommand(areasCreateCmd)
+	projectsCmd.AddCommand(projectsListCmd)
+	reposCmd.AddCommand(reposCommitsCmd)
+	pipelinesApprovalsCmd.AddCommand(pipelinesApprovalsListCmd)
+	pipelinesApprovalsCmd.AddCommand(pipelinesApprovalsApproveCmd)
+	pipelinesApprovalsCmd.AddCommand(pipelinesApprovalsRejectCmd)
+	pipelinesCmd.AddCommand(pipelinesApprovalsCmd)
+	pipelinesVarsCmd.AddCommand(pipelinesVarsGetCmd)
+	pipelinesVarsCmd.AddCommand(pipelinesVarsSetCmd)
+	pipelinesCmd.AddCommand(pipelinesVarsCmd)
+	buildsCmd.AddCommand(buildsQueueCmd)
+	auditCmd.AddCommand(auditQueryCmd)
+	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authLogoutCmd)
+	authCmd.AddCommand(authWhoAmICmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(workItemsCmd)
+	rootCmd.AddCommand(prCmd)
+	rootCmd.AddCommand(pipelinesCmd)
+	rootCmd.AddCommand(releasesCmd)
+	rootCmd.AddCommand(reposCmd)
+	rootCmd.AddCommand(artifactsCmd)
+	rootCmd.AddCommand(testsCmd)
+	rootCmd.AddCommand(wikiCmd)
+	rootCmd.AddCommand(iterationsCmd)
+	rootCmd.AddCommand(areasCmd)
+	rootCmd.AddCommand(projectsCmd)
+	rootCmd.AddCommand(buildsCmd)
+	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(authCmd)
+	rootCmd.AddCommand(cacheCmd)
+
+	return rootCmd
+}
+
+// Note: The implementations for the command handlers (createWorkItems and generateWorkItemTemplate)
+// are defined in separate files (create.go and template.go)
@@ -0,0 +1,172 @@
+package azuredevops
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Environment variables of service principal authentication, as used by the Azure SDKs
+const (
+	EnvAzureTenantID          = "AZURE_TENANT_ID"
+	EnvAzureClientID          = "AZURE_CLIENT_ID"
+	EnvAzureClientSecret      = "AZURE_CLIENT_SECRET"
+	EnvAzureClientCertificate = "AZURE_CLIENT_CERTIFICATE_PATH"
+)
+
+// tokenSourceServicePrincipal is the token source of tokens issued to a service principal
+const tokenSourceServicePrincipal = "service-principal"
+
+// clientAssertionType is the OAuth 2.0 client assertion type of certificate credentials
+const clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// clientAssertionLifetime is how long a certificate client assertion is valid
+const clientAssertionLifetime = 10 * time.Minute
+
+// servicePrincipal holds the credentials of an Azure AD application used for unattended automation
+type servicePrincipal struct {
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+	// ClientCertificate is a PEM file with the certificate and the RSA private key of the application
+	ClientCertificate string
+}
+
+// configured checks if a service principal is set up with a tenant, a client and a credential
+func (sp servicePrincipal) configured() bool {
+	return sp.TenantID != "" && sp.ClientID != "" && (sp.ClientSecret != "" || sp.ClientCertificate != "")
+}
+
+// servicePrincipalToken gets an Azure DevOps access token for a service principal with the client credentials flow
+func servicePrincipalToken(sp servicePrincipal) (string, error) {
+	endpoint := aadEndpoint(sp.TenantID, "token")
+	form := url.Values{
+		"grant_type": {"client_credentials"},
+		"client_id":  {sp.ClientID},
+		"scope":      {azureDevOpsResource + "/.default"},
+	}
+
+	if sp.ClientCertificate != "" {
+		assertion, err := certificateClientAssertion(sp.ClientCertificate, sp.ClientID, endpoint, time.Now())
+		if err != nil {
+			return "", err
+		}
+		form.Set("client_assertion_type", clientAssertionType)
+		form.Set("client_assertion", assertion)
+	} else {
+		form.Set("client_secret", sp.ClientSecret)
+	}
+
+	var response tokenResponse
+	if err := postAADForm(endpoint, form, &response); err != nil {
+		return "", err
+	}
+	if response.Error != "" {
+		return "", errors.Errorf("service principal sign-in failed: %s: %s", response.Error, response.ErrorDescription)
+	}
+	return response.AccessToken, nil
+}
+
+// certificateClientAssertion builds the signed JWT that proves possession of the certificate of an application
+func certificateClientAssertion(path, clientID, audience string, now time.Time) (string, error) {
+	certificate, key, err := loadClientCertificate(path)
+	if err != nil {
+		return "", err
+	}
+
+	thumbprint := sha1.Sum(certificate.Raw)
+	header := map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"x5t": base64.RawURLEncoding.EncodeToString(thumbprint[:]),
+	}
+
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", errors.Wrap(err, "failed to generate assertion ID")
+	}
+	claims := map[string]interface{}{
+		"aud": audience,
+		"iss": clientID,
+		"sub": clientID,
+		"jti": hex.EncodeToString(jti),
+		"nbf": now.Unix(),
+		"exp": now.Add(clientAssertionLifetime).Unix(),
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign client assertion")
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// loadClientCertificate reads the certificate and the RSA private key of an application from a PEM file
+func loadClientCertificate(path string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read client certificate %s", path)
+	}
+
+	var certificate *x509.Certificate
+	var key *rsa.PrivateKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case "CERTIFICATE":
+			if certificate == nil {
+				if certificate, err = x509.ParseCertificate(block.Bytes); err != nil {
+					return nil, nil, errors.Wrapf(err, "invalid certificate in %s", path)
+				}
+			}
+		case "RSA PRIVATE KEY":
+			if key, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+				return nil, nil, errors.Wrapf(err, "invalid private key in %s", path)
+			}
+		case "PRIVATE KEY":
+			parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "invalid private key in %s", path)
+			}
+			rsaKey, ok := parsed.(*rsa.PrivateKey)
+			if !ok {
+				return nil, nil, errors.Errorf("the private key in %s is not an RSA key", path)
+			}
+			key = rsaKey
+		}
+	}
+
+	if certificate == nil || key == nil {
+		return nil, nil, errors.Errorf("%s must contain a certificate and its private key", path)
+	}
+	return certificate, key, nil
+}
@@ -0,0 +1,127 @@
+package azuredevops
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTestClientCertificate writes a self-signed certificate and its PKCS #8 private key to a PEM file
+func writeTestClientCertificate(t *testing.T) (string, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "automation"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})
+	data = append(data, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})...)
+	path := filepath.Join(t.TempDir(), "automation.pem")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	return path, key
+}
+
+func TestServicePrincipalTokenWithSecret(t *testing.T) {
+	useAADServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+		if r.URL.Path != "/contoso/oauth2/v2.0/token" {
+			t.Errorf("request path = %s, want the token endpoint of the tenant", r.URL.Path)
+		}
+		if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("client_id") != "app" || r.Form.Get("client_secret") != "secret" {
+			t.Errorf("unexpected form %v", r.Form)
+		}
+		json.NewEncoder(w).Encode(tokenResponse{AccessToken: "sp-token", ExpiresIn: 3600})
+	})
+
+	token, err := servicePrincipalToken(servicePrincipal{TenantID: "contoso", ClientID: "app", ClientSecret: "secret"})
+	if err != nil || token != "sp-token" {
+		t.Errorf("servicePrincipalToken() = %q, %v, want sp-token", token, err)
+	}
+}
+
+func TestServicePrincipalTokenError(t *testing.T) {
+	useAADServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(tokenResponse{Error: "invalid_client", ErrorDescription: "Invalid client secret"})
+	})
+
+	_, err := servicePrincipalToken(servicePrincipal{TenantID: "contoso", ClientID: "app", ClientSecret: "wrong"})
+	if err == nil || !strings.Contains(err.Error(), "invalid_client") {
+		t.Errorf("servicePrincipalToken() error = %v, want invalid_client", err)
+	}
+}
+
+func TestCertificateClientAssertion(t *testing.T) {
+	path, key := writeTestClientCertificate(t)
+	now := time.Unix(1714561200, 0)
+
+	assertion, err := certificateClientAssertion(path, "app", "https://login.example.com/token", now)
+	if err != nil {
+		t.Fatalf("certificateClientAssertion() error = %v", err)
+	}
+
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 {
+		t.Fatalf("certificateClientAssertion() = %q, want a JWT", assertion)
+	}
+
+	var header map[string]string
+	headerJSON, _ := base64.RawURLEncoding.DecodeString(parts[0])
+	if err := json.Unmarshal(headerJSON, &header); err != nil || header["alg"] != "RS256" || header["x5t"] == "" {
+		t.Errorf("assertion header = %s, want RS256 with a thumbprint", headerJSON)
+	}
+
+	var claims map[string]interface{}
+	claimsJSON, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		t.Fatalf("failed to decode claims: %v", err)
+	}
+	if claims["aud"] != "https://login.example.com/token" || claims["iss"] != "app" || claims["sub"] != "app" || claims["exp"] != float64(now.Add(clientAssertionLifetime).Unix()) {
+		t.Errorf("assertion claims = %s", claimsJSON)
+	}
+
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("assertion signature is invalid: %v", err)
+	}
+}
+
+func TestLoadClientCertificateWithoutKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cert.pem")
+	os.WriteFile(path, []byte("not a certificate"), 0600)
+
+	if _, _, err := loadClientCertificate(path); err == nil {
+		t.Error("loadClientCertificate() error = nil, want an error")
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package azuredevops
 
 import (
 	"encoding/json"
@@ -21,25 +21,20 @@ type WorkItemField struct {
 const DefaultTemplateFileName = "work-item-template.json"
 
 // generateWorkItemTemplate generates a template JSON file for creating work items
-func generateWorkItemTemplate(cmd *cobra.Command, args []string) {
-	generateWorkItemTemplateWithExit(cmd, args, defaultExitFunc)
-}
-
-// generateWorkItemTemplateWithExit generates a template JSON file for creating work items with a custom exit function
-func generateWorkItemTemplateWithExit(cmd *cobra.Command, args []string, exit exitFunc) {
+func generateWorkItemTemplate(cmd *cobra.Command, args []string) error {
 	logger.Info("Generating work item template")
 
 	// Generate the template file
 	err := generateTemplateFile(DefaultTemplateFileName)
 	if err != nil {
-		handleTemplateErrorWithExit("Failed to generate template", err, exit)
-		return
+		return handleError("Failed to generate template", err)
 	}
 
 	// Print success message
 	printTemplateSuccessMessage(DefaultTemplateFileName)
 
 	logger.Info("Template generation completed successfully")
+	return nil
 }
 
 // generateTemplateFile generates a template file with the given filename
@@ -58,24 +53,6 @@ func generateTemplateFile(filename string) error {
 	return nil
 }
 
-// exitFunc is a function that exits the program
-type exitFunc func(int)
-
-// defaultExitFunc is the default exit function
-var defaultExitFunc exitFunc = os.Exit
-
-// handleTemplateError handles errors during template generation
-func handleTemplateError(message string, err error) {
-	handleTemplateErrorWithExit(message, err, defaultExitFunc)
-}
-
-// handleTemplateErrorWithExit handles errors during template generation with a custom exit function
-func handleTemplateErrorWithExit(message string, err error, exit exitFunc) {
-	logger.Error(message, "error", err)
-	fmt.Printf("Error: %s: %v\n", message, err)
-	exit(1)
-}
-
 // printTemplateSuccessMessage prints a success message after template generation
 func printTemplateSuccessMessage(filename string) {
 	absPath, err := filepath.Abs(filename)
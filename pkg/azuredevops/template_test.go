@@ -1,17 +1,13 @@
-package main
+package azuredevops
 
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"io"
-	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
-
-	"github.com/spf13/cobra"
 )
 
 func TestCreateWorkItemTemplate(t *testing.T) {
@@ -178,53 +174,6 @@ func TestGenerateTemplateFile(t *testing.T) {
 	}
 }
 
-func TestHandleTemplateErrorWithExit(t *testing.T) {
-	// Create a test error
-	testErr := fmt.Errorf("test error")
-	testMessage := "test message"
-
-	// Create a custom exit function that records the exit code
-	var exitCode int
-	testExitFunc := func(code int) {
-		exitCode = code
-	}
-
-	// Redirect stdout to capture output
-	oldStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
-	// Create a logger for the test
-	oldLogger := logger
-	logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-	}))
-
-	// Call the function
-	handleTemplateErrorWithExit(testMessage, testErr, testExitFunc)
-
-	// Restore stdout and logger
-	w.Close()
-	os.Stdout = oldStdout
-	logger = oldLogger
-
-	// Read the captured output
-	var buf bytes.Buffer
-	io.Copy(&buf, r)
-	output := buf.String()
-
-	// Check that the exit code is 1
-	if exitCode != 1 {
-		t.Errorf("handleTemplateErrorWithExit() exit code = %d, want 1", exitCode)
-	}
-
-	// Check that the output contains the expected message
-	expectedOutput := fmt.Sprintf("Error: %s: %v", testMessage, testErr)
-	if !strings.Contains(output, expectedOutput) {
-		t.Errorf("handleTemplateErrorWithExit() output = %q, want to contain %q", output, expectedOutput)
-	}
-}
-
 func TestPrintTemplateSuccessMessage(t *testing.T) {
 	// Create a temporary file path
 	filename := "test-template.json"
@@ -0,0 +1,516 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/test"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/testplan"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// maxTestRunDays is the widest window of days the test runs query accepts
+const maxTestRunDays = 7
+
+// defaultTestRunCount is the number of test runs listed when no --top flag is given
+const defaultTestRunCount = 25
+
+// Test outcomes counted in the run summaries
+const (
+	testOutcomePassed = "Passed"
+	testOutcomeFailed = "Failed"
+)
+
+// TestPlan represents a test plan in Azure DevOps
+type TestPlan struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	Iteration string `json:"iteration"`
+	Owner     string `json:"owner"`
+}
+
+// TestSuite represents a test suite of a test plan
+type TestSuite struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	ParentID int    `json:"parentId,omitempty"`
+	Depth    int    `json:"-"`
+}
+
+// TestRunSummary represents a test run with its pass/fail counts
+type TestRunSummary struct {
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	State     string     `json:"state"`
+	Plan      string     `json:"plan,omitempty"`
+	Total     int        `json:"total"`
+	Passed    int        `json:"passed"`
+	Failed    int        `json:"failed"`
+	Started   *time.Time `json:"started,omitempty"`
+	Completed *time.Time `json:"completed,omitempty"`
+	URL       string     `json:"url"`
+}
+
+// listTestPlansCommand lists the test plans of the project
+func listTestPlansCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing test plans")
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Check if only active test plans are requested
+	active, err := cmd.Flags().GetBool("active")
+	if err != nil {
+		return handleError("Failed to get active flag", err)
+	}
+
+	// Get the test plans
+	plans, err := getTestPlans(active)
+	if err != nil {
+		return handleError("Failed to get test plans", err)
+	}
+
+	// Print the test plans
+	if jsonOutput {
+		printTestPlansAsJSON(plans)
+	} else {
+		printTestPlansAsText(plans)
+	}
+
+	logger.Info("Test plans listed successfully")
+	return nil
+}
+
+// listTestSuitesCommand lists the test suites of a test plan
+func listTestSuitesCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing test suites", "plan", args[0])
+
+	// Parse the test plan ID
+	planID, err := strconv.Atoi(args[0])
+	if err != nil || planID <= 0 {
+		return handleError("Invalid test plan ID", errors.Errorf("'%s' is not a valid test plan ID", args[0]))
+	}
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the test suites
+	suites, err := getTestSuites(planID)
+	if err != nil {
+		return handleError("Failed to get test suites", err)
+	}
+
+	// Print the test suites
+	if jsonOutput {
+		printTestSuitesAsJSON(suites)
+	} else {
+		printTestSuitesAsText(suites)
+	}
+
+	logger.Info("Test suites listed successfully", "plan", planID)
+	return nil
+}
+
+// listTestRunsCommand lists the recent test runs of the project with their pass/fail counts
+func listTestRunsCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Listing test runs")
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the run filters
+	planID, err := cmd.Flags().GetInt("plan")
+	if err != nil {
+		return handleError("Failed to get plan flag", err)
+	}
+	days, err := cmd.Flags().GetInt("days")
+	if err != nil {
+		return handleError("Failed to get days flag", err)
+	}
+	if days <= 0 || days > maxTestRunDays {
+		return handleError("Invalid days flag", errors.Errorf("days must be between 1 and %d, got %d", maxTestRunDays, days))
+	}
+	top, err := cmd.Flags().GetInt("top")
+	if err != nil {
+		return handleError("Failed to get top flag", err)
+	}
+	if top <= 0 {
+		return handleError("Invalid top flag", errors.Errorf("top must be positive, got %d", top))
+	}
+
+	// Get the test runs
+	runs, err := getTestRuns(planID, days, top)
+	if err != nil {
+		return handleError("Failed to get test runs", err)
+	}
+
+	// Print the test runs
+	if jsonOutput {
+		printTestRunsAsJSON(runs)
+	} else {
+		printTestRunsAsText(runs)
+	}
+
+	logger.Info("Test runs listed successfully")
+	return nil
+}
+
+// createTestPlanClient creates a client for the Test Plan API
+func createTestPlanClient(connectionDetails *ConnectionDetails) testplan.Client {
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	return testplan.NewClient(commandContext, connection)
+}
+
+// getTestPlans gets the test plans of the project, following continuation tokens
+func getTestPlans(active bool) ([]TestPlan, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	client := createTestPlanClient(connectionDetails)
+
+	result := []TestPlan{}
+	includeDetails := true
+	var continuationToken *string
+	for {
+		response, err := client.GetTestPlans(commandContext, testplan.GetTestPlansArgs{
+			Project:            &connectionDetails.Project,
+			IncludePlanDetails: &includeDetails,
+			FilterActivePlans:  &active,
+			ContinuationToken:  continuationToken,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get test plans")
+		}
+
+		for _, plan := range response.Value {
+			result = append(result, convertTestPlan(plan))
+		}
+
+		if response.ContinuationToken == "" {
+			return result, nil
+		}
+		token := response.ContinuationToken
+		continuationToken = &token
+	}
+}
+
+// getTestSuites gets the test suites of a test plan in tree order, following continuation tokens
+func getTestSuites(planID int) ([]TestSuite, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	client := createTestPlanClient(connectionDetails)
+
+	var suites []testplan.TestSuite
+	var continuationToken *string
+	for {
+		response, err := client.GetTestSuitesForPlan(commandContext, testplan.GetTestSuitesForPlanArgs{
+			Project:           &connectionDetails.Project,
+			PlanId:            &planID,
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get test suites of plan %d", planID)
+		}
+
+		suites = append(suites, response.Value...)
+
+		if response.ContinuationToken == "" {
+			break
+		}
+		token := response.ContinuationToken
+		continuationToken = &token
+	}
+
+	return orderTestSuites(suites), nil
+}
+
+// orderTestSuites converts test suites to our model and orders them depth-first under their parents
+func orderTestSuites(suites []testplan.TestSuite) []TestSuite {
+	ids := make(map[int]bool, len(suites))
+	for _, suite := range suites {
+		ids[intValue(suite.Id)] = true
+	}
+
+	children := make(map[int][]TestSuite)
+	var roots []TestSuite
+	for _, suite := range suites {
+		converted := convertTestSuite(suite)
+		if converted.ParentID == 0 || !ids[converted.ParentID] {
+			roots = append(roots, converted)
+			continue
+		}
+		children[converted.ParentID] = append(children[converted.ParentID], converted)
+	}
+
+	result := []TestSuite{}
+	var visit func(list []TestSuite, depth int)
+	visit = func(list []TestSuite, depth int) {
+		for _, suite := range list {
+			suite.Depth = depth
+			result = append(result, suite)
+			visit(children[suite.ID], depth+1)
+		}
+	}
+	visit(roots, 0)
+	return result
+}
+
+// getTestRuns gets the most recent test runs of the project, optionally of a single test plan
+func getTestRuns(planID, days, top int) ([]TestRunSummary, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for the Test API
+	connection := newConnection(connectionDetails)
+	client, err := test.NewClient(commandContext, connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Test client")
+	}
+
+	now := time.Now()
+	args := test.QueryTestRunsArgs{
+		Project:            &connectionDetails.Project,
+		MinLastUpdatedDate: &azuredevops.Time{Time: now.AddDate(0, 0, -days)},
+		MaxLastUpdatedDate: &azuredevops.Time{Time: now},
+	}
+	if planID > 0 {
+		args.PlanIds = &[]int{planID}
+	}
+
+	var runs []test.TestRun
+	for {
+		response, err := client.QueryTestRuns(commandContext, args)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query test runs")
+		}
+
+		runs = append(runs, response.Value...)
+
+		if response.ContinuationToken == "" {
+			break
+		}
+		token := response.ContinuationToken
+		args.ContinuationToken = &token
+	}
+
+	// Show the most recent runs first
+	sort.SliceStable(runs, func(i, j int) bool {
+		return intValue(runs[i].Id) > intValue(runs[j].Id)
+	})
+	if len(runs) > top {
+		runs = runs[:top]
+	}
+
+	result := []TestRunSummary{}
+	for _, run := range runs {
+		result = append(result, convertTestRun(run))
+	}
+	return result, nil
+}
+
+// convertTestPlan converts an Azure DevOps test plan to our model
+func convertTestPlan(plan testplan.TestPlan) TestPlan {
+	result := TestPlan{
+		ID:        intValue(plan.Id),
+		Name:      stringValue(plan.Name),
+		State:     stringValue(plan.State),
+		Iteration: stringValue(plan.Iteration),
+	}
+	if plan.Owner != nil {
+		result.Owner = stringValue(plan.Owner.DisplayName)
+	}
+	return result
+}
+
+// convertTestSuite converts an Azure DevOps test suite to our model
+func convertTestSuite(suite testplan.TestSuite) TestSuite {
+	result := TestSuite{
+		ID:   intValue(suite.Id),
+		Name: stringValue(suite.Name),
+	}
+	if suite.SuiteType != nil {
+		result.Type = string(*suite.SuiteType)
+	}
+	if suite.ParentSuite != nil {
+		result.ParentID = intValue(suite.ParentSuite.Id)
+	}
+	return result
+}
+
+// testRunCounts counts the total, passed and failed tests of a run
+func testRunCounts(run test.TestRun) (int, int, int) {
+	total := intValue(run.TotalTests)
+
+	// Prefer the per-outcome statistics, which count every outcome
+	if run.RunStatistics != nil && len(*run.RunStatistics) > 0 {
+		passed, failed := 0, 0
+		for _, statistic := range *run.RunStatistics {
+			switch stringValue(statistic.Outcome) {
+			case testOutcomePassed:
+				passed += intValue(statistic.Count)
+			case testOutcomeFailed:
+				failed += intValue(statistic.Count)
+			}
+		}
+		return total, passed, failed
+	}
+
+	passed := intValue(run.PassedTests)
+	failed := total - passed - intValue(run.NotApplicableTests) - intValue(run.IncompleteTests)
+	if failed < 0 {
+		failed = 0
+	}
+	return total, passed, failed
+}
+
+// convertTestRun converts an Azure DevOps test run to our model
+func convertTestRun(run test.TestRun) TestRunSummary {
+	result := TestRunSummary{
+		ID:    intValue(run.Id),
+		Name:  stringValue(run.Name),
+		State: stringValue(run.State),
+		URL:   stringValue(run.WebAccessUrl),
+	}
+	result.Total, result.Passed, result.Failed = testRunCounts(run)
+	if run.Plan != nil {
+		result.Plan = stringValue(run.Plan.Name)
+	}
+	if run.StartedDate != nil {
+		started := run.StartedDate.Time
+		result.Started = &started
+	}
+	if run.CompletedDate != nil {
+		completed := run.CompletedDate.Time
+		result.Completed = &completed
+	}
+	return result
+}
+
+// printTestPlansAsText prints test plans in a human-readable format
+func printTestPlansAsText(plans []TestPlan) {
+	if len(plans) == 0 {
+		fmt.Println("No test plans found.")
+		return
+	}
+
+	fmt.Printf("Found %d test plans:\n\n", len(plans))
+
+	for _, plan := range plans {
+		fmt.Printf("ID: %d\n", plan.ID)
+		fmt.Printf("Name: %s\n", plan.Name)
+		fmt.Printf("State: %s\n", plan.State)
+		fmt.Printf("Iteration: %s\n", plan.Iteration)
+		fmt.Printf("Owner: %s\n", plan.Owner)
+		fmt.Println()
+	}
+}
+
+// printTestPlansAsJSON prints test plans in JSON format
+func printTestPlansAsJSON(plans []TestPlan) {
+	// Marshal the test plans to JSON with indentation
+	jsonData, err := json.MarshalIndent(plans, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal test plans to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal test plans to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
+
+// printTestSuitesAsText prints test suites as an indented tree
+func printTestSuitesAsText(suites []TestSuite) {
+	if len(suites) == 0 {
+		fmt.Println("No test suites found.")
+		return
+	}
+
+	fmt.Printf("Found %d test suites:\n\n", len(suites))
+
+	for _, suite := range suites {
+		fmt.Printf("%s%d %s (%s)\n", strings.Repeat("  ", suite.Depth), suite.ID, suite.Name, suite.Type)
+	}
+}
+
+// printTestSuitesAsJSON prints test suites in JSON format
+func printTestSuitesAsJSON(suites []TestSuite) {
+	// Marshal the test suites to JSON with indentation
+	jsonData, err := json.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal test suites to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal test suites to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
+
+// printTestRunsAsText prints test runs in a human-readable format
+func printTestRunsAsText(runs []TestRunSummary) {
+	if len(runs) == 0 {
+		fmt.Println("No test runs found.")
+		return
+	}
+
+	fmt.Printf("Found %d test runs:\n\n", len(runs))
+
+	for _, run := range runs {
+		fmt.Printf("ID: %d\n", run.ID)
+		fmt.Printf("Name: %s\n", run.Name)
+		fmt.Printf("State: %s\n", run.State)
+		if run.Plan != "" {
+			fmt.Printf("Plan: %s\n", run.Plan)
+		}
+		fmt.Printf("Results: %d passed, %d failed, %d total\n", run.Passed, run.Failed, run.Total)
+		if run.Completed != nil {
+			fmt.Printf("Completed: %s\n", run.Completed.Format(time.RFC3339))
+		}
+		fmt.Printf("URL: %s\n", run.URL)
+		fmt.Println()
+	}
+}
+
+// printTestRunsAsJSON prints test runs in JSON format
+func printTestRunsAsJSON(runs []TestRunSummary) {
+	// Marshal the test runs to JSON with indentation
+	jsonData, err := json.MarshalIndent(runs, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal test runs to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal test runs to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
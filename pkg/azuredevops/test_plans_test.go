@@ -0,0 +1,61 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/test"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/testplan"
+)
+
+func testSuite(id int, name string, parentID int) testplan.TestSuite {
+	suite := testplan.TestSuite{Id: &id, Name: &name}
+	if parentID != 0 {
+		suite.ParentSuite = &testplan.TestSuiteReference{Id: &parentID}
+	}
+	return suite
+}
+
+func TestOrderTestSuites(t *testing.T) {
+	suites := []testplan.TestSuite{
+		testSuite(3, "Checkout", 1),
+		testSuite(1, "Root", 0),
+		testSuite(4, "Payments", 3),
+		testSuite(2, "Login", 1),
+	}
+
+	got := orderTestSuites(suites)
+
+	want := []struct {
+		name  string
+		depth int
+	}{{"Root", 0}, {"Checkout", 1}, {"Payments", 2}, {"Login", 1}}
+	if len(got) != len(want) {
+		t.Fatalf("orderTestSuites() returned %d suites, want %d", len(got), len(want))
+	}
+	for i, suite := range got {
+		if suite.Name != want[i].name || suite.Depth != want[i].depth {
+			t.Errorf("orderTestSuites()[%d] = %s at depth %d, want %s at depth %d", i, suite.Name, suite.Depth, want[i].name, want[i].depth)
+		}
+	}
+}
+
+func TestTestRunCounts(t *testing.T) {
+	total, passed, notApplicable := 10, 7, 1
+	run := test.TestRun{TotalTests: &total, PassedTests: &passed, NotApplicableTests: &notApplicable}
+
+	if gotTotal, gotPassed, gotFailed := testRunCounts(run); gotTotal != 10 || gotPassed != 7 || gotFailed != 2 {
+		t.Errorf("testRunCounts() without statistics = %d, %d, %d, want 10, 7, 2", gotTotal, gotPassed, gotFailed)
+	}
+
+	passedOutcome, failedOutcome, skippedOutcome := testOutcomePassed, testOutcomeFailed, "NotExecuted"
+	passedCount, failedCount, skippedCount := 6, 3, 1
+	run.RunStatistics = &[]test.RunStatistic{
+		{Outcome: &passedOutcome, Count: &passedCount},
+		{Outcome: &failedOutcome, Count: &failedCount},
+		{Outcome: &skippedOutcome, Count: &skippedCount},
+	}
+
+	if gotTotal, gotPassed, gotFailed := testRunCounts(run); gotTotal != 10 || gotPassed != 6 || gotFailed != 3 {
+		t.Errorf("testRunCounts() with statistics = %d, %d, %d, want 10, 6, 3", gotTotal, gotPassed, gotFailed)
+	}
+}
@@ -0,0 +1,99 @@
+package azuredevops
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// baseTransport is the standard transport, kept before it is replaced by setupTransport
+var baseTransport = http.DefaultTransport.(*http.Transport)
+
+// setupTransport configures the HTTP transport used by all API calls from the configuration file
+func setupTransport(cmd *cobra.Command, args []string) error {
+	config, err := loadAzureDevOpsConfig(azureDevOpsConfigPath())
+	if err != nil {
+		return handleError("Failed to load configuration", err)
+	}
+
+	transport, err := newTransport(config)
+	if err != nil {
+		return handleError("Failed to configure HTTP transport", err)
+	}
+
+	// Record or replay the API traffic
+	switch {
+	case recordCassette != "" && replayCassette != "":
+		return handleError("Invalid flags", errors.New("--record and --replay cannot be used together"))
+	case replayCassette != "":
+		transport, err = newReplayTransport(replayCassette)
+		if err != nil {
+			return handleError("Failed to load cassette", err)
+		}
+	case recordCassette != "":
+		transport = newRecordTransport(transport, recordCassette)
+	}
+
+	// The Azure DevOps clients use the default transport
+	http.DefaultTransport = transport
+	return nil
+}
+
+// newTransport builds an HTTP transport honoring the proxy, certificate authority, retry and cache settings of the configuration
+func newTransport(config *AzureDevOpsConfig) (http.RoundTripper, error) {
+	transport := baseTransport.Clone()
+
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil || proxyURL.Host == "" {
+			return nil, errors.Errorf("invalid proxy_url '%s'", config.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if config.CABundle != "" {
+		roots, err := loadCertificatePool(os.Expand(config.CABundle, env.Getenv))
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: roots}
+	}
+
+	retryMax := defaultRetryMax
+	if config.RetryMax != nil {
+		if *config.RetryMax < 0 {
+			return nil, errors.Errorf("invalid retry_max %d", *config.RetryMax)
+		}
+		retryMax = *config.RetryMax
+	}
+
+	var roundTripper http.RoundTripper = newRetryTransport(transport, retryMax)
+	if config.Cache {
+		roundTripper = newCacheTransport(roundTripper, azureDevOpsCacheDir())
+	}
+
+	return roundTripper, nil
+}
+
+// loadCertificatePool adds the certificates of a PEM file to the system certificate pool
+func loadCertificatePool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read ca_bundle %s", path)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, errors.Errorf("ca_bundle %s contains no PEM certificates", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,55 @@
+package azuredevops
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewTransportProxy(t *testing.T) {
+	transport, err := newTransport(&AzureDevOpsConfig{ProxyURL: "http://proxy.example.com:3128"})
+	if err != nil {
+		t.Fatalf("newTransport() error = %v", err)
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, "https://dev.azure.com/myorg/_apis/projects", nil)
+	proxyURL, err := transport.(*retryTransport).next.(*http.Transport).Proxy(request)
+	if err != nil || proxyURL == nil || proxyURL.Host != "proxy.example.com:3128" {
+		t.Errorf("Proxy() = %v, %v, want proxy.example.com:3128", proxyURL, err)
+	}
+
+	if _, err := newTransport(&AzureDevOpsConfig{ProxyURL: "not a url"}); err == nil {
+		t.Error("newTransport() with an invalid proxy_url error = nil, want an error")
+	}
+}
+
+func TestNewTransportCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundle, data, 0600); err != nil {
+		t.Fatalf("Failed to write CA bundle: %v", err)
+	}
+
+	transport, err := newTransport(&AzureDevOpsConfig{CABundle: bundle})
+	if err != nil {
+		t.Fatalf("newTransport() error = %v", err)
+	}
+
+	response, err := (&http.Client{Transport: transport.(*retryTransport).next}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("request through the transport failed: %v", err)
+	}
+	response.Body.Close()
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	os.WriteFile(empty, []byte("not a certificate"), 0600)
+	if _, err := newTransport(&AzureDevOpsConfig{CABundle: empty}); err == nil {
+		t.Error("newTransport() with an empty bundle error = nil, want an error")
+	}
+}
@@ -0,0 +1,59 @@
+package azuredevops
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// stringValue dereferences an optional string returned by the Azure DevOps API
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// intValue dereferences an optional int returned by the Azure DevOps API
+func intValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+// boolValue dereferences an optional bool returned by the Azure DevOps API
+func boolValue(value *bool) bool {
+	if value == nil {
+		return false
+	}
+	return *value
+}
+
+// parseKeyValues parses key=value pairs given in repeated flags
+func parseKeyValues(pairs []string) (map[string]string, error) {
+	result := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, errors.Errorf("invalid value '%s': use key=value", pair)
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// linkHref gets the URL of a named link from the _links field returned by the Azure DevOps API
+func linkHref(links interface{}, name string) string {
+	linkMap, ok := links.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	link, ok := linkMap[name].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	href, _ := link["href"].(string)
+	return href
+}
@@ -0,0 +1,49 @@
+package azuredevops
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKeyValues(t *testing.T) {
+	got, err := parseKeyValues([]string{"env=prod", "tags=a=b", " empty="})
+	if err != nil {
+		t.Fatalf("parseKeyValues() error = %v", err)
+	}
+	want := map[string]string{"env": "prod", "tags": "a=b", "empty": ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseKeyValues() = %v, want %v", got, want)
+	}
+
+	for _, pair := range []string{"novalue", "=value"} {
+		if _, err := parseKeyValues([]string{pair}); err == nil {
+			t.Errorf("parseKeyValues(%q) error = nil, want an error", pair)
+		}
+	}
+}
+
+func TestLinkHref(t *testing.T) {
+	links := map[string]interface{}{
+		"web": map[string]interface{}{"href": "https://dev.azure.com/org/project/_build?definitionId=1"},
+	}
+
+	if got := linkHref(links, "web"); got != "https://dev.azure.com/org/project/_build?definitionId=1" {
+		t.Errorf("linkHref(web) = %q", got)
+	}
+	if got := linkHref(links, "self"); got != "" {
+		t.Errorf("linkHref(self) = %q, want empty", got)
+	}
+	if got := linkHref(nil, "web"); got != "" {
+		t.Errorf("linkHref(nil) = %q, want empty", got)
+	}
+}
+
+func TestBoolValue(t *testing.T) {
+	value := true
+	if !boolValue(&value) {
+		t.Error("boolValue(&true) = false, want true")
+	}
+	if boolValue(nil) {
+		t.Error("boolValue(nil) = true, want false")
+	}
+}
@@ -0,0 +1,201 @@
+package azuredevops
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/identity"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// anonymousDescriptorPrefix starts the descriptor Azure DevOps reports for requests it did not authenticate
+const anonymousDescriptorPrefix = "System:PublicAccess"
+
+// WhoAmI describes the identity and credential commands run with
+type WhoAmI struct {
+	Organization string     `json:"organization"`
+	Project      string     `json:"project"`
+	User         string     `json:"user"`
+	Email        string     `json:"email,omitempty"`
+	UserID       string     `json:"userId"`
+	Credential   string     `json:"credential"`
+	TokenSource  string     `json:"tokenSource"`
+	Scopes       []string   `json:"scopes,omitempty"`
+	ExpiresAt    *time.Time `json:"expiresAt,omitempty"`
+}
+
+// whoAmICommand prints the authenticated identity and the credential in use
+func whoAmICommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Checking authentication")
+
+	// Check if JSON output is requested
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return handleError("Failed to get json flag", err)
+	}
+
+	// Get the identity
+	whoAmI, err := getWhoAmI()
+	if err != nil {
+		return handleError("Failed to check authentication", err)
+	}
+
+	// Print the identity
+	if jsonOutput {
+		printWhoAmIAsJSON(whoAmI)
+	} else {
+		printWhoAmIAsText(whoAmI)
+	}
+
+	logger.Info("Authentication checked successfully", "user", whoAmI.User)
+	return nil
+}
+
+// getWhoAmI gets the authenticated identity, and the scopes and expiry of the token when it reveals them
+func getWhoAmI() (*WhoAmI, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WhoAmI{
+		Organization: connectionDetails.Organization,
+		Project:      connectionDetails.Project,
+		Credential:   describeCredential(connectionDetails.Bearer),
+		TokenSource:  connectionDetails.TokenSource,
+	}
+
+	user, err := getAuthenticatedUser(connectionDetails)
+	if err == nil && isAnonymousIdentity(user) {
+		err = errors.New("the request was not authenticated")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "the %s from %s was rejected by %s; check that it has not expired or been revoked and that it has access to the organization",
+			result.Credential, result.TokenSource, result.Organization)
+	}
+
+	result.User = stringValue(user.ProviderDisplayName)
+	result.Email = identityProperty(user, "Account")
+	result.UserID = user.Id.String()
+
+	// Azure AD access tokens carry their scopes and expiry; PATs are opaque
+	if connectionDetails.Bearer {
+		claims, err := parseTokenClaims(connectionDetails.Token)
+		if err != nil {
+			logger.Warn("Could not read the access token claims", "error", err)
+		} else {
+			result.Scopes = claims.scopes()
+			result.ExpiresAt = claims.expiresAt()
+		}
+	}
+
+	return result, nil
+}
+
+// describeCredential names the kind of credential in use
+func describeCredential(bearer bool) string {
+	if bearer {
+		return "Azure AD access token"
+	}
+	return "Personal Access Token"
+}
+
+// isAnonymousIdentity checks if Azure DevOps treated the request as anonymous
+func isAnonymousIdentity(user *identity.Identity) bool {
+	return strings.HasPrefix(stringValue(user.Descriptor), anonymousDescriptorPrefix)
+}
+
+// identityProperty gets a string property of an identity, which the API returns as a {"$type", "$value"} pair
+func identityProperty(user *identity.Identity, name string) string {
+	properties, ok := user.Properties.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	property, ok := properties[name].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	value, _ := property["$value"].(string)
+	return value
+}
+
+// tokenClaims holds the claims of an Azure AD access token used to describe it
+type tokenClaims struct {
+	Scope      string `json:"scp"`
+	Expiration int64  `json:"exp"`
+}
+
+// parseTokenClaims decodes the claims of a JWT access token without verifying its signature
+func parseTokenClaims(token string) (*tokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("the token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode token payload")
+	}
+
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, errors.Wrap(err, "failed to parse token claims")
+	}
+	return &claims, nil
+}
+
+// scopes gets the scopes granted to the token
+func (claims *tokenClaims) scopes() []string {
+	return strings.Fields(claims.Scope)
+}
+
+// expiresAt gets the expiry of the token, or nil when it has none
+func (claims *tokenClaims) expiresAt() *time.Time {
+	if claims.Expiration == 0 {
+		return nil
+	}
+	expiry := time.Unix(claims.Expiration, 0)
+	return &expiry
+}
+
+// printWhoAmIAsText prints the identity in a human-readable format
+func printWhoAmIAsText(whoAmI *WhoAmI) {
+	fmt.Printf("Organization: %s\n", whoAmI.Organization)
+	fmt.Printf("Project: %s\n", whoAmI.Project)
+	if whoAmI.Email != "" {
+		fmt.Printf("User: %s <%s>\n", whoAmI.User, whoAmI.Email)
+	} else {
+		fmt.Printf("User: %s\n", whoAmI.User)
+	}
+	fmt.Printf("User ID: %s\n", whoAmI.UserID)
+	fmt.Printf("Credential: %s from %s\n", whoAmI.Credential, whoAmI.TokenSource)
+
+	if len(whoAmI.Scopes) > 0 {
+		fmt.Printf("Scopes: %s\n", strings.Join(whoAmI.Scopes, ", "))
+	}
+	if whoAmI.ExpiresAt != nil {
+		fmt.Printf("Expires: %s\n", whoAmI.ExpiresAt.Format(time.RFC3339))
+	}
+	if whoAmI.Credential == describeCredential(false) {
+		fmt.Printf("Scopes and expiry of PATs are shown at https://dev.azure.com/%s/_usersSettings/tokens\n", whoAmI.Organization)
+	}
+}
+
+// printWhoAmIAsJSON prints the identity in JSON format
+func printWhoAmIAsJSON(whoAmI *WhoAmI) {
+	// Marshal the identity to JSON with indentation
+	jsonData, err := json.MarshalIndent(whoAmI, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal identity to JSON", "error", err)
+		fmt.Println("Error: Failed to marshal identity to JSON:", err)
+		return
+	}
+
+	// Print the JSON
+	fmt.Println(string(jsonData))
+}
@@ -0,0 +1,60 @@
+package azuredevops
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/identity"
+)
+
+func TestParseTokenClaims(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"scp":"user_impersonation vso.code","exp":1717408800}`))
+	claims, err := parseTokenClaims("header." + payload + ".signature")
+	if err != nil {
+		t.Fatalf("parseTokenClaims() error = %v", err)
+	}
+
+	if got := claims.scopes(); !reflect.DeepEqual(got, []string{"user_impersonation", "vso.code"}) {
+		t.Errorf("scopes() = %v", got)
+	}
+	if got := claims.expiresAt(); got == nil || !got.Equal(time.Unix(1717408800, 0)) {
+		t.Errorf("expiresAt() = %v, want 1717408800", got)
+	}
+
+	if _, err := parseTokenClaims("opaque-pat"); err == nil {
+		t.Error("parseTokenClaims(opaque-pat) error = nil, want an error")
+	}
+	if (&tokenClaims{}).expiresAt() != nil {
+		t.Error("expiresAt() without exp claim should be nil")
+	}
+}
+
+func TestIdentityProperty(t *testing.T) {
+	user := &identity.Identity{Properties: map[string]interface{}{
+		"Account": map[string]interface{}{"$type": "System.String", "$value": "jane@example.com"},
+	}}
+
+	if got := identityProperty(user, "Account"); got != "jane@example.com" {
+		t.Errorf("identityProperty(Account) = %s, want jane@example.com", got)
+	}
+	if got := identityProperty(user, "Mail"); got != "" {
+		t.Errorf("identityProperty(Mail) = %s, want empty", got)
+	}
+	if got := identityProperty(&identity.Identity{}, "Account"); got != "" {
+		t.Errorf("identityProperty() without properties = %s, want empty", got)
+	}
+}
+
+func TestIsAnonymousIdentity(t *testing.T) {
+	anonymous := "System:PublicAccess;aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	user := "Microsoft.IdentityModel.Claims.ClaimsIdentity;contoso\\jane@example.com"
+
+	if !isAnonymousIdentity(&identity.Identity{Descriptor: &anonymous}) {
+		t.Error("isAnonymousIdentity(public access) = false, want true")
+	}
+	if isAnonymousIdentity(&identity.Identity{Descriptor: &user}) {
+		t.Error("isAnonymousIdentity(user) = true, want false")
+	}
+}
@@ -0,0 +1,271 @@
+package azuredevops
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/wiki"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// getWikiPageCommand prints the content of a wiki page
+func getWikiPageCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Getting wiki page", "path", args[0])
+
+	// Get the wiki to read from
+	wikiName, err := cmd.Flags().GetString("wiki")
+	if err != nil {
+		return handleError("Failed to get wiki flag", err)
+	}
+
+	// Get the page
+	content, err := getWikiPage(wikiName, args[0])
+	if err != nil {
+		return handleError("Failed to get wiki page", err)
+	}
+
+	fmt.Print(content)
+	if !strings.HasSuffix(content, "\n") {
+		fmt.Println()
+	}
+
+	logger.Info("Wiki page retrieved successfully", "path", args[0])
+	return nil
+}
+
+// putWikiPageCommand creates or updates a wiki page with the content of a file
+func putWikiPageCommand(cmd *cobra.Command, args []string) error {
+	logger.Info("Putting wiki page", "path", args[0])
+
+	// Get the wiki to write to
+	wikiName, err := cmd.Flags().GetString("wiki")
+	if err != nil {
+		return handleError("Failed to get wiki flag", err)
+	}
+
+	// Get the file with the page content
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return handleError("Failed to get file flag", err)
+	}
+
+	// Get the commit comment
+	comment, err := cmd.Flags().GetString("comment")
+	if err != nil {
+		return handleError("Failed to get comment flag", err)
+	}
+
+	// Read the page content
+	content, err := readPageContent(file)
+	if err != nil {
+		return handleError("Failed to read page content", err)
+	}
+
+	// Put the page
+	created, url, err := putWikiPage(wikiName, args[0], content, comment)
+	if err != nil {
+		return handleError("Failed to put wiki page", err)
+	}
+
+	if created {
+		fmt.Printf("Created wiki page %s\n", normalizeWikiPath(args[0]))
+	} else {
+		fmt.Printf("Updated wiki page %s\n", normalizeWikiPath(args[0]))
+	}
+	if url != "" {
+		fmt.Printf("URL: %s\n", url)
+	}
+
+	logger.Info("Wiki page put successfully", "path", args[0], "created", created)
+	return nil
+}
+
+// readPageContent reads page content from a file, or from standard input when the file is "-"
+func readPageContent(file string) (string, error) {
+	if file == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to read standard input")
+		}
+		return string(data), nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read %s", file)
+	}
+	return string(data), nil
+}
+
+// normalizeWikiPath makes a wiki page path absolute
+func normalizeWikiPath(path string) string {
+	return "/" + strings.Trim(path, "/")
+}
+
+// isNotFoundError checks if an Azure DevOps API error is a 404 Not Found
+func isNotFoundError(err error) bool {
+	var wrapped azuredevops.WrappedError
+	if errors.As(err, &wrapped) {
+		return wrapped.StatusCode != nil && *wrapped.StatusCode == http.StatusNotFound
+	}
+	var wrappedPointer *azuredevops.WrappedError
+	if errors.As(err, &wrappedPointer) {
+		return wrappedPointer.StatusCode != nil && *wrappedPointer.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// createWikiClient creates a client for the Wiki API
+func createWikiClient(connectionDetails *ConnectionDetails) (wiki.Client, error) {
+	// Create a connection to Azure DevOps
+	connection := newConnection(connectionDetails)
+
+	// Create a client for the Wiki API
+	client, err := wiki.NewClient(commandContext, connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Wiki client")
+	}
+
+	return client, nil
+}
+
+// resolveWiki returns the wiki to use, defaulting to the project wiki when no name is given
+func resolveWiki(client wiki.Client, project, wikiName string) (string, error) {
+	if wikiName != "" {
+		return wikiName, nil
+	}
+
+	wikis, err := client.GetAllWikis(commandContext, wiki.GetAllWikisArgs{
+		Project: &project,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get wikis")
+	}
+
+	return selectProjectWiki(*wikis)
+}
+
+// selectProjectWiki picks the project wiki, or the only wiki, from the wikis of a project
+func selectProjectWiki(wikis []wiki.WikiV2) (string, error) {
+	for _, w := range wikis {
+		if w.Type != nil && *w.Type == wiki.WikiTypeValues.ProjectWiki {
+			return stringValue(w.Name), nil
+		}
+	}
+
+	switch len(wikis) {
+	case 0:
+		return "", errors.New("the project has no wiki")
+	case 1:
+		return stringValue(wikis[0].Name), nil
+	}
+
+	var names []string
+	for _, w := range wikis {
+		names = append(names, stringValue(w.Name))
+	}
+	return "", errors.Errorf("the project has several wikis (%s); use --wiki", strings.Join(names, ", "))
+}
+
+// getWikiPage gets the content of a wiki page
+func getWikiPage(wikiName, path string) (string, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	// Create a client for the Wiki API
+	client, err := createWikiClient(connectionDetails)
+	if err != nil {
+		return "", err
+	}
+
+	wikiName, err = resolveWiki(client, connectionDetails.Project, wikiName)
+	if err != nil {
+		return "", err
+	}
+
+	pagePath := normalizeWikiPath(path)
+	includeContent := true
+	response, err := client.GetPage(commandContext, wiki.GetPageArgs{
+		Project:        &connectionDetails.Project,
+		WikiIdentifier: &wikiName,
+		Path:           &pagePath,
+		IncludeContent: &includeContent,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get page %s", pagePath)
+	}
+	if response.Page == nil {
+		return "", errors.Errorf("page %s has no content", pagePath)
+	}
+
+	return stringValue(response.Page.Content), nil
+}
+
+// putWikiPage creates a wiki page, or updates it when it exists; it reports whether the page was created
+func putWikiPage(wikiName, path, content, comment string) (bool, string, error) {
+	// Get the Azure DevOps connection details from environment variables
+	connectionDetails, err := getAzureDevOpsConnectionDetails()
+	if err != nil {
+		return false, "", err
+	}
+
+	// Create a client for the Wiki API
+	client, err := createWikiClient(connectionDetails)
+	if err != nil {
+		return false, "", err
+	}
+
+	wikiName, err = resolveWiki(client, connectionDetails.Project, wikiName)
+	if err != nil {
+		return false, "", err
+	}
+
+	// Updating a page requires the ETag of its current version
+	pagePath := normalizeWikiPath(path)
+	var version *string
+	existing, err := client.GetPage(commandContext, wiki.GetPageArgs{
+		Project:        &connectionDetails.Project,
+		WikiIdentifier: &wikiName,
+		Path:           &pagePath,
+	})
+	switch {
+	case err == nil:
+		if existing.ETag != nil && len(*existing.ETag) > 0 && (*existing.ETag)[0] != "" {
+			version = &(*existing.ETag)[0]
+		}
+	case isNotFoundError(err):
+		// The page does not exist yet, so it is created
+	default:
+		return false, "", errors.Wrapf(err, "failed to get page %s", pagePath)
+	}
+
+	args := wiki.CreateOrUpdatePageArgs{
+		Parameters:     &wiki.WikiPageCreateOrUpdateParameters{Content: &content},
+		Project:        &connectionDetails.Project,
+		WikiIdentifier: &wikiName,
+		Path:           &pagePath,
+		Version:        version,
+	}
+	if comment != "" {
+		args.Comment = &comment
+	}
+
+	response, err := client.CreateOrUpdatePage(commandContext, args)
+	if err != nil {
+		return false, "", errors.Wrapf(err, "failed to put page %s", pagePath)
+	}
+
+	url := ""
+	if response.Page != nil {
+		url = stringValue(response.Page.RemoteUrl)
+	}
+	return version == nil, url, nil
+}
@@ -0,0 +1,63 @@
+package azuredevops
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/wiki"
+	"github.com/pkg/errors"
+)
+
+func TestNormalizeWikiPath(t *testing.T) {
+	for path, want := range map[string]string{
+		"Home":          "/Home",
+		"/Guides/Setup": "/Guides/Setup",
+		"Guides/Setup/": "/Guides/Setup",
+		"/":             "/",
+	} {
+		if got := normalizeWikiPath(path); got != want {
+			t.Errorf("normalizeWikiPath(%q) = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestIsNotFoundError(t *testing.T) {
+	notFound, serverError := http.StatusNotFound, http.StatusInternalServerError
+
+	if !isNotFoundError(errors.Wrap(azuredevops.WrappedError{StatusCode: &notFound}, "failed")) {
+		t.Error("isNotFoundError() = false for a wrapped 404")
+	}
+	if !isNotFoundError(&azuredevops.WrappedError{StatusCode: &notFound}) {
+		t.Error("isNotFoundError() = false for a 404 pointer")
+	}
+	if isNotFoundError(azuredevops.WrappedError{StatusCode: &serverError}) {
+		t.Error("isNotFoundError() = true for a 500")
+	}
+	if isNotFoundError(errors.New("boom")) {
+		t.Error("isNotFoundError() = true for a plain error")
+	}
+}
+
+func TestSelectProjectWiki(t *testing.T) {
+	projectWiki, codeWiki := wiki.WikiTypeValues.ProjectWiki, wiki.WikiTypeValues.CodeWiki
+	projectName, docsName, apiName := "MyProject.wiki", "docs", "api-docs"
+
+	got, err := selectProjectWiki([]wiki.WikiV2{
+		{Name: &docsName, Type: &codeWiki},
+		{Name: &projectName, Type: &projectWiki},
+	})
+	if err != nil || got != projectName {
+		t.Errorf("selectProjectWiki() = %s, %v, want %s", got, err, projectName)
+	}
+
+	if got, err := selectProjectWiki([]wiki.WikiV2{{Name: &docsName, Type: &codeWiki}}); err != nil || got != docsName {
+		t.Errorf("selectProjectWiki() with one wiki = %s, %v, want %s", got, err, docsName)
+	}
+	if _, err := selectProjectWiki([]wiki.WikiV2{{Name: &docsName, Type: &codeWiki}, {Name: &apiName, Type: &codeWiki}}); err == nil {
+		t.Error("selectProjectWiki() with several code wikis error = nil, want an error")
+	}
+	if _, err := selectProjectWiki(nil); err == nil {
+		t.Error("selectProjectWiki() without wikis error = nil, want an error")
+	}
+}
@@ -0,0 +1,129 @@
+package azuredevops
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WIQL macro names supported in filter flags
+const (
+	WiqlMacroToday            = "@Today"
+	WiqlMacroCurrentIteration = "@CurrentIteration"
+)
+
+// wiqlDateLayout is the date format accepted for date-based filter flags
+const wiqlDateLayout = "2006-01-02"
+
+var (
+	todayMacroPattern            = regexp.MustCompile(`(?i)^@today\s*(?:([+-])\s*(\d+))?$`)
+	currentIterationMacroPattern = regexp.MustCompile(`(?i)^@currentiteration\s*(?:([+-])\s*(\d+))?$`)
+)
+
+// WorkItemFilters holds the optional filters that can be applied to work item queries
+type WorkItemFilters struct {
+	CreatedSince string
+	ChangedSince string
+	Iteration    string
+}
+
+// quoteWiqlString quotes a string literal for use in a WIQL query
+func quoteWiqlString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// formatMacroWithOffset renders a macro with an optional offset in canonical WIQL form
+func formatMacroWithOffset(macro string, sign string, offset string) string {
+	if sign == "" || offset == "" {
+		return macro
+	}
+	return fmt.Sprintf("%s %s %s", macro, sign, offset)
+}
+
+// formatDateFilterValue converts a date filter flag value into a WIQL expression.
+// Accepts @Today, @Today-N, @Today+N or a date in YYYY-MM-DD format.
+func formatDateFilterValue(value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	if matches := todayMacroPattern.FindStringSubmatch(value); matches != nil {
+		return formatMacroWithOffset(WiqlMacroToday, matches[1], matches[2]), nil
+	}
+
+	if _, err := time.Parse(wiqlDateLayout, value); err != nil {
+		return "", errors.Errorf("invalid date filter '%s': use YYYY-MM-DD, %s or %s-N", value, WiqlMacroToday, WiqlMacroToday)
+	}
+
+	return quoteWiqlString(value), nil
+}
+
+// formatIterationFilterValue converts an iteration filter flag value into a WIQL expression.
+// Accepts @CurrentIteration, @CurrentIteration-N, @CurrentIteration+N or an iteration path.
+func formatIterationFilterValue(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", errors.New("iteration filter must not be empty")
+	}
+
+	if matches := currentIterationMacroPattern.FindStringSubmatch(value); matches != nil {
+		return formatMacroWithOffset(WiqlMacroCurrentIteration, matches[1], matches[2]), nil
+	}
+
+	if strings.HasPrefix(value, "@") {
+		return "", errors.Errorf("unsupported iteration macro '%s'", value)
+	}
+
+	return quoteWiqlString(value), nil
+}
+
+// buildFilterClauses builds the WIQL WHERE clauses for the given filters
+func buildFilterClauses(filters WorkItemFilters) ([]string, error) {
+	var clauses []string
+
+	if filters.CreatedSince != "" {
+		expr, err := formatDateFilterValue(filters.CreatedSince)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid created-since filter")
+		}
+		clauses = append(clauses, fmt.Sprintf("[System.CreatedDate] >= %s", expr))
+	}
+
+	if filters.ChangedSince != "" {
+		expr, err := formatDateFilterValue(filters.ChangedSince)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid changed-since filter")
+		}
+		clauses = append(clauses, fmt.Sprintf("[System.ChangedDate] >= %s", expr))
+	}
+
+	if filters.Iteration != "" {
+		expr, err := formatIterationFilterValue(filters.Iteration)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid iteration filter")
+		}
+		operator := "UNDER"
+		if strings.HasPrefix(expr, WiqlMacroCurrentIteration) {
+			operator = "="
+		}
+		clauses = append(clauses, fmt.Sprintf("[System.IterationPath] %s %s", operator, expr))
+	}
+
+	return clauses, nil
+}
+
+// buildAssignedWorkItemsQuery builds the WIQL query for work items assigned to a user
+func buildAssignedWorkItemsQuery(username string, filters WorkItemFilters) (string, error) {
+	clauses := []string{fmt.Sprintf("[System.AssignedTo] = %s", quoteWiqlString(username))}
+
+	filterClauses, err := buildFilterClauses(filters)
+	if err != nil {
+		return "", err
+	}
+	clauses = append(clauses, filterClauses...)
+
+	return "SELECT [System.Id], [System.Title], [System.WorkItemType], [System.State], [System.AssignedTo], [Microsoft.VSTS.Scheduling.CompletedWork] FROM WorkItems WHERE " +
+		strings.Join(clauses, " AND ") +
+		" ORDER BY [System.ChangedDate] DESC", nil
+}
@@ -0,0 +1,98 @@
+package azuredevops
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatDateFilterValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		want      string
+		wantError bool
+	}{
+		{name: "today macro", value: "@Today", want: "@Today"},
+		{name: "lowercase today macro", value: "@today", want: "@Today"},
+		{name: "negative offset", value: "@Today-7", want: "@Today - 7"},
+		{name: "positive offset with spaces", value: "@Today + 2", want: "@Today + 2"},
+		{name: "date", value: "2024-07-24", want: "'2024-07-24'"},
+		{name: "invalid date", value: "24/07/2024", wantError: true},
+		{name: "unknown macro", value: "@Yesterday", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatDateFilterValue(tt.value)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("formatDateFilterValue() error = %v, wantError %v", err, tt.wantError)
+			}
+			if got != tt.want {
+				t.Errorf("formatDateFilterValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatIterationFilterValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		want      string
+		wantError bool
+	}{
+		{name: "current iteration", value: "@CurrentIteration", want: "@CurrentIteration"},
+		{name: "previous iteration", value: "@CurrentIteration-1", want: "@CurrentIteration - 1"},
+		{name: "iteration path", value: `MyProject\Sprint 1`, want: `'MyProject\Sprint 1'`},
+		{name: "path with quote", value: "Bob's Sprint", want: "'Bob''s Sprint'"},
+		{name: "unknown macro", value: "@Today", wantError: true},
+		{name: "empty", value: " ", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatIterationFilterValue(tt.value)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("formatIterationFilterValue() error = %v, wantError %v", err, tt.wantError)
+			}
+			if got != tt.want {
+				t.Errorf("formatIterationFilterValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildAssignedWorkItemsQuery(t *testing.T) {
+	query, err := buildAssignedWorkItemsQuery("John O'Neil", WorkItemFilters{
+		ChangedSince: "@Today-7",
+		Iteration:    "@CurrentIteration",
+	})
+	if err != nil {
+		t.Fatalf("buildAssignedWorkItemsQuery() returned an error: %v", err)
+	}
+
+	wantClauses := []string{
+		"[System.AssignedTo] = 'John O''Neil'",
+		"[System.ChangedDate] >= @Today - 7",
+		"[System.IterationPath] = @CurrentIteration",
+	}
+	for _, clause := range wantClauses {
+		if !strings.Contains(query, clause) {
+			t.Errorf("buildAssignedWorkItemsQuery() = %q, want to contain %q", query, clause)
+		}
+	}
+
+	// An iteration path should match the iteration and its children
+	query, err = buildAssignedWorkItemsQuery("John Doe", WorkItemFilters{Iteration: `MyProject\Sprint 1`})
+	if err != nil {
+		t.Fatalf("buildAssignedWorkItemsQuery() returned an error: %v", err)
+	}
+	if !strings.Contains(query, `[System.IterationPath] UNDER 'MyProject\Sprint 1'`) {
+		t.Errorf("buildAssignedWorkItemsQuery() = %q, want an UNDER clause", query)
+	}
+
+	// Invalid filters should be rejected
+	if _, err := buildAssignedWorkItemsQuery("John Doe", WorkItemFilters{CreatedSince: "last week"}); err == nil {
+		t.Errorf("buildAssignedWorkItemsQuery() did not return an error for an invalid filter")
+	}
+}
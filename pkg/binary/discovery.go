@@ -3,7 +3,10 @@ package binary
 import (
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -33,13 +36,21 @@ func HasValidPrefix(filename string) bool {
 	return false
 }
 
-// IsExecutable checks if a file is executable
+// IsExecutable checks if a file is executable. On the platforms that don't honor shebang lines, scripts are run
+// through their interpreter, so they count as executable too.
 func IsExecutable(path string) bool {
 	fileInfo, err := os.Stat(path)
 	if err != nil {
 		return false
 	}
-	return fileInfo.Mode()&0111 != 0
+	if fileInfo.Mode()&0111 != 0 {
+		return true
+	}
+	if !honorsShebang && fileInfo.Mode().IsRegular() {
+		_, script, _ := ReadShebang(path)
+		return script
+	}
+	return false
 }
 
 // FindInDirectory finds all master-mold binaries in a specific directory
@@ -73,26 +84,70 @@ func FindInDirectory(dir string) ([]string, error) {
 	return binaries, nil
 }
 
-// FindInPath finds all master-mold binaries in the system PATH
-func FindInPath() ([]string, error) {
-	var allBinaries []string
+// Settings of the scan of the PATH
+var (
+	// pathScanWorkers is the number of PATH directories scanned at once
+	pathScanWorkers = 8
+	// pathScanTimeout is how long a PATH directory may take to scan before it is skipped, so an unreachable
+	// network mount can't hang discovery
+	pathScanTimeout = 2 * time.Second
+	// scanDirectory finds the binaries of a directory, replaced in tests
+	scanDirectory = FindInDirectory
+)
 
+// FindInPath finds all master-mold binaries in the system PATH, in the order of its directories. The directories
+// are scanned concurrently, and those that can't be read in time are skipped.
+func FindInPath() ([]string, error) {
 	pathEnv := os.Getenv("PATH")
 	paths := strings.Split(pathEnv, string(os.PathListSeparator))
 
-	for _, path := range paths {
-		binaries, err := FindInDirectory(path)
+	// Scan the directories with a bounded number of workers, keeping the results in the order of the PATH
+	results := make([][]string, len(paths))
+	workers := make(chan struct{}, pathScanWorkers)
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(i int, path string) {
+			defer wg.Done()
+			defer func() { <-workers }()
+			results[i] = scanDirectoryWithTimeout(path, pathScanTimeout)
+		}(i, path)
+	}
+	wg.Wait()
+
+	var allBinaries []string
+	for _, binaries := range results {
+		allBinaries = append(allBinaries, binaries...)
+	}
+	return allBinaries, nil
+}
+
+// scanDirectoryWithTimeout finds the binaries of a directory, giving up on directories that can't be read or take
+// longer than the timeout. A scan that is given up on finishes in the background, since reading a directory
+// can't be interrupted.
+func scanDirectoryWithTimeout(dir string, timeout time.Duration) []string {
+	done := make(chan []string, 1)
+	go func() {
+		binaries, err := scanDirectory(dir)
 		if err != nil {
 			// Skip directories we can't read
-			continue
+			binaries = nil
 		}
-		allBinaries = append(allBinaries, binaries...)
+		done <- binaries
+	}()
+
+	select {
+	case binaries := <-done:
+		return binaries
+	case <-time.After(timeout):
+		return nil
 	}
-
-	return allBinaries, nil
 }
 
-// FindAll finds all master-mold binaries in both the specified directory and PATH
+// FindAll finds all master-mold binaries in both the specified directory and PATH, in the order Resolve prefers
+// them: the PATH before the base directory and the "mm-" prefix before the "master-mold-" prefix. The first binary
+// of a command is the one it runs, and later ones of the same command are shadowed by it.
 func FindAll(baseDir string) ([]string, error) {
 	// Create the base directory if it doesn't exist
 	if _, err := os.Stat(baseDir); os.IsNotExist(err) {
@@ -101,20 +156,71 @@ func FindAll(baseDir string) ([]string, error) {
 		}
 	}
 
-	// Find binaries in the base directory
-	baseDirBinaries, err := FindInDirectory(baseDir)
+	// Find binaries in PATH
+	pathBinaries, err := FindInPath()
 	if err != nil {
 		return nil, err
 	}
 
-	// Find binaries in PATH
-	pathBinaries, err := FindInPath()
+	// Find binaries in the base directory
+	baseDirBinaries, err := FindInDirectory(baseDir)
 	if err != nil {
 		return nil, err
 	}
 
-	// Combine the results
-	return append(baseDirBinaries, pathBinaries...), nil
+	// Combine the results, without the binaries of directories listed twice
+	sortByPrefix(pathBinaries)
+	sortByPrefix(baseDirBinaries)
+	var binaries []string
+	seen := make(map[string]bool)
+	for _, binaryPath := range append(pathBinaries, baseDirBinaries...) {
+		if cleaned := filepath.Clean(binaryPath); !seen[cleaned] {
+			seen[cleaned] = true
+			binaries = append(binaries, binaryPath)
+		}
+	}
+	return binaries, nil
+}
+
+// ResolveAll finds all the binaries a subcommand name could resolve to, in the order Resolve prefers them. The
+// first is the binary the subcommand runs and the others are shadowed by it.
+func ResolveAll(command string, baseDir string) []Resolution {
+	var resolutions []Resolution
+	seen := make(map[string]bool)
+	add := func(dir string, source Source, prefix BinaryPrefix) {
+		fullPath := filepath.Join(dir, string(prefix)+command)
+		if !seen[fullPath] && IsExecutable(fullPath) {
+			seen[fullPath] = true
+			resolutions = append(resolutions, Resolution{Path: fullPath, Source: source, Prefix: prefix})
+		}
+	}
+
+	for _, prefix := range ValidPrefixes() {
+		for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+			if dir != "" {
+				add(dir, SourcePath, prefix)
+			}
+		}
+	}
+	for _, prefix := range ValidPrefixes() {
+		add(os.ExpandEnv(baseDir), SourceBaseDir, prefix)
+	}
+	return resolutions
+}
+
+// sortByPrefix sorts binary paths by the precedence of their prefix, keeping the order of those with the same one
+func sortByPrefix(binaryPaths []string) {
+	rank := func(binaryPath string) int {
+		for i, prefix := range ValidPrefixes() {
+			if strings.HasPrefix(filepath.Base(binaryPath), string(prefix)) {
+				return i
+			}
+		}
+		return len(ValidPrefixes())
+	}
+	sort.SliceStable(binaryPaths, func(i, j int) bool {
+		return rank(binaryPaths[i]) < rank(binaryPaths[j])
+	})
 }
 
 // ExtractCommandName extracts the command name from a binary path
@@ -3,7 +3,9 @@ package binary
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestHasValidPrefix(t *testing.T) {
@@ -190,4 +192,86 @@ func TestFindInDirectory(t *testing.T) {
 	if !foundTest3 {
 		t.Errorf("FindInDirectory() did not find master-mold-test3")
 	}
-}
\ No newline at end of file
+}
+func TestFindAll_Precedence(t *testing.T) {
+	pathDir1, pathDir2, baseDir := t.TempDir(), t.TempDir(), t.TempDir()
+	for _, path := range []string{
+		filepath.Join(pathDir1, "master-mold-deploy"),
+		filepath.Join(pathDir2, "mm-deploy"),
+		filepath.Join(pathDir2, "mm-lint"),
+		filepath.Join(baseDir, "mm-deploy"),
+	} {
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", path, err)
+		}
+	}
+	// The base directory is also in the PATH, which must not list its binaries twice
+	t.Setenv("PATH", strings.Join([]string{pathDir1, pathDir2, baseDir}, string(os.PathListSeparator)))
+
+	got, err := FindAll(baseDir)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	want := []string{
+		filepath.Join(pathDir2, "mm-deploy"),
+		filepath.Join(pathDir2, "mm-lint"),
+		filepath.Join(baseDir, "mm-deploy"),
+		filepath.Join(pathDir1, "master-mold-deploy"),
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("FindAll() = %v, want %v", got, want)
+	}
+
+	// The first binary is the one Resolve finds, and the others are in the same order
+	resolution, err := Resolve("deploy", baseDir)
+	if err != nil || resolution.Path != want[0] {
+		t.Errorf("Resolve() = %v, %v, want %s", resolution.Path, err, want[0])
+	}
+	var paths []string
+	for _, resolution := range ResolveAll("deploy", baseDir) {
+		paths = append(paths, resolution.Path)
+	}
+	if wantPaths := []string{want[0], want[2], want[3]}; strings.Join(paths, ",") != strings.Join(wantPaths, ",") {
+		t.Errorf("ResolveAll() = %v, want %v", paths, wantPaths)
+	}
+}
+
+func TestFindInPath_Concurrent(t *testing.T) {
+	// More directories than workers, each with a binary, and one that never finishes reading
+	var dirs, want []string
+	for i := 0; i < 20; i++ {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "mm-tool")
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", path, err)
+		}
+		dirs, want = append(dirs, dir), append(want, path)
+	}
+	hung := "/mnt/unreachable"
+	dirs = append(dirs[:10], append([]string{hung}, dirs[10:]...)...)
+	t.Setenv("PATH", strings.Join(dirs, string(os.PathListSeparator)))
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+	originalScan, originalTimeout := scanDirectory, pathScanTimeout
+	t.Cleanup(func() { scanDirectory, pathScanTimeout = originalScan, originalTimeout })
+	scanDirectory = func(dir string) ([]string, error) {
+		if dir == hung {
+			<-unblock
+		}
+		return FindInDirectory(dir)
+	}
+	pathScanTimeout = 50 * time.Millisecond
+
+	started := time.Now()
+	got, err := FindInPath()
+	if err != nil {
+		t.Fatalf("FindInPath() error = %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("FindInPath() took %s, want the unreachable directory skipped after the timeout", elapsed)
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("FindInPath() = %v, want %v in the order of the PATH", got, want)
+	}
+}
@@ -1,58 +1,167 @@
 package binary
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
+	"time"
 
+	"github.com/oscarrieken/master-mold/pkg/env"
 	"github.com/pkg/errors"
 	"log/slog"
 )
 
+// waitDelay is how long to wait for the outputs of a subcommand with a timeout to be closed once it exited or was
+// killed
+const waitDelay = 2 * time.Second
+
+// Source is where the binary of a subcommand was found
+type Source string
+
+const (
+	// SourcePath is a binary found in a directory of the PATH
+	SourcePath Source = "PATH"
+	// SourceBaseDir is a binary found in the base directory
+	SourceBaseDir Source = "base directory"
+)
+
+// SourceOf gets where a discovered binary was found: the base directory, or else the PATH
+func SourceOf(binaryPath string, baseDir string) Source {
+	if filepath.Dir(binaryPath) == filepath.Clean(os.ExpandEnv(baseDir)) {
+		return SourceBaseDir
+	}
+	return SourcePath
+}
+
+// Resolution describes the binary a subcommand name resolves to
+type Resolution struct {
+	// Path is the path of the binary
+	Path string
+	// Source is where the binary was found
+	Source Source
+	// Prefix is the prefix of the binary name
+	Prefix BinaryPrefix
+}
+
 // FindExecutable finds the executable for a given command name
 func FindExecutable(command string, baseDir string) (string, error) {
-	// Try both naming conventions
-	binNames := []string{
-		string(MMPrefix) + command,
-		string(MasterMoldPrefix) + command,
+	resolution, err := Resolve(command, baseDir)
+	if err != nil {
+		return "", err
 	}
+	return resolution.Path, nil
+}
 
+// Resolve finds the binary a subcommand name resolves to. The PATH is searched first, then the base
+// directory, each time for the "mm-" prefix before the "master-mold-" prefix.
+func Resolve(command string, baseDir string) (Resolution, error) {
 	// Expand environment variables in the base directory
 	expandedBaseDir := os.ExpandEnv(baseDir)
 
 	// First, look for the binary in PATH
-	for _, binName := range binNames {
-		cmdPath, err := exec.LookPath(binName)
+	for _, prefix := range ValidPrefixes() {
+		cmdPath, err := exec.LookPath(string(prefix) + command)
 		if err == nil {
 			// Found the binary in PATH
-			return cmdPath, nil
+			return Resolution{Path: cmdPath, Source: SourcePath, Prefix: prefix}, nil
 		}
 	}
 
 	// If not found in PATH, check the base directory
-	for _, binName := range binNames {
-		fullPath := filepath.Join(expandedBaseDir, binName)
+	for _, prefix := range ValidPrefixes() {
+		fullPath := filepath.Join(expandedBaseDir, string(prefix)+command)
 		if IsExecutable(fullPath) {
-			return fullPath, nil
+			return Resolution{Path: fullPath, Source: SourceBaseDir, Prefix: prefix}, nil
 		}
 	}
 
-	return "", errors.Errorf("subcommand '%s' not found", command)
+	return Resolution{}, errors.WithStack(&NotFoundError{Command: command})
 }
 
-// Execute executes a subcommand binary
-func Execute(cmdPath string, args []string, logger *slog.Logger) error {
-	logger.Info("Executing binary", "path", cmdPath, "args", args)
+// NotFoundError is the error of resolving a subcommand that has no binary
+type NotFoundError struct {
+	Command string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("subcommand '%s' not found", e.Command)
+}
+
+// TimeoutError is the error of a binary killed for running longer than the timeout
+type TimeoutError struct {
+	Path    string
+	Timeout time.Duration
+}
 
-	// Create the command
-	cmd := exec.Command(cmdPath, args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("binary '%s' timed out after %s", e.Path, e.Timeout)
+}
+
+// Execute executes a subcommand binary. The binary is killed when it runs longer than the timeout;
+// a timeout of zero means no limit.
+func Execute(cmdPath string, args []string, timeout time.Duration, logger *slog.Logger) error {
+	return ExecuteWithStreams(cmdPath, args, timeout, os.Stdin, os.Stdout, os.Stderr, logger)
+}
+
+// ExecuteWithStreams executes a subcommand binary like Execute, with the given standard input and outputs
+func ExecuteWithStreams(cmdPath string, args []string, timeout time.Duration, stdin io.Reader, stdout, stderr io.Writer, logger *slog.Logger) error {
+	return ExecuteWithEnv(cmdPath, args, timeout, nil, stdin, stdout, stderr, logger)
+}
+
+// ExecuteWithEnv executes a subcommand binary like ExecuteWithStreams, with the given "NAME=value" variables
+// added to the environment of master-mold. MM_PARENT_PID tells the subcommand it runs under master-mold.
+func ExecuteWithEnv(cmdPath string, args []string, timeout time.Duration, variables []string, stdin io.Reader, stdout, stderr io.Writer, logger *slog.Logger) error {
+	logger.Info("Executing binary", "path", cmdPath, "args", args, "timeout", timeout)
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// Scripts fail with a confusing error when their interpreter is missing, so check it first
+	if err := ValidateScript(cmdPath); err != nil {
+		return errors.Wrapf(err, "script '%s' can't run", cmdPath)
+	}
+
+	// Create the command, running scripts through their interpreter where shebangs aren't honored
+	cmd, err := CommandContext(ctx, cmdPath, args)
+	if err != nil {
+		return err
+	}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Stdin = stdin
+	cmd.Env = append(os.Environ(), env.ParentPID+"="+strconv.Itoa(os.Getpid()))
+	cmd.Env = append(cmd.Env, variables...)
+	if timeout > 0 {
+		// Kill the processes the subcommand started along with it, and stop waiting for those that still hold its
+		// outputs open
+		inProcessGroup(cmd)
+		cmd.WaitDelay = waitDelay
+	}
 
 	// Execute the command
-	if err := cmd.Run(); err != nil {
+	err = cmd.Start()
+	if err == nil {
+		if timeout > 0 {
+			defer forwardToProcessGroup(cmd)()
+		}
+		err = cmd.Wait()
+	}
+	if errors.Is(err, exec.ErrWaitDelay) {
+		// The subcommand exited successfully, leaving processes behind
+		err = nil
+	}
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return errors.WithStack(&TimeoutError{Path: cmdPath, Timeout: timeout})
+		}
 		return errors.Wrapf(err, "failed to execute binary '%s'", cmdPath)
 	}
 
@@ -60,7 +169,7 @@ func Execute(cmdPath string, args []string, logger *slog.Logger) error {
 }
 
 // ExecuteSubcommand finds and executes a subcommand
-func ExecuteSubcommand(command string, args []string, baseDir string, logger *slog.Logger) error {
+func ExecuteSubcommand(command string, args []string, baseDir string, timeout time.Duration, logger *slog.Logger) error {
 	// Find the executable
 	cmdPath, err := FindExecutable(command, baseDir)
 	if err != nil {
@@ -70,17 +179,5 @@ func ExecuteSubcommand(command string, args []string, baseDir string, logger *sl
 	logger.Info("Executing subcommand", "command", command, "binary", cmdPath)
 
 	// Execute the command
-	return Execute(cmdPath, args, logger)
-}
-
-// IsRunningAsSubcommand checks if the current process is running as a subcommand of master-mold
-func IsRunningAsSubcommand() (bool, error) {
-	parentPID := os.Getppid()
-	parentProcess, err := exec.Command("ps", "-o", "comm=", "-p", fmt.Sprintf("%d", parentPID)).Output()
-	if err != nil {
-		return false, errors.Wrap(err, "failed to get parent process information")
-	}
-
-	parentName := string(parentProcess)
-	return filepath.Base(parentName) == "master-mold", nil
+	return Execute(cmdPath, args, timeout, logger)
 }
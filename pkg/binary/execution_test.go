@@ -1,9 +1,14 @@
 package binary
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/pkg/errors"
 
 	"log/slog"
 )
@@ -125,8 +130,103 @@ func TestExecute(t *testing.T) {
 	}
 	
 	// Test that Execute doesn't panic
-	err = Execute(echoPath, []string{"test"}, logger)
+	err = Execute(echoPath, []string{"test"}, 0, logger)
 	if err != nil {
 		t.Errorf("Execute() error = %v", err)
 	}
-}
\ No newline at end of file
+}
+func TestExecute_Timeout(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+
+	sleepPath := "/bin/sleep"
+	if _, err := os.Stat(sleepPath); os.IsNotExist(err) {
+		t.Skip("Skipping test on non-Unix platform")
+	}
+
+	start := time.Now()
+	err := Execute(sleepPath, []string{"10"}, 100*time.Millisecond, logger)
+	if err == nil || !strings.Contains(err.Error(), "timed out after 100ms") {
+		t.Errorf("Execute() error = %v, want a timeout error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Execute() took %s, want the binary to be killed after the timeout", elapsed)
+	}
+}
+
+func TestExecute_TimeoutKillsProcessGroup(t *testing.T) {
+	if _, err := os.Stat("/bin/sleep"); os.IsNotExist(err) {
+		t.Skip("Skipping test on non-Unix platform")
+	}
+	script := filepath.Join(t.TempDir(), "mm-spawn")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\n/bin/sleep 10\n"), 0755); err != nil {
+		t.Fatalf("Failed to create script: %v", err)
+	}
+
+	// The sleep started by the script holds the output pipe open until it's killed too
+	start := time.Now()
+	var stdout strings.Builder
+	err := ExecuteWithEnv(script, nil, 100*time.Millisecond, nil, nil, &stdout, &stdout, slog.New(slog.DiscardHandler))
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Errorf("ExecuteWithEnv() error = %v, want a timeout error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("ExecuteWithEnv() took %s, want the processes started by the binary to be killed after the timeout", elapsed)
+	}
+}
+
+func TestExecuteWithEnv(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "mm-env")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho \"$MM_TEST_VALUE $MM_TEST_INHERITED $MM_PARENT_PID\"\n"), 0755); err != nil {
+		t.Fatalf("Failed to create script: %v", err)
+	}
+	t.Setenv("MM_TEST_INHERITED", "inherited")
+
+	var stdout strings.Builder
+	err := ExecuteWithEnv(script, nil, 0, []string{"MM_TEST_VALUE=added"}, nil, &stdout, &stdout, slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatalf("ExecuteWithEnv() error = %v", err)
+	}
+	if got, want := stdout.String(), fmt.Sprintf("added inherited %d\n", os.Getpid()); got != want {
+		t.Errorf("ExecuteWithEnv() output = %q, want %q with the added and the inherited variables", got, want)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	pathDir := t.TempDir()
+	baseDir := t.TempDir()
+	for _, path := range []string{
+		filepath.Join(pathDir, "master-mold-shared"),
+		filepath.Join(baseDir, "mm-shared"),
+		filepath.Join(baseDir, "master-mold-local"),
+	} {
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", path, err)
+		}
+	}
+	t.Setenv("PATH", pathDir)
+
+	tests := []struct {
+		command string
+		want    Resolution
+	}{
+		// The PATH wins over the base directory, whatever the prefix
+		{"shared", Resolution{Path: filepath.Join(pathDir, "master-mold-shared"), Source: SourcePath, Prefix: MasterMoldPrefix}},
+		{"local", Resolution{Path: filepath.Join(baseDir, "master-mold-local"), Source: SourceBaseDir, Prefix: MasterMoldPrefix}},
+	}
+
+	for _, tt := range tests {
+		got, err := Resolve(tt.command, baseDir)
+		if err != nil {
+			t.Errorf("Resolve(%s) error = %v", tt.command, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Resolve(%s) = %+v, want %+v", tt.command, got, tt.want)
+		}
+	}
+
+	if _, err := Resolve("missing", baseDir); err == nil {
+		t.Error("Resolve(missing) error = nil, want a not found error")
+	}
+}
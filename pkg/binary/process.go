@@ -0,0 +1,47 @@
+package binary
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/pkg/errors"
+)
+
+// masterMoldName is the name of the master-mold executable, without extension
+const masterMoldName = "master-mold"
+
+// IsRunningAsSubcommand checks if the current process is running as a subcommand of master-mold. master-mold
+// passes its process ID in MM_PARENT_PID; without it, the name of the parent process is checked.
+func IsRunningAsSubcommand() (bool, error) {
+	parentPID := os.Getppid()
+	if os.Getenv(env.ParentPID) == strconv.Itoa(parentPID) {
+		return true, nil
+	}
+
+	name, err := processName(parentPID)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get parent process information")
+	}
+	return isMasterMoldName(name), nil
+}
+
+// ProcessRunning checks if a process is running. Where processes can't be looked up, every process is assumed to be
+// running.
+func ProcessRunning(pid int) bool {
+	if !slices.Contains([]string{"darwin", "linux", "windows"}, runtime.GOOS) {
+		return true
+	}
+	_, err := processName(pid)
+	return err == nil
+}
+
+// isMasterMoldName checks if a process name or executable path is that of master-mold
+func isMasterMoldName(name string) bool {
+	name = strings.ToLower(filepath.Base(strings.TrimSpace(name)))
+	return strings.TrimSuffix(name, ".exe") == masterMoldName
+}
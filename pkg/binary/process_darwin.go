@@ -0,0 +1,21 @@
+package binary
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// processName gets the name of a process with the kern.proc.pid sysctl
+func processName(pid int) (string, error) {
+	info, err := unix.SysctlKinfoProc("kern.proc.pid", pid)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read the name of process %d", pid)
+	}
+	comm := info.Proc.P_comm[:]
+	if end := bytes.IndexByte(comm, 0); end >= 0 {
+		comm = comm[:end]
+	}
+	return string(comm), nil
+}
@@ -0,0 +1,15 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+
+package binary
+
+import "os/exec"
+
+// inProcessGroup leaves the subcommand in the process group of master-mold on this platform, where only the
+// subcommand itself is killed on timeout
+func inProcessGroup(cmd *exec.Cmd) {}
+
+// forwardToProcessGroup does nothing on this platform, where the subcommand gets the signals of master-mold's
+// process group
+func forwardToProcessGroup(cmd *exec.Cmd) func() {
+	return func() {}
+}
@@ -0,0 +1,59 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package binary
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// inProcessGroup makes the subcommand start in a process group of its own, so that on timeout the processes it
+// started are killed with it instead of holding its outputs open. When master-mold owns the terminal the subcommand
+// reads, the group gets the terminal so that the subcommand can still prompt and get Ctrl-C.
+func inProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if file, ok := cmd.Stdin.(*os.File); ok {
+		fd := int(file.Fd())
+		if pgrp, err := unix.IoctlGetInt(fd, unix.TIOCGPGRP); err == nil && pgrp == syscall.Getpgrp() {
+			cmd.SysProcAttr.Foreground = true
+			cmd.SysProcAttr.Ctty = fd
+		}
+	}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
+
+// forwardToProcessGroup forwards the signals master-mold gets to the process group of the started subcommand. The
+// returned function, called once the subcommand exited, stops it and takes the terminal back.
+func forwardToProcessGroup(cmd *exec.Cmd) func() {
+	pgid := cmd.Process.Pid
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				_ = syscall.Kill(-pgid, sig.(syscall.Signal))
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+		if cmd.SysProcAttr.Foreground {
+			// Taking the terminal back from the background is only allowed with SIGTTOU ignored
+			signal.Ignore(syscall.SIGTTOU)
+			_ = unix.IoctlSetPointerInt(cmd.SysProcAttr.Ctty, unix.TIOCSPGRP, syscall.Getpgrp())
+			signal.Reset(syscall.SIGTTOU)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package binary
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// processName gets the name of a process from /proc
+func processName(pid int) (string, error) {
+	comm, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read the name of process %d", pid)
+	}
+	return string(comm), nil
+}
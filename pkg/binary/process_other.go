@@ -0,0 +1,15 @@
+//go:build !darwin && !linux && !windows
+
+package binary
+
+import (
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// processName reports that process names can't be read on this platform, where only MM_PARENT_PID identifies
+// master-mold
+func processName(pid int) (string, error) {
+	return "", errors.Errorf("process names can't be read on %s", runtime.GOOS)
+}
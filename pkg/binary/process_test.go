@@ -0,0 +1,47 @@
+package binary
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestIsMasterMoldName(t *testing.T) {
+	tests := map[string]bool{
+		"master-mold\n":              true,
+		"/usr/local/bin/master-mold": true,
+		"Master-Mold.exe":            true,
+		"master-mold-azure-devops":   false,
+		"bash":                       false,
+	}
+
+	for name, want := range tests {
+		if got := isMasterMoldName(name); got != want {
+			t.Errorf("isMasterMoldName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestIsRunningAsSubcommand(t *testing.T) {
+	// The parent of the test is go test, not master-mold
+	t.Setenv("MM_PARENT_PID", "")
+	if got, err := IsRunningAsSubcommand(); err != nil || got {
+		t.Errorf("IsRunningAsSubcommand() = %v, %v, want false", got, err)
+	}
+
+	t.Setenv("MM_PARENT_PID", strconv.Itoa(os.Getppid()))
+	if got, err := IsRunningAsSubcommand(); err != nil || !got {
+		t.Errorf("IsRunningAsSubcommand() with MM_PARENT_PID = %v, %v, want true", got, err)
+	}
+}
+
+func TestProcessName(t *testing.T) {
+	name, err := processName(os.Getpid())
+	if err != nil {
+		t.Fatalf("processName() error = %v", err)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(name), "binary.test") {
+		t.Errorf("processName() = %q, want the name of the test binary", name)
+	}
+}
@@ -0,0 +1,26 @@
+package binary
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/windows"
+)
+
+// processName gets the executable name of a process from a snapshot of the processes
+func processName(pid int) (string, error) {
+	snapshot, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list processes")
+	}
+	defer windows.CloseHandle(snapshot)
+
+	var entry windows.ProcessEntry32
+	entry.Size = uint32(unsafe.Sizeof(entry))
+	for err = windows.Process32First(snapshot, &entry); err == nil; err = windows.Process32Next(snapshot, &entry) {
+		if entry.ProcessID == uint32(pid) {
+			return windows.UTF16ToString(entry.ExeFile[:]), nil
+		}
+	}
+	return "", errors.Errorf("process %d not found", pid)
+}
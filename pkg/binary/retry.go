@@ -0,0 +1,51 @@
+package binary
+
+import (
+	"io"
+	"log/slog"
+	"os/exec"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RetryPolicy is how the failed runs of a subcommand binary are retried
+type RetryPolicy struct {
+	// Retries is how many times a failed run is retried
+	Retries int
+	// ExitCodes are the exit codes retried; when empty, every non-zero exit code is. Runs that timed out, were killed
+	// by a signal or couldn't start aren't retried.
+	ExitCodes []int
+	// Backoff is the wait before the first retry, doubled before each of the next ones
+	Backoff time.Duration
+}
+
+// sleep waits between retries. It is replaced in tests.
+var sleep = time.Sleep
+
+// retries checks if a failed run is retried by the policy
+func (p RetryPolicy) retries(err error) bool {
+	var exitErr *exec.ExitError
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) || !errors.As(err, &exitErr) || exitErr.ExitCode() <= 0 {
+		return false
+	}
+	return len(p.ExitCodes) == 0 || slices.Contains(p.ExitCodes, exitErr.ExitCode())
+}
+
+// ExecuteWithRetry executes a subcommand binary like ExecuteWithEnv, running it again when it fails as the policy
+// allows. Each run gets the same arguments; what was read from stdin by a failed run isn't replayed.
+func ExecuteWithRetry(policy RetryPolicy, cmdPath string, args []string, timeout time.Duration, variables []string, stdin io.Reader, stdout, stderr io.Writer, logger *slog.Logger) error {
+	backoff := policy.Backoff
+	for attempt := 0; ; attempt++ {
+		err := ExecuteWithEnv(cmdPath, args, timeout, variables, stdin, stdout, stderr, logger)
+		if err == nil || attempt >= policy.Retries || !policy.retries(err) {
+			return err
+		}
+
+		logger.Warn("Retrying failed binary", "path", cmdPath, "attempt", attempt+1, "retries", policy.Retries, "backoff", backoff, "error", err)
+		sleep(backoff)
+		backoff *= 2
+	}
+}
@@ -0,0 +1,117 @@
+package binary
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestExecuteWithRetry(t *testing.T) {
+	var waits []time.Duration
+	sleep = func(d time.Duration) { waits = append(waits, d) }
+	defer func() { sleep = time.Sleep }()
+
+	tests := []struct {
+		name      string
+		exitCode  string
+		policy    RetryPolicy
+		wantErr   bool
+		wantRuns  int
+		wantWaits []time.Duration
+	}{
+		{
+			name:      "succeeds after retries",
+			exitCode:  "75",
+			policy:    RetryPolicy{Retries: 2, ExitCodes: []int{75}, Backoff: time.Second},
+			wantRuns:  3,
+			wantWaits: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:      "gives up after the retries",
+			exitCode:  "75",
+			policy:    RetryPolicy{Retries: 1, ExitCodes: []int{75}},
+			wantErr:   true,
+			wantRuns:  2,
+			wantWaits: []time.Duration{0},
+		},
+		{
+			name:     "doesn't retry other exit codes",
+			exitCode: "1",
+			policy:   RetryPolicy{Retries: 2, ExitCodes: []int{75}},
+			wantErr:  true,
+			wantRuns: 1,
+		},
+		{
+			name:      "retries every non-zero exit code without exit codes",
+			exitCode:  "1",
+			policy:    RetryPolicy{Retries: 2},
+			wantRuns:  3,
+			wantWaits: []time.Duration{0, 0},
+		},
+		{
+			name:     "doesn't retry without retries",
+			exitCode: "75",
+			policy:   RetryPolicy{ExitCodes: []int{75}},
+			wantErr:  true,
+			wantRuns: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			waits = nil
+			runs := filepath.Join(t.TempDir(), "runs")
+			// The script fails until its third run
+			script := writeScript(t, "mm-flaky", "#!/bin/sh\necho run >> \"$MM_TEST_RUNS\"\n[ \"$(wc -l < \"$MM_TEST_RUNS\")\" -ge 3 ] || exit "+tt.exitCode+"\n", 0755)
+
+			err := ExecuteWithRetry(tt.policy, script, nil, 0, []string{"MM_TEST_RUNS=" + runs}, nil, nil, nil, slog.New(slog.DiscardHandler))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExecuteWithRetry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			content, err := os.ReadFile(runs)
+			if err != nil {
+				t.Fatalf("Failed to read the runs: %v", err)
+			}
+			if got := strings.Count(string(content), "run\n"); got != tt.wantRuns {
+				t.Errorf("ExecuteWithRetry() ran the binary %d times, want %d", got, tt.wantRuns)
+			}
+			if len(waits) != len(tt.wantWaits) {
+				t.Fatalf("ExecuteWithRetry() waited %v, want %v", waits, tt.wantWaits)
+			}
+			for i := range waits {
+				if waits[i] != tt.wantWaits[i] {
+					t.Errorf("ExecuteWithRetry() waited %v, want %v", waits, tt.wantWaits)
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteWithRetry_Unretried(t *testing.T) {
+	sleep = func(time.Duration) {}
+	defer func() { sleep = time.Sleep }()
+	policy := RetryPolicy{Retries: 2}
+
+	// A run that timed out isn't retried
+	runs := filepath.Join(t.TempDir(), "runs")
+	script := writeScript(t, "mm-slow", "#!/bin/sh\necho run >> \"$MM_TEST_RUNS\"\nexec /bin/sleep 10\n", 0755)
+	err := ExecuteWithRetry(policy, script, nil, 100*time.Millisecond, []string{"MM_TEST_RUNS=" + runs}, nil, nil, nil, slog.New(slog.DiscardHandler))
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("ExecuteWithRetry() error = %v, want a timeout error", err)
+	}
+	if content, _ := os.ReadFile(runs); strings.Count(string(content), "run\n") != 1 {
+		t.Errorf("ExecuteWithRetry() ran the binary that timed out %d times, want once", strings.Count(string(content), "run\n"))
+	}
+
+	// Neither is a binary that can't start
+	if err := ExecuteWithRetry(policy, filepath.Join(t.TempDir(), "mm-missing"), nil, 0, nil, nil, nil, nil, slog.New(slog.DiscardHandler)); err == nil {
+		t.Error("ExecuteWithRetry() of a missing binary error = nil")
+	}
+}
@@ -0,0 +1,54 @@
+package binary
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// UnsafeError is the error of a binary that other users could replace, planting their own code
+type UnsafeError struct {
+	// Path is the path of the binary or of its directory
+	Path string
+	// Reason is why it is unsafe, such as "is world-writable"
+	Reason string
+}
+
+func (e *UnsafeError) Error() string {
+	return fmt.Sprintf("'%s' %s", e.Path, e.Reason)
+}
+
+// CheckSafety checks that other users can't replace a binary: that neither the binary nor its directory is
+// world-writable, or owned by a user other than the current one and root. For a symlink, the file it resolves to
+// and the directory of that file are checked, along with the directory of the symlink. Windows isn't checked, since
+// its permissions aren't mode bits.
+func CheckSafety(binaryPath string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	target, err := filepath.EvalSymlinks(binaryPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to resolve '%s'", binaryPath)
+	}
+	paths := []string{target, filepath.Dir(target)}
+	if dir := filepath.Dir(binaryPath); dir != filepath.Dir(target) {
+		paths = append(paths, dir)
+	}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to check the permissions of '%s'", path)
+		}
+		if info.Mode().Perm()&0002 != 0 {
+			return &UnsafeError{Path: path, Reason: "is world-writable"}
+		}
+		if uid, ok := fileOwner(info); ok && uid != 0 && uid != os.Getuid() {
+			return &UnsafeError{Path: path, Reason: fmt.Sprintf("is owned by another user (uid %d)", uid)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package binary
+
+import "os"
+
+// fileOwner reports that the owners of files aren't known on this platform
+func fileOwner(info os.FileInfo) (int, bool) {
+	return 0, false
+}
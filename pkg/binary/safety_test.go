@@ -0,0 +1,118 @@
+package binary
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCheckSafety(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Permissions aren't checked on Windows")
+	}
+
+	tests := []struct {
+		name       string
+		dirMode    os.FileMode
+		binaryMode os.FileMode
+		wantReason string
+	}{
+		{name: "safe", dirMode: 0755, binaryMode: 0755},
+		{name: "world-writable binary", dirMode: 0755, binaryMode: 0777, wantReason: "is world-writable"},
+		{name: "world-writable directory", dirMode: 0777, binaryMode: 0755, wantReason: "is world-writable"},
+		{name: "sticky world-writable directory", dirMode: 0777 | os.ModeSticky, binaryMode: 0755, wantReason: "is world-writable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "bin")
+			path := filepath.Join(dir, "mm-deploy")
+			if err := os.Mkdir(dir, 0755); err != nil {
+				t.Fatalf("Failed to create %s: %v", dir, err)
+			}
+			if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+				t.Fatalf("Failed to create %s: %v", path, err)
+			}
+			// Chmod isn't subject to the umask
+			if err := os.Chmod(path, tt.binaryMode); err != nil {
+				t.Fatalf("Failed to change the mode of %s: %v", path, err)
+			}
+			if err := os.Chmod(dir, tt.dirMode); err != nil {
+				t.Fatalf("Failed to change the mode of %s: %v", dir, err)
+			}
+
+			err := CheckSafety(path)
+			var unsafe *UnsafeError
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Errorf("CheckSafety() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.As(err, &unsafe) || unsafe.Reason != tt.wantReason {
+				t.Errorf("CheckSafety() error = %v, want an UnsafeError because it %s", err, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestCheckSafety_Symlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Permissions aren't checked on Windows")
+	}
+
+	// The symlink sits in a safe directory, and the binary it resolves to in a world-writable one
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to resolve the temporary directory: %v", err)
+	}
+	targetDir := filepath.Join(root, "shared")
+	linkDir := filepath.Join(root, "bin")
+	for _, dir := range []string{targetDir, linkDir} {
+		if err := os.Mkdir(dir, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	target := filepath.Join(targetDir, "deploy")
+	if err := os.WriteFile(target, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", target, err)
+	}
+	link := filepath.Join(linkDir, "mm-deploy")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatalf("Failed to create %s: %v", link, err)
+	}
+	if err := CheckSafety(link); err != nil {
+		t.Fatalf("CheckSafety() error = %v, want nil", err)
+	}
+
+	if err := os.Chmod(targetDir, 0777); err != nil {
+		t.Fatalf("Failed to change the mode of %s: %v", targetDir, err)
+	}
+	var unsafe *UnsafeError
+	if err := CheckSafety(link); !errors.As(err, &unsafe) || unsafe.Path != targetDir {
+		t.Errorf("CheckSafety() error = %v, want the directory of the resolved binary reported", err)
+	}
+}
+
+func TestCheckSafety_Owner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Permissions aren't checked on Windows")
+	}
+
+	path := filepath.Join(t.TempDir(), "mm-deploy")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", path, err)
+	}
+	// Only root can give a file to another user
+	if err := os.Chown(path, 4242, -1); err != nil {
+		t.Skipf("Can't change the owner of %s: %v", path, err)
+	}
+
+	err := CheckSafety(path)
+	if err == nil || !strings.Contains(err.Error(), "is owned by another user (uid 4242)") {
+		t.Errorf("CheckSafety() error = %v, want the binary to be owned by another user", err)
+	}
+}
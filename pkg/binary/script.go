@@ -0,0 +1,124 @@
+package binary
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxShebangLength is how much of a file is read to find its shebang line, as much as Linux reads
+const maxShebangLength = 256
+
+// honorsShebang is set on the platforms that run scripts with the interpreter of their shebang line; elsewhere
+// master-mold runs them through it. It is replaced in tests.
+var honorsShebang = runtime.GOOS != "windows"
+
+// Shebang is the interpreter line of a script, such as "#!/usr/bin/env python3"
+type Shebang struct {
+	// Interpreter is the path of the interpreter, such as /usr/bin/env
+	Interpreter string
+	// Args are the arguments of the interpreter before the script, such as python3
+	Args []string
+	// crlf is set when the line ends with a carriage return, which becomes part of the interpreter name
+	crlf bool
+}
+
+// ReadShebang reads the shebang line of a script. It reports false for files that aren't scripts, such as compiled
+// binaries.
+func ReadShebang(path string) (Shebang, bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Shebang{}, false, errors.Wrapf(err, "failed to read '%s'", path)
+	}
+	defer file.Close()
+
+	head := make([]byte, maxShebangLength)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return Shebang{}, false, errors.Wrapf(err, "failed to read '%s'", path)
+	}
+	head = head[:n]
+	if !bytes.HasPrefix(head, []byte("#!")) {
+		return Shebang{}, false, nil
+	}
+
+	line, _, _ := bytes.Cut(head[2:], []byte("\n"))
+	shebang := Shebang{crlf: bytes.HasSuffix(line, []byte("\r"))}
+	fields := strings.Fields(string(line))
+	if len(fields) > 0 {
+		shebang.Interpreter, shebang.Args = fields[0], fields[1:]
+	}
+	return shebang, true, nil
+}
+
+// ValidateScript checks that a script can run: that its shebang line names an interpreter and that the interpreter
+// is installed. Files that aren't scripts are valid.
+func ValidateScript(path string) error {
+	shebang, ok, err := ReadShebang(path)
+	if err != nil || !ok {
+		return err
+	}
+	_, _, err = shebang.interpreter()
+	return err
+}
+
+// Command gets the program and the arguments that run a subcommand binary: the binary itself, or the interpreter
+// of a script on the platforms that don't honor shebang lines
+func Command(cmdPath string, args []string) (string, []string) {
+	if honorsShebang {
+		return cmdPath, args
+	}
+
+	shebang, ok, err := ReadShebang(cmdPath)
+	if err != nil || !ok {
+		return cmdPath, args
+	}
+	program, programArgs, err := shebang.interpreter()
+	if err != nil {
+		return cmdPath, args
+	}
+	return program, append(append(programArgs, cmdPath), args...)
+}
+
+// interpreter finds the program that runs the script and its arguments before the script. With /usr/bin/env, it
+// is the program env would run from the PATH.
+func (s Shebang) interpreter() (string, []string, error) {
+	switch {
+	case s.Interpreter == "":
+		return "", nil, errors.New("the shebang line names no interpreter")
+	case s.crlf:
+		return "", nil, errors.New("the shebang line ends with a carriage return; convert the script to Unix line endings")
+	}
+
+	if filepath.Base(s.Interpreter) == "env" {
+		args := s.Args
+		for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+			args = args[1:]
+		}
+		if len(args) == 0 {
+			return "", nil, errors.Errorf("the shebang line runs %s without a program", s.Interpreter)
+		}
+		program, err := exec.LookPath(args[0])
+		if err != nil {
+			return "", nil, errors.Errorf("interpreter '%s' not found in the PATH", args[0])
+		}
+		return program, args[1:], nil
+	}
+
+	if IsExecutable(s.Interpreter) {
+		return s.Interpreter, s.Args, nil
+	}
+	// Where shebangs aren't honored, /bin/bash is whichever bash is installed
+	if !honorsShebang {
+		if program, err := exec.LookPath(filepath.Base(s.Interpreter)); err == nil {
+			return program, s.Args, nil
+		}
+	}
+	return "", nil, errors.Errorf("interpreter '%s' not found", s.Interpreter)
+}
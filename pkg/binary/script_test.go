@@ -0,0 +1,91 @@
+package binary
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeScript writes a script with the content in a temporary directory and returns its path
+func writeScript(t *testing.T, name string, content string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		t.Fatalf("Failed to create %s: %v", name, err)
+	}
+	return path
+}
+
+func TestReadShebang(t *testing.T) {
+	tests := []struct {
+		content    string
+		want       Shebang
+		wantScript bool
+	}{
+		{"#!/bin/sh\necho hi\n", Shebang{Interpreter: "/bin/sh", Args: []string{}}, true},
+		{"#! /usr/bin/env python3 -u\n", Shebang{Interpreter: "/usr/bin/env", Args: []string{"python3", "-u"}}, true},
+		{"#!/bin/sh\r\n", Shebang{Interpreter: "/bin/sh", Args: []string{}, crlf: true}, true},
+		{"\x7fELF\x02\x01\x01", Shebang{}, false},
+		{"", Shebang{}, false},
+	}
+
+	for _, tt := range tests {
+		got, script, err := ReadShebang(writeScript(t, "mm-test", tt.content, 0755))
+		if err != nil {
+			t.Errorf("ReadShebang(%q) error = %v", tt.content, err)
+			continue
+		}
+		if script != tt.wantScript || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ReadShebang(%q) = %+v, %v, want %+v, %v", tt.content, got, script, tt.want, tt.wantScript)
+		}
+	}
+}
+
+func TestValidateScript(t *testing.T) {
+	tests := []struct {
+		content string
+		wantErr string
+	}{
+		{"#!/bin/sh\n", ""},
+		{"#!/usr/bin/env sh\n", ""},
+		{"not a script", ""},
+		{"#!\n", "names no interpreter"},
+		{"#!/bin/sh\r\n", "carriage return"},
+		{"#!/nonexistent/python9\n", "'/nonexistent/python9' not found"},
+		{"#!/usr/bin/env mm-missing-interpreter\n", "'mm-missing-interpreter' not found in the PATH"},
+	}
+
+	for _, tt := range tests {
+		err := ValidateScript(writeScript(t, "mm-test", tt.content, 0755))
+		if (err == nil) != (tt.wantErr == "") || (err != nil && !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("ValidateScript(%q) error = %v, want %q", tt.content, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCommand(t *testing.T) {
+	script := writeScript(t, "mm-test", "#!/usr/bin/env sh -e\necho hi\n", 0644)
+	defer func(honors bool) { honorsShebang = honors }(honorsShebang)
+	honorsShebang = true
+
+	program, args := Command(script, []string{"--flag"})
+	if program != script || !reflect.DeepEqual(args, []string{"--flag"}) {
+		t.Errorf("Command() = %s %q, want the script itself where shebangs are honored", program, args)
+	}
+	if IsExecutable(script) {
+		t.Errorf("IsExecutable(%s) = true for a script without execute permissions", script)
+	}
+
+	// Elsewhere, scripts run through their interpreter and don't need execute permissions
+	honorsShebang = false
+
+	program, args = Command(script, []string{"--flag"})
+	if filepath.Base(program) != "sh" || !reflect.DeepEqual(args, []string{"-e", script, "--flag"}) {
+		t.Errorf("Command() = %s %q, want sh -e %s --flag", program, args, script)
+	}
+	if !IsExecutable(script) {
+		t.Errorf("IsExecutable(%s) = false for a script", script)
+	}
+}
@@ -0,0 +1,45 @@
+package binary
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+)
+
+// Verifier checks a binary before it runs, refusing to run it by returning an error
+type Verifier func(cmdPath string) error
+
+var (
+	verifierMu sync.RWMutex
+	verifier   Verifier
+)
+
+// SetVerifier sets the check every binary goes through before it runs, whether it runs as a subcommand, to describe
+// itself, to print its version or to complete words. The program sets it once at startup; a nil verifier runs
+// binaries unchecked.
+func SetVerifier(v Verifier) {
+	verifierMu.Lock()
+	defer verifierMu.Unlock()
+	verifier = v
+}
+
+// Verify checks a binary with the verifier before it runs
+func Verify(cmdPath string) error {
+	verifierMu.RLock()
+	v := verifier
+	verifierMu.RUnlock()
+	if v == nil {
+		return nil
+	}
+	return v(cmdPath)
+}
+
+// CommandContext creates the command that runs a binary with arguments, once the verifier allowed it, through the
+// interpreter of scripts where shebangs aren't honored. Every binary runs through it.
+func CommandContext(ctx context.Context, cmdPath string, args []string) (*exec.Cmd, error) {
+	if err := Verify(cmdPath); err != nil {
+		return nil, err
+	}
+	program, programArgs := Command(cmdPath, args)
+	return exec.CommandContext(ctx, program, programArgs...), nil
+}
@@ -0,0 +1,164 @@
+package command
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxAliasDepth limits how many aliases may expand to other aliases, to stop alias loops
+const maxAliasDepth = 10
+
+// ExpandAlias expands a command that is an alias into the command and arguments it stands for.
+// "$1", "$2", ... in the alias are replaced by the corresponding arguments, and "$@" by all of them.
+// Arguments that no placeholder uses are appended. Commands that aren't aliases are returned unchanged.
+func ExpandAlias(aliases map[string]string, name string, args []string) (string, []string, error) {
+	alias, ok := aliases[name]
+	if !ok {
+		return name, args, nil
+	}
+
+	words, err := splitAliasWords(alias)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "invalid alias '%s'", name)
+	}
+	if len(words) == 0 {
+		return "", nil, errors.Errorf("alias '%s' is empty", name)
+	}
+
+	expanded, err := substituteAliasArgs(words, args)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "alias '%s'", name)
+	}
+	return expanded[0], expanded[1:], nil
+}
+
+// expandAliases expands aliases, which may stand for other aliases, until a built-in command or a subcommand
+// is reached. Built-in commands can't be overridden by aliases.
+func (r *Registry) expandAliases(name string, args []string) (string, []string, error) {
+	if r.config == nil {
+		return name, args, nil
+	}
+
+	alias := name
+	for depth := 0; depth < maxAliasDepth; depth++ {
+		if _, builtin := r.Get(name); builtin {
+			return name, args, nil
+		}
+		if _, ok := r.config.Aliases[name]; !ok {
+			return name, args, nil
+		}
+
+		var err error
+		name, args, err = ExpandAlias(r.config.Aliases, name, args)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	return "", nil, errors.Errorf("alias '%s' expands to itself", alias)
+}
+
+// substituteAliasArgs replaces the positional placeholders of the alias words by the arguments
+func substituteAliasArgs(words []string, args []string) ([]string, error) {
+	var expanded []string
+	used := 0
+	allUsed := false
+
+	for _, word := range words {
+		if word == "$@" {
+			expanded = append(expanded, args...)
+			allUsed = true
+			continue
+		}
+
+		var err error
+		word, err = replacePlaceholders(word, func(position int) (string, error) {
+			if position > len(args) {
+				return "", errors.Errorf("needs at least %d arguments", position)
+			}
+			if position > used {
+				used = position
+			}
+			return args[position-1], nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, word)
+	}
+
+	if !allUsed {
+		expanded = append(expanded, args[used:]...)
+	}
+	return expanded, nil
+}
+
+// replacePlaceholders replaces the "$<n>" placeholders of a word by the values of their positions
+func replacePlaceholders(word string, value func(position int) (string, error)) (string, error) {
+	var result strings.Builder
+	for i := 0; i < len(word); i++ {
+		if word[i] != '$' {
+			result.WriteByte(word[i])
+			continue
+		}
+
+		end := i + 1
+		for end < len(word) && word[end] >= '0' && word[end] <= '9' {
+			end++
+		}
+		position, err := strconv.Atoi(word[i+1 : end])
+		if err != nil || position == 0 {
+			// Not a placeholder, such as "$HOME"
+			result.WriteByte(word[i])
+			continue
+		}
+
+		replacement, err := value(position)
+		if err != nil {
+			return "", err
+		}
+		result.WriteString(replacement)
+		i = end - 1
+	}
+	return result.String(), nil
+}
+
+// splitAliasWords splits an alias into words on whitespace, keeping quoted text together
+func splitAliasWords(alias string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	for _, r := range alias {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,88 @@
+package command
+
+import (
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+func TestExpandAlias(t *testing.T) {
+	aliases := map[string]string{
+		"prs":     "azure-devops pull-requests list-open --json",
+		"pr":      "azure-devops pull-requests show $1 --repo=$2",
+		"both":    "deploy --to $@ --verbose",
+		"quoted":  `azure-devops work-items create --title "Fix the build"`,
+		"home":    "deploy $HOME",
+		"missing": "deploy $2",
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantName string
+		wantArgs []string
+	}{
+		{"prs", []string{"--status", "active"}, "azure-devops", []string{"pull-requests", "list-open", "--json", "--status", "active"}},
+		{"pr", []string{"42", "web", "--open"}, "azure-devops", []string{"pull-requests", "show", "42", "--repo=web", "--open"}},
+		{"both", []string{"staging", "prod"}, "deploy", []string{"--to", "staging", "prod", "--verbose"}},
+		{"quoted", nil, "azure-devops", []string{"work-items", "create", "--title", "Fix the build"}},
+		{"home", nil, "deploy", []string{"$HOME"}},
+		{"list-binaries", []string{"-v"}, "list-binaries", []string{"-v"}},
+	}
+
+	for _, tt := range tests {
+		name, args, err := ExpandAlias(aliases, tt.name, tt.args)
+		if err != nil {
+			t.Errorf("ExpandAlias(%s) error = %v", tt.name, err)
+			continue
+		}
+		if name != tt.wantName || !reflect.DeepEqual(args, tt.wantArgs) {
+			t.Errorf("ExpandAlias(%s, %q) = %s %q, want %s %q", tt.name, tt.args, name, args, tt.wantName, tt.wantArgs)
+		}
+	}
+
+	if _, _, err := ExpandAlias(aliases, "missing", []string{"x"}); err == nil {
+		t.Error("ExpandAlias(missing) error = nil, want a missing argument error")
+	}
+}
+
+func TestSplitAliasWords(t *testing.T) {
+	words, err := splitAliasWords(`create --title 'It''s "done"'  --tag x`)
+	if err != nil {
+		t.Fatalf("splitAliasWords() error = %v", err)
+	}
+	want := []string{"create", "--title", `Its "done"`, "--tag", "x"}
+	if !reflect.DeepEqual(words, want) {
+		t.Errorf("splitAliasWords() = %q, want %q", words, want)
+	}
+
+	if _, err := splitAliasWords(`create --title "oops`); err == nil {
+		t.Error("splitAliasWords() error = nil, want an unterminated quote error")
+	}
+}
+
+func TestRegistry_ExpandAliases(t *testing.T) {
+	registry := NewRegistry(&config.Config{Aliases: map[string]string{
+		"prs":    "azure-devops pull-requests list-open --json",
+		"nested": "prs --project $1",
+		"ls":     "list-binaries",
+		"loop":   "loop again",
+	}}, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+
+	name, args, err := registry.expandAliases("nested", []string{"Web"})
+	wantArgs := []string{"pull-requests", "list-open", "--json", "--project", "Web"}
+	if err != nil || name != "azure-devops" || !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("expandAliases(nested) = %s %q, %v, want azure-devops %q", name, args, err, wantArgs)
+	}
+
+	if name, _, err := registry.expandAliases("ls", nil); err != nil || name != "list-binaries" {
+		t.Errorf("expandAliases(ls) = %s, %v, want list-binaries", name, err)
+	}
+
+	if _, _, err := registry.expandAliases("loop", nil); err == nil {
+		t.Error("expandAliases(loop) error = nil, want an alias loop error")
+	}
+}
@@ -0,0 +1,180 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/audit"
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/pkg/errors"
+)
+
+// AuditActions are the actions of the audit command
+var AuditActions = []string{"search", "tail"}
+
+// defaultAuditTail is how many entries audit tail prints by default
+const defaultAuditTail = 20
+
+// AuditHandler handles the audit command
+type AuditHandler struct {
+	registry *Registry
+	output   io.Writer
+}
+
+// NewAuditHandler creates a new audit command handler
+func NewAuditHandler(registry *Registry) *AuditHandler {
+	return &AuditHandler{
+		registry: registry,
+		output:   os.Stdout,
+	}
+}
+
+// Help returns the usage of the audit command
+func (h *AuditHandler) Help() string {
+	return "Usage: master-mold audit tail [-n <count>] [--json]\n       master-mold audit search <term> [--json]\n\nShows the audit log, which records every command run with master-mold once 'audit' is set to true in the\nconfiguration: when it ran, who ran it, its arguments with secrets masked, its exit code and how long it took.\ntail prints the last entries, and search the entries whose user, host or command line contain the term."
+}
+
+// Execute executes the audit command
+func (h *AuditHandler) Execute(args []string) error {
+	flags := newFlagSet("audit", h.output)
+	count := flags.IntP("count", "n", defaultAuditTail, "Number of entries tail prints, 0 for all")
+	jsonOutput := flags.Bool("json", env.JSONOutput(), "Print the entries in JSON format")
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+
+	entries, err := audit.Load(auditPath(h.registry.Config()))
+	if err != nil {
+		return err
+	}
+
+	var matches []audit.Entry
+	switch {
+	case len(args) == 1 && args[0] == "tail" && *count >= 0:
+		matches = entries
+		if *count > 0 && len(entries) > *count {
+			matches = entries[len(entries)-*count:]
+		}
+	case len(args) == 2 && args[0] == "search":
+		for _, entry := range entries {
+			if entry.Matches(args[1]) {
+				matches = append(matches, entry)
+			}
+		}
+	default:
+		return errors.New("usage: master-mold audit tail [-n <count>] | search <term> [--json]")
+	}
+
+	if *jsonOutput {
+		if matches == nil {
+			matches = []audit.Entry{}
+		}
+		return printJSON(h.output, matches)
+	}
+	if len(entries) == 0 && !h.registry.Config().Audit {
+		fmt.Fprintln(h.output, "The audit log is empty; enable it with 'master-mold config set audit true'.")
+		return nil
+	}
+	for _, entry := range matches {
+		fmt.Fprintf(h.output, "%s  %s  %8s  %3d  %s\n", entry.Time.Local().Format("2006-01-02 15:04:05"), auditWho(entry), entry.Duration().Round(10*time.Millisecond), entry.ExitCode, entry.Line())
+	}
+	return nil
+}
+
+// auditWho formats who ran the command of an entry, as user@host
+func auditWho(entry audit.Entry) string {
+	if entry.Host == "" {
+		return entry.User
+	}
+	return entry.User + "@" + entry.Host
+}
+
+// auditPath gets the path of the audit log in the base directory
+func auditPath(cfg *config.Config) string {
+	return filepath.Join(config.GetExpandedBaseDir(cfg), "audit.jsonl")
+}
+
+// secretNameWords are the words of flag, key and variable names whose values are secrets
+var secretNameWords = []string{"token", "password", "secret", "pat", "key"}
+
+// isSecretName checks if one of the words of a flag, key or variable name, separated by dots, dashes or
+// underscores, is the name of a secret, like in --token, plugins.azure-devops.token or DEPLOY_API_KEY
+func isSecretName(name string) bool {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == '.' || r == '-' || r == '_'
+	})
+	for _, word := range words {
+		if slices.Contains(secretNameWords, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactArgs masks the secrets of the configuration in the arguments of a command, along with the values of
+// flags, keys and variables named like secrets: --token <value>, --password=<value>, NAME=<value> and
+// config set <key> <value>
+func redactArgs(cfg *config.Config, name string, args []string) []string {
+	redacted := make([]string, len(args))
+	secretNext := false
+	for i, arg := range args {
+		redacted[i] = config.RedactSecrets(cfg, arg)
+		switch {
+		case secretNext:
+			redacted[i] = config.RedactedSecret
+			secretNext = false
+		case name == "config" && i == 2 && args[0] == "set" && isSecretName(args[1]):
+			redacted[i] = config.RedactedSecret
+		case strings.HasPrefix(arg, "-"):
+			flag, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+			if !isSecretName(flag) {
+				continue
+			}
+			if hasValue {
+				redacted[i] = arg[:strings.Index(arg, "=")+1] + config.RedactedSecret
+			} else {
+				secretNext = true
+			}
+		default:
+			if variable, _, ok := strings.Cut(arg, "="); ok && isSecretName(variable) {
+				redacted[i] = variable + "=" + config.RedactedSecret
+			}
+		}
+	}
+	return redacted
+}
+
+// recordAudit records a dispatched command in the audit log when it is enabled, with secrets masked. Hidden commands
+// and dry runs are not recorded, and failing to record is logged as a warning, since the log is expected to be
+// complete.
+func (r *Registry) recordAudit(name string, args []string, start time.Time, err error) {
+	if r.config == nil || !r.config.Audit || r.config.BaseDir == "" || r.dryRun || strings.HasPrefix(name, "__") {
+		return
+	}
+
+	host, _ := os.Hostname()
+	entry := audit.Entry{
+		Time:       start,
+		User:       audit.CurrentUser(),
+		Host:       host,
+		Command:    name,
+		Args:       redactArgs(r.config, name, args),
+		DurationMS: time.Since(start).Milliseconds(),
+		ExitCode:   ExitCode(err),
+	}
+	if err := audit.Append(auditPath(r.config), entry); err != nil {
+		r.logger.Warn("Failed to record the command in the audit log", "error", err)
+	}
+}
+
+// RegisterAuditCommand registers the audit command
+func RegisterAuditCommand(registry *Registry) {
+	registry.Register("audit", NewAuditHandler(registry))
+}
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/audit"
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+func TestAuditHandler_Execute(t *testing.T) {
+	baseDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(baseDir, "mm-lint"), []byte("#!/bin/sh\nexit 3\n"), 0755); err != nil {
+		t.Fatalf("Failed to create mm-lint: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	cfg := &config.Config{BaseDir: baseDir}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+	output := &bytes.Buffer{}
+	handler := NewAuditHandler(registry)
+	handler.output = output
+
+	// Nothing is recorded until the audit log is enabled
+	registry.Execute("lint", []string{"--fix"})
+	if err := handler.Execute([]string{"tail"}); err != nil {
+		t.Fatalf("Execute(tail) error = %v", err)
+	}
+	if !strings.Contains(output.String(), "config set audit true") {
+		t.Errorf("Execute(tail) output = %q, want how to enable the audit log", output.String())
+	}
+
+	cfg.Audit = true
+	registry.Execute("lint", []string{"--fix"})
+	registry.Execute("list-binaries", nil)
+	registry.Execute("__complete", []string{"li"})
+
+	entries, err := audit.Load(auditPath(cfg))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Line() != "lint --fix" || entries[0].ExitCode != 3 || entries[0].User != audit.CurrentUser() || entries[1].Command != "list-binaries" {
+		t.Fatalf("audit log = %+v, want lint and list-binaries", entries)
+	}
+
+	output.Reset()
+	if err := handler.Execute([]string{"-n", "1", "tail"}); err != nil {
+		t.Fatalf("Execute(-n 1 tail) error = %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(output.String()), "\n"); len(lines) != 1 || !strings.HasSuffix(lines[0], "  0  list-binaries") {
+		t.Errorf("Execute(-n 1 tail) output = %q, want the last entry", output.String())
+	}
+
+	output.Reset()
+	if err := handler.Execute([]string{"--json", "search", "FIX"}); err != nil {
+		t.Fatalf("Execute(search) error = %v", err)
+	}
+	var found []audit.Entry
+	if err := json.Unmarshal(output.Bytes(), &found); err != nil {
+		t.Fatalf("Execute(search) output is not JSON: %v", err)
+	}
+	if len(found) != 1 || found[0].Command != "lint" {
+		t.Errorf("Execute(search) = %+v, want the lint entry", found)
+	}
+
+	if err := handler.Execute([]string{"search"}); err == nil {
+		t.Error("Execute(search) without a term succeeded, want a usage error")
+	}
+}
+
+func TestRedactArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		args    []string
+		want    []string
+	}{
+		{name: "flag with a separate value", command: "deploy", args: []string{"--token", "s3cret", "prod"}, want: []string{"--token", "********", "prod"}},
+		{name: "flag with an inline value", command: "deploy", args: []string{"--password=s3cret", "-v"}, want: []string{"--password=********", "-v"}},
+		{name: "compound flag name", command: "deploy", args: []string{"--api-key", "s3cret"}, want: []string{"--api-key", "********"}},
+		{name: "config set of a secret key", command: "config", args: []string{"set", "plugins.azure-devops.token", "s3cret"}, want: []string{"set", "plugins.azure-devops.token", "********"}},
+		{name: "config set of another key", command: "config", args: []string{"set", "timeout", "30"}, want: []string{"set", "timeout", "30"}},
+		{name: "variable", command: "exec", args: []string{"DEPLOY_PAT=s3cret", "REGION=eu"}, want: []string{"DEPLOY_PAT=********", "REGION=eu"}},
+		{name: "names containing secret words", command: "deploy", args: []string{"--monkey", "banana", "--path", "/tmp", "patch"}, want: []string{"--monkey", "banana", "--path", "/tmp", "patch"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactArgs(&config.Config{}, tt.command, tt.args); !slices.Equal(got, tt.want) {
+				t.Errorf("redactArgs(%v) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/pkg/errors"
+)
+
+// defaultBenchRuns is how many times the bench command runs a subcommand by default
+const defaultBenchRuns = 10
+
+// BenchResult is the timing of the runs of a binary
+type BenchResult struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Runs int    `json:"runs"`
+	// MinMS, MedianMS and MaxMS are the wall times of the runs, in milliseconds
+	MinMS    float64 `json:"min_ms"`
+	MedianMS float64 `json:"median_ms"`
+	MaxMS    float64 `json:"max_ms"`
+	// ExitCodes count the runs by exit code
+	ExitCodes map[int]int `json:"exit_codes"`
+}
+
+// BenchHandler handles the bench command
+type BenchHandler struct {
+	registry *Registry
+}
+
+// NewBenchHandler creates a new bench command handler
+func NewBenchHandler(registry *Registry) *BenchHandler {
+	return &BenchHandler{
+		registry: registry,
+	}
+}
+
+// Help returns the usage of the bench command
+func (h *BenchHandler) Help() string {
+	return "Usage: master-mold bench [-n <runs>] [--compare <binary>] [--json] <command> -- [arguments]\n\nRuns a subcommand several times, 10 by default, with its output discarded, and reports the shortest, median and\nlongest wall time of the runs with their exit codes. --compare runs another binary, given by path or subcommand\nname, with the same arguments, such as another version of the plugin, and reports how they compare."
+}
+
+// Execute executes the bench command
+func (h *BenchHandler) Execute(args []string) error {
+	flags := newFlagSet("bench", h.registry.Output())
+	runs := flags.IntP("runs", "n", defaultBenchRuns, "Number of runs of each binary")
+	compare := flags.String("compare", "", "Path or subcommand name of another binary to run with the same arguments")
+	jsonOutput := flags.Bool("json", env.JSONOutput(), "Print the timings in JSON format")
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 || flags.ArgsLenAtDash() == 0 || *runs < 1 {
+		return errors.New("usage: master-mold bench [-n <runs>] [--compare <binary>] [--json] <command> -- [arguments]")
+	}
+
+	name, args, err := h.registry.expandAliases(args[0], args[1:])
+	if err != nil {
+		return err
+	}
+	cfg := h.registry.Config()
+	name, args, resolution, err := resolveNamespaced(cfg, name, args)
+	if _, ok := h.registry.bundledFallback(name, err); ok {
+		return errors.Errorf("'%s' is bundled with master-mold; bench needs its binary", name)
+	}
+	if err != nil {
+		return err
+	}
+
+	targets := []benchTarget{{name: name, path: resolution.Path}}
+	if *compare != "" {
+		target, err := h.compareTarget(*compare)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, target)
+	}
+
+	results := []BenchResult{}
+	for _, target := range targets {
+		result, err := h.bench(target, args, *runs)
+		if err != nil {
+			return err
+		}
+		results = append(results, result)
+	}
+
+	if *jsonOutput {
+		return printJSON(h.registry.Output(), results)
+	}
+	for _, result := range results {
+		h.printResult(result)
+	}
+	if len(results) == 2 {
+		h.printComparison(results[0], results[1])
+	}
+	return nil
+}
+
+// benchTarget is a binary the bench command runs
+type benchTarget struct {
+	name string
+	path string
+}
+
+// compareTarget resolves the binary given to --compare, a path or the name of a subcommand
+func (h *BenchHandler) compareTarget(compare string) (benchTarget, error) {
+	if strings.ContainsRune(compare, os.PathSeparator) || strings.Contains(compare, "/") {
+		if !binary.IsExecutable(compare) {
+			return benchTarget{}, errors.Errorf("'%s' is not an executable", compare)
+		}
+		return benchTarget{name: compare, path: compare}, nil
+	}
+
+	resolution, err := resolveSubcommand(h.registry.Config(), compare)
+	if err != nil {
+		return benchTarget{}, err
+	}
+	return benchTarget{name: compare, path: resolution.Path}, nil
+}
+
+// bench runs a binary the number of times and times the runs. Failed runs are counted by exit code rather than
+// stopping the benchmark.
+func (h *BenchHandler) bench(target benchTarget, args []string, runs int) (BenchResult, error) {
+	cfg := h.registry.Config()
+	if err := h.registry.VerifyBinary(target.path); err != nil {
+		return BenchResult{}, err
+	}
+	variables, err := subcommandEnv(cfg, target.name)
+	if err != nil {
+		return BenchResult{}, err
+	}
+
+	durations := make([]time.Duration, 0, runs)
+	exitCodes := make(map[int]int)
+	for range runs {
+		start := time.Now()
+		err := binary.ExecuteWithEnv(target.path, args, config.GetTimeout(cfg), variables, nil, io.Discard, io.Discard, h.registry.Logger())
+		durations = append(durations, time.Since(start))
+		exitCodes[ExitCode(err)]++
+	}
+
+	slices.Sort(durations)
+	median := durations[len(durations)/2]
+	if len(durations)%2 == 0 {
+		median = (durations[len(durations)/2-1] + median) / 2
+	}
+	return BenchResult{
+		Name:      target.name,
+		Path:      target.path,
+		Runs:      runs,
+		MinMS:     milliseconds(durations[0]),
+		MedianMS:  milliseconds(median),
+		MaxMS:     milliseconds(durations[len(durations)-1]),
+		ExitCodes: exitCodes,
+	}, nil
+}
+
+// printResult prints the timing of the runs of a binary
+func (h *BenchHandler) printResult(result BenchResult) {
+	output := h.registry.Output()
+	fmt.Fprintf(output, "%s (%s), %d runs\n", result.Name, result.Path, result.Runs)
+	fmt.Fprintf(output, "  min %s  median %s  max %s\n", formatMilliseconds(result.MinMS), formatMilliseconds(result.MedianMS), formatMilliseconds(result.MaxMS))
+
+	codes := make([]int, 0, len(result.ExitCodes))
+	for code := range result.ExitCodes {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	counts := make([]string, 0, len(codes))
+	for _, code := range codes {
+		counts = append(counts, fmt.Sprintf("%d (%dx)", code, result.ExitCodes[code]))
+	}
+	fmt.Fprintf(output, "  exit codes: %s\n", strings.Join(counts, ", "))
+}
+
+// printComparison prints how much faster or slower the compared binary is, by median
+func (h *BenchHandler) printComparison(result, compared BenchResult) {
+	if result.MedianMS <= 0 || compared.MedianMS <= 0 {
+		return
+	}
+	output := h.registry.Output()
+	if compared.MedianMS <= result.MedianMS {
+		fmt.Fprintf(output, "%s is %.2fx faster than %s\n", compared.Name, result.MedianMS/compared.MedianMS, result.Name)
+		return
+	}
+	fmt.Fprintf(output, "%s is %.2fx slower than %s\n", compared.Name, compared.MedianMS/result.MedianMS, result.Name)
+}
+
+// milliseconds converts a duration to milliseconds, to the microsecond
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// formatMilliseconds formats a number of milliseconds as a duration, such as 12.3ms or 1.5s
+func formatMilliseconds(ms float64) string {
+	d := time.Duration(ms * float64(time.Millisecond))
+	return d.Round(100 * time.Microsecond).String()
+}
+
+// RegisterBenchCommand registers the bench command
+func RegisterBenchCommand(registry *Registry) {
+	registry.Register("bench", NewBenchHandler(registry))
+}
@@ -0,0 +1,70 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+func TestBenchHandler_Execute(t *testing.T) {
+	baseDir := t.TempDir()
+	scripts := map[string]string{
+		// deploy fails when given "fail"
+		"mm-deploy":    "#!/bin/sh\n[ \"$1\" = fail ] && exit 3\necho deployed\n",
+		"mm-deploy-v2": "#!/bin/sh\necho deployed\n",
+	}
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(baseDir, name), []byte(script), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	t.Setenv("PATH", "")
+
+	registry := NewRegistry(&config.Config{BaseDir: baseDir}, slog.New(slog.DiscardHandler))
+	output := &bytes.Buffer{}
+	registry.SetOutput(output)
+	handler := NewBenchHandler(registry)
+
+	if err := handler.Execute([]string{"-n", "3", "--compare", "deploy-v2", "deploy", "--", "fail"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	text := output.String()
+	for _, want := range []string{
+		"deploy (" + filepath.Join(baseDir, "mm-deploy") + "), 3 runs",
+		"exit codes: 3 (3x)",
+		"deploy-v2 (" + filepath.Join(baseDir, "mm-deploy-v2") + "), 3 runs",
+		"exit codes: 0 (3x)",
+		" than deploy\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Execute() output = %q, want it to contain %q", text, want)
+		}
+	}
+	if strings.Contains(text, "deployed") {
+		t.Errorf("Execute() output = %q, want the output of the runs discarded", text)
+	}
+
+	output.Reset()
+	if err := handler.Execute([]string{"--json", "-n", "2", "deploy"}); err != nil {
+		t.Fatalf("Execute(--json) error = %v", err)
+	}
+	var results []BenchResult
+	if err := json.Unmarshal(output.Bytes(), &results); err != nil {
+		t.Fatalf("Execute(--json) output = %q, error = %v", output.String(), err)
+	}
+	if len(results) != 1 || results[0].Runs != 2 || results[0].ExitCodes[0] != 2 || results[0].MinMS > results[0].MedianMS || results[0].MedianMS > results[0].MaxMS {
+		t.Errorf("Execute(--json) = %+v, want the timings of 2 successful runs", results)
+	}
+
+	for _, args := range [][]string{nil, {"-n", "0", "deploy"}, {"--", "deploy"}} {
+		if err := handler.Execute(args); err == nil || !strings.Contains(err.Error(), "usage") {
+			t.Errorf("Execute(%v) error = %v, want the usage", args, err)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package command
+
+import (
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/display"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// CompletionShells are the shells the completion command generates scripts for
+var CompletionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// CompletionHandler handles the completion command. The scripts are generated by cobra from the command line the
+// handler is attached to; they call master-mold back for the completions, so subcommands installed after the
+// script was generated complete immediately.
+type CompletionHandler struct {
+	root   *cobra.Command
+	output io.Writer
+}
+
+// NewCompletionHandler creates a new completion command handler
+func NewCompletionHandler() *CompletionHandler {
+	return &CompletionHandler{
+		output: os.Stdout,
+	}
+}
+
+// SetRoot attaches the handler to the root command of the command line to complete
+func (h *CompletionHandler) SetRoot(root *cobra.Command) {
+	h.root = root
+}
+
+// Help returns the usage of the completion command
+func (h *CompletionHandler) Help() string {
+	return "Usage: master-mold completion bash|zsh|fish|powershell\n\nGenerates the shell completion script. Load it in the current shell with:\n  source <(master-mold completion bash)"
+}
+
+// Execute executes the completion command
+func (h *CompletionHandler) Execute(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: master-mold completion bash|zsh|fish|powershell")
+	}
+	if h.root == nil {
+		return errors.New("completion is not available")
+	}
+
+	switch args[0] {
+	case "bash":
+		return h.root.GenBashCompletionV2(h.output, true)
+	case "zsh":
+		return h.root.GenZshCompletion(h.output)
+	case "fish":
+		return h.root.GenFishCompletion(h.output, true)
+	case "powershell":
+		return h.root.GenPowerShellCompletionWithDesc(h.output)
+	}
+	return errors.Errorf("unsupported shell '%s', expected bash, zsh, fish or powershell", args[0])
+}
+
+// Completer completes master-mold command lines: built-in commands, subcommands, aliases, and the arguments of
+// subcommands that describe themselves
+type Completer struct {
+	registry *Registry
+}
+
+// NewCompleter creates a new completer of the commands of the registry
+func NewCompleter(registry *Registry) *Completer {
+	return &Completer{
+		registry: registry,
+	}
+}
+
+// Complete gets the completions of the last word of a command line, without the master-mold command itself
+func (h *Completer) Complete(words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	current := words[len(words)-1]
+
+	var candidates []string
+	switch {
+	case len(words) == 1, len(words) == 2 && (words[0] == "help" || words[0] == "which"):
+		candidates = append(h.registry.Names(), h.subcommandNames()...)
+		for name := range h.registry.Config().Aliases {
+			candidates = append(candidates, name)
+		}
+	case len(words) == 2 && (words[0] == "uninstall" || words[0] == "disable" || words[0] == "env" || words[0] == "logs" || words[0] == "bench"):
+		candidates = h.subcommandNames()
+	case len(words) == 2 && words[0] == "enable":
+		candidates = h.registry.Config().Disabled
+	case len(words) == 2 && words[0] == "completion":
+		candidates = CompletionShells
+	case len(words) == 2 && words[0] == "config":
+		candidates = ConfigActions
+	case len(words) == 2 && words[0] == "fav":
+		candidates = FavoriteActions
+	case len(words) == 2 && words[0] == "audit":
+		candidates = AuditActions
+	case len(words) == 2 && words[0] == "integrity":
+		candidates = IntegrityActions
+	case len(words) >= 3 && words[0] == "integrity" && words[1] == "update":
+		candidates = h.subcommandNames()
+	case len(words) == 3 && words[0] == "config" && (words[1] == "get" || words[1] == "set" || words[1] == "unset"):
+		candidates = config.SettingKeys()
+	default:
+		if _, builtin := h.registry.Get(words[0]); !builtin {
+			candidates = append(namespaceCompletions(h.subcommandNames(), words), describedCompletions(h.registry.Config(), words)...)
+		}
+	}
+
+	return filterCompletions(candidates, current)
+}
+
+// subcommandNames discovers the names of the installed subcommands
+func (h *Completer) subcommandNames() []string {
+	binaryPaths, err := findBinaries(h.registry.Config())
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, info := range display.ProcessBinaries(binaryPaths) {
+		names = append(names, info.Name)
+	}
+	return names
+}
+
+// filterCompletions keeps the candidates starting with the prefix, sorted and without duplicates
+func filterCompletions(candidates []string, prefix string) []string {
+	seen := make(map[string]bool)
+	var completions []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) && !seen[candidate] {
+			seen[candidate] = true
+			completions = append(completions, candidate)
+		}
+	}
+	sort.Strings(completions)
+	return completions
+}
+
+// RegisterCompletionCommand registers the completion command
+func RegisterCompletionCommand(registry *Registry) {
+	registry.Register("completion", NewCompletionHandler())
+}
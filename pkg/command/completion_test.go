@@ -0,0 +1,75 @@
+package command
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+func TestCompletionHandler_Execute(t *testing.T) {
+	for _, shell := range CompletionShells {
+		output := &bytes.Buffer{}
+		handler := NewCompletionHandler()
+		handler.SetRoot(&cobra.Command{Use: "master-mold"})
+		handler.output = output
+
+		if err := handler.Execute([]string{shell}); err != nil {
+			t.Fatalf("Execute(%s) error = %v", shell, err)
+		}
+		if !strings.Contains(output.String(), "__complete") {
+			t.Errorf("Execute(%s) script = %q, want it to call master-mold __complete", shell, output.String())
+		}
+	}
+
+	handler := NewCompletionHandler()
+	handler.SetRoot(&cobra.Command{Use: "master-mold"})
+	if err := handler.Execute([]string{"tcsh"}); err == nil {
+		t.Error("Execute(tcsh) error = nil, want an unsupported shell error")
+	}
+	if err := NewCompletionHandler().Execute([]string{"bash"}); err == nil {
+		t.Error("Execute(bash) without a root command error = nil, want an error")
+	}
+}
+
+func TestCompleter_Complete(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, name := range []string{"mm-deploy", "master-mold-k8s-pods"} {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	t.Setenv("PATH", "")
+
+	registry := NewRegistry(&config.Config{BaseDir: tempDir, Disabled: []string{"lint"}}, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+	completer := NewCompleter(registry)
+
+	tests := []struct {
+		words []string
+		want  []string
+	}{
+		{[]string{"de"}, []string{"deploy"}},
+		{[]string{"k"}, []string{"k8s-pods"}},
+		{[]string{"uninstall", ""}, []string{"deploy", "k8s-pods"}},
+		{[]string{"disable", "d"}, []string{"deploy"}},
+		{[]string{"enable", ""}, []string{"lint"}},
+		{[]string{"help", "se"}, []string{"search"}},
+		{[]string{"completion", "z"}, []string{"zsh"}},
+		{[]string{"deploy", ""}, nil},
+		{[]string{"k8s", ""}, []string{"pods"}},
+		{[]string{"__"}, nil},
+	}
+
+	for _, tt := range tests {
+		if got := completer.Complete(tt.words); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Complete(%q) = %q, want %q", tt.words, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/pkg/errors"
+)
+
+// ConfigActions are the actions of the config command
+var ConfigActions = []string{"edit", "get", "list", "set", "unset"}
+
+// ConfigHandler handles the config command
+type ConfigHandler struct {
+	config *config.Config
+	output io.Writer
+}
+
+// NewConfigHandler creates a new config command handler
+func NewConfigHandler(config *config.Config) *ConfigHandler {
+	return &ConfigHandler{
+		config: config,
+		output: os.Stdout,
+	}
+}
+
+// Help returns the usage of the config command
+func (h *ConfigHandler) Help() string {
+	return "Usage: master-mold config get <key> [--json]\n       master-mold config set <key> <value>\n       master-mold config unset <key>\n       master-mold config list [--json]\n       master-mold config edit\n\nReads and writes the configuration file. The keys are " + strings.Join(config.SettingKeys(), ", ") + ",\naliases.<name>, pins.<name> and plugins.<name>.<key>; registries and disabled are set as comma-separated lists. Changes are validated before the\nfile is written, and edit opens the file in $VISUAL or $EDITOR and keeps the changes only when they are valid."
+}
+
+// Execute executes the config command
+func (h *ConfigHandler) Execute(args []string) error {
+	flags := newFlagSet("config", h.output)
+	jsonOutput := flags.Bool("json", env.JSONOutput(), "Print the settings in JSON format")
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("usage: master-mold config get|set|unset|list|edit")
+	}
+
+	file := h.config.File
+	switch action, args := args[0], args[1:]; {
+	case action == "get" && len(args) == 1:
+		value, err := config.GetSetting(file, args[0])
+		if err != nil {
+			return err
+		}
+		if *jsonOutput {
+			return printJSON(h.output, value)
+		}
+		printSetting(h.output, value)
+		return nil
+
+	case action == "set" && len(args) == 2:
+		if err := config.SetSetting(file, args[0], args[1]); err != nil {
+			return err
+		}
+		fmt.Fprintf(h.output, "Set %s in %s\n", strings.ToLower(args[0]), file)
+		return nil
+
+	case action == "unset" && len(args) == 1:
+		if err := config.UnsetSetting(file, args[0]); err != nil {
+			return err
+		}
+		fmt.Fprintf(h.output, "Unset %s in %s\n", strings.ToLower(args[0]), file)
+		return nil
+
+	case action == "list" && len(args) == 0:
+		settings, err := config.ListSettings(file)
+		if err != nil {
+			return err
+		}
+		if *jsonOutput {
+			return printJSON(h.output, settings)
+		}
+		keys := make([]string, 0, len(settings))
+		for key := range settings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(h.output, "%s = %s\n", key, formatSetting(settings[key]))
+		}
+		return nil
+
+	case action == "edit" && len(args) == 0:
+		return h.edit(file)
+	}
+
+	return errors.New("usage: master-mold config get <key> | set <key> <value> | unset <key> | list | edit")
+}
+
+// edit opens a copy of the configuration file in the editor, and replaces the file with it when it is valid
+func (h *ConfigHandler) edit(file string) error {
+	if err := config.ValidateFile(file); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read config file %s", file)
+	}
+
+	// The copy is next to the file, so replacing the file with it is a rename
+	draft, err := os.CreateTemp(filepath.Dir(file), "config-*.toml")
+	if err != nil {
+		return errors.Wrap(err, "failed to create a copy of the config file")
+	}
+	defer os.Remove(draft.Name())
+	_, err = draft.Write(data)
+	if closeErr := draft.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to create a copy of the config file")
+	}
+
+	editor := strings.Fields(editorCommand())
+	cmd := exec.Command(editor[0], append(editor[1:], draft.Name())...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "editor '%s' failed", editor[0])
+	}
+
+	if err := config.ValidateFile(draft.Name()); err != nil {
+		return errors.Wrap(err, "the configuration was not changed")
+	}
+	if err := os.Rename(draft.Name(), file); err != nil {
+		return errors.Wrapf(err, "failed to write config file %s", file)
+	}
+	fmt.Fprintf(h.output, "Updated %s\n", file)
+	return nil
+}
+
+// editorCommand gets the command line of the editor of the user
+func editorCommand() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
+
+// printSetting prints the value of a setting for scripts: lists one item per line, tables one key per line
+func printSetting(output io.Writer, value interface{}) {
+	switch value := value.(type) {
+	case []interface{}:
+		for _, item := range value {
+			fmt.Fprintln(output, item)
+		}
+	case []string:
+		for _, item := range value {
+			fmt.Fprintln(output, item)
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(output, "%s = %s\n", key, formatSetting(value[key]))
+		}
+	default:
+		fmt.Fprintln(output, value)
+	}
+}
+
+// formatSetting formats the value of a setting on one line
+func formatSetting(value interface{}) string {
+	switch value := value.(type) {
+	case []interface{}:
+		items := make([]string, len(value))
+		for i, item := range value {
+			items[i] = fmt.Sprint(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case []string:
+		return "[" + strings.Join(value, ", ") + "]"
+	}
+	return fmt.Sprint(value)
+}
+
+// printJSON prints a value in indented JSON format
+func printJSON(output io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// RegisterConfigCommand registers the config command
+func RegisterConfigCommand(registry *Registry) {
+	registry.Register("config", NewConfigHandler(registry.Config()))
+}
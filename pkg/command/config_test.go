@@ -0,0 +1,110 @@
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+// newConfigTestHandler creates a config handler over a configuration file in a temporary directory
+func newConfigTestHandler(t *testing.T, content string) (*ConfigHandler, *bytes.Buffer, string) {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	output := &bytes.Buffer{}
+	handler := NewConfigHandler(&config.Config{File: file})
+	handler.output = output
+	return handler, output, file
+}
+
+func TestConfigHandler_Execute(t *testing.T) {
+	handler, output, _ := newConfigTestHandler(t, "base_dir = \"/opt/mm\"\ntimeout = 10\n")
+	t.Setenv("MM_OUTPUT", "")
+
+	steps := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"set", "timeout", "30"}, "Set timeout in "},
+		{[]string{"set", "registries", "a.json,b.json"}, "Set registries in "},
+		{[]string{"set", "aliases.prs", "ado prs list-open"}, "Set aliases.prs in "},
+		{[]string{"get", "timeout"}, "30\n"},
+		{[]string{"get", "registries"}, "a.json\nb.json\n"},
+		{[]string{"get", "aliases"}, "prs = ado prs list-open\n"},
+		{[]string{"unset", "registries"}, "Unset registries in "},
+		{[]string{"list"}, "aliases.prs = ado prs list-open\nbase_dir = /opt/mm\ntimeout = 30\n"},
+		{[]string{"get", "timeout", "--json"}, "30\n"},
+	}
+
+	for _, step := range steps {
+		output.Reset()
+		if err := handler.Execute(step.args); err != nil {
+			t.Fatalf("Execute(%q) error = %v", step.args, err)
+		}
+		if !strings.HasPrefix(output.String(), step.want) {
+			t.Errorf("Execute(%q) output = %q, want %q", step.args, output.String(), step.want)
+		}
+	}
+}
+
+func TestConfigHandler_Execute_Errors(t *testing.T) {
+	handler, _, _ := newConfigTestHandler(t, "timeout = 10\n")
+
+	for _, args := range [][]string{
+		nil,
+		{"get"},
+		{"get", "log_level"},
+		{"set", "timeout"},
+		{"set", "log_level", "loud"},
+		{"unset", "registries"},
+		{"remove", "timeout"},
+	} {
+		if err := handler.Execute(args); err == nil {
+			t.Errorf("Execute(%q) error = nil, want an error", args)
+		}
+	}
+}
+
+func TestConfigHandler_Edit(t *testing.T) {
+	content := "timeout = 10\n"
+	handler, _, file := newConfigTestHandler(t, content)
+
+	// The editor appends the line given to it
+	editor := filepath.Join(t.TempDir(), "editor")
+	script := "#!/bin/sh\necho \"$EDIT_LINE\" >> \"$1\"\n"
+	if err := os.WriteFile(editor, []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create editor: %v", err)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", editor)
+
+	// Invalid changes are thrown away
+	t.Setenv("EDIT_LINE", "log_level = \"loud\"")
+	if err := handler.Execute([]string{"edit"}); err == nil {
+		t.Error("Execute(edit) with an invalid change error = nil, want an error")
+	}
+	if data, _ := os.ReadFile(file); string(data) != content {
+		t.Errorf("config file = %q after an invalid edit, want it unchanged", data)
+	}
+
+	t.Setenv("EDIT_LINE", "log_level = \"debug\"")
+	if err := handler.Execute([]string{"edit"}); err != nil {
+		t.Fatalf("Execute(edit) error = %v", err)
+	}
+	if value, err := config.GetSetting(file, "log_level"); err != nil || value != "debug" {
+		t.Errorf("log_level = %v, %v after the edit, want debug", value, err)
+	}
+
+	// Only the configuration file is left in its directory
+	entries, _ := os.ReadDir(filepath.Dir(file))
+	if len(entries) != 1 {
+		t.Errorf("config directory has %d entries after editing, want only the config file", len(entries))
+	}
+}
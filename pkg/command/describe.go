@@ -0,0 +1,140 @@
+package command
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/describe"
+	"github.com/oscarrieken/master-mold/pkg/display"
+)
+
+// describeTimeout limits how long a plugin may take to describe itself
+const describeTimeout = 5 * time.Second
+
+// pluginCompletionTimeout limits how long a plugin may take to complete a command line, as the shell waits for it
+const pluginCompletionTimeout = 2 * time.Second
+
+// loadDescribeCache loads the cache of plugin descriptions from the base directory
+func loadDescribeCache(cfg *config.Config) *describe.Cache {
+	return describe.LoadCache(filepath.Join(config.GetExpandedBaseDir(cfg), "cache", "describe.json"), describeTimeout)
+}
+
+// describeBinaries fills in the versions and descriptions of the binaries that implement the describe protocol
+func describeBinaries(cfg *config.Config, binaries []display.BinaryInfo) []display.BinaryInfo {
+	paths := make([]string, len(binaries))
+	for i, info := range binaries {
+		paths[i] = info.FullPath
+	}
+
+	cache := loadDescribeCache(cfg)
+	descriptions := cache.DescribeAll(paths)
+	cache.Save()
+
+	for i, info := range binaries {
+		if description, ok := descriptions[info.FullPath]; ok {
+			binaries[i].Version = description.Version
+			binaries[i].Description = description.Description
+		}
+	}
+	return binaries
+}
+
+// FormatDescription formats the description of a plugin for its help
+func FormatDescription(description describe.Description) string {
+	var b strings.Builder
+
+	b.WriteString(description.Name)
+	if description.Version != "" {
+		b.WriteString(" " + description.Version)
+	}
+	if description.Description != "" {
+		b.WriteString(": " + description.Description)
+	}
+	b.WriteString("\n")
+
+	if len(description.Subcommands) > 0 {
+		b.WriteString("\nSubcommands:\n")
+		for _, subcommand := range description.Subcommands {
+			fmt.Fprintf(&b, "  - %s", subcommand.Name)
+			if subcommand.Description != "" {
+				fmt.Fprintf(&b, ": %s", subcommand.Description)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(description.Flags) > 0 {
+		b.WriteString("\nFlags:\n")
+		for _, flag := range description.Flags {
+			fmt.Fprintf(&b, "  --%s", flag.Name)
+			if flag.Shorthand != "" {
+				fmt.Fprintf(&b, ", -%s", flag.Shorthand)
+			}
+			if flag.Usage != "" {
+				fmt.Fprintf(&b, ": %s", flag.Usage)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// describedCompletions completes the subcommands and flags of a plugin from its description, merged with the
+// completions of the plugin itself when it has a completion command. The words are the plugin name, the words typed
+// after it, and the word being completed.
+func describedCompletions(cfg *config.Config, words []string) []string {
+	name, args, resolution, err := resolveNamespaced(cfg, words[0], words[1:len(words)-1])
+	if err != nil {
+		return nil
+	}
+	cmdPath := resolution.Path
+	words = append(append([]string{name}, args...), words[len(words)-1])
+
+	cache := loadDescribeCache(cfg)
+	description, ok := cache.Describe(cmdPath)
+	cache.Save()
+	if !ok {
+		return nil
+	}
+
+	var candidates []string
+	if description.Completion != "" {
+		candidates = pluginCompletions(cfg, cmdPath, description.Completion, words)
+	}
+
+	// Walk down the subcommands typed so far, skipping flags and their values
+	for _, word := range words[1 : len(words)-1] {
+		if subcommand, ok := description.Find([]string{word}); ok {
+			description = subcommand
+		}
+	}
+
+	if strings.HasPrefix(words[len(words)-1], "-") {
+		for _, flag := range description.Flags {
+			candidates = append(candidates, "--"+flag.Name)
+		}
+		return candidates
+	}
+	for _, subcommand := range description.Subcommands {
+		candidates = append(candidates, subcommand.Name)
+	}
+	return candidates
+}
+
+// pluginCompletions asks a plugin to complete the words typed after its name with its completion command, which
+// knows about flag values and dynamic arguments its description can't list. A plugin failing to complete gives no
+// completions.
+func pluginCompletions(cfg *config.Config, cmdPath string, command string, words []string) []string {
+	variables, err := subcommandEnv(cfg, words[0])
+	if err != nil {
+		return nil
+	}
+	completions, err := describe.Complete(cmdPath, command, words[1:], pluginCompletionTimeout, variables)
+	if err != nil {
+		return nil
+	}
+	return completions
+}
@@ -0,0 +1,155 @@
+package command
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/describe"
+	"github.com/oscarrieken/master-mold/pkg/display"
+)
+
+// describedScript is a plugin implementing the describe protocol
+const describedScript = `#!/bin/sh
+if [ "$1" = "--mm-describe" ]; then
+  echo '{"name":"deploy","version":"1.0.0","description":"Deploy services","flags":[{"name":"env","usage":"Environment"}],"subcommands":[{"name":"status","description":"Show the status","flags":[{"name":"json"}]},{"name":"rollback"}]}'
+  exit 0
+fi
+exit 1
+`
+
+func TestDescribeBinaries(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "mm-deploy"), []byte(describedScript), 0755); err != nil {
+		t.Fatalf("Failed to create binary: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "mm-old"), []byte("#!/bin/sh\nexit 1\n"), 0755); err != nil {
+		t.Fatalf("Failed to create binary: %v", err)
+	}
+
+	binaries := describeBinaries(&config.Config{BaseDir: tempDir}, []display.BinaryInfo{
+		{Name: "deploy", FullPath: filepath.Join(tempDir, "mm-deploy")},
+		{Name: "old", FullPath: filepath.Join(tempDir, "mm-old")},
+	})
+	if binaries[0].Description != "Deploy services" || binaries[1].Description != "" {
+		t.Errorf("describeBinaries() = %+v, want the description of deploy only", binaries)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "cache", "describe.json")); err != nil {
+		t.Errorf("describeBinaries() did not save the cache: %v", err)
+	}
+}
+
+func TestFormatDescription(t *testing.T) {
+	description := describe.Description{
+		Name:        "deploy",
+		Version:     "1.0.0",
+		Description: "Deploy services",
+		Flags:       []describe.FlagInfo{{Name: "env", Shorthand: "e", Usage: "Environment"}},
+		Subcommands: []describe.Description{{Name: "status", Description: "Show the status"}, {Name: "rollback"}},
+	}
+
+	want := "deploy 1.0.0: Deploy services\n\nSubcommands:\n  - status: Show the status\n  - rollback\n\nFlags:\n  --env, -e: Environment\n"
+	if got := FormatDescription(description); got != want {
+		t.Errorf("FormatDescription() = %q, want %q", got, want)
+	}
+}
+
+func TestCompleter_DescribedPlugin(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "mm-deploy"), []byte(describedScript), 0755); err != nil {
+		t.Fatalf("Failed to create binary: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	registry := NewRegistry(&config.Config{BaseDir: tempDir}, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+	completer := NewCompleter(registry)
+
+	tests := []struct {
+		words []string
+		want  []string
+	}{
+		{[]string{"deploy", ""}, []string{"rollback", "status"}},
+		{[]string{"deploy", "s"}, []string{"status"}},
+		{[]string{"deploy", "--e"}, []string{"--env"}},
+		{[]string{"deploy", "--env", "prod", "status", "--"}, []string{"--json"}},
+	}
+
+	for _, tt := range tests {
+		if got := completer.Complete(tt.words); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Complete(%q) = %q, want %q", tt.words, got, tt.want)
+		}
+	}
+}
+
+func TestCompleter_PluginCompletion(t *testing.T) {
+	// The plugin completes the values of --env itself, from its settings
+	tempDir := t.TempDir()
+	script := `#!/bin/sh
+if [ "$1" = "--mm-describe" ]; then
+  echo '{"name":"deploy","flags":[{"name":"env"}],"subcommands":[{"name":"status"}],"completion":"__completeNoDesc"}'
+  exit 0
+fi
+if [ "$1" = "__completeNoDesc" ] && [ "$2" = "--env" ]; then
+  printf 'prod\tProduction\nstaging\n'
+  case "$MM_PLUGIN_CONFIG" in *web*) echo web-preview;; esac
+  echo :4
+  exit 0
+fi
+echo :4
+`
+	if err := os.WriteFile(filepath.Join(tempDir, "mm-deploy"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create binary: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	cfg := &config.Config{BaseDir: tempDir, Plugins: map[string]map[string]interface{}{"deploy": {"project": "web"}}}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+	completer := NewCompleter(registry)
+
+	tests := []struct {
+		words []string
+		want  []string
+	}{
+		{[]string{"deploy", "--env", ""}, []string{"prod", "staging", "status", "web-preview"}},
+		{[]string{"deploy", "--env", "st"}, []string{"staging", "status"}},
+		{[]string{"deploy", "--"}, []string{"--env"}},
+	}
+
+	for _, tt := range tests {
+		if got := completer.Complete(tt.words); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Complete(%q) = %q, want %q", tt.words, got, tt.want)
+		}
+	}
+}
+
+func TestHelpHandler_DescribedPlugin(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "mm-deploy"), []byte(describedScript), 0755); err != nil {
+		t.Fatalf("Failed to create binary: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	registry, output := newTestHelpRegistry(&config.Config{BaseDir: tempDir})
+
+	// The overview shows the description, and the help falls back to it when --help fails
+	if err := registry.Execute("help", nil); err != nil {
+		t.Fatalf("Execute(help) error = %v", err)
+	}
+	if !strings.Contains(output.String(), "  - deploy: Deploy services\n") {
+		t.Errorf("help output = %q, want the description of deploy", output.String())
+	}
+
+	output.Reset()
+	if err := registry.Execute("help", []string{"deploy"}); err != nil {
+		t.Fatalf("Execute(help deploy) error = %v", err)
+	}
+	if !strings.Contains(output.String(), "deploy 1.0.0: Deploy services\n\nSubcommands:\n  - status: Show the status\n") {
+		t.Errorf("help deploy output = %q, want the formatted description", output.String())
+	}
+}
@@ -0,0 +1,240 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/pkg/errors"
+)
+
+// disabledKey is the setting listing the disabled subcommands and binaries
+const disabledKey = "disabled"
+
+// DisableHandler handles the disable and enable commands, which add subcommands and binaries to the disabled
+// setting and remove them from it
+type DisableHandler struct {
+	registry *Registry
+	output   io.Writer
+	enable   bool
+}
+
+// NewDisableHandler creates a new disable command handler
+func NewDisableHandler(registry *Registry) *DisableHandler {
+	return &DisableHandler{
+		registry: registry,
+		output:   os.Stdout,
+	}
+}
+
+// NewEnableHandler creates a new enable command handler
+func NewEnableHandler(registry *Registry) *DisableHandler {
+	return &DisableHandler{
+		registry: registry,
+		output:   os.Stdout,
+		enable:   true,
+	}
+}
+
+// Help returns the usage of the disable or enable command
+func (h *DisableHandler) Help() string {
+	if h.enable {
+		return "Usage: master-mold enable <command|path>\n\nEnables a subcommand or binary disabled with 'master-mold disable'. Without arguments, lists the disabled\nsubcommands and binaries."
+	}
+	return "Usage: master-mold disable <command|path>\n\nDisables a subcommand without uninstalling it: its binaries stay on disk but are skipped by discovery and\ndispatch. Given the path of a binary, only that binary is disabled and the subcommand runs the next one found,\nsuch as another version. Without arguments, lists the disabled subcommands and binaries."
+}
+
+// Execute executes the disable or enable command
+func (h *DisableHandler) Execute(args []string) error {
+	name := "disable"
+	if h.enable {
+		name = "enable"
+	}
+	flags := newFlagSet(name, h.output)
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+
+	cfg := h.registry.Config()
+	switch len(args) {
+	case 0:
+		if len(cfg.Disabled) == 0 {
+			fmt.Fprintln(h.output, "No subcommands are disabled")
+		}
+		for _, entry := range cfg.Disabled {
+			fmt.Fprintln(h.output, entry)
+		}
+		return nil
+	case 1:
+	default:
+		return errors.Errorf("usage: master-mold %s <command|path>", name)
+	}
+
+	entry, err := h.entry(args[0])
+	if err != nil {
+		return err
+	}
+	if h.enable {
+		return h.enableEntry(cfg, entry)
+	}
+	return h.disableEntry(cfg, entry)
+}
+
+// entry gets the entry of the disabled setting for a subcommand name, or for the path of a binary, which is made
+// absolute
+func (h *DisableHandler) entry(target string) (string, error) {
+	if !strings.ContainsRune(target, '/') && !strings.ContainsRune(target, filepath.Separator) {
+		return target, nil
+	}
+	path, err := filepath.Abs(target)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid path '%s'", target)
+	}
+	return path, nil
+}
+
+// disableEntry disables a subcommand or binary that exists
+func (h *DisableHandler) disableEntry(cfg *config.Config, entry string) error {
+	if filepath.IsAbs(entry) {
+		if !binary.HasValidPrefix(filepath.Base(entry)) || !binary.IsExecutable(entry) {
+			return errors.Errorf("'%s' is not a subcommand binary", entry)
+		}
+	} else {
+		if _, ok := h.registry.Get(entry); ok {
+			return errors.Errorf("'%s' is a built-in command and cannot be disabled", entry)
+		}
+		if len(binary.ResolveAll(entry, config.GetExpandedBaseDir(cfg))) == 0 {
+			return errors.Errorf("subcommand '%s' not found", entry)
+		}
+	}
+
+	added, err := config.AddListItem(cfg.File, disabledKey, entry)
+	if err != nil {
+		return err
+	}
+	if !added {
+		fmt.Fprintf(h.output, "%s is already disabled\n", entry)
+		return nil
+	}
+	cfg.Disabled = append(cfg.Disabled, entry)
+	fmt.Fprintf(h.output, "Disabled %s; run 'master-mold enable %s' to enable it again\n", entry, entry)
+	return nil
+}
+
+// enableEntry enables a disabled subcommand or binary
+func (h *DisableHandler) enableEntry(cfg *config.Config, entry string) error {
+	removed, err := config.RemoveListItem(cfg.File, disabledKey, entry)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return errors.Errorf("'%s' is not disabled", entry)
+	}
+	if index := slices.Index(cfg.Disabled, entry); index >= 0 {
+		cfg.Disabled = slices.Delete(cfg.Disabled, index, index+1)
+	}
+	fmt.Fprintf(h.output, "Enabled %s\n", entry)
+	return nil
+}
+
+// disabledError is the error of running a disabled subcommand
+type disabledError struct {
+	name string
+}
+
+func (e *disabledError) Error() string {
+	return fmt.Sprintf("subcommand '%s' is disabled; run 'master-mold enable %s' to enable it", e.name, e.name)
+}
+
+// isDisabled checks if a binary is disabled, by the name of its subcommand or by its path
+func isDisabled(cfg *config.Config, binaryPath string) bool {
+	if len(cfg.Disabled) == 0 {
+		return false
+	}
+	name := binary.ExtractCommandName(binaryPath)
+	cleaned := filepath.Clean(binaryPath)
+	for _, entry := range cfg.Disabled {
+		if entry == name || filepath.IsAbs(entry) && filepath.Clean(entry) == cleaned {
+			return true
+		}
+	}
+	return false
+}
+
+// findBinaries finds the binaries of the subcommands in order of precedence, like binary.FindAll, without the
+// disabled ones and, with base_dir_only, those outside the base directory
+func findBinaries(cfg *config.Config) ([]string, error) {
+	binaryPaths, err := binary.FindAll(config.GetExpandedBaseDir(cfg))
+	if err != nil || (len(cfg.Disabled) == 0 && !cfg.BaseDirOnly) {
+		return binaryPaths, err
+	}
+	return slices.DeleteFunc(binaryPaths, func(binaryPath string) bool {
+		return isDisabled(cfg, binaryPath) || isIgnored(cfg, binaryPath)
+	}), nil
+}
+
+// resolveSubcommand finds the binary a subcommand name resolves to, like binary.Resolve, skipping the disabled
+// binaries and, with base_dir_only, those outside the base directory
+func resolveSubcommand(cfg *config.Config, name string) (binary.Resolution, error) {
+	baseDir := config.GetExpandedBaseDir(cfg)
+	if len(cfg.Disabled) == 0 && !cfg.BaseDirOnly {
+		return binary.Resolve(name, baseDir)
+	}
+	if slices.Contains(cfg.Disabled, name) {
+		return binary.Resolution{}, &disabledError{name: name}
+	}
+
+	resolutions := binary.ResolveAll(name, baseDir)
+	var ignored []string
+	for _, resolution := range resolutions {
+		switch {
+		case isDisabled(cfg, resolution.Path):
+		case isIgnored(cfg, resolution.Path):
+			ignored = append(ignored, resolution.Path)
+		default:
+			return resolution, nil
+		}
+	}
+	if len(ignored) > 0 {
+		err := errors.Errorf("subcommand '%s' is only in the PATH, at %s, and base_dir_only runs subcommands from %s only; install it there to run it", name, ignored[0], baseDir)
+		return binary.Resolution{}, withCategory(CategoryNotFound, err)
+	}
+	if len(resolutions) > 0 {
+		return binary.Resolution{}, errors.Errorf("all the binaries of subcommand '%s' are disabled; run 'master-mold enable' to list them", name)
+	}
+	return binary.Resolution{}, errors.WithStack(&binary.NotFoundError{Command: name})
+}
+
+// isIgnored checks if a binary is ignored for being outside the base directory, with base_dir_only
+func isIgnored(cfg *config.Config, binaryPath string) bool {
+	if !cfg.BaseDirOnly {
+		return false
+	}
+	baseDir, err := filepath.Abs(config.GetExpandedBaseDir(cfg))
+	if err != nil {
+		return true
+	}
+	dir, err := filepath.Abs(filepath.Dir(binaryPath))
+	return err != nil || dir != baseDir
+}
+
+// findSubcommand finds the path of the binary a subcommand name resolves to, skipping the disabled binaries
+func findSubcommand(cfg *config.Config, name string) (string, error) {
+	resolution, err := resolveSubcommand(cfg, name)
+	if err != nil {
+		return "", err
+	}
+	return resolution.Path, nil
+}
+
+// RegisterDisableCommands registers the disable and enable commands
+func RegisterDisableCommands(registry *Registry) {
+	registry.Register("disable", NewDisableHandler(registry))
+	registry.Register("enable", NewEnableHandler(registry))
+}
@@ -0,0 +1,153 @@
+package command
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+func TestDisableHandler_Execute(t *testing.T) {
+	pathDir := t.TempDir()
+	baseDir := t.TempDir()
+	for _, path := range []string{filepath.Join(pathDir, "mm-deploy"), filepath.Join(baseDir, "mm-deploy"), filepath.Join(baseDir, "mm-lint")} {
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", path, err)
+		}
+	}
+	t.Setenv("PATH", pathDir)
+
+	file := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(file, []byte("timeout = 10\n"), 0644); err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+	cfg := &config.Config{BaseDir: baseDir, File: file}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+	output := &bytes.Buffer{}
+	disable, enable := NewDisableHandler(registry), NewEnableHandler(registry)
+	disable.output, enable.output = output, output
+
+	// Disabling the binary in PATH makes the one in the base directory run instead
+	stale := filepath.Join(pathDir, "mm-deploy")
+	if err := disable.Execute([]string{stale}); err != nil {
+		t.Fatalf("Execute(%s) error = %v", stale, err)
+	}
+	if got, err := findSubcommand(cfg, "deploy"); err != nil || got != filepath.Join(baseDir, "mm-deploy") {
+		t.Errorf("findSubcommand(deploy) = %s, %v, want the binary of the base directory", got, err)
+	}
+
+	// A disabled subcommand is not found, nor listed
+	if err := disable.Execute([]string{"lint"}); err != nil {
+		t.Fatalf("Execute(lint) error = %v", err)
+	}
+	if _, err := findSubcommand(cfg, "lint"); err == nil || !strings.Contains(err.Error(), "master-mold enable lint") {
+		t.Errorf("findSubcommand(lint) error = %v, want it to be disabled", err)
+	}
+	binaryPaths, err := findBinaries(cfg)
+	if err != nil {
+		t.Fatalf("findBinaries() error = %v", err)
+	}
+	if want := []string{filepath.Join(baseDir, "mm-deploy")}; !reflect.DeepEqual(binaryPaths, want) {
+		t.Errorf("findBinaries() = %v, want %v", binaryPaths, want)
+	}
+
+	// The disabled entries are kept in the configuration file
+	disabled, err := config.GetSetting(file, "disabled")
+	if err != nil {
+		t.Fatalf("GetSetting(disabled) error = %v", err)
+	}
+	if want := []interface{}{stale, "lint"}; !reflect.DeepEqual(disabled, want) {
+		t.Errorf("GetSetting(disabled) = %v, want %v", disabled, want)
+	}
+
+	output.Reset()
+	if err := enable.Execute(nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := stale + "\nlint\n"; output.String() != want {
+		t.Errorf("Execute() output = %q, want %q", output.String(), want)
+	}
+
+	if err := enable.Execute([]string{"lint"}); err != nil {
+		t.Fatalf("Execute(lint) error = %v", err)
+	}
+	if _, err := findSubcommand(cfg, "lint"); err != nil {
+		t.Errorf("findSubcommand(lint) error = %v, want it to be enabled", err)
+	}
+	if !reflect.DeepEqual(cfg.Disabled, []string{stale}) {
+		t.Errorf("Disabled = %v, want %v", cfg.Disabled, []string{stale})
+	}
+}
+
+func TestDisableHandler_Execute_Errors(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	file := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(file, []byte("timeout = 10\n"), 0644); err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+	cfg := &config.Config{BaseDir: t.TempDir(), File: file}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+
+	tests := []struct {
+		handler *DisableHandler
+		args    []string
+		wantErr string
+	}{
+		{NewDisableHandler(registry), []string{"config"}, "built-in command"},
+		{NewDisableHandler(registry), []string{"missing"}, "not found"},
+		{NewDisableHandler(registry), []string{file}, "not a subcommand binary"},
+		{NewDisableHandler(registry), []string{"a", "b"}, "usage"},
+		{NewEnableHandler(registry), []string{"deploy"}, "is not disabled"},
+	}
+
+	for _, tt := range tests {
+		tt.handler.output = &bytes.Buffer{}
+		if err := tt.handler.Execute(tt.args); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Execute(%v) error = %v, want %q", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestResolveSubcommand_BaseDirOnly(t *testing.T) {
+	pathDir := t.TempDir()
+	baseDir := t.TempDir()
+	for _, path := range []string{filepath.Join(pathDir, "mm-deploy"), filepath.Join(pathDir, "mm-lint"), filepath.Join(baseDir, "mm-lint")} {
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", path, err)
+		}
+	}
+	t.Setenv("PATH", pathDir)
+	cfg := &config.Config{BaseDir: baseDir, BaseDirOnly: true}
+
+	// The binary of the base directory runs, although the one in PATH comes first
+	if got, err := findSubcommand(cfg, "lint"); err != nil || got != filepath.Join(baseDir, "mm-lint") {
+		t.Errorf("findSubcommand(lint) = %s, %v, want the binary of the base directory", got, err)
+	}
+	if got := shadowedBinaries(cfg, "lint", filepath.Join(baseDir, "mm-lint")); len(got) != 0 {
+		t.Errorf("shadowedBinaries(lint) = %v, want the binaries of the PATH left out", got)
+	}
+
+	// A subcommand only in PATH is not found, with where it is
+	_, err := findSubcommand(cfg, "deploy")
+	if err == nil || !strings.Contains(err.Error(), "only in the PATH, at "+filepath.Join(pathDir, "mm-deploy")) {
+		t.Errorf("findSubcommand(deploy) error = %v, want it to be only in the PATH", err)
+	}
+	if code := ExitCode(err); code != ExitNotFound {
+		t.Errorf("ExitCode() = %d, want %d", code, ExitNotFound)
+	}
+
+	binaryPaths, err := findBinaries(cfg)
+	if err != nil {
+		t.Fatalf("findBinaries() error = %v", err)
+	}
+	if want := []string{filepath.Join(baseDir, "mm-lint")}; !reflect.DeepEqual(binaryPaths, want) {
+		t.Errorf("findBinaries() = %v, want %v", binaryPaths, want)
+	}
+}
@@ -0,0 +1,83 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+// printDryRun prints how a command, expanded from an alias when alias differs from name, would be executed
+func (r *Registry) printDryRun(alias, name string, args []string) error {
+	if alias != name {
+		fmt.Fprintf(r.output, "Alias:    %s = %s\n", alias, r.config.Aliases[alias])
+	}
+
+	if _, ok := r.Get(name); ok {
+		fmt.Fprintf(r.output, "Command:  %s (built-in)\n", name)
+		fmt.Fprintf(r.output, "Args:     %s\n", formatArgs(args))
+		return nil
+	}
+
+	name, args, resolution, err := resolveNamespaced(r.config, name, args)
+	if _, ok := r.bundledFallback(name, err); ok {
+		fmt.Fprintf(r.output, "Command:  %s (bundled)\n", name)
+		fmt.Fprintf(r.output, "Args:     %s\n", formatArgs(args))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	cmdPath := resolution.Path
+
+	fmt.Fprintf(r.output, "Command:  %s (subcommand)\n", name)
+	fmt.Fprintf(r.output, "Binary:   %s\n", cmdPath)
+	fmt.Fprintf(r.output, "Args:     %s\n", formatArgs(args))
+	if timeout := config.GetTimeout(r.config); timeout > 0 {
+		fmt.Fprintf(r.output, "Timeout:  %s\n", timeout)
+	} else {
+		fmt.Fprintln(r.output, "Timeout:  none")
+	}
+	variables, err := redactedSubcommandEnv(r.config, name)
+	if err != nil {
+		return err
+	}
+	for _, variable := range injectedEnv() {
+		fmt.Fprintf(r.output, "Env:      %s\n", config.RedactSecrets(r.config, variable))
+	}
+	for _, variable := range variables {
+		fmt.Fprintf(r.output, "Env:      %s\n", variable)
+	}
+	return nil
+}
+
+// injectedEnv gets the environment variables master-mold passes to subcommands, sorted
+func injectedEnv() []string {
+	var variables []string
+	for _, variable := range os.Environ() {
+		if strings.HasPrefix(variable, "MM_") || strings.HasPrefix(variable, "NO_COLOR=") {
+			variables = append(variables, variable)
+		}
+	}
+	sort.Strings(variables)
+	return variables
+}
+
+// formatArgs formats arguments for display, quoting those that are empty or contain whitespace or quotes
+func formatArgs(args []string) string {
+	if len(args) == 0 {
+		return "(none)"
+	}
+
+	formatted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"") {
+			formatted[i] = fmt.Sprintf("%q", arg)
+		} else {
+			formatted[i] = arg
+		}
+	}
+	return strings.Join(formatted, " ")
+}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+func TestRegistry_DryRun(t *testing.T) {
+	tempDir := t.TempDir()
+	marker := filepath.Join(tempDir, "ran")
+	script := "#!/bin/sh\ntouch " + marker + "\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "mm-deploy"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create binary: %v", err)
+	}
+	t.Setenv("PATH", "")
+	t.Setenv("MM_OUTPUT", "json")
+
+	cfg := &config.Config{BaseDir: tempDir, Timeout: 10, Aliases: map[string]string{"ship": "deploy --env $1"}}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+	registry.SetDryRun(true)
+	output := &bytes.Buffer{}
+	registry.output = output
+
+	if err := registry.Execute("ship", []string{"prod", "two words"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("Execute() ran the subcommand in dry-run mode")
+	}
+	for _, want := range []string{
+		"Alias:    ship = deploy --env $1\n",
+		"Command:  deploy (subcommand)\n",
+		"Binary:   " + filepath.Join(tempDir, "mm-deploy") + "\n",
+		"Args:     --env prod \"two words\"\n",
+		"Timeout:  10s\n",
+		"Env:      MM_OUTPUT=json\n",
+	} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("Execute() output = %q, want it to contain %q", output.String(), want)
+		}
+	}
+
+	output.Reset()
+	if err := registry.Execute("list-binaries", nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := "Command:  list-binaries (built-in)\nArgs:     (none)\n"; output.String() != want {
+		t.Errorf("Execute() output = %q, want %q", output.String(), want)
+	}
+
+	if err := registry.Execute("missing", nil); err == nil {
+		t.Error("Execute() error = nil, want a subcommand not found error")
+	}
+}
@@ -0,0 +1,159 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/oscarrieken/master-mold/pkg/describe"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/pkg/errors"
+)
+
+// EnvStatus is an environment variable of a plugin, and whether it is set
+type EnvStatus struct {
+	describe.EnvVar
+	Set bool `json:"set"`
+}
+
+// EnvHandler handles the env command
+type EnvHandler struct {
+	registry *Registry
+	output   io.Writer
+}
+
+// NewEnvHandler creates a new env command handler
+func NewEnvHandler(registry *Registry) *EnvHandler {
+	return &EnvHandler{
+		registry: registry,
+		output:   os.Stdout,
+	}
+}
+
+// Help returns the usage of the env command
+func (h *EnvHandler) Help() string {
+	return "Usage: master-mold env [--json] <command> [<subcommand>...]\n\nLists the environment variables a subcommand reads, from the description it gives of itself, with whether\nthey are set, in the environment or in the [env.<name>] table of the configuration; their values are never\nprinted. Fails when a required variable is missing."
+}
+
+// Execute executes the env command
+func (h *EnvHandler) Execute(args []string) error {
+	flags := newFlagSet("env", h.output)
+	jsonOutput := flags.Bool("json", env.JSONOutput(), "Print the variables in JSON format")
+	names, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return errors.New("usage: master-mold env [--json] <command> [<subcommand>...]")
+	}
+
+	name, description, err := h.describe(names[0], names[1:])
+	if err != nil {
+		return err
+	}
+
+	// The variables of the [env.<name>] table of the configuration are set when the subcommand runs
+	configured := h.registry.Config().Env[name]
+	statuses := []EnvStatus{}
+	var missing []string
+	for _, variable := range description.Env {
+		_, set := os.LookupEnv(variable.Name)
+		if _, ok := configured[strings.ToLower(variable.Name)]; ok {
+			set = true
+		}
+		statuses = append(statuses, EnvStatus{EnvVar: variable, Set: set})
+		if variable.Required && !set {
+			missing = append(missing, variable.Name)
+		}
+	}
+
+	if *jsonOutput {
+		if err := printJSON(h.output, statuses); err != nil {
+			return err
+		}
+	} else {
+		h.printStatuses(name, statuses)
+	}
+
+	if len(missing) > 0 {
+		return errors.Errorf("'%s' is missing required environment variables: %s", name, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// describe gets the description of a subcommand, asking its binary, or the bundled subcommand when it has none
+func (h *EnvHandler) describe(name string, args []string) (string, describe.Description, error) {
+	cfg := h.registry.Config()
+	name, _, resolution, err := resolveNamespaced(cfg, name, args)
+	if handler, ok := h.registry.bundledFallback(name, err); ok {
+		description, err := describeBundled(handler)
+		return name, description, err
+	}
+	if err != nil {
+		return name, describe.Description{}, err
+	}
+
+	cache := loadDescribeCache(cfg)
+	description, ok := cache.Describe(resolution.Path)
+	cache.Save()
+	if !ok {
+		return name, describe.Description{}, errors.Errorf("'%s' doesn't describe itself, so its environment variables are unknown", name)
+	}
+	return name, description, nil
+}
+
+// printStatuses prints the environment variables of a subcommand with whether they are required and set
+func (h *EnvHandler) printStatuses(name string, statuses []EnvStatus) {
+	if len(statuses) == 0 {
+		fmt.Fprintf(h.output, "'%s' doesn't read any environment variables.\n", name)
+		return
+	}
+
+	width := 0
+	for _, status := range statuses {
+		width = max(width, len(status.Name))
+	}
+	for _, status := range statuses {
+		required, set := "optional", "missing"
+		if status.Required {
+			required = "required"
+		}
+		if status.Set {
+			set = "set"
+		}
+		line := fmt.Sprintf("%-*s  %-8s  %-7s  %s", width, status.Name, required, set, status.Description)
+		fmt.Fprintln(h.output, strings.TrimRight(line, " "))
+	}
+}
+
+// describeBundled asks a bundled subcommand for its description, in-process. Its logs are discarded, as those of
+// the binaries describing themselves are.
+func describeBundled(handler Handler) (describe.Description, error) {
+	if devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0); err == nil {
+		stderr := os.Stderr
+		os.Stderr = devNull
+		defer func() {
+			os.Stderr = stderr
+			devNull.Close()
+		}()
+	}
+
+	var output bytes.Buffer
+	restore, err := redirectStdout(&output)
+	if err != nil {
+		return describe.Description{}, err
+	}
+	err = handler.Execute([]string{describe.Flag})
+	restore()
+	if err != nil {
+		return describe.Description{}, errors.Wrap(err, "failed to describe the bundled subcommand")
+	}
+	return describe.Parse(output.Bytes())
+}
+
+// RegisterEnvCommand registers the env command
+func RegisterEnvCommand(registry *Registry) {
+	registry.Register("env", NewEnvHandler(registry))
+}
@@ -0,0 +1,55 @@
+package command
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+func TestEnvHandler_Execute(t *testing.T) {
+	baseDir := t.TempDir()
+	description := `{"name": "deploy", "env": [{"name": "MM_TEST_TOKEN", "required": true}, {"name": "MM_TEST_REGION", "description": "Region to deploy to"}]}`
+	script := "#!/bin/sh\necho '" + description + "'\n"
+	if err := os.WriteFile(filepath.Join(baseDir, "mm-deploy"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create mm-deploy: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(baseDir, "mm-lint"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("Failed to create mm-lint: %v", err)
+	}
+	t.Setenv("PATH", "")
+	t.Setenv("MM_TEST_REGION", "eu")
+	os.Unsetenv("MM_TEST_TOKEN")
+
+	registry := NewRegistry(&config.Config{BaseDir: baseDir}, slog.New(slog.DiscardHandler))
+	output := &bytes.Buffer{}
+	handler := NewEnvHandler(registry)
+	handler.output = output
+
+	// A missing required variable fails the command
+	err := handler.Execute([]string{"deploy"})
+	if err == nil || !strings.Contains(err.Error(), "MM_TEST_TOKEN") {
+		t.Errorf("Execute(deploy) error = %v, want MM_TEST_TOKEN missing", err)
+	}
+	want := "MM_TEST_TOKEN   required  missing\nMM_TEST_REGION  optional  set      Region to deploy to\n"
+	if output.String() != want {
+		t.Errorf("Execute(deploy) output = %q, want %q", output.String(), want)
+	}
+
+	t.Setenv("MM_TEST_TOKEN", "secret")
+	output.Reset()
+	if err := handler.Execute([]string{"--json", "deploy"}); err != nil {
+		t.Fatalf("Execute(--json deploy) error = %v", err)
+	}
+	if !strings.Contains(output.String(), `"set": true`) || strings.Contains(output.String(), "secret") {
+		t.Errorf("Execute(--json deploy) output = %s, want the variables set without their values", output.String())
+	}
+
+	if err := handler.Execute([]string{"lint"}); err == nil {
+		t.Error("Execute(lint) succeeded, want an error for a subcommand that doesn't describe itself")
+	}
+}
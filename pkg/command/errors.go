@@ -0,0 +1,113 @@
+package command
+
+import (
+	"context"
+	"os"
+	"os/exec"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/pkg/errors"
+)
+
+// Category is the category of an error, which decides the exit code of master-mold and is logged with the error
+type Category string
+
+// Categories of errors
+const (
+	// CategoryNotFound is a subcommand that has no binary, or whose binaries are all disabled
+	CategoryNotFound Category = "not_found"
+	// CategoryConfig is an invalid or unreadable configuration
+	CategoryConfig Category = "config"
+	// CategoryExecution is a subcommand that ran and failed
+	CategoryExecution Category = "execution"
+	// CategoryTimeout is a subcommand killed for running longer than the timeout
+	CategoryTimeout Category = "timeout"
+	// CategoryPermission is a binary or file master-mold isn't allowed to run or read
+	CategoryPermission Category = "permission"
+	// CategoryOther is any other error, such as the wrong usage of a built-in command
+	CategoryOther Category = "other"
+)
+
+// Exit codes of master-mold by category. A failed subcommand exits with its own exit code, and an interrupted
+// bundled subcommand with ExitInterrupted, as a shell reports Ctrl-C.
+const (
+	ExitOther       = 1
+	ExitConfig      = 78
+	ExitTimeout     = 124
+	ExitPermission  = 126
+	ExitNotFound    = 127
+	ExitInterrupted = 130
+)
+
+// categorizedError is an error given a category where it happened
+type categorizedError struct {
+	category Category
+	err      error
+}
+
+func (e *categorizedError) Error() string { return e.err.Error() }
+func (e *categorizedError) Unwrap() error { return e.err }
+func (e *categorizedError) Cause() error  { return e.err }
+
+// withCategory gives an error a category, unless it already has one
+func withCategory(category Category, err error) error {
+	if err == nil || ErrorCategory(err) != CategoryOther {
+		return err
+	}
+	return &categorizedError{category: category, err: err}
+}
+
+// ConfigError gives an error loading the configuration the config category
+func ConfigError(err error) error {
+	return withCategory(CategoryConfig, err)
+}
+
+// ErrorCategory gets the category of an error: the one it was given, or else the one its cause implies
+func ErrorCategory(err error) Category {
+	var categorized *categorizedError
+	var timeout *binary.TimeoutError
+	var notFound *binary.NotFoundError
+	var disabled *disabledError
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &categorized):
+		return categorized.category
+	case errors.As(err, &timeout):
+		return CategoryTimeout
+	case errors.As(err, &notFound), errors.As(err, &disabled):
+		return CategoryNotFound
+	case errors.Is(err, os.ErrPermission):
+		return CategoryPermission
+	case errors.As(err, &exitErr):
+		return CategoryExecution
+	}
+	return CategoryOther
+}
+
+// ExitCode gets the exit code of master-mold for an error: the exit code of the failed subcommand, or else the
+// exit code of the category of the error
+func ExitCode(err error) int {
+	switch ErrorCategory(err) {
+	case "":
+		return 0
+	case CategoryNotFound:
+		return ExitNotFound
+	case CategoryConfig:
+		return ExitConfig
+	case CategoryTimeout:
+		return ExitTimeout
+	case CategoryPermission:
+		return ExitPermission
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		return exitErr.ExitCode()
+	}
+	if errors.Is(err, context.Canceled) {
+		return ExitInterrupted
+	}
+	return ExitOther
+}
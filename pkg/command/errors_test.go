@@ -0,0 +1,55 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/pkg/errors"
+)
+
+func TestExitCode(t *testing.T) {
+	baseDir := t.TempDir()
+	for name, script := range map[string]string{"mm-fail": "#!/bin/sh\nexit 3\n", "mm-pass": "#!/bin/sh\n"} {
+		if err := os.WriteFile(filepath.Join(baseDir, name), []byte(script), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	t.Setenv("PATH", "")
+
+	registry := NewRegistry(&config.Config{BaseDir: baseDir}, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+
+	tests := []struct {
+		name         string
+		err          error
+		wantCategory Category
+		wantCode     int
+	}{
+		{"success", registry.Execute("pass", nil), "", 0},
+		{"subcommand failure", registry.Execute("fail", nil), CategoryExecution, 3},
+		{"not found", registry.Execute("missing", nil), CategoryNotFound, ExitNotFound},
+		{"timeout", errors.Wrap(&binary.TimeoutError{Path: "mm-slow", Timeout: time.Second}, "failed"), CategoryTimeout, ExitTimeout},
+		{"configuration", ConfigError(errors.New("invalid timeout")), CategoryConfig, ExitConfig},
+		{"permission", errors.Wrap(&os.PathError{Op: "open", Path: "config.toml", Err: os.ErrPermission}, "failed to read"), CategoryPermission, ExitPermission},
+		{"other", errors.New("usage: master-mold which <command>"), CategoryOther, ExitOther},
+		{"interrupted", withCategory(CategoryExecution, errors.Wrap(context.Canceled, "interrupted")), CategoryExecution, ExitInterrupted},
+		{"category kept", withCategory(CategoryExecution, ConfigError(errors.New("invalid"))), CategoryConfig, ExitConfig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorCategory(tt.err); got != tt.wantCategory {
+				t.Errorf("ErrorCategory(%v) = %q, want %q", tt.err, got, tt.wantCategory)
+			}
+			if got := ExitCode(tt.err); got != tt.wantCode {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.wantCode)
+			}
+		})
+	}
+}
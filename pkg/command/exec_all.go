@@ -0,0 +1,160 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/display"
+	"github.com/pkg/errors"
+)
+
+// ExecAllHandler handles the exec-all command
+type ExecAllHandler struct {
+	registry *Registry
+}
+
+// NewExecAllHandler creates a new exec-all command handler
+func NewExecAllHandler(registry *Registry) *ExecAllHandler {
+	return &ExecAllHandler{
+		registry: registry,
+	}
+}
+
+// Help returns the usage of the exec-all command
+func (h *ExecAllHandler) Help() string {
+	return "Usage: master-mold exec-all --match <pattern> -- [arguments]\n\nRuns the subcommands matching the pattern concurrently with the same arguments. The pattern is a glob\nmatched against the subcommand name or its binary name, such as 'deploy-*' or 'mm-deploy-*'.\nEach output line is prefixed with the subcommand name; the command fails if any subcommand fails."
+}
+
+// Execute executes the exec-all command
+func (h *ExecAllHandler) Execute(args []string) error {
+	flags := newFlagSet("exec-all", h.registry.Output())
+	match := flags.String("match", "", "Glob matched against the subcommand or binary names")
+
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if *match == "" {
+		return errors.New("usage: master-mold exec-all --match <pattern> -- [arguments]")
+	}
+	if _, err := path.Match(*match, ""); err != nil {
+		return errors.Wrapf(err, "invalid pattern '%s'", *match)
+	}
+
+	cfg := h.registry.Config()
+	binaryPaths, err := findBinaries(cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to find binaries")
+	}
+
+	// Run the binaries that dispatching each matching subcommand would run
+	var targets []display.BinaryInfo
+	for _, info := range display.ProcessBinaries(binaryPaths) {
+		if !matchesSubcommand(*match, info) {
+			continue
+		}
+		if cmdPath, err := findSubcommand(cfg, info.Name); err == nil {
+			info.FullPath = cmdPath
+		}
+		targets = append(targets, info)
+	}
+	if len(targets) == 0 {
+		return errors.Errorf("no subcommands match '%s'", *match)
+	}
+
+	variables := make([][]string, len(targets))
+	for i, target := range targets {
+		if err := h.registry.VerifyBinary(target.FullPath); err != nil {
+			return err
+		}
+		if variables[i], err = subcommandEnv(cfg, target.Name); err != nil {
+			return err
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make([]error, len(targets))
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target display.BinaryInfo) {
+			defer wg.Done()
+			out := &prefixWriter{prefix: "[" + target.Name + "] ", output: h.registry.Output(), mu: &mu}
+			errs[i] = binary.ExecuteWithEnv(target.FullPath, args, config.GetTimeout(cfg), variables[i], nil, out, out, h.registry.Logger())
+			out.Flush()
+		}(i, target)
+	}
+	wg.Wait()
+
+	var failed []string
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, targets[i].Name)
+			fmt.Fprintf(h.registry.Output(), "[%s] failed: %v\n", targets[i].Name, err)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("%d of %d subcommands failed: %s", len(failed), len(targets), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// matchesSubcommand checks if the pattern matches the name of a subcommand or of its binary
+func matchesSubcommand(pattern string, info display.BinaryInfo) bool {
+	if ok, _ := path.Match(pattern, info.Name); ok {
+		return true
+	}
+	ok, _ := path.Match(pattern, filepath.Base(info.FullPath))
+	return ok
+}
+
+// prefixWriter writes complete lines to a shared output, each with a prefix, so concurrent outputs interleave by line
+type prefixWriter struct {
+	prefix  string
+	output  io.Writer
+	mu      *sync.Mutex
+	pending []byte
+}
+
+// Write writes the complete lines of p and keeps the last partial line until it is complete
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+	for {
+		i := bytes.IndexByte(w.pending, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		if err := w.writeLine(w.pending[:i+1]); err != nil {
+			return 0, err
+		}
+		w.pending = w.pending[i+1:]
+	}
+}
+
+// Flush writes the last partial line
+func (w *prefixWriter) Flush() {
+	if len(w.pending) > 0 {
+		w.writeLine(append(w.pending, '\n'))
+		w.pending = nil
+	}
+}
+
+// writeLine writes a line with the prefix
+func (w *prefixWriter) writeLine(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := fmt.Fprintf(w.output, "%s%s", w.prefix, line)
+	return err
+}
+
+// RegisterExecAllCommand registers the exec-all command
+func RegisterExecAllCommand(registry *Registry) {
+	registry.Register("exec-all", NewExecAllHandler(registry))
+}
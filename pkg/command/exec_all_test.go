@@ -0,0 +1,71 @@
+package command
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+func TestExecAllHandler_Execute(t *testing.T) {
+	tempDir := t.TempDir()
+	scripts := map[string]string{
+		"mm-deploy-eu": "#!/bin/sh\necho \"eu $1\"\n",
+		"mm-deploy-us": "#!/bin/sh\necho \"us $1\"\nexit 3\n",
+		"mm-other":     "#!/bin/sh\necho other\n",
+	}
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(script), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	t.Setenv("PATH", "")
+
+	registry := NewRegistry(&config.Config{BaseDir: tempDir}, slog.New(slog.DiscardHandler))
+	output := &bytes.Buffer{}
+	registry.SetOutput(output)
+	handler := NewExecAllHandler(registry)
+
+	err := handler.Execute([]string{"--match", "mm-deploy-*", "--", "status"})
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 subcommands failed: deploy-us") {
+		t.Errorf("Execute() error = %v, want deploy-us to fail", err)
+	}
+	for _, want := range []string{"[deploy-eu] eu status\n", "[deploy-us] us status\n", "[deploy-us] failed:"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("Execute() output = %q, want it to contain %q", output.String(), want)
+		}
+	}
+	if strings.Contains(output.String(), "other") {
+		t.Errorf("Execute() output = %q, want only the matching subcommands", output.String())
+	}
+
+	output.Reset()
+	if err := handler.Execute([]string{"--match", "deploy-e*", "--", "status"}); err != nil {
+		t.Errorf("Execute() error = %v", err)
+	}
+
+	if err := handler.Execute([]string{"--match", "nothing-*"}); err == nil {
+		t.Error("Execute() error = nil, want no matching subcommands")
+	}
+	if err := handler.Execute([]string{"status"}); err == nil {
+		t.Error("Execute() error = nil, want a usage error without --match")
+	}
+}
+
+func TestPrefixWriter(t *testing.T) {
+	output := &bytes.Buffer{}
+	w := &prefixWriter{prefix: "[a] ", output: output, mu: &sync.Mutex{}}
+
+	w.Write([]byte("one\ntw"))
+	w.Write([]byte("o\nthree"))
+	w.Flush()
+
+	if want := "[a] one\n[a] two\n[a] three\n"; output.String() != want {
+		t.Errorf("prefixWriter output = %q, want %q", output.String(), want)
+	}
+}
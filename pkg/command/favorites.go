@@ -0,0 +1,134 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/oscarrieken/master-mold/pkg/history"
+	"github.com/pkg/errors"
+)
+
+// FavoriteActions are the actions of the fav command
+var FavoriteActions = []string{"add", "list", "remove", "run"}
+
+// FavoritesHandler handles the fav command
+type FavoritesHandler struct {
+	registry *Registry
+	output   io.Writer
+}
+
+// NewFavoritesHandler creates a new fav command handler
+func NewFavoritesHandler(registry *Registry) *FavoritesHandler {
+	return &FavoritesHandler{
+		registry: registry,
+		output:   os.Stdout,
+	}
+}
+
+// Help returns the usage of the fav command
+func (h *FavoritesHandler) Help() string {
+	return "Usage: master-mold fav add <n>\n       master-mold fav list [--json]\n       master-mold fav run <n>\n       master-mold fav remove <n>\n\nKeeps command lines of the history as favorites for quick reuse. add takes the number of a command in\n'master-mold history' or 'master-mold recent'; list, run and remove take the number of a favorite."
+}
+
+// Execute executes the fav command
+func (h *FavoritesHandler) Execute(args []string) error {
+	flags := newFlagSet("fav", h.output)
+	jsonOutput := flags.Bool("json", env.JSONOutput(), "Print the favorites in JSON format")
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	path := favoritesPath(h.registry.Config())
+	favorites, err := history.LoadFavorites(path)
+	if err != nil {
+		return err
+	}
+
+	switch action, args := args[0], args[1:]; {
+	case action == "list" && len(args) == 0:
+		if *jsonOutput {
+			if favorites == nil {
+				favorites = []history.Favorite{}
+			}
+			return printJSON(h.output, favorites)
+		}
+		for i, favorite := range favorites {
+			fmt.Fprintf(h.output, "%3d  %s\n", i+1, favorite.Line())
+		}
+		return nil
+
+	case action == "add" && len(args) == 1:
+		entries, err := history.Load(historyPath(h.registry.Config()))
+		if err != nil {
+			return err
+		}
+		n, err := parseNumber(args[0], len(entries), "command", "the history")
+		if err != nil {
+			return err
+		}
+		favorite := history.Favorite{Command: entries[n-1].Command, Args: entries[n-1].Args}
+		for _, existing := range favorites {
+			if existing.Line() == favorite.Line() {
+				fmt.Fprintf(h.output, "%s is already a favorite\n", favorite.Line())
+				return nil
+			}
+		}
+		if err := history.SaveFavorites(path, append(favorites, favorite)); err != nil {
+			return err
+		}
+		fmt.Fprintf(h.output, "Added favorite %d: %s\n", len(favorites)+1, favorite.Line())
+		return nil
+
+	case action == "run" && len(args) == 1:
+		n, err := parseNumber(args[0], len(favorites), "favorite", "the favorites")
+		if err != nil {
+			return err
+		}
+		favorite := favorites[n-1]
+		fmt.Fprintln(h.output, favorite.Line())
+		return h.registry.Execute(favorite.Command, favorite.Args)
+
+	case action == "remove" && len(args) == 1:
+		n, err := parseNumber(args[0], len(favorites), "favorite", "the favorites")
+		if err != nil {
+			return err
+		}
+		removed := favorites[n-1]
+		if err := history.SaveFavorites(path, slices.Delete(favorites, n-1, n)); err != nil {
+			return err
+		}
+		fmt.Fprintf(h.output, "Removed favorite %d: %s\n", n, removed.Line())
+		return nil
+	}
+
+	return errors.New("usage: master-mold fav add <n> | list | run <n> | remove <n>")
+}
+
+// favoritesPath gets the path of the favorites file in the base directory
+func favoritesPath(cfg *config.Config) string {
+	return filepath.Join(config.GetExpandedBaseDir(cfg), "favorites.json")
+}
+
+// parseNumber parses the number of an item of a list counted from 1, such as a command of the history
+func parseNumber(value string, count int, item string, list string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 || n > count {
+		return 0, errors.Errorf("no %s %s in %s", item, value, list)
+	}
+	return n, nil
+}
+
+// RegisterFavoritesCommand registers the fav command
+func RegisterFavoritesCommand(registry *Registry) {
+	registry.Register("fav", NewFavoritesHandler(registry))
+}
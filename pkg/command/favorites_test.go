@@ -0,0 +1,103 @@
+package command
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/history"
+)
+
+// newHistoryTestRegistry creates a registry whose base directory has a deploy subcommand, recording each run in a
+// file, and a history of commands
+func newHistoryTestRegistry(t *testing.T, entries ...history.Entry) (*Registry, string) {
+	t.Helper()
+	baseDir := t.TempDir()
+	deploys := filepath.Join(baseDir, "deploys")
+	script := "#!/bin/sh\necho \"deployed $*\" >> " + deploys + "\n"
+	if err := os.WriteFile(filepath.Join(baseDir, "mm-deploy"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create mm-deploy: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	cfg := &config.Config{BaseDir: baseDir}
+	for _, entry := range entries {
+		if err := history.Append(historyPath(cfg), entry); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+	return registry, deploys
+}
+
+func TestRecentHandler_Execute(t *testing.T) {
+	registry, _ := newHistoryTestRegistry(t,
+		history.Entry{Command: "deploy", Args: []string{"--env", "prod"}},
+		history.Entry{Command: "lint"},
+		history.Entry{Command: "deploy", Args: []string{"--env", "prod"}},
+	)
+	output := &bytes.Buffer{}
+	handler := NewRecentHandler(registry)
+	handler.output = output
+
+	if err := handler.Execute(nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := "    3  deploy --env prod  (2 runs)\n    2  lint  (1 run)\n"; output.String() != want {
+		t.Errorf("Execute() output = %q, want %q", output.String(), want)
+	}
+
+	output.Reset()
+	if err := handler.Execute([]string{"-n", "1"}); err != nil {
+		t.Fatalf("Execute(-n 1) error = %v", err)
+	}
+	if strings.Count(output.String(), "\n") != 1 {
+		t.Errorf("Execute(-n 1) output = %q, want 1 command line", output.String())
+	}
+}
+
+func TestFavoritesHandler_Execute(t *testing.T) {
+	registry, deploys := newHistoryTestRegistry(t,
+		history.Entry{Command: "lint"},
+		history.Entry{Command: "deploy", Args: []string{"--env", "prod"}},
+	)
+	output := &bytes.Buffer{}
+	handler := NewFavoritesHandler(registry)
+	handler.output = output
+
+	for _, args := range [][]string{{"add", "2"}, {"add", "1"}, {"add", "2"}, {"remove", "2"}} {
+		if err := handler.Execute(args); err != nil {
+			t.Fatalf("Execute(%v) error = %v", args, err)
+		}
+	}
+	if !strings.Contains(output.String(), "deploy --env prod is already a favorite") {
+		t.Errorf("Execute() output = %q, want the duplicate favorite reported", output.String())
+	}
+
+	output.Reset()
+	if err := handler.Execute(nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := "  1  deploy --env prod\n"; output.String() != want {
+		t.Errorf("Execute() output = %q, want %q", output.String(), want)
+	}
+
+	output.Reset()
+	if err := handler.Execute([]string{"run", "1"}); err != nil {
+		t.Fatalf("Execute(run 1) error = %v", err)
+	}
+	if data, _ := os.ReadFile(deploys); string(data) != "deployed --env prod\n" {
+		t.Errorf("deploys = %q, want the favorite run", data)
+	}
+
+	for _, args := range [][]string{{"run", "2"}, {"add", "9"}, {"remove", "x"}, {"share"}} {
+		if err := handler.Execute(args); err == nil {
+			t.Errorf("Execute(%v) error = nil, want an error", args)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package command
+
+import (
+	"io"
+
+	"github.com/spf13/pflag"
+)
+
+// newFlagSet creates a flag set for a built-in command that reports errors instead of exiting. Built-in commands
+// parse their flags like the cobra commands of the subcommands do.
+func newFlagSet(name string, output io.Writer) *pflag.FlagSet {
+	flags := pflag.NewFlagSet(name, pflag.ContinueOnError)
+	flags.SetOutput(output)
+	return flags
+}
+
+// parseFlags parses flags given before, between or after the positional arguments, and returns the positional
+// arguments. Arguments after "--" are always positional.
+func parseFlags(flags *pflag.FlagSet, args []string) ([]string, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	return flags.Args(), nil
+}
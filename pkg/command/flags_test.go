@@ -0,0 +1,41 @@
+package command
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		wantPositional []string
+		wantYes        bool
+	}{
+		{"flag first", []string{"--yes", "k8s-pods"}, []string{"k8s-pods"}, true},
+		{"flag last", []string{"k8s-pods", "-y"}, []string{"k8s-pods"}, true},
+		{"no flags", []string{"a", "b"}, []string{"a", "b"}, false},
+		{"terminator", []string{"a", "--", "--yes"}, []string{"a", "--yes"}, false},
+		{"between", []string{"a", "--yes", "b"}, []string{"a", "b"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := newFlagSet("test", io.Discard)
+			yes := flags.BoolP("yes", "y", false, "")
+
+			positional, err := parseFlags(flags, tt.args)
+			if err != nil {
+				t.Fatalf("parseFlags() error = %v", err)
+			}
+			if !reflect.DeepEqual(positional, tt.wantPositional) || *yes != tt.wantYes {
+				t.Errorf("parseFlags() = %v, yes = %v, want %v, yes = %v", positional, *yes, tt.wantPositional, tt.wantYes)
+			}
+		})
+	}
+
+	if _, err := parseFlags(newFlagSet("test", io.Discard), []string{"--unknown"}); err == nil {
+		t.Error("parseFlags() with an unknown flag error = nil, want an error")
+	}
+}
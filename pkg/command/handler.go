@@ -1,7 +1,14 @@
 package command
 
 import (
+	"context"
+	"io"
 	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/oscarrieken/master-mold/pkg/config"
 )
@@ -20,23 +27,71 @@ func (f HandlerFunc) Execute(args []string) error {
 	return f(args)
 }
 
+// ContextHandler is a handler that runs within a context, such as a bundled subcommand given the timeout of
+// subcommands
+type ContextHandler interface {
+	Handler
+	// ExecuteContext executes the command with the given arguments within the context
+	ExecuteContext(ctx context.Context, args []string) error
+}
+
+// ContextHandlerFunc is a function type that implements the ContextHandler interface
+type ContextHandlerFunc func(ctx context.Context, args []string) error
+
+// Execute calls the handler function without a deadline
+func (f ContextHandlerFunc) Execute(args []string) error {
+	return f(context.Background(), args)
+}
+
+// ExecuteContext calls the handler function within the context
+func (f ContextHandlerFunc) ExecuteContext(ctx context.Context, args []string) error {
+	return f(ctx, args)
+}
+
 // Registry is a registry of command handlers
 type Registry struct {
 	handlers          map[string]Handler
+	// bundled are the subcommands built into master-mold, which run in-process when their binary isn't installed
+	bundled           map[string]Handler
 	config            *config.Config
 	logger            *slog.Logger
 	subcommandExecutor func(name string, args []string) error
+	// dryRun prints how commands would be executed instead of executing them
+	dryRun            bool
+	// output is where dry runs and the output of subcommands go
+	output            io.Writer
+	// verified are the binaries that passed VerifyBinary, with their state then
+	verified          map[string]binaryStamp
+	verifiedMu        sync.Mutex
 }
 
 // NewRegistry creates a new command registry
 func NewRegistry(config *config.Config, logger *slog.Logger) *Registry {
 	return &Registry{
 		handlers: make(map[string]Handler),
+		bundled:  make(map[string]Handler),
 		config:   config,
 		logger:   logger,
+		output:   os.Stdout,
+		verified: make(map[string]binaryStamp),
 	}
 }
 
+// SetDryRun makes the registry print how commands would be executed instead of executing them
+func (r *Registry) SetDryRun(dryRun bool) {
+	r.dryRun = dryRun
+}
+
+// SetOutput sets where dry runs and the output of subcommands go, instead of stdout
+func (r *Registry) SetOutput(output io.Writer) {
+	r.output = output
+}
+
+// SetLogger replaces the logger of the registry and of the commands using it
+func (r *Registry) SetLogger(logger *slog.Logger) {
+	r.logger = logger
+}
+
 // Register registers a command handler
 func (r *Registry) Register(name string, handler Handler) {
 	r.handlers[name] = handler
@@ -47,14 +102,73 @@ func (r *Registry) RegisterFunc(name string, fn func(args []string) error) {
 	r.Register(name, HandlerFunc(fn))
 }
 
+// RegisterBundled registers a subcommand built into master-mold. Unlike built-in commands, an installed binary of
+// the subcommand runs instead of it.
+func (r *Registry) RegisterBundled(name string, handler Handler) {
+	r.bundled[name] = handler
+}
+
+// Bundled returns the handler of a subcommand built into master-mold
+func (r *Registry) Bundled(name string) (Handler, bool) {
+	handler, ok := r.bundled[name]
+	return handler, ok
+}
+
+// bundledFallback gets the bundled subcommand to run when resolving the binary of a subcommand failed with err. A
+// disabled subcommand doesn't fall back to its bundled copy.
+func (r *Registry) bundledFallback(name string, err error) (Handler, bool) {
+	if err == nil || isDisabledError(err) {
+		return nil, false
+	}
+	return r.Bundled(name)
+}
+
 // Get returns the handler for the given command
 func (r *Registry) Get(name string) (Handler, bool) {
 	handler, ok := r.handlers[name]
 	return handler, ok
 }
 
-// Execute executes the given command with the given arguments
+// Names returns the names of the registered commands, sorted. Hidden commands, starting with "__", are left out.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.handlers))
+	for name := range r.handlers {
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Execute executes the given command with the given arguments, and records it in the history and the audit log
 func (r *Registry) Execute(name string, args []string) error {
+	start := time.Now()
+	err := r.dispatch(name, args)
+	r.recordHistory(name, args, start, err)
+	r.recordAudit(name, args, start, err)
+	return err
+}
+
+// dispatch executes the given command with the given arguments, as a built-in command or a subcommand
+func (r *Registry) dispatch(name string, args []string) error {
+	// Expand the aliases from the configuration
+	alias := name
+	name, args, err := r.expandAliases(name, args)
+	if err != nil {
+		return err
+	}
+
+	// Show the help of built-in commands and subcommands alike
+	if _, ok := r.Get("help"); ok && name != "help" && len(args) == 1 && isHelpFlag(args[0]) {
+		name, args = "help", []string{name}
+	}
+
+	if r.dryRun {
+		return r.printDryRun(alias, name, args)
+	}
+
 	handler, ok := r.Get(name)
 	if !ok {
 		// If the command is not found in the registry, try to execute it as a subcommand
@@ -79,6 +193,11 @@ func (r *Registry) Config() *config.Config {
 	return r.config
 }
 
+// Output returns where dry runs and the output of subcommands go
+func (r *Registry) Output() io.Writer {
+	return r.output
+}
+
 // Logger returns the logger
 func (r *Registry) Logger() *slog.Logger {
 	return r.logger
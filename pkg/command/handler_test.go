@@ -143,4 +143,38 @@ func TestRegistry_Execute(t *testing.T) {
 			t.Errorf("Execute() handler arg %d = %v, want %v", i, handler.Args[i], arg)
 		}
 	}
-}
\ No newline at end of file
+}
+func TestRegistry_ExecuteAlias(t *testing.T) {
+	// Create a registry with aliases, one of them shadowing a built-in command
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	cfg := &config.Config{Aliases: map[string]string{
+		"t":    "test --verbose $1",
+		"test": "other",
+	}}
+	registry := NewRegistry(cfg, logger)
+	handler := &MockHandler{}
+	registry.Register("test", handler)
+
+	// The alias is expanded to the built-in command
+	if err := registry.Execute("t", []string{"one", "two"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	want := []string{"--verbose", "one", "two"}
+	if len(handler.Args) != len(want) {
+		t.Fatalf("Execute() args = %v, want %v", handler.Args, want)
+	}
+	for i := range want {
+		if handler.Args[i] != want[i] {
+			t.Errorf("Execute() arg %d = %v, want %v", i, handler.Args[i], want[i])
+		}
+	}
+
+	// Built-in commands are never overridden by aliases
+	handler.Args = nil
+	if err := registry.Execute("test", []string{"x"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(handler.Args) != 1 || handler.Args[0] != "x" {
+		t.Errorf("Execute() args = %v, want [x]", handler.Args)
+	}
+}
@@ -0,0 +1,166 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/display"
+	"github.com/oscarrieken/master-mold/pkg/index"
+	"github.com/pkg/errors"
+)
+
+// HelpProvider is implemented by built-in command handlers that describe their usage
+type HelpProvider interface {
+	// Help returns the usage and description of the command
+	Help() string
+}
+
+// HelpHandler handles the help command
+type HelpHandler struct {
+	registry *Registry
+	output   io.Writer
+}
+
+// NewHelpHandler creates a new help command handler
+func NewHelpHandler(registry *Registry) *HelpHandler {
+	return &HelpHandler{
+		registry: registry,
+		output:   os.Stdout,
+	}
+}
+
+// Help returns the usage of the help command
+func (h *HelpHandler) Help() string {
+	return "Usage: master-mold help [command]\n\nShows the help of a command, or lists the available commands."
+}
+
+// Execute executes the help command
+func (h *HelpHandler) Execute(args []string) error {
+	switch len(args) {
+	case 0:
+		return h.printOverview()
+	case 1:
+		return h.printCommandHelp(args[0])
+	}
+	return errors.New("usage: master-mold help [command]")
+}
+
+// printOverview prints the built-in commands and the discovered subcommands
+func (h *HelpHandler) printOverview() error {
+	fmt.Fprintln(h.output, "Usage: master-mold <command> [options]")
+	fmt.Fprintln(h.output)
+	fmt.Fprintln(h.output, "Built-in commands:")
+	for _, name := range h.registry.Names() {
+		fmt.Fprintf(h.output, "  - %s\n", name)
+	}
+
+	binaryPaths, err := findBinaries(h.registry.Config())
+	if err != nil {
+		return errors.Wrap(err, "failed to find binaries")
+	}
+
+	fmt.Fprintln(h.output)
+	binaries := describeBinaries(h.registry.Config(), display.ProcessBinaries(binaryPaths))
+	if len(binaries) == 0 {
+		fmt.Fprintln(h.output, "No subcommand binaries found.")
+	} else {
+		fmt.Fprintln(h.output, "Subcommands:")
+		for _, info := range binaries {
+			if info.Description != "" {
+				fmt.Fprintf(h.output, "  - %s: %s\n", info.Name, info.Description)
+			} else {
+				fmt.Fprintf(h.output, "  - %s\n", info.Name)
+			}
+		}
+	}
+
+	if aliases := h.registry.Config().Aliases; len(aliases) > 0 {
+		fmt.Fprintln(h.output)
+		fmt.Fprintln(h.output, "Aliases:")
+		names := make([]string, 0, len(aliases))
+		for name := range aliases {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(h.output, "  - %s = %s\n", name, aliases[name])
+		}
+	}
+
+	fmt.Fprintln(h.output)
+	fmt.Fprintln(h.output, "Run 'master-mold help <command>' for the help of a command.")
+	return nil
+}
+
+// printCommandHelp prints the help of a built-in command or of a subcommand
+func (h *HelpHandler) printCommandHelp(name string) error {
+	if handler, ok := h.registry.Get(name); ok {
+		if provider, ok := handler.(HelpProvider); ok {
+			fmt.Fprintln(h.output, provider.Help())
+		} else {
+			fmt.Fprintf(h.output, "'%s' is a built-in command without help.\n", name)
+		}
+		return nil
+	}
+
+	if alias, ok := h.registry.Config().Aliases[name]; ok {
+		fmt.Fprintf(h.output, "'%s' is an alias for '%s'.\n", name, alias)
+		return nil
+	}
+
+	// Ask the subcommand for its help, falling back to the description it gives of itself, then to its
+	// description in the plugin registries
+	cfg := h.registry.Config()
+	cmdPath, findErr := findSubcommand(cfg, name)
+	if findErr == nil {
+		err := binary.Execute(cmdPath, []string{"--help"}, config.GetTimeout(cfg), h.registry.Logger())
+		if err == nil {
+			return nil
+		}
+		h.registry.Logger().Warn("Subcommand failed to show its help", "command", name, "error", err)
+
+		if description, ok := loadDescribeCache(cfg).Describe(cmdPath); ok {
+			fmt.Fprint(h.output, FormatDescription(description))
+			return nil
+		}
+	}
+
+	if handler, ok := h.registry.bundledFallback(name, findErr); ok {
+		return handler.Execute([]string{"--help"})
+	}
+
+	if plugin, ok := findPluginDescription(cfg.Registries, name); ok {
+		fmt.Fprintln(h.output, FormatPlugin(plugin, findErr == nil))
+		return nil
+	}
+
+	if findErr != nil {
+		return errors.Wrapf(findErr, "no help for '%s'", name)
+	}
+	return errors.Errorf("no help for '%s'", name)
+}
+
+// findPluginDescription finds a plugin by name in the plugin registries
+func findPluginDescription(registries []string, name string) (index.Plugin, bool) {
+	plugins, _ := index.FetchAll(registries)
+	for _, plugin := range plugins {
+		if plugin.Name == name {
+			return plugin, true
+		}
+	}
+	return index.Plugin{}, false
+}
+
+// isHelpFlag checks if an argument asks for help
+func isHelpFlag(arg string) bool {
+	return arg == "-h" || arg == "--help" || arg == "-help"
+}
+
+// RegisterHelpCommand registers the help command
+func RegisterHelpCommand(registry *Registry) {
+	registry.Register("help", NewHelpHandler(registry))
+}
@@ -0,0 +1,82 @@
+package command
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+// newTestHelpRegistry creates a registry with the built-in commands and a help handler writing to a buffer
+func newTestHelpRegistry(cfg *config.Config) (*Registry, *bytes.Buffer) {
+	registry := NewRegistry(cfg, slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})))
+	RegisterCommands(registry)
+
+	output := &bytes.Buffer{}
+	help := NewHelpHandler(registry)
+	help.output = output
+	registry.Register("help", help)
+	return registry, output
+}
+
+func TestHelpHandler_Overview(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "mm-deploy"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("Failed to create binary: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	registry, output := newTestHelpRegistry(&config.Config{BaseDir: tempDir})
+	if err := registry.Execute("help", nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	for _, want := range []string{"  - list-binaries", "  - uninstall", "Subcommands:", "  - deploy"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("help output = %q, want it to contain %q", output.String(), want)
+		}
+	}
+}
+
+func TestHelpHandler_BuiltinCommand(t *testing.T) {
+	registry, output := newTestHelpRegistry(&config.Config{BaseDir: t.TempDir()})
+
+	// Both forms show the help of the built-in command
+	if err := registry.Execute("help", []string{"uninstall"}); err != nil {
+		t.Fatalf("Execute(help uninstall) error = %v", err)
+	}
+	if err := registry.Execute("uninstall", []string{"--help"}); err != nil {
+		t.Fatalf("Execute(uninstall --help) error = %v", err)
+	}
+	if got := strings.Count(output.String(), "Usage: master-mold uninstall"); got != 2 {
+		t.Errorf("help output = %q, want the uninstall usage twice", output.String())
+	}
+}
+
+func TestHelpHandler_SubcommandFallback(t *testing.T) {
+	tempDir := t.TempDir()
+	registryPath := filepath.Join(tempDir, "index.json")
+	indexContent := `{"plugins": [{"name": "deploy", "description": "Deploy services", "version": "1.0.0"}]}`
+	if err := os.WriteFile(registryPath, []byte(indexContent), 0644); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	registry, output := newTestHelpRegistry(&config.Config{BaseDir: tempDir, Registries: []string{registryPath}})
+
+	// A subcommand that is not installed is described by the plugin registries
+	if err := registry.Execute("deploy", []string{"--help"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(output.String(), "deploy 1.0.0: Deploy services") {
+		t.Errorf("help output = %q, want the registry description", output.String())
+	}
+
+	if err := registry.Execute("help", []string{"missing"}); err == nil {
+		t.Error("Execute(help missing) error = nil, want an error")
+	}
+}
@@ -0,0 +1,121 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/oscarrieken/master-mold/pkg/history"
+	"github.com/pkg/errors"
+)
+
+// unrecordedCommands are the commands that browse the history, which are not recorded in it; the commands they run
+// again are
+var unrecordedCommands = map[string]bool{"history": true, "recent": true, "fav": true}
+
+// HistoryEntry is a command of the history with its number, counted from the oldest
+type HistoryEntry struct {
+	Number int `json:"number"`
+	history.Entry
+}
+
+// HistoryHandler handles the history command
+type HistoryHandler struct {
+	registry *Registry
+	output   io.Writer
+}
+
+// NewHistoryHandler creates a new history command handler
+func NewHistoryHandler(registry *Registry) *HistoryHandler {
+	return &HistoryHandler{
+		registry: registry,
+		output:   os.Stdout,
+	}
+}
+
+// Help returns the usage of the history command
+func (h *HistoryHandler) Help() string {
+	return "Usage: master-mold history [--search <term>] [--json]\n       master-mold history --rerun <n>\n\nLists the commands run with master-mold, numbered from the oldest, with when they ran, how long they took and\ntheir exit code. --search only lists the commands containing the term, and --rerun runs command <n> again."
+}
+
+// Execute executes the history command
+func (h *HistoryHandler) Execute(args []string) error {
+	flags := newFlagSet("history", h.output)
+	search := flags.String("search", "", "Only list the commands containing the term")
+	rerun := flags.Int("rerun", 0, "Run the command with this number again")
+	jsonOutput := flags.Bool("json", env.JSONOutput(), "Print the history in JSON format")
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return errors.New("usage: master-mold history [--search <term>] [--rerun <n>] [--json]")
+	}
+
+	entries, err := history.Load(historyPath(h.registry.Config()))
+	if err != nil {
+		return err
+	}
+
+	if flags.Changed("rerun") {
+		if *rerun < 1 || *rerun > len(entries) {
+			return errors.Errorf("no command %d in the history", *rerun)
+		}
+		entry := entries[*rerun-1]
+		fmt.Fprintln(h.output, entry.Line())
+		return h.registry.Execute(entry.Command, entry.Args)
+	}
+
+	var matches []HistoryEntry
+	term := strings.ToLower(*search)
+	for i, entry := range entries {
+		if strings.Contains(strings.ToLower(entry.Line()), term) {
+			matches = append(matches, HistoryEntry{Number: i + 1, Entry: entry})
+		}
+	}
+
+	if *jsonOutput {
+		if matches == nil {
+			matches = []HistoryEntry{}
+		}
+		return printJSON(h.output, matches)
+	}
+	for _, entry := range matches {
+		fmt.Fprintf(h.output, "%5d  %s  %8s  %3d  %s\n", entry.Number, entry.Time.Local().Format("2006-01-02 15:04:05"), entry.Duration().Round(10*time.Millisecond), entry.ExitCode, entry.Line())
+	}
+	return nil
+}
+
+// historyPath gets the path of the history file in the base directory
+func historyPath(cfg *config.Config) string {
+	return filepath.Join(config.GetExpandedBaseDir(cfg), "history.jsonl")
+}
+
+// recordHistory records a dispatched command in the history, with secrets masked as in the audit log. The commands
+// browsing the history, hidden commands and dry runs are not recorded, and failing to record is only logged.
+func (r *Registry) recordHistory(name string, args []string, start time.Time, err error) {
+	if r.config == nil || r.config.BaseDir == "" || r.dryRun || unrecordedCommands[name] || strings.HasPrefix(name, "__") {
+		return
+	}
+
+	entry := history.Entry{
+		Command:    name,
+		Args:       redactArgs(r.config, name, args),
+		Time:       start,
+		DurationMS: time.Since(start).Milliseconds(),
+		ExitCode:   ExitCode(err),
+	}
+	if err := history.Append(historyPath(r.config), entry); err != nil {
+		r.logger.Debug("Failed to record the command in the history", "error", err)
+	}
+}
+
+// RegisterHistoryCommand registers the history command
+func RegisterHistoryCommand(registry *Registry) {
+	registry.Register("history", NewHistoryHandler(registry))
+}
@@ -0,0 +1,103 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/history"
+)
+
+func TestHistoryHandler_Execute(t *testing.T) {
+	baseDir := t.TempDir()
+	deploys := filepath.Join(baseDir, "deploys")
+	scripts := map[string]string{
+		"mm-deploy": "#!/bin/sh\necho \"deployed $*\" >> " + deploys + "\n",
+		"mm-lint":   "#!/bin/sh\nexit 3\n",
+	}
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(baseDir, name), []byte(script), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	t.Setenv("PATH", "")
+
+	cfg := &config.Config{BaseDir: baseDir, Aliases: map[string]string{"ship": "deploy --env prod"}}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+	output := &bytes.Buffer{}
+	handler := NewHistoryHandler(registry)
+	handler.output = output
+
+	// Commands are recorded as typed, with their exit code
+	if err := registry.Execute("ship", []string{"now"}); err != nil {
+		t.Fatalf("Execute(ship) error = %v", err)
+	}
+	if err := registry.Execute("lint", nil); err == nil {
+		t.Fatal("Execute(lint) error = nil, want the exit code of the subcommand")
+	}
+	if err := registry.Execute("history", nil); err != nil {
+		t.Fatalf("Execute(history) error = %v", err)
+	}
+
+	entries, err := history.Load(historyPath(cfg))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Line() != "ship now" || entries[0].ExitCode != 0 || entries[1].Line() != "lint" || entries[1].ExitCode != 3 {
+		t.Fatalf("history = %+v, want ship and lint", entries)
+	}
+
+	output.Reset()
+	if err := handler.Execute([]string{"--search", "SHIP"}); err != nil {
+		t.Fatalf("Execute(--search) error = %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(output.String()), "\n"); len(lines) != 1 || !strings.HasPrefix(strings.TrimSpace(lines[0]), "1 ") || !strings.HasSuffix(lines[0], "  0  ship now") {
+		t.Errorf("Execute(--search) output = %q, want command 1", output.String())
+	}
+
+	output.Reset()
+	if err := handler.Execute([]string{"--json"}); err != nil {
+		t.Fatalf("Execute(--json) error = %v", err)
+	}
+	var listed []HistoryEntry
+	if err := json.Unmarshal(output.Bytes(), &listed); err != nil {
+		t.Fatalf("Execute(--json) output is not JSON: %v", err)
+	}
+	if len(listed) != 2 || listed[1].Number != 2 || listed[1].Command != "lint" {
+		t.Errorf("Execute(--json) = %+v, want the numbered commands", listed)
+	}
+
+	// Rerunning a command runs it again, and records it again
+	output.Reset()
+	if err := handler.Execute([]string{"--rerun", "1"}); err != nil {
+		t.Fatalf("Execute(--rerun 1) error = %v", err)
+	}
+	if output.String() != "ship now\n" {
+		t.Errorf("Execute(--rerun 1) output = %q, want the command line", output.String())
+	}
+	if data, _ := os.ReadFile(deploys); string(data) != "deployed --env prod now\ndeployed --env prod now\n" {
+		t.Errorf("deploys = %q, want the command run twice", data)
+	}
+	if entries, _ := history.Load(historyPath(cfg)); len(entries) != 3 {
+		t.Errorf("history = %+v, want the rerun recorded", entries)
+	}
+
+	if err := handler.Execute([]string{"--rerun", "9"}); err == nil {
+		t.Error("Execute(--rerun 9) error = nil, want an error")
+	}
+
+	// Secrets are masked as in the audit log
+	if err := registry.Execute("deploy", []string{"--token", "t0ken", "--pat=p4t", "API_KEY=k3y"}); err != nil {
+		t.Fatalf("Execute(deploy) error = %v", err)
+	}
+	entries, _ = history.Load(historyPath(cfg))
+	if got, want := entries[len(entries)-1].Line(), "deploy --token ******** --pat=******** API_KEY=********"; got != want {
+		t.Errorf("history line = %q, want %q", got, want)
+	}
+}
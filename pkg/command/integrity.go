@@ -0,0 +1,224 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/oscarrieken/master-mold/pkg/integrity"
+	"github.com/oscarrieken/master-mold/pkg/lock"
+	"github.com/pkg/errors"
+)
+
+// IntegrityActions are the actions of the integrity command
+var IntegrityActions = []string{"check", "update"}
+
+// IntegrityStatus is how the binary of a subcommand compares to its recorded hash
+type IntegrityStatus struct {
+	Name   string           `json:"name"`
+	Path   string           `json:"path"`
+	Status integrity.Status `json:"status"`
+	SHA256 string           `json:"sha256"`
+}
+
+// IntegrityHandler handles the integrity command
+type IntegrityHandler struct {
+	registry *Registry
+	output   io.Writer
+}
+
+// NewIntegrityHandler creates a new integrity command handler
+func NewIntegrityHandler(registry *Registry) *IntegrityHandler {
+	return &IntegrityHandler{
+		registry: registry,
+		output:   os.Stdout,
+	}
+}
+
+// Help returns the usage of the integrity command
+func (h *IntegrityHandler) Help() string {
+	return "Usage: master-mold integrity check [--json]\n       master-mold integrity update [<command>...]\n\nmaster-mold records the SHA-256 hash of the binary of a subcommand the first time it runs, and warns when the\nbinary changes afterwards, or refuses to run it once 'strict_integrity' is set to true in the configuration.\ncheck compares every discovered binary to its record and fails when one changed. update records the hashes of\nthe binaries of the commands after they were installed or updated on purpose, or of every discovered binary."
+}
+
+// Execute executes the integrity command
+func (h *IntegrityHandler) Execute(args []string) error {
+	flags := newFlagSet("integrity", h.output)
+	jsonOutput := flags.Bool("json", env.JSONOutput(), "Print the statuses in JSON format")
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(args) == 1 && args[0] == "check":
+		return h.check(*jsonOutput)
+	case len(args) >= 1 && args[0] == "update":
+		return h.update(args[1:])
+	}
+	return errors.New("usage: master-mold integrity check [--json] | update [<command>...]")
+}
+
+// check compares every discovered binary to its record
+func (h *IntegrityHandler) check(jsonOutput bool) error {
+	cfg := h.registry.Config()
+	database, err := integrity.Load(integrityPath(cfg))
+	if err != nil {
+		return err
+	}
+	binaryPaths, err := findBinaries(cfg)
+	if err != nil {
+		return err
+	}
+
+	statuses := []IntegrityStatus{}
+	changed := 0
+	for _, binaryPath := range binaryPaths {
+		hash, err := integrity.Hash(binaryPath)
+		if err != nil {
+			return err
+		}
+		status, _ := database.Check(binaryPath, hash)
+		if status == integrity.StatusChanged {
+			changed++
+		}
+		statuses = append(statuses, IntegrityStatus{Name: binary.ExtractCommandName(binaryPath), Path: binaryPath, Status: status, SHA256: hash})
+	}
+
+	if jsonOutput {
+		if err := printJSON(h.output, statuses); err != nil {
+			return err
+		}
+	} else {
+		for _, status := range statuses {
+			fmt.Fprintf(h.output, "%-8s  %s\n", status.Status, status.Path)
+		}
+	}
+	if changed > 0 {
+		return errors.Errorf("%d binaries changed since their hashes were recorded", changed)
+	}
+	return nil
+}
+
+// update records the hashes of the binaries of the commands, or of every discovered binary without commands, which
+// also forgets the binaries that are gone
+func (h *IntegrityHandler) update(names []string) error {
+	cfg := h.registry.Config()
+	var binaryPaths []string
+	if len(names) == 0 {
+		var err error
+		if binaryPaths, err = findBinaries(cfg); err != nil {
+			return err
+		}
+	}
+	for _, name := range names {
+		resolution, err := resolveSubcommand(cfg, name)
+		if err != nil {
+			return err
+		}
+		binaryPaths = append(binaryPaths, resolution.Path)
+	}
+
+	hashes := make([]string, len(binaryPaths))
+	for i, binaryPath := range binaryPaths {
+		var err error
+		if hashes[i], err = integrity.Hash(binaryPath); err != nil {
+			return err
+		}
+	}
+	err := updateIntegrity(cfg, func(database integrity.Database) {
+		if len(names) == 0 {
+			clear(database)
+		}
+		for i, binaryPath := range binaryPaths {
+			database.Record(binaryPath, hashes[i])
+		}
+	})
+	if err != nil {
+		return err
+	}
+	for _, binaryPath := range binaryPaths {
+		fmt.Fprintf(h.output, "Recorded %s\n", binaryPath)
+	}
+	return nil
+}
+
+// integrityLockWait is how long changing the integrity database waits for another run changing it
+const integrityLockWait = 10 * time.Second
+
+// integrityPath gets the path of the integrity database in the base directory
+func integrityPath(cfg *config.Config) string {
+	return filepath.Join(config.GetExpandedBaseDir(cfg), "integrity.json")
+}
+
+// updateIntegrity changes the integrity database while holding its lock, so concurrent runs recording or forgetting
+// binaries don't lose each other's changes
+func updateIntegrity(cfg *config.Config, update func(database integrity.Database)) error {
+	path := integrityPath(cfg)
+	release, err := lock.Acquire(path+".lock", integrityLockWait)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	database, err := integrity.Load(path)
+	if err != nil {
+		return err
+	}
+	update(database)
+	return database.Save(path)
+}
+
+// checkIntegrity compares the binary of a subcommand to its recorded hash before it runs, recording it the first
+// time. A changed binary is warned about, or refused with strict_integrity.
+func checkIntegrity(cfg *config.Config, logger *slog.Logger, name string, cmdPath string) error {
+	if cfg.BaseDir == "" {
+		return nil
+	}
+
+	// Binaries that can't be read fail when they run
+	hash, err := integrity.Hash(cmdPath)
+	if err != nil {
+		return nil
+	}
+	path := integrityPath(cfg)
+	database, err := integrity.Load(path)
+	if err != nil {
+		if cfg.StrictIntegrity {
+			return withCategory(CategoryPermission, err)
+		}
+		logger.Warn("Failed to check the integrity of the binary", "command", name, "error", err)
+		return nil
+	}
+
+	status, record := database.Check(cmdPath, hash)
+	switch status {
+	case integrity.StatusUnknown:
+		err := updateIntegrity(cfg, func(database integrity.Database) {
+			if status, _ := database.Check(cmdPath, hash); status == integrity.StatusUnknown {
+				database.Record(cmdPath, hash)
+			}
+		})
+		if err != nil {
+			logger.Warn("Failed to record the hash of the binary", "command", name, "error", err)
+		}
+	case integrity.StatusChanged:
+		hint := fmt.Sprintf("run 'master-mold integrity update %s' if it was updated on purpose", name)
+		if cfg.StrictIntegrity {
+			err := errors.Errorf("refusing to run '%s': %s changed since its hash was recorded on %s; %s", name, cmdPath, record.Recorded.Local().Format("2006-01-02 15:04"), hint)
+			return withCategory(CategoryPermission, err)
+		}
+		logger.Warn("The binary of the subcommand changed since its hash was recorded", "command", name, "binary", cmdPath, "recorded", record.Recorded, "hint", hint)
+	}
+	return nil
+}
+
+// RegisterIntegrityCommand registers the integrity command
+func RegisterIntegrityCommand(registry *Registry) {
+	registry.Register("integrity", NewIntegrityHandler(registry))
+}
@@ -0,0 +1,169 @@
+package command
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/integrity"
+)
+
+func TestRegistry_ExecuteChangedBinary(t *testing.T) {
+	baseDir := t.TempDir()
+	output := filepath.Join(baseDir, "ran")
+	path := filepath.Join(baseDir, "mm-deploy")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho v1 > "+output+"\n"), 0755); err != nil {
+		t.Fatalf("Failed to create mm-deploy: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	cfg := &config.Config{BaseDir: baseDir, StrictIntegrity: true}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+
+	// The first run records the hash of the binary
+	if err := registry.Execute("deploy", nil); err != nil {
+		t.Fatalf("Execute(deploy) error = %v", err)
+	}
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho v2 > "+output+"\n"), 0755); err != nil {
+		t.Fatalf("Failed to change mm-deploy: %v", err)
+	}
+
+	err := registry.Execute("deploy", nil)
+	if err == nil || !strings.Contains(err.Error(), "refusing to run 'deploy': "+path+" changed") {
+		t.Errorf("Execute(deploy) error = %v, want it refused", err)
+	}
+	if code := ExitCode(err); code != ExitPermission {
+		t.Errorf("ExitCode() = %d, want %d", code, ExitPermission)
+	}
+
+	handler := NewIntegrityHandler(registry)
+	var out bytes.Buffer
+	handler.output = &out
+	if err := handler.Execute([]string{"check"}); err == nil {
+		t.Error("Execute(check) error = nil, want the changed binary reported")
+	}
+	if !strings.Contains(out.String(), "changed   "+path) {
+		t.Errorf("Execute(check) output = %q, want %s changed", out.String(), path)
+	}
+
+	// Changed on purpose, the binary runs once updated
+	if err := handler.Execute([]string{"update", "deploy"}); err != nil {
+		t.Fatalf("Execute(update, deploy) error = %v", err)
+	}
+	if err := registry.Execute("deploy", nil); err != nil {
+		t.Errorf("Execute(deploy) after the update error = %v", err)
+	}
+	if data, _ := os.ReadFile(output); string(data) != "v2\n" {
+		t.Errorf("Execute(deploy) output = %q, want v2", data)
+	}
+
+	// Without strict_integrity, changed binaries still run
+	cfg.StrictIntegrity = false
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho v3 > "+output+"\n"), 0755); err != nil {
+		t.Fatalf("Failed to change mm-deploy: %v", err)
+	}
+	if err := registry.Execute("deploy", nil); err != nil {
+		t.Errorf("Execute(deploy) without strict_integrity error = %v", err)
+	}
+}
+
+func TestRegistry_ChangedBinaryNeverRuns(t *testing.T) {
+	baseDir := t.TempDir()
+	output := filepath.Join(baseDir, "ran")
+	path := filepath.Join(baseDir, "mm-deploy")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("Failed to create mm-deploy: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	cfg := &config.Config{BaseDir: baseDir, StrictIntegrity: true, Pins: map[string]string{"deploy": "1.0.0"}}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+	registry.SetOutput(&strings.Builder{})
+	useVerifier(t, registry)
+	if err := registry.Execute("integrity", []string{"update"}); err != nil {
+		t.Fatalf("Execute(integrity, update) error = %v", err)
+	}
+
+	// Whatever master-mold runs the changed binary for, it records it
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho \"$@\" >> "+output+"\n"), 0755); err != nil {
+		t.Fatalf("Failed to change mm-deploy: %v", err)
+	}
+	for _, args := range [][]string{{"version"}, {"help", "deploy"}, {"list-binaries"}, {"__complete", "deploy", ""}, {"deploy"}} {
+		registry.Execute(args[0], args[1:])
+	}
+	if data, err := os.ReadFile(output); !os.IsNotExist(err) {
+		t.Errorf("the changed binary ran with %q, want it never run", data)
+	}
+}
+
+func TestRegistry_ExecuteReinstalledBinary(t *testing.T) {
+	baseDir := t.TempDir()
+	path := filepath.Join(baseDir, "mm-deploy")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho v1\n"), 0755); err != nil {
+		t.Fatalf("Failed to create mm-deploy: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	cfg := &config.Config{BaseDir: baseDir, StrictIntegrity: true}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+	registry.SetOutput(&strings.Builder{})
+	if err := registry.Execute("deploy", nil); err != nil {
+		t.Fatalf("Execute(deploy) error = %v", err)
+	}
+
+	// Uninstalling forgets the binary, so another version installed at its path runs
+	uninstall, _ := newTestUninstallHandler(baseDir, "")
+	uninstall.config = cfg
+	if err := uninstall.Execute([]string{"deploy", "--yes"}); err != nil {
+		t.Fatalf("Execute(uninstall) error = %v", err)
+	}
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho v2\n"), 0755); err != nil {
+		t.Fatalf("Failed to reinstall mm-deploy: %v", err)
+	}
+	if err := registry.Execute("deploy", nil); err != nil {
+		t.Errorf("Execute(deploy) of the reinstalled binary error = %v, want it run", err)
+	}
+}
+
+func TestCheckIntegrity_Concurrent(t *testing.T) {
+	baseDir := t.TempDir()
+	cfg := &config.Config{BaseDir: baseDir}
+	const binaries = 10
+	for i := range binaries {
+		path := filepath.Join(baseDir, "mm-tool"+strconv.Itoa(i))
+		if err := os.WriteFile(path, []byte("#!/bin/sh\necho "+strconv.Itoa(i)+"\n"), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", path, err)
+		}
+	}
+
+	// The first runs of different binaries all record them
+	var wg sync.WaitGroup
+	for i := range binaries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := "tool" + strconv.Itoa(i)
+			if err := checkIntegrity(cfg, slog.New(slog.DiscardHandler), name, filepath.Join(baseDir, "mm-"+name)); err != nil {
+				t.Errorf("checkIntegrity(%s) error = %v", name, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	database, err := integrity.Load(integrityPath(cfg))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(database) != binaries {
+		t.Errorf("integrity database has %d records, want %d", len(database), binaries)
+	}
+}
@@ -1,10 +1,14 @@
 package command
 
 import (
+	"os"
+	"strings"
+
 	"github.com/pkg/errors"
 	"github.com/oscarrieken/master-mold/pkg/binary"
 	"github.com/oscarrieken/master-mold/pkg/config"
 	"github.com/oscarrieken/master-mold/pkg/display"
+	"github.com/oscarrieken/master-mold/pkg/env"
 )
 
 // ListBinariesHandler handles the list-binaries command
@@ -19,26 +23,92 @@ func NewListBinariesHandler(config *config.Config) *ListBinariesHandler {
 	}
 }
 
+// Help returns the usage of the list-binaries command
+func (h *ListBinariesHandler) Help() string {
+	return "Usage: master-mold list-binaries [--filter <text>] [--source path|basedir] [--json]\n\nLists the subcommand binaries found in the base directory and the PATH. --filter lists only those whose name or\ndescription contains the text, ignoring case, and --source only those found in the PATH or the base directory.\n--json, or master-mold --json, prints them as an array of objects with their name, path, source, version and\ndescription."
+}
+
 // Execute executes the list-binaries command
 func (h *ListBinariesHandler) Execute(args []string) error {
+	flags := newFlagSet("list-binaries", os.Stdout)
+	jsonOutput := flags.Bool("json", env.JSONOutput(), "Print the binaries in JSON format")
+	filter := flags.String("filter", "", "List only the binaries whose name or description contains the text")
+	sourceFlag := flags.String("source", "", "List only the binaries found in the PATH (path) or the base directory (basedir)")
+	if _, err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	source, err := parseSource(*sourceFlag)
+	if err != nil {
+		return err
+	}
+
 	// Ensure the base directory exists
 	if err := config.EnsureBaseDirExists(h.config); err != nil {
 		return errors.Wrap(err, "failed to ensure base directory exists")
 	}
 
 	// Find all binaries
-	baseDir := config.GetExpandedBaseDir(h.config)
-	binaryPaths, err := binary.FindAll(baseDir)
+	binaryPaths, err := findBinaries(h.config)
 	if err != nil {
 		return errors.Wrap(err, "failed to find binaries")
 	}
 
-	// Display the binaries
-	display.PrintBinaryPaths(binaryPaths)
+	// Display the binaries with their descriptions, in JSON format when requested
+	binaries := display.ProcessBinaries(binaryPaths)
+	binaries = display.SetSources(binaries, config.GetExpandedBaseDir(h.config))
+	binaries = display.GroupNamespaces(binaries)
+	if source != "" {
+		binaries = filterBinaries(binaries, func(info display.BinaryInfo) bool { return info.Source == source })
+	}
+	binaries = validateScripts(describeBinaries(h.config, binaries))
+	if *filter != "" {
+		text := strings.ToLower(*filter)
+		binaries = filterBinaries(binaries, func(info display.BinaryInfo) bool {
+			return strings.Contains(strings.ToLower(info.Name), text) || strings.Contains(strings.ToLower(info.Description), text)
+		})
+	}
+	if *jsonOutput {
+		return display.PrintBinariesJSON(binaries)
+	}
+	display.PrintBinaries(binaries)
 	
 	return nil
 }
 
+// parseSource parses the value of --source, where the binaries to list were found
+func parseSource(value string) (binary.Source, error) {
+	switch strings.ToLower(value) {
+	case "":
+		return "", nil
+	case "path":
+		return binary.SourcePath, nil
+	case "basedir":
+		return binary.SourceBaseDir, nil
+	}
+	return "", errors.Errorf("invalid source '%s': must be path or basedir", value)
+}
+
+// filterBinaries keeps the binaries matching a condition
+func filterBinaries(binaries []display.BinaryInfo, keep func(display.BinaryInfo) bool) []display.BinaryInfo {
+	var kept []display.BinaryInfo
+	for _, info := range binaries {
+		if keep(info) {
+			kept = append(kept, info)
+		}
+	}
+	return kept
+}
+
+// validateScripts records the problems of the script binaries that can't run, such as a missing interpreter
+func validateScripts(binaries []display.BinaryInfo) []display.BinaryInfo {
+	for i, info := range binaries {
+		if err := binary.ValidateScript(info.FullPath); err != nil {
+			binaries[i].Problem = err.Error()
+		}
+	}
+	return binaries
+}
+
 // RegisterListBinariesCommand registers the list-binaries command
 func RegisterListBinariesCommand(registry *Registry) {
 	registry.Register("list-binaries", NewListBinariesHandler(registry.Config()))
@@ -1,8 +1,10 @@
 package command
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"log/slog"
@@ -94,3 +96,84 @@ func TestRegisterListBinariesCommand(t *testing.T) {
 		t.Errorf("RegisterListBinariesCommand() registered handler of type %T, want *ListBinariesHandler", handler)
 	}
 }
+
+// listBinariesJSON runs list-binaries with --json and decodes the binaries it prints
+func listBinariesJSON(t *testing.T, cfg *config.Config, args ...string) []map[string]string {
+	t.Helper()
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := NewListBinariesHandler(cfg).Execute(append([]string{"--json"}, args...))
+	w.Close()
+	os.Stdout = oldStdout
+	if err != nil {
+		t.Fatalf("Execute(--json %v) error = %v", args, err)
+	}
+
+	var binaries []map[string]string
+	if err := json.NewDecoder(r).Decode(&binaries); err != nil {
+		t.Fatalf("Execute(--json %v) printed invalid JSON: %v", args, err)
+	}
+	return binaries
+}
+
+func TestListBinariesHandler_ExecuteJSON(t *testing.T) {
+	baseDir := t.TempDir()
+	script := "#!/bin/sh\necho '{\"name\":\"deploy\",\"version\":\"1.2.0\",\"description\":\"Deploy services\"}'\n"
+	if err := os.WriteFile(filepath.Join(baseDir, "mm-deploy"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create mm-deploy: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	binaries := listBinariesJSON(t, &config.Config{BaseDir: baseDir})
+	want := []map[string]string{{
+		"name":        "deploy",
+		"path":        filepath.Join(baseDir, "mm-deploy"),
+		"source":      "base directory",
+		"dir":         baseDir,
+		"version":     "1.2.0",
+		"description": "Deploy services",
+	}}
+	if !reflect.DeepEqual(binaries, want) {
+		t.Errorf("Execute(--json) = %v, want %v", binaries, want)
+	}
+}
+
+func TestListBinariesHandler_ExecuteFiltered(t *testing.T) {
+	baseDir, pathDir := t.TempDir(), t.TempDir()
+	for path, script := range map[string]string{
+		filepath.Join(baseDir, "mm-deploy"):   "#!/bin/sh\necho '{\"name\":\"deploy\",\"description\":\"Roll out services\"}'\n",
+		filepath.Join(baseDir, "mm-lint"):     "#!/bin/sh\n",
+		filepath.Join(pathDir, "mm-k8s-pods"): "#!/bin/sh\n",
+	} {
+		if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", path, err)
+		}
+	}
+	t.Setenv("PATH", pathDir)
+	cfg := &config.Config{BaseDir: baseDir}
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{nil, []string{"k8s-pods", "deploy", "lint"}},
+		{[]string{"--filter", "LIN"}, []string{"lint"}},
+		{[]string{"--filter", "services"}, []string{"deploy"}},
+		{[]string{"--source", "path"}, []string{"k8s-pods"}},
+		{[]string{"--source", "basedir", "--filter", "k8s"}, []string{}},
+	}
+	for _, tt := range tests {
+		names := []string{}
+		for _, info := range listBinariesJSON(t, cfg, tt.args...) {
+			names = append(names, info["name"])
+		}
+		if !reflect.DeepEqual(names, tt.want) {
+			t.Errorf("Execute(%v) = %v, want %v", tt.args, names, tt.want)
+		}
+	}
+
+	if err := NewListBinariesHandler(cfg).Execute([]string{"--source", "home"}); err == nil {
+		t.Error("Execute(--source home) error = nil, want an invalid source")
+	}
+}
@@ -0,0 +1,29 @@
+package command
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/lock"
+)
+
+// lockPath gets the path of the lock of a subcommand in the base directory
+func lockPath(cfg *config.Config, name string) string {
+	return filepath.Join(config.GetExpandedBaseDir(cfg), "locks", name+".lock")
+}
+
+// acquireLock takes the lock of a subcommand configured as exclusive, waiting as long as the configuration allows
+// for another run to finish. The returned function releases it; subcommands that aren't exclusive have nothing to
+// release.
+func (e *SubcommandExecutor) acquireLock(name string) (func(), error) {
+	if !slices.Contains(e.config.Exclusive, name) {
+		return func() {}, nil
+	}
+
+	path := lockPath(e.config, name)
+	if pid, held := lock.Holder(path); held && e.config.LockWait > 0 {
+		e.registry.Logger().Warn("Waiting for another run of the subcommand to finish", "command", name, "pid", pid, "wait", config.GetLockWait(e.config))
+	}
+	return lock.Acquire(path, config.GetLockWait(e.config))
+}
@@ -0,0 +1,50 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/lock"
+)
+
+func TestRegistry_ExecuteExclusive(t *testing.T) {
+	baseDir := t.TempDir()
+	for _, name := range []string{"mm-deploy", "mm-lint"} {
+		if err := os.WriteFile(filepath.Join(baseDir, name), []byte("#!/bin/sh\necho done\n"), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	t.Setenv("PATH", "")
+
+	cfg := &config.Config{BaseDir: baseDir, Exclusive: []string{"deploy"}}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	registry.SetOutput(&bytes.Buffer{})
+	RegisterCommands(registry)
+
+	// Another run holds the lock of deploy
+	release, err := lock.Acquire(lockPath(cfg, "deploy"), 0)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	err = registry.Execute("deploy", nil)
+	if want := fmt.Sprintf("'deploy' is already running (pid %d)", os.Getpid()); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Execute(deploy) error = %v, want %q", err, want)
+	}
+	if err := registry.Execute("lint", nil); err != nil {
+		t.Errorf("Execute(lint) error = %v, want subcommands that aren't exclusive to run", err)
+	}
+
+	release()
+	if err := registry.Execute("deploy", nil); err != nil {
+		t.Fatalf("Execute(deploy) error = %v", err)
+	}
+	if _, err := os.Stat(lockPath(cfg, "deploy")); !os.IsNotExist(err) {
+		t.Errorf("lock after the run error = %v, want it released", err)
+	}
+}
@@ -0,0 +1,131 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/pkg/errors"
+)
+
+// maxCapturedLogs is how many captured runs are kept per subcommand; older ones are removed
+const maxCapturedLogs = 20
+
+// captureLogTimeFormat names the captured logs after when the run started, in UTC, so they sort by name
+const captureLogTimeFormat = "20060102-150405.000000"
+
+// LogsHandler handles the logs command
+type LogsHandler struct {
+	registry *Registry
+	output   io.Writer
+}
+
+// NewLogsHandler creates a new logs command handler
+func NewLogsHandler(registry *Registry) *LogsHandler {
+	return &LogsHandler{
+		registry: registry,
+		output:   os.Stdout,
+	}
+}
+
+// Help returns the usage of the logs command
+func (h *LogsHandler) Help() string {
+	return "Usage: master-mold logs [--list] <command>\n\nPrints what the last run of a subcommand wrote to stderr, captured once 'capture_logs' is set to true in the\nconfiguration. --list prints the captured runs instead, newest first; the last 20 are kept."
+}
+
+// Execute executes the logs command
+func (h *LogsHandler) Execute(args []string) error {
+	flags := newFlagSet("logs", h.output)
+	list := flags.Bool("list", false, "List the captured runs instead of printing the last one")
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return errors.New("usage: master-mold logs [--list] <command>")
+	}
+
+	cfg := h.registry.Config()
+	name := args[0]
+	paths, err := capturedLogs(cfg, name)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		if !cfg.CaptureLogs {
+			return errors.Errorf("no logs of '%s'; capture them with 'master-mold config set capture_logs true'", name)
+		}
+		return errors.Errorf("no logs of '%s'", name)
+	}
+
+	if *list {
+		for _, path := range paths {
+			fmt.Fprintln(h.output, path)
+		}
+		return nil
+	}
+
+	file, err := os.Open(paths[0])
+	if err != nil {
+		return errors.Wrap(err, "failed to open the log")
+	}
+	defer file.Close()
+	_, err = io.Copy(h.output, file)
+	return err
+}
+
+// logsDir gets the directory of the captured logs of a subcommand in the base directory
+func logsDir(cfg *config.Config, name string) string {
+	return filepath.Join(config.GetExpandedBaseDir(cfg), "logs", name)
+}
+
+// capturedLogs gets the paths of the captured logs of a subcommand, newest first
+func capturedLogs(cfg *config.Config, name string) ([]string, error) {
+	entries, err := os.ReadDir(logsDir(cfg, name))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the logs directory")
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".log") {
+			paths = append(paths, filepath.Join(logsDir(cfg, name), entry.Name()))
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+	return paths, nil
+}
+
+// createCaptureLog creates the log capturing a run of a subcommand, removing the oldest logs past the ones kept
+func createCaptureLog(cfg *config.Config, name string) (*os.File, error) {
+	dir := logsDir(cfg, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to create the logs directory")
+	}
+
+	if paths, err := capturedLogs(cfg, name); err == nil && len(paths) >= maxCapturedLogs {
+		for _, path := range paths[maxCapturedLogs-1:] {
+			os.Remove(path)
+		}
+	}
+
+	path := filepath.Join(dir, time.Now().UTC().Format(captureLogTimeFormat)+".log")
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the log")
+	}
+	return file, nil
+}
+
+// RegisterLogsCommand registers the logs command
+func RegisterLogsCommand(registry *Registry) {
+	registry.Register("logs", NewLogsHandler(registry))
+}
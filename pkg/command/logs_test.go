@@ -0,0 +1,88 @@
+package command
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+func TestLogsHandler_Execute(t *testing.T) {
+	baseDir := t.TempDir()
+	script := "#!/bin/sh\necho \"deploying $*\" >&2\necho done\n"
+	if err := os.WriteFile(filepath.Join(baseDir, "mm-deploy"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create mm-deploy: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	cfg := &config.Config{BaseDir: baseDir}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	registry.SetOutput(&bytes.Buffer{})
+	RegisterCommands(registry)
+	output := &bytes.Buffer{}
+	handler := NewLogsHandler(registry)
+	handler.output = output
+
+	// Nothing is captured until it is enabled
+	if err := registry.Execute("deploy", nil); err != nil {
+		t.Fatalf("Execute(deploy) error = %v", err)
+	}
+	if err := handler.Execute([]string{"deploy"}); err == nil || !strings.Contains(err.Error(), "capture_logs") {
+		t.Errorf("Execute(deploy) error = %v, want how to capture logs", err)
+	}
+
+	cfg.CaptureLogs = true
+	for _, env := range []string{"staging", "prod"} {
+		if err := registry.Execute("deploy", []string{env}); err != nil {
+			t.Fatalf("Execute(deploy %s) error = %v", env, err)
+		}
+	}
+
+	// The last run is printed, with only what went to stderr
+	if err := handler.Execute([]string{"deploy"}); err != nil {
+		t.Fatalf("Execute(deploy) error = %v", err)
+	}
+	if output.String() != "deploying prod\n" {
+		t.Errorf("Execute(deploy) output = %q, want the stderr of the last run", output.String())
+	}
+
+	output.Reset()
+	if err := handler.Execute([]string{"--list", "deploy"}); err != nil {
+		t.Fatalf("Execute(--list deploy) error = %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(output.String()), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[0], logsDir(cfg, "deploy")) {
+		t.Errorf("Execute(--list deploy) output = %q, want the two captured runs", output.String())
+	}
+}
+
+func TestCreateCaptureLog_Prunes(t *testing.T) {
+	cfg := &config.Config{BaseDir: t.TempDir()}
+	dir := logsDir(cfg, "deploy")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("Failed to create the logs directory: %v", err)
+	}
+	for i := 0; i < maxCapturedLogs+5; i++ {
+		name := filepath.Join(dir, "20000101-0000"+string(rune('a'+i))+".log")
+		if err := os.WriteFile(name, nil, 0600); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+
+	file, err := createCaptureLog(cfg, "deploy")
+	if err != nil {
+		t.Fatalf("createCaptureLog() error = %v", err)
+	}
+	file.Close()
+
+	paths, err := capturedLogs(cfg, "deploy")
+	if err != nil {
+		t.Fatalf("capturedLogs() error = %v", err)
+	}
+	if len(paths) != maxCapturedLogs || paths[0] != file.Name() {
+		t.Errorf("capturedLogs() = %d logs starting with %s, want %d starting with %s", len(paths), paths[0], maxCapturedLogs, file.Name())
+	}
+}
@@ -0,0 +1,88 @@
+package command
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/pkg/errors"
+)
+
+// maxNamespaceDepth is how many arguments may be joined to a command name to find a namespaced subcommand
+const maxNamespaceDepth = 4
+
+// namespaceWord matches the arguments that can be part of a subcommand name
+var namespaceWord = regexp.MustCompile(`^[A-Za-z0-9_.][A-Za-z0-9_.-]*$`)
+
+// resolveNamespaced resolves a command and its arguments to a subcommand, by longest-prefix match of the
+// arguments joined to the name with dashes: "ado prs list" runs mm-ado-prs-list when it exists, then mm-ado-prs
+// with "list", then mm-ado with "prs list". It returns the name of the subcommand and the arguments left for it.
+func resolveNamespaced(cfg *config.Config, name string, args []string) (string, []string, binary.Resolution, error) {
+	depth := 0
+	for depth < len(args) && depth < maxNamespaceDepth && namespaceWord.MatchString(args[depth]) {
+		depth++
+	}
+
+	for i := depth; i > 0; i-- {
+		candidate := name + "-" + strings.Join(args[:i], "-")
+		resolution, err := resolveSubcommand(cfg, candidate)
+		if err == nil || isDisabledError(err) {
+			return candidate, args[i:], resolution, err
+		}
+	}
+
+	resolution, err := resolveSubcommand(cfg, name)
+	if err != nil && !isDisabledError(err) {
+		if members := namespaceMembers(cfg, name); len(members) > 0 {
+			err = errors.Errorf("'%s' is a namespace; run one of its subcommands: %s", name, strings.Join(members, ", "))
+			return name, args, resolution, withCategory(CategoryNotFound, err)
+		}
+	}
+	return name, args, resolution, err
+}
+
+// isDisabledError checks if an error is the error of resolving a disabled subcommand
+func isDisabledError(err error) bool {
+	var disabled *disabledError
+	return errors.As(err, &disabled)
+}
+
+// namespaceMembers gets the subcommands of a namespace as they are typed, such as "ado prs" for mm-ado-prs in the
+// ado namespace, sorted
+func namespaceMembers(cfg *config.Config, namespace string) []string {
+	binaryPaths, err := findBinaries(cfg)
+	if err != nil {
+		return nil
+	}
+
+	var members []string
+	seen := make(map[string]bool)
+	for _, binaryPath := range binaryPaths {
+		if rest, ok := strings.CutPrefix(binary.ExtractCommandName(binaryPath), namespace+"-"); ok && rest != "" {
+			member := namespace + " " + strings.ReplaceAll(rest, "-", " ")
+			if !seen[member] {
+				seen[member] = true
+				members = append(members, member)
+			}
+		}
+	}
+	sort.Strings(members)
+	return members
+}
+
+// namespaceCompletions completes the words typed so far to the next word of the subcommand names they start, such as
+// prs and workitems after "ado" for ado-prs and ado-workitems
+func namespaceCompletions(names []string, words []string) []string {
+	prefix := strings.Join(words[:len(words)-1], "-") + "-"
+
+	var candidates []string
+	for _, name := range names {
+		if rest, ok := strings.CutPrefix(name, prefix); ok && rest != "" {
+			word, _, _ := strings.Cut(rest, "-")
+			candidates = append(candidates, word)
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+func TestResolveNamespaced(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, name := range []string{"mm-ado", "mm-ado-prs", "mm-ado-prs-list", "mm-k8s-pods", "mm-k8s-nodes"} {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	t.Setenv("PATH", "")
+	cfg := &config.Config{BaseDir: tempDir}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantName string
+		wantArgs []string
+	}{
+		{"ado", []string{"prs", "list", "--mine"}, "ado-prs-list", []string{"--mine"}},
+		{"ado", []string{"prs", "show", "42"}, "ado-prs", []string{"show", "42"}},
+		{"ado", []string{"boards"}, "ado", []string{"boards"}},
+		{"ado", []string{"--help", "prs"}, "ado", []string{"--help", "prs"}},
+		{"k8s", []string{"pods"}, "k8s-pods", []string{}},
+	}
+
+	for _, tt := range tests {
+		name, args, resolution, err := resolveNamespaced(cfg, tt.name, tt.args)
+		if err != nil {
+			t.Errorf("resolveNamespaced(%s, %q) error = %v", tt.name, tt.args, err)
+			continue
+		}
+		if name != tt.wantName || !reflect.DeepEqual(args, tt.wantArgs) {
+			t.Errorf("resolveNamespaced(%s, %q) = %s %q, want %s %q", tt.name, tt.args, name, args, tt.wantName, tt.wantArgs)
+		}
+		if want := filepath.Join(tempDir, "mm-"+tt.wantName); resolution.Path != want {
+			t.Errorf("resolveNamespaced(%s, %q) path = %s, want %s", tt.name, tt.args, resolution.Path, want)
+		}
+	}
+
+	// A namespace without a binary of its own lists its subcommands
+	_, _, _, err := resolveNamespaced(cfg, "k8s", []string{"services"})
+	if err == nil || !strings.Contains(err.Error(), "k8s nodes, k8s pods") {
+		t.Errorf("resolveNamespaced(k8s, services) error = %v, want the namespace subcommands", err)
+	}
+
+	// A disabled subcommand isn't skipped for a shorter one
+	cfg.Disabled = []string{"ado-prs"}
+	if _, _, _, err := resolveNamespaced(cfg, "ado", []string{"prs", "show"}); !isDisabledError(err) {
+		t.Errorf("resolveNamespaced(ado, prs show) error = %v, want the subcommand disabled", err)
+	}
+}
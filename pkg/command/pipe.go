@@ -0,0 +1,202 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/pkg/errors"
+)
+
+// PipeHandler handles the pipe command
+type PipeHandler struct {
+	registry *Registry
+	input    io.Reader
+}
+
+// NewPipeHandler creates a new pipe command handler
+func NewPipeHandler(registry *Registry) *PipeHandler {
+	return &PipeHandler{
+		registry: registry,
+		input:    os.Stdin,
+	}
+}
+
+// Help returns the usage of the pipe command
+func (h *PipeHandler) Help() string {
+	return "Usage: master-mold pipe \"<command> [arguments] | <command> [arguments] ...\"\n\nRuns subcommands concurrently with the output of each connected to the input of the next, like a\nshell pipeline. Aliases are expanded in every stage. The command fails if any stage fails, naming the\nfailed stages."
+}
+
+// pipeStage is a subcommand of a pipeline
+type pipeStage struct {
+	// command is the command line of the stage, as given
+	command string
+	path    string
+	args    []string
+	// env are the variables added to the environment of the subcommand
+	env []string
+}
+
+// Execute executes the pipe command
+func (h *PipeHandler) Execute(args []string) error {
+	stages, err := h.resolveStages(args)
+	if err != nil {
+		return err
+	}
+
+	// Connect the stages with pipes, so each reads what the previous one writes
+	inputs := make([]io.Reader, len(stages))
+	outputs := make([]io.Writer, len(stages))
+	inputs[0], outputs[len(stages)-1] = h.input, h.registry.Output()
+	var pipes []*os.File
+	defer func() {
+		for _, pipe := range pipes {
+			pipe.Close()
+		}
+	}()
+	for i := 0; i < len(stages)-1; i++ {
+		reader, writer, err := os.Pipe()
+		if err != nil {
+			return errors.Wrap(err, "failed to create pipe")
+		}
+		pipes = append(pipes, reader, writer)
+		outputs[i], inputs[i+1] = writer, reader
+	}
+
+	cfg := h.registry.Config()
+	var wg sync.WaitGroup
+	errs := make([]error, len(stages))
+	for i, stage := range stages {
+		wg.Add(1)
+		go func(i int, stage pipeStage) {
+			defer wg.Done()
+			errs[i] = binary.ExecuteWithEnv(stage.path, stage.args, config.GetTimeout(cfg), stage.env, inputs[i], outputs[i], os.Stderr, h.registry.Logger())
+
+			// Once a stage exits, the next one reads the end of its input and the previous one can no longer write
+			if writer, ok := outputs[i].(*os.File); ok && i < len(stages)-1 {
+				writer.Close()
+			}
+			if reader, ok := inputs[i].(*os.File); ok && i > 0 {
+				reader.Close()
+			}
+		}(i, stage)
+	}
+	wg.Wait()
+
+	var failed []string
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("stage %d '%s': %v", i+1, stages[i].command, err))
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("%d of %d pipeline stages failed: %s", len(failed), len(stages), strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// resolveStages parses the pipeline and finds the binary of every stage
+func (h *PipeHandler) resolveStages(args []string) ([]pipeStage, error) {
+	var commands [][]string
+	var err error
+	switch len(args) {
+	case 0:
+		return nil, errors.New("usage: master-mold pipe \"<command> [arguments] | <command> [arguments] ...\"")
+	case 1:
+		commands, err = splitPipeline(args[0])
+	default:
+		commands, err = splitPipelineWords(args)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid pipeline")
+	}
+
+	cfg := h.registry.Config()
+	stages := make([]pipeStage, len(commands))
+	for i, words := range commands {
+		name, stageArgs, err := h.registry.expandAliases(words[0], words[1:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "stage %d", i+1)
+		}
+		if _, builtin := h.registry.Get(name); builtin {
+			return nil, errors.Errorf("stage %d: built-in command '%s' cannot be piped", i+1, name)
+		}
+
+		name, stageArgs, resolution, err := resolveNamespaced(cfg, name, stageArgs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "stage %d", i+1)
+		}
+		if err := h.registry.VerifyBinary(resolution.Path); err != nil {
+			return nil, errors.Wrapf(err, "stage %d", i+1)
+		}
+		variables, err := subcommandEnv(cfg, name)
+		if err != nil {
+			return nil, err
+		}
+		stages[i] = pipeStage{command: strings.Join(words, " "), path: resolution.Path, args: stageArgs, env: variables}
+	}
+	return stages, nil
+}
+
+// splitPipeline splits a pipeline into the words of its stages, at the "|" outside quotes
+func splitPipeline(line string) ([][]string, error) {
+	var segments []string
+	var quote rune
+	start := 0
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '|':
+			segments = append(segments, line[start:i])
+			start = i + 1
+		}
+	}
+	segments = append(segments, line[start:])
+
+	var stages [][]string
+	for _, segment := range segments {
+		words, err := splitAliasWords(segment)
+		if err != nil {
+			return nil, err
+		}
+		stages = append(stages, words)
+	}
+	return stages, checkStages(stages)
+}
+
+// splitPipelineWords splits the words of a pipeline given as separate arguments at the "|" words
+func splitPipelineWords(words []string) ([][]string, error) {
+	stages := [][]string{nil}
+	for _, word := range words {
+		if word == "|" {
+			stages = append(stages, nil)
+			continue
+		}
+		stages[len(stages)-1] = append(stages[len(stages)-1], word)
+	}
+	return stages, checkStages(stages)
+}
+
+// checkStages checks that every stage of a pipeline has a command
+func checkStages(stages [][]string) error {
+	for i, stage := range stages {
+		if len(stage) == 0 {
+			return errors.Errorf("stage %d is empty", i+1)
+		}
+	}
+	return nil
+}
+
+// RegisterPipeCommand registers the pipe command
+func RegisterPipeCommand(registry *Registry) {
+	registry.Register("pipe", NewPipeHandler(registry))
+}
@@ -0,0 +1,108 @@
+package command
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+// newPipeTestRegistry creates a registry over scripts that write, prefix and fail
+func newPipeTestRegistry(t *testing.T) *Registry {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	scripts := map[string]string{
+		"mm-hello":  "#!/bin/sh\necho \"hello $1\"\necho \"bye $1\"\n",
+		"mm-prefix": "#!/bin/sh\nwhile read line; do echo \"$1 $line\"; done\n",
+		"mm-fail":   "#!/bin/sh\nwhile read line; do :; done\nexit 3\n",
+	}
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(script), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	t.Setenv("PATH", "")
+
+	registry := NewRegistry(&config.Config{
+		BaseDir: tempDir,
+		Aliases: map[string]string{"shout": "prefix '>>'"},
+	}, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+	return registry
+}
+
+func TestPipeHandler_Execute(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"one argument", []string{"hello world | prefix 1: | shout"}},
+		{"separate words", []string{"hello", "world", "|", "prefix", "1:", "|", "shout"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			registry := newPipeTestRegistry(t)
+			registry.SetOutput(output)
+			handler := NewPipeHandler(registry)
+			handler.input = strings.NewReader("")
+
+			if err := handler.Execute(tt.args); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if want := ">> 1: hello world\n>> 1: bye world\n"; output.String() != want {
+				t.Errorf("Execute() output = %q, want %q", output.String(), want)
+			}
+		})
+	}
+}
+
+func TestPipeHandler_Execute_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"failed stage", []string{"hello world | fail | prefix x"}, "1 of 3 pipeline stages failed: stage 2 'fail'"},
+		{"built-in stage", []string{"hello | list-binaries"}, "stage 2: built-in command 'list-binaries' cannot be piped"},
+		{"missing stage", []string{"hello | unknown"}, "stage 2"},
+		{"empty stage", []string{"hello | | prefix x"}, "stage 2 is empty"},
+		{"no pipeline", nil, "usage:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := newPipeTestRegistry(t)
+			registry.SetOutput(&bytes.Buffer{})
+			handler := NewPipeHandler(registry)
+			handler.input = strings.NewReader("")
+
+			err := handler.Execute(tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Execute(%q) error = %v, want %q", tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplitPipeline(t *testing.T) {
+	got, err := splitPipeline(`ado prs list --json|notify slack --title "a | b"`)
+	if err != nil {
+		t.Fatalf("splitPipeline() error = %v", err)
+	}
+	want := [][]string{{"ado", "prs", "list", "--json"}, {"notify", "slack", "--title", "a | b"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("splitPipeline() = %q, want %q", got, want)
+	}
+
+	if _, err := splitPipeline(`ado | notify "slack`); err == nil {
+		t.Error("splitPipeline() with an unterminated quote error = nil, want an error")
+	}
+}
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/oscarrieken/master-mold/pkg/history"
+	"github.com/pkg/errors"
+)
+
+// defaultRecentLimit is how many command lines the recent command lists by default
+const defaultRecentLimit = 10
+
+// RecentHandler handles the recent command
+type RecentHandler struct {
+	registry *Registry
+	output   io.Writer
+}
+
+// NewRecentHandler creates a new recent command handler
+func NewRecentHandler(registry *Registry) *RecentHandler {
+	return &RecentHandler{
+		registry: registry,
+		output:   os.Stdout,
+	}
+}
+
+// Help returns the usage of the recent command
+func (h *RecentHandler) Help() string {
+	return "Usage: master-mold recent [-n <count>] [--json]\n\nLists the last distinct command lines run with master-mold, most recent first, with how many times each ran.\nThe numbers are those of the history, so 'master-mold history --rerun <n>' runs a command line again and\n'master-mold fav add <n>' keeps it as a favorite."
+}
+
+// Execute executes the recent command
+func (h *RecentHandler) Execute(args []string) error {
+	flags := newFlagSet("recent", h.output)
+	limit := flags.IntP("count", "n", defaultRecentLimit, "Number of command lines to list, 0 for all")
+	jsonOutput := flags.Bool("json", env.JSONOutput(), "Print the command lines in JSON format")
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 || *limit < 0 {
+		return errors.New("usage: master-mold recent [-n <count>] [--json]")
+	}
+
+	entries, err := history.Load(historyPath(h.registry.Config()))
+	if err != nil {
+		return err
+	}
+	recent := history.FindRecent(entries, *limit)
+
+	if *jsonOutput {
+		if recent == nil {
+			recent = []history.Recent{}
+		}
+		return printJSON(h.output, recent)
+	}
+	for _, entry := range recent {
+		fmt.Fprintf(h.output, "%5d  %s  (%d %s)\n", entry.Number, entry.Line(), entry.Count, plural(entry.Count, "run", "runs"))
+	}
+	return nil
+}
+
+// plural picks the singular or plural form of a word for a count
+func plural(count int, singular string, pluralForm string) string {
+	if count == 1 {
+		return singular
+	}
+	return pluralForm
+}
+
+// RegisterRecentCommand registers the recent command
+func RegisterRecentCommand(registry *Registry) {
+	registry.Register("recent", NewRecentHandler(registry))
+}
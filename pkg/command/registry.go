@@ -3,7 +3,26 @@ package command
 // RegisterCommands registers all commands with the registry
 func RegisterCommands(registry *Registry) {
 	// Register built-in commands
+	RegisterHelpCommand(registry)
 	RegisterListBinariesCommand(registry)
+	RegisterUninstallCommand(registry)
+	RegisterSearchCommand(registry)
+	RegisterVersionCommand(registry)
+	RegisterCompletionCommand(registry)
+	RegisterExecAllCommand(registry)
+	RegisterWhichCommand(registry)
+	RegisterPipeCommand(registry)
+	RegisterWatchCommand(registry)
+	RegisterConfigCommand(registry)
+	RegisterDisableCommands(registry)
+	RegisterHistoryCommand(registry)
+	RegisterRecentCommand(registry)
+	RegisterFavoritesCommand(registry)
+	RegisterEnvCommand(registry)
+	RegisterAuditCommand(registry)
+	RegisterLogsCommand(registry)
+	RegisterBenchCommand(registry)
+	RegisterIntegrityCommand(registry)
 	
 	// Register the subcommand executor
 	RegisterSubcommandExecutor(registry)
@@ -0,0 +1,32 @@
+package command
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/pkg/errors"
+)
+
+// checkSafety checks that other users can't replace the binary of a subcommand before it runs. An unsafe binary is
+// warned about, refused or allowed, as unsafe_binaries says; it is warned about by default.
+func checkSafety(cfg *config.Config, logger *slog.Logger, name string, cmdPath string) error {
+	mode := strings.ToLower(cfg.UnsafeBinaries)
+	if mode == config.UnsafeBinariesAllow {
+		return nil
+	}
+
+	// Binaries that can't be checked fail when they run
+	var unsafe *binary.UnsafeError
+	if err := binary.CheckSafety(cmdPath); !errors.As(err, &unsafe) {
+		return nil
+	}
+
+	if mode == config.UnsafeBinariesRefuse {
+		err := errors.Errorf("refusing to run '%s': %s, so other users could replace %s; fix its permissions, or set unsafe_binaries to warn", name, unsafe, cmdPath)
+		return withCategory(CategoryPermission, err)
+	}
+	logger.Warn("Other users could replace the binary of the subcommand", "command", name, "binary", cmdPath, "reason", unsafe.Error())
+	return nil
+}
@@ -0,0 +1,109 @@
+package command
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+func TestRegistry_ExecuteUnsafeBinary(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Permissions aren't checked on Windows")
+	}
+
+	baseDir := t.TempDir()
+	output := filepath.Join(baseDir, "ran")
+	path := filepath.Join(baseDir, "mm-deploy")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho ran > "+output+"\n"), 0755); err != nil {
+		t.Fatalf("Failed to create mm-deploy: %v", err)
+	}
+	if err := os.Chmod(path, 0777); err != nil {
+		t.Fatalf("Failed to make mm-deploy world-writable: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	cfg := &config.Config{BaseDir: baseDir, UnsafeBinaries: config.UnsafeBinariesRefuse}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+
+	err := registry.Execute("deploy", nil)
+	if err == nil || !strings.Contains(err.Error(), "refusing to run 'deploy': '"+path+"' is world-writable") {
+		t.Errorf("Execute(deploy) error = %v, want it refused", err)
+	}
+	if code := ExitCode(err); code != ExitPermission {
+		t.Errorf("ExitCode() = %d, want %d", code, ExitPermission)
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Error("Execute(deploy) ran the unsafe binary")
+	}
+
+	// Warned about, unsafe binaries still run
+	for _, mode := range []string{config.UnsafeBinariesWarn, config.UnsafeBinariesAllow} {
+		cfg.UnsafeBinaries = mode
+		if err := registry.Execute("deploy", nil); err != nil {
+			t.Errorf("Execute(deploy) with unsafe_binaries %s error = %v", mode, err)
+		}
+	}
+	if _, err := os.Stat(output); err != nil {
+		t.Errorf("Execute(deploy) didn't run the binary: %v", err)
+	}
+}
+
+// useVerifier installs the binary verifier of a registry, as master-mold does, for the duration of a test
+func useVerifier(t *testing.T, registry *Registry) {
+	t.Helper()
+	binary.SetVerifier(registry.VerifyBinary)
+	t.Cleanup(func() { binary.SetVerifier(nil) })
+}
+
+func TestNewRegistry_KeepsVerifier(t *testing.T) {
+	verified := false
+	binary.SetVerifier(func(string) error {
+		verified = true
+		return nil
+	})
+	t.Cleanup(func() { binary.SetVerifier(nil) })
+
+	// Registries created later, such as in tests, leave the verifier master-mold installed alone
+	NewRegistry(&config.Config{}, slog.New(slog.DiscardHandler))
+	if err := binary.Verify("mm-deploy"); err != nil || !verified {
+		t.Errorf("Verify() = %v after NewRegistry(), want the installed verifier to run", err)
+	}
+}
+
+func TestRegistry_UnsafeBinaryNeverRuns(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Permissions aren't checked on Windows")
+	}
+
+	// Whatever master-mold runs the binary for, it records it
+	baseDir := t.TempDir()
+	output := filepath.Join(baseDir, "ran")
+	path := filepath.Join(baseDir, "mm-deploy")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho \"$@\" >> "+output+"\n"), 0755); err != nil {
+		t.Fatalf("Failed to create mm-deploy: %v", err)
+	}
+	if err := os.Chmod(path, 0777); err != nil {
+		t.Fatalf("Failed to make mm-deploy world-writable: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	cfg := &config.Config{BaseDir: baseDir, UnsafeBinaries: config.UnsafeBinariesRefuse, Pins: map[string]string{"deploy": "1.0.0"}}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+	registry.SetOutput(&strings.Builder{})
+	useVerifier(t, registry)
+
+	for _, args := range [][]string{{"version"}, {"help", "deploy"}, {"list-binaries"}, {"__complete", "deploy", ""}, {"deploy"}} {
+		registry.Execute(args[0], args[1:])
+	}
+	if data, err := os.ReadFile(output); !os.IsNotExist(err) {
+		t.Errorf("the unsafe binary ran with %q, want it never run", data)
+	}
+}
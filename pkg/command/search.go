@@ -0,0 +1,95 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/index"
+	"github.com/pkg/errors"
+)
+
+// SearchHandler handles the search command
+type SearchHandler struct {
+	config *config.Config
+	output io.Writer
+}
+
+// NewSearchHandler creates a new search command handler
+func NewSearchHandler(config *config.Config) *SearchHandler {
+	return &SearchHandler{
+		config: config,
+		output: os.Stdout,
+	}
+}
+
+// Help returns the usage of the search command
+func (h *SearchHandler) Help() string {
+	return "Usage: master-mold search [term]\n\nSearches the configured plugin registries for plugins whose name or description contains the term."
+}
+
+// Execute executes the search command
+func (h *SearchHandler) Execute(args []string) error {
+	if len(args) > 1 {
+		return errors.New("usage: master-mold search [term]")
+	}
+	term := ""
+	if len(args) == 1 {
+		term = args[0]
+	}
+
+	if len(h.config.Registries) == 0 {
+		return errors.New("no plugin registries configured; add registries to config.toml")
+	}
+
+	// Search the registries that can be fetched, reporting the others
+	plugins, errs := index.FetchAll(h.config.Registries)
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if len(errs) == len(h.config.Registries) {
+		return errors.New("no plugin registry could be fetched")
+	}
+
+	matches := index.Search(plugins, term)
+	if len(matches) == 0 {
+		fmt.Fprintln(h.output, "No plugins found.")
+		return nil
+	}
+
+	baseDir := config.GetExpandedBaseDir(h.config)
+	fmt.Fprintln(h.output, "Available plugins:")
+	for _, plugin := range matches {
+		fmt.Fprintln(h.output, FormatPlugin(plugin, isInstalled(plugin.Name, baseDir)))
+	}
+
+	return nil
+}
+
+// FormatPlugin formats a plugin of a registry index for display
+func FormatPlugin(plugin index.Plugin, installed bool) string {
+	line := "  - " + plugin.Name
+	if plugin.Version != "" {
+		line += " " + plugin.Version
+	}
+	if plugin.Description != "" {
+		line += ": " + plugin.Description
+	}
+	if installed {
+		line += " (installed)"
+	}
+	return line
+}
+
+// isInstalled checks if a subcommand is installed
+func isInstalled(name string, baseDir string) bool {
+	_, err := binary.FindExecutable(name, baseDir)
+	return err == nil
+}
+
+// RegisterSearchCommand registers the search command
+func RegisterSearchCommand(registry *Registry) {
+	registry.Register("search", NewSearchHandler(registry.Config()))
+}
@@ -0,0 +1,64 @@
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/index"
+)
+
+func TestSearchHandler_Execute(t *testing.T) {
+	tempDir := t.TempDir()
+	registryPath := filepath.Join(tempDir, "index.json")
+	indexContent := `{"plugins": [
+		{"name": "k8s-pods", "description": "Show Kubernetes pod status", "version": "1.2.0"},
+		{"name": "azure-devops", "description": "Manage Azure DevOps work items", "version": "2.0.0"}
+	]}`
+	if err := os.WriteFile(registryPath, []byte(indexContent), 0644); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "mm-k8s-pods"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("Failed to create binary: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	output := &bytes.Buffer{}
+	handler := NewSearchHandler(&config.Config{BaseDir: tempDir, Registries: []string{registryPath}})
+	handler.output = output
+
+	if err := handler.Execute([]string{"kubernetes"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := output.String(); !strings.Contains(got, "k8s-pods 1.2.0: Show Kubernetes pod status (installed)") || strings.Contains(got, "azure-devops") {
+		t.Errorf("Execute() output = %q, want only the installed k8s-pods", got)
+	}
+
+	output.Reset()
+	if err := handler.Execute([]string{"terraform"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(output.String(), "No plugins found.") {
+		t.Errorf("Execute() output = %q, want no plugins", output.String())
+	}
+}
+
+func TestSearchHandler_ExecuteWithoutRegistries(t *testing.T) {
+	handler := NewSearchHandler(&config.Config{})
+	if err := handler.Execute([]string{"pods"}); err == nil || !strings.Contains(err.Error(), "no plugin registries") {
+		t.Errorf("Execute() error = %v, want a missing registries error", err)
+	}
+}
+
+func TestFormatPlugin(t *testing.T) {
+	plugin := index.Plugin{Name: "k8s-pods", Version: "1.2.0", Description: "Show Kubernetes pod status"}
+	if got := FormatPlugin(plugin, false); got != "  - k8s-pods 1.2.0: Show Kubernetes pod status" {
+		t.Errorf("FormatPlugin() = %q", got)
+	}
+	if got := FormatPlugin(index.Plugin{Name: "bare"}, true); got != "  - bare (installed)" {
+		t.Errorf("FormatPlugin() = %q", got)
+	}
+}
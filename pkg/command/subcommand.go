@@ -1,36 +1,182 @@
 package command
 
 import (
-	"github.com/pkg/errors"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+
 	"github.com/oscarrieken/master-mold/pkg/binary"
 	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/oscarrieken/master-mold/pkg/terminal"
+	"github.com/pkg/errors"
 )
 
 // SubcommandExecutor executes subcommands
 type SubcommandExecutor struct {
 	config *config.Config
 	registry *Registry
+	// notices is where notices of newer versions are printed, or nil when they are not wanted
+	notices io.Writer
 }
 
 // NewSubcommandExecutor creates a new subcommand executor
 func NewSubcommandExecutor(config *config.Config, registry *Registry) *SubcommandExecutor {
-	return &SubcommandExecutor{
+	executor := &SubcommandExecutor{
 		config: config,
 		registry: registry,
 	}
+
+	// Notices are for people, not for scripts reading the output
+	if terminal.IsTerminal(os.Stderr) {
+		executor.notices = os.Stderr
+	}
+	return executor
 }
 
 // Execute executes a subcommand
 func (e *SubcommandExecutor) Execute(name string, args []string) error {
 	// Find the executable
-	baseDir := config.GetExpandedBaseDir(e.config)
-	cmdPath, err := binary.FindExecutable(name, baseDir)
+	name, args, resolution, err := resolveNamespaced(e.config, name, args)
+	handler, bundled := e.registry.bundledFallback(name, err)
+	if err != nil && !bundled {
+		return err
+	}
+
+	// Exclusive subcommands wait for, or fail on, another run
+	release, err := e.acquireLock(name)
 	if err != nil {
-		return errors.Wrapf(err, "subcommand '%s' not found", name)
+		return err
+	}
+	defer release()
+
+	if bundled {
+		return withCategory(CategoryExecution, e.executeBundled(name, handler, args))
+	}
+	cmdPath := resolution.Path
+	if err := e.registry.VerifyBinary(cmdPath); err != nil {
+		return err
+	}
+	warnShadowed(e.registry.Logger(), e.config, name, cmdPath)
+	warnUnpinned(e.registry.Logger(), e.config, name, cmdPath)
+
+	variables, err := subcommandEnv(e.config, name)
+	if err != nil {
+		return withCategory(CategoryConfig, err)
+	}
+
+	// Capture the stderr of the subcommand when enabled, while still streaming it
+	var stderr io.Writer = os.Stderr
+	if e.config.CaptureLogs {
+		capture, err := createCaptureLog(e.config, name)
+		if err != nil {
+			e.registry.Logger().Warn("Failed to capture the output of the subcommand", "command", name, "error", err)
+		} else {
+			defer capture.Close()
+			stderr = io.MultiWriter(os.Stderr, capture)
+		}
 	}
 
 	// Execute the command
-	return binary.Execute(cmdPath, args, e.registry.Logger())
+	policy := config.GetRetryPolicy(e.config, name)
+	retry := binary.RetryPolicy{Retries: policy.Retries, ExitCodes: policy.RetryOnExitCodes, Backoff: policy.Backoff}
+	err = binary.ExecuteWithRetry(retry, cmdPath, args, config.GetTimeout(e.config), variables, os.Stdin, e.registry.Output(), stderr, e.registry.Logger())
+	err = withCategory(CategoryExecution, err)
+	if e.notices != nil && !env.Enabled(env.Quiet) {
+		notifyUpdate(e.config, e.notices, name, cmdPath)
+	}
+	return err
+}
+
+// executeBundled runs a subcommand built into master-mold in-process, with the environment its binary would get,
+// given without changing the environment of master-mold, and within the timeout of the configuration
+func (e *SubcommandExecutor) executeBundled(name string, handler Handler, args []string) error {
+	variables, err := subcommandEnv(e.config, name)
+	if err != nil {
+		return withCategory(CategoryConfig, err)
+	}
+	defer env.Override(variables)()
+
+	// The bundled subcommand prints to stdout, which goes where the output of subcommands goes
+	if output := e.registry.Output(); output != os.Stdout {
+		restore, err := redirectStdout(output)
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+
+	e.registry.Logger().Info("Executing bundled subcommand", "command", name)
+	contextHandler, ok := handler.(ContextHandler)
+	timeout := config.GetTimeout(e.config)
+	if !ok || timeout <= 0 {
+		return handler.Execute(args)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	err = contextHandler.ExecuteContext(ctx, args)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return withCategory(CategoryTimeout, errors.Wrapf(err, "'%s' timed out after %s", name, timeout))
+	}
+	return err
+}
+
+// redirectStdout redirects stdout to a writer until the returned function is called
+func redirectStdout(output io.Writer) (func(), error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to redirect the output")
+	}
+
+	stdout := os.Stdout
+	os.Stdout = writer
+	done := make(chan struct{})
+	go func() {
+		io.Copy(output, reader)
+		close(done)
+	}()
+
+	return func() {
+		os.Stdout = stdout
+		writer.Close()
+		<-done
+		reader.Close()
+	}, nil
+}
+
+// warnShadowed warns when other binaries of a subcommand are shadowed by the one it runs, which is often a stale
+// copy left behind in another directory
+func warnShadowed(logger *slog.Logger, cfg *config.Config, name string, cmdPath string) {
+	if shadowed := shadowedBinaries(cfg, name, cmdPath); len(shadowed) > 0 {
+		logger.Warn("Subcommand has several binaries; the first one found runs", "command", name, "binary", cmdPath, "shadowed", shadowed)
+	}
+}
+
+// warnUnpinned warns when a subcommand pinned to a version reports another one, which usually means it was upgraded
+// by accident
+func warnUnpinned(logger *slog.Logger, cfg *config.Config, name string, cmdPath string) {
+	pin, ok := cfg.Pins[name]
+	if !ok {
+		return
+	}
+	if version := getPluginVersion(cmdPath); !matchesPin(version, pin) {
+		logger.Warn("Subcommand version differs from its pin", "command", name, "binary", cmdPath, "version", version, "pin", pin)
+	}
+}
+
+// shadowedBinaries gets the paths of the binaries of a subcommand other than the one it runs, leaving out the
+// disabled and the ignored ones
+func shadowedBinaries(cfg *config.Config, name string, cmdPath string) []string {
+	var shadowed []string
+	for _, resolution := range binary.ResolveAll(name, config.GetExpandedBaseDir(cfg)) {
+		if filepath.Clean(resolution.Path) != filepath.Clean(cmdPath) && !isDisabled(cfg, resolution.Path) && !isIgnored(cfg, resolution.Path) {
+			shadowed = append(shadowed, resolution.Path)
+		}
+	}
+	return shadowed
 }
 
 // RegisterSubcommandExecutor registers the subcommand executor with the registry
@@ -0,0 +1,56 @@
+package command
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/pkg/errors"
+)
+
+// subcommandEnv gets the "NAME=value" variables master-mold adds to the environment of a subcommand, on top of
+// those of the global flags: the base directory, the [plugins.<name>] table of the configuration, as JSON, and the
+// variables of its [env.<name>] table
+func subcommandEnv(cfg *config.Config, name string) ([]string, error) {
+	var variables []string
+	if cfg.BaseDir != "" {
+		variables = append(variables, env.BaseDir+"="+config.GetExpandedBaseDir(cfg))
+	}
+
+	if settings, ok := cfg.Plugins[name]; ok {
+		data, err := json.Marshal(settings)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to serialize the configuration of '%s'", name)
+		}
+		variables = append(variables, env.PluginConfig+"="+string(data))
+	}
+
+	// The secrets of the [env.<name>] table are only read now
+	configured, err := config.SubcommandEnv(cfg, name)
+	if err != nil {
+		return nil, err
+	}
+	return append(variables, configured...), nil
+}
+
+// redactedSubcommandEnv gets the variables of subcommandEnv with the secrets of the configuration masked, so they
+// can be shown. The plugin configuration is masked before it is serialized, since JSON escapes some secrets.
+func redactedSubcommandEnv(cfg *config.Config, name string) ([]string, error) {
+	variables, err := subcommandEnv(cfg, name)
+	if err != nil {
+		return nil, err
+	}
+	for i, variable := range variables {
+		if !strings.HasPrefix(variable, env.PluginConfig+"=") {
+			variables[i] = config.RedactSecrets(cfg, variable)
+			continue
+		}
+		data, err := json.Marshal(config.RedactSecretValues(cfg, cfg.Plugins[name]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to serialize the configuration of '%s'", name)
+		}
+		variables[i] = env.PluginConfig + "=" + string(data)
+	}
+	return variables, nil
+}
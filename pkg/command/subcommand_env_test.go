@@ -0,0 +1,102 @@
+package command
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/env"
+)
+
+func TestSubcommandEnv(t *testing.T) {
+	cfg := &config.Config{
+		Plugins: map[string]map[string]interface{}{
+			"azure-devops": {"project": "web", "retries": 3},
+		},
+		Env: map[string]map[string]string{
+			"azure-devops": {"azure_devops_org": "contoso"},
+		},
+	}
+
+	got, err := subcommandEnv(cfg, "azure-devops")
+	if err != nil {
+		t.Fatalf("subcommandEnv() error = %v", err)
+	}
+	if want := []string{env.PluginConfig + `={"project":"web","retries":3}`, "AZURE_DEVOPS_ORG=contoso"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("subcommandEnv() = %q, want %q", got, want)
+	}
+
+	if got, err := subcommandEnv(cfg, "k8s-pods"); err != nil || len(got) != 0 {
+		t.Errorf("subcommandEnv() of a plugin without configuration = %q, %v, want none", got, err)
+	}
+
+	t.Setenv("MM_TEST_HOME", "/home/mm")
+	cfg.BaseDir = "${MM_TEST_HOME}/.master-mold"
+	if got, err := subcommandEnv(cfg, "k8s-pods"); err != nil || !reflect.DeepEqual(got, []string{env.BaseDir + "=/home/mm/.master-mold"}) {
+		t.Errorf("subcommandEnv() with a base directory = %q, %v, want the expanded base directory", got, err)
+	}
+}
+
+func TestRedactedSubcommandEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The keyring is read from the Credential Manager on Windows")
+	}
+
+	// The keyring tools print a secret that JSON escapes
+	keyringDir := t.TempDir()
+	for _, tool := range []string{"secret-tool", "security"} {
+		if err := os.WriteFile(filepath.Join(keyringDir, tool), []byte("#!/bin/sh\nprintf '%s' 's3\"c\\\\ret'\n"), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", tool, err)
+		}
+	}
+	t.Setenv("PATH", keyringDir)
+
+	tempDir := t.TempDir()
+	content := "[plugins.azure-devops]\ntoken = \"keyring:ado-pat\"\nproject = \"web\"\n\n[env.azure-devops]\nazure_devops_org = \"contoso\"\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "config.toml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+	cfg, err := config.LoadConfig([]string{tempDir}, slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if got := cfg.Plugins["azure-devops"]["token"]; got != `s3"c\\ret` {
+		t.Fatalf("LoadConfig().Plugins[azure-devops][token] = %q, want the secret of the keyring", got)
+	}
+
+	got, err := redactedSubcommandEnv(cfg, "azure-devops")
+	if err != nil {
+		t.Fatalf("redactedSubcommandEnv() error = %v", err)
+	}
+	want := []string{env.PluginConfig + `={"project":"web","token":"` + config.RedactedSecret + `"}`, "AZURE_DEVOPS_ORG=contoso"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("redactedSubcommandEnv() = %q, want %q", got, want)
+	}
+}
+
+func TestRegistry_ExecutePassesPluginConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	output := filepath.Join(tempDir, "config.json")
+	script := "#!/bin/sh\nprintf '%s' \"$" + env.PluginConfig + "\" > " + output + "\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "mm-deploy"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create binary: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	registry := NewRegistry(&config.Config{
+		BaseDir: tempDir,
+		Plugins: map[string]map[string]interface{}{"deploy": {"region": "eu"}},
+	}, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+
+	if err := registry.Execute("deploy", nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got, _ := os.ReadFile(output); string(got) != `{"region":"eu"}` {
+		t.Errorf("subcommand got %s = %q, want its configuration", env.PluginConfig, got)
+	}
+}
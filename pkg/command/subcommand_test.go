@@ -0,0 +1,128 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/pkg/errors"
+)
+
+func TestRegistry_ExecuteWarnsShadowed(t *testing.T) {
+	pathDir, baseDir := t.TempDir(), t.TempDir()
+	for _, path := range []string{filepath.Join(pathDir, "mm-deploy"), filepath.Join(baseDir, "master-mold-deploy"), filepath.Join(baseDir, "mm-lint")} {
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", path, err)
+		}
+	}
+	t.Setenv("PATH", pathDir)
+
+	logs := &bytes.Buffer{}
+	registry := NewRegistry(&config.Config{BaseDir: baseDir}, slog.New(slog.NewTextHandler(logs, nil)))
+	RegisterCommands(registry)
+
+	if err := registry.Execute("deploy", nil); err != nil {
+		t.Fatalf("Execute(deploy) error = %v", err)
+	}
+	if !strings.Contains(logs.String(), "level=WARN") || !strings.Contains(logs.String(), filepath.Join(baseDir, "master-mold-deploy")) {
+		t.Errorf("logs = %q, want a warning naming the shadowed binary", logs.String())
+	}
+
+	logs.Reset()
+	if err := registry.Execute("lint", nil); err != nil {
+		t.Fatalf("Execute(lint) error = %v", err)
+	}
+	if strings.Contains(logs.String(), "level=WARN") {
+		t.Errorf("logs = %q, want no warning for a single binary", logs.String())
+	}
+}
+
+func TestRegistry_ExecuteWarnsUnpinned(t *testing.T) {
+	baseDir := t.TempDir()
+	script := "#!/bin/sh\necho 'deploy version 1.3.0'\n"
+	if err := os.WriteFile(filepath.Join(baseDir, "mm-deploy"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create mm-deploy: %v", err)
+	}
+	t.Setenv("PATH", "")
+
+	for pin, wantWarning := range map[string]bool{"1.2.0": true, "v1.3.0": false} {
+		logs := &bytes.Buffer{}
+		cfg := &config.Config{BaseDir: baseDir, Pins: map[string]string{"deploy": pin}}
+		registry := NewRegistry(cfg, slog.New(slog.NewTextHandler(logs, nil)))
+		RegisterCommands(registry)
+
+		if err := registry.Execute("deploy", nil); err != nil {
+			t.Fatalf("Execute(deploy) error = %v", err)
+		}
+		if got := strings.Contains(logs.String(), "level=WARN"); got != wantWarning {
+			t.Errorf("pinned to %s, logs = %q, want a warning %v", pin, logs.String(), wantWarning)
+		}
+	}
+}
+
+func TestRegistry_ExecuteBundled(t *testing.T) {
+	baseDir := t.TempDir()
+	t.Setenv("PATH", "")
+	t.Setenv(env.PluginConfig, "")
+
+	cfg := &config.Config{BaseDir: baseDir, Plugins: map[string]map[string]interface{}{"deploy": {"region": "eu"}}}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+	var got []string
+	registry.RegisterBundled("deploy", HandlerFunc(func(args []string) error {
+		got = append(args, env.Getenv(env.PluginConfig))
+		return nil
+	}))
+
+	// Without a binary, the bundled subcommand runs in-process with the environment of the binary
+	if err := registry.Execute("deploy", []string{"--env", "prod"}); err != nil {
+		t.Fatalf("Execute(deploy) error = %v", err)
+	}
+	if want := []string{"--env", "prod", `{"region":"eu"}`}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bundled subcommand got %q, want %q", got, want)
+	}
+	if value := os.Getenv(env.PluginConfig); value != "" {
+		t.Errorf("the environment of master-mold has %s = %q, want it unchanged", env.PluginConfig, value)
+	}
+
+	// An installed binary runs instead
+	got = nil
+	if err := os.WriteFile(filepath.Join(baseDir, "mm-deploy"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("Failed to create mm-deploy: %v", err)
+	}
+	if err := registry.Execute("deploy", nil); err != nil || got != nil {
+		t.Errorf("Execute(deploy) error = %v, bundled subcommand got %q, want the binary to run", err, got)
+	}
+
+	// A disabled subcommand doesn't fall back to its bundled copy
+	cfg.Disabled = []string{"deploy"}
+	if err := registry.Execute("deploy", nil); !isDisabledError(err) || got != nil {
+		t.Errorf("Execute(deploy) error = %v, bundled subcommand got %q, want the subcommand disabled", err, got)
+	}
+}
+
+func TestRegistry_ExecuteBundledTimeout(t *testing.T) {
+	t.Setenv("PATH", "")
+	cfg := &config.Config{BaseDir: t.TempDir(), Timeout: 1}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+	registry.RegisterBundled("deploy", ContextHandlerFunc(func(ctx context.Context, args []string) error {
+		<-ctx.Done()
+		return errors.Wrap(ctx.Err(), "failed to deploy")
+	}))
+
+	err := registry.Execute("deploy", nil)
+	if err == nil || !strings.Contains(err.Error(), "'deploy' timed out after 1s") {
+		t.Errorf("Execute(deploy) error = %v, want it timed out", err)
+	}
+	if code := ExitCode(err); code != ExitTimeout {
+		t.Errorf("ExitCode() = %d, want %d", code, ExitTimeout)
+	}
+}
@@ -0,0 +1,119 @@
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/integrity"
+	"github.com/pkg/errors"
+)
+
+// UninstallHandler handles the uninstall command
+type UninstallHandler struct {
+	config *config.Config
+	input  io.Reader
+	output io.Writer
+}
+
+// NewUninstallHandler creates a new uninstall command handler
+func NewUninstallHandler(config *config.Config) *UninstallHandler {
+	return &UninstallHandler{
+		config: config,
+		input:  os.Stdin,
+		output: os.Stdout,
+	}
+}
+
+// Help returns the usage of the uninstall command
+func (h *UninstallHandler) Help() string {
+	return "Usage: master-mold uninstall <name> [--yes]\n\nRemoves the binary of a subcommand from the base directory, after confirmation unless --yes is given.\nBinaries elsewhere on the PATH are never removed."
+}
+
+// Execute executes the uninstall command
+func (h *UninstallHandler) Execute(args []string) error {
+	flags := newFlagSet("uninstall", h.output)
+	yes := flags.BoolP("yes", "y", false, "Remove without asking for confirmation")
+
+	names, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(names) != 1 {
+		return errors.New("usage: master-mold uninstall <name> [--yes]")
+	}
+	name := names[0]
+
+	// Only binaries in the base directory are removed; others belong to the system or a package manager
+	baseDir := config.GetExpandedBaseDir(h.config)
+	paths := FindInstalledBinaries(name, baseDir)
+	if len(paths) == 0 {
+		if path, err := binary.FindExecutable(name, baseDir); err == nil {
+			return errors.Errorf("'%s' is installed at %s, outside %s; remove it with the tool that installed it", name, path, baseDir)
+		}
+		return errors.Errorf("subcommand '%s' is not installed in %s", name, baseDir)
+	}
+
+	if !*yes {
+		confirmed, err := confirm(h.input, h.output, fmt.Sprintf("Remove %s?", strings.Join(paths, ", ")))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(h.output, "Uninstall cancelled.")
+			return nil
+		}
+	}
+
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil {
+			return errors.Wrapf(err, "failed to remove %s", path)
+		}
+		fmt.Fprintf(h.output, "Removed %s\n", path)
+	}
+
+	// A binary installed again at the same path is a new one, not a changed one
+	if h.config.BaseDir == "" {
+		return nil
+	}
+	return updateIntegrity(h.config, func(database integrity.Database) {
+		for _, path := range paths {
+			database.Forget(path)
+		}
+	})
+}
+
+// FindInstalledBinaries finds the binaries of a subcommand in the base directory, under either prefix
+func FindInstalledBinaries(name string, baseDir string) []string {
+	var paths []string
+	for _, prefix := range binary.ValidPrefixes() {
+		path := filepath.Join(baseDir, string(prefix)+name)
+		if info, err := os.Lstat(path); err == nil && !info.IsDir() {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// confirm asks a yes/no question, defaulting to no
+func confirm(input io.Reader, output io.Writer, question string) (bool, error) {
+	fmt.Fprintf(output, "%s [y/N] ", question)
+
+	answer, err := bufio.NewReader(input).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, errors.Wrap(err, "failed to read answer")
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// RegisterUninstallCommand registers the uninstall command
+func RegisterUninstallCommand(registry *Registry) {
+	registry.Register("uninstall", NewUninstallHandler(registry.Config()))
+}
@@ -0,0 +1,93 @@
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+// newTestUninstallHandler creates an uninstall handler for a base directory, answering prompts with the answer
+func newTestUninstallHandler(baseDir string, answer string) (*UninstallHandler, *bytes.Buffer) {
+	output := &bytes.Buffer{}
+	handler := NewUninstallHandler(&config.Config{BaseDir: baseDir})
+	handler.input = strings.NewReader(answer)
+	handler.output = output
+	return handler, output
+}
+
+func TestUninstallHandler_Execute(t *testing.T) {
+	tempDir := t.TempDir()
+	binaryPath := filepath.Join(tempDir, "mm-deploy")
+	if err := os.WriteFile(binaryPath, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("Failed to create binary: %v", err)
+	}
+
+	// Declining keeps the binary
+	handler, output := newTestUninstallHandler(tempDir, "n\n")
+	if err := handler.Execute([]string{"deploy"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := os.Stat(binaryPath); err != nil {
+		t.Errorf("Execute() removed the binary after the prompt was declined")
+	}
+	if !strings.Contains(output.String(), "[y/N]") {
+		t.Errorf("Execute() output = %q, want a confirmation prompt", output.String())
+	}
+
+	// Confirming removes it
+	handler, _ = newTestUninstallHandler(tempDir, "y\n")
+	if err := handler.Execute([]string{"deploy"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := os.Stat(binaryPath); !os.IsNotExist(err) {
+		t.Errorf("Execute() did not remove the binary")
+	}
+}
+
+func TestUninstallHandler_ExecuteYes(t *testing.T) {
+	tempDir := t.TempDir()
+	binaryPath := filepath.Join(tempDir, "master-mold-deploy")
+	if err := os.WriteFile(binaryPath, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("Failed to create binary: %v", err)
+	}
+
+	handler, output := newTestUninstallHandler(tempDir, "")
+	if err := handler.Execute([]string{"deploy", "--yes"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := os.Stat(binaryPath); !os.IsNotExist(err) {
+		t.Errorf("Execute() did not remove the binary")
+	}
+	if strings.Contains(output.String(), "[y/N]") {
+		t.Errorf("Execute() with --yes prompted for confirmation")
+	}
+}
+
+func TestUninstallHandler_ExecuteOutsideBaseDir(t *testing.T) {
+	baseDir := t.TempDir()
+	pathDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(pathDir, "mm-system"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("Failed to create binary: %v", err)
+	}
+	t.Setenv("PATH", pathDir)
+
+	handler, _ := newTestUninstallHandler(baseDir, "y\n")
+	err := handler.Execute([]string{"system"})
+	if err == nil || !strings.Contains(err.Error(), "outside") {
+		t.Errorf("Execute() error = %v, want a refusal to remove a binary outside the base directory", err)
+	}
+	if _, err := os.Stat(filepath.Join(pathDir, "mm-system")); err != nil {
+		t.Errorf("Execute() removed a binary outside the base directory")
+	}
+
+	if err := handler.Execute([]string{"missing"}); err == nil {
+		t.Error("Execute() for a missing subcommand error = nil, want an error")
+	}
+	if err := handler.Execute(nil); err == nil {
+		t.Error("Execute() without a name error = nil, want a usage error")
+	}
+}
@@ -0,0 +1,151 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/index"
+)
+
+// updateCheckInterval is how often the registries are fetched for newer plugin versions, and how often a plugin is
+// compared with its latest version
+const updateCheckInterval = 24 * time.Hour
+
+// updateState is the cached state of the update checks
+type updateState struct {
+	// CheckedAt is when the registries were last fetched
+	CheckedAt time.Time `json:"checked_at"`
+	// Latest are the latest versions of the plugins in the registries, by name
+	Latest map[string]index.Plugin `json:"latest"`
+	// ComparedAt is when each plugin was last compared with its latest version, by name
+	ComparedAt map[string]time.Time `json:"compared_at"`
+}
+
+// updateStatePath gets the path of the cache of the update checks in the base directory
+func updateStatePath(cfg *config.Config) string {
+	return filepath.Join(config.GetExpandedBaseDir(cfg), "cache", "updates.json")
+}
+
+// notifyUpdate prints a notice when the registries have a newer version of a subcommand that just ran. Registries
+// are fetched at most once a day, and a subcommand is compared with its latest version at most once a day, so the
+// notices neither slow down nor clutter every run. Pinned subcommands are never announced.
+func notifyUpdate(cfg *config.Config, output io.Writer, name string, cmdPath string) {
+	if !cfg.UpdateNotifications || len(cfg.Registries) == 0 {
+		return
+	}
+	if _, pinned := cfg.Pins[name]; pinned {
+		return
+	}
+
+	path := updateStatePath(cfg)
+	state := loadUpdateState(path)
+	changed := false
+	if time.Since(state.CheckedAt) >= updateCheckInterval {
+		// Unreachable registries are only retried the next day, rather than on every run
+		plugins, _ := index.FetchAll(cfg.Registries)
+		state.Latest = latestVersions(plugins)
+		state.CheckedAt = time.Now()
+		changed = true
+	}
+
+	if latest, ok := state.Latest[name]; ok && time.Since(state.ComparedAt[name]) >= updateCheckInterval {
+		state.ComparedAt[name] = time.Now()
+		changed = true
+		if installed := getPluginVersion(cmdPath); compareVersions(latest.Version, installed) > 0 {
+			fmt.Fprintf(output, "%s %s available (%s installed)", filepath.Base(cmdPath), latest.Version, installed)
+			if url := latest.CurrentDownloadURL(); url != "" {
+				fmt.Fprintf(output, ", download it from %s", url)
+			}
+			fmt.Fprintln(output)
+		}
+	}
+
+	if changed {
+		saveUpdateState(path, state)
+	}
+}
+
+// loadUpdateState loads the state of the update checks; a missing or unreadable file gives an empty state
+func loadUpdateState(path string) *updateState {
+	state := &updateState{}
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, state)
+	}
+	if state.Latest == nil {
+		state.Latest = make(map[string]index.Plugin)
+	}
+	if state.ComparedAt == nil {
+		state.ComparedAt = make(map[string]time.Time)
+	}
+	return state
+}
+
+// saveUpdateState writes the state of the update checks; failing to is not worth reporting, the checks just run again
+func saveUpdateState(path string, state *updateState) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	os.WriteFile(path, data, 0644)
+}
+
+// latestVersions gets the plugins of the registries with their latest version, by name
+func latestVersions(plugins []index.Plugin) map[string]index.Plugin {
+	latest := make(map[string]index.Plugin)
+	for _, plugin := range plugins {
+		if current, ok := latest[plugin.Name]; !ok || compareVersions(plugin.Version, current.Version) > 0 {
+			latest[plugin.Name] = plugin
+		}
+	}
+	return latest
+}
+
+// compareVersions compares two versions such as 1.2.0 and v1.10.1, number by number, returning a negative number
+// when a is older than b, zero when they are the same and a positive number when a is newer. Unknown versions are
+// never older or newer.
+func compareVersions(a string, b string) int {
+	if a == "" || b == "" || a == unknownVersion || b == unknownVersion {
+		return 0
+	}
+
+	partsA := versionParts(a)
+	partsB := versionParts(b)
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		partA, partB := "0", "0"
+		if i < len(partsA) {
+			partA = partsA[i]
+		}
+		if i < len(partsB) {
+			partB = partsB[i]
+		}
+
+		numberA, errA := strconv.Atoi(partA)
+		numberB, errB := strconv.Atoi(partB)
+		switch {
+		case errA == nil && errB == nil && numberA != numberB:
+			return numberA - numberB
+		case (errA != nil || errB != nil) && partA != partB:
+			return strings.Compare(partA, partB)
+		}
+	}
+	return 0
+}
+
+// versionParts splits a version into its numbers, without the "v" prefix and the pre-release or build suffix
+func versionParts(version string) []string {
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	return strings.Split(version, ".")
+}
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.3.0", "1.2.0", 1},
+		{"v1.10.0", "1.9.2", 1},
+		{"1.2.0", "v1.2.0", 0},
+		{"1.2", "1.2.0", 0},
+		{"1.2.0-beta.1", "1.2.0", 0},
+		{"1.2.0", "1.2.1", -1},
+		{"1.3.0", unknownVersion, 0},
+	}
+
+	for _, tt := range tests {
+		got := compareVersions(tt.a, tt.b)
+		if (got > 0) != (tt.want > 0) || (got < 0) != (tt.want < 0) {
+			t.Errorf("compareVersions(%s, %s) = %d, want the sign of %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestNotifyUpdate(t *testing.T) {
+	baseDir := t.TempDir()
+	cmdPath := filepath.Join(baseDir, "mm-deploy")
+	if err := os.WriteFile(cmdPath, []byte("#!/bin/sh\necho 'deploy version 1.2.0'\n"), 0755); err != nil {
+		t.Fatalf("Failed to create mm-deploy: %v", err)
+	}
+	registry := filepath.Join(t.TempDir(), "index.json")
+	content := `{"plugins": [{"name": "deploy", "version": "1.3.0", "url": "https://example.com/mm-deploy"}, {"name": "deploy", "version": "1.1.0"}]}`
+	if err := os.WriteFile(registry, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+
+	// Opted out or pinned subcommands are never announced
+	output := &bytes.Buffer{}
+	notifyUpdate(&config.Config{BaseDir: baseDir, Registries: []string{registry}}, output, "deploy", cmdPath)
+	notifyUpdate(&config.Config{BaseDir: baseDir, Registries: []string{registry}, UpdateNotifications: true, Pins: map[string]string{"deploy": "1.2.0"}}, output, "deploy", cmdPath)
+	if output.Len() != 0 {
+		t.Errorf("notifyUpdate() output = %q, want no notice", output.String())
+	}
+
+	cfg := &config.Config{BaseDir: baseDir, Registries: []string{registry}, UpdateNotifications: true}
+	notifyUpdate(cfg, output, "deploy", cmdPath)
+	if want := "mm-deploy 1.3.0 available (1.2.0 installed), download it from https://example.com/mm-deploy\n"; output.String() != want {
+		t.Errorf("notifyUpdate() output = %q, want %q", output.String(), want)
+	}
+
+	// The notice is only shown once a day
+	output.Reset()
+	notifyUpdate(cfg, output, "deploy", cmdPath)
+	if output.Len() != 0 {
+		t.Errorf("notifyUpdate() output = %q, want the notice throttled", output.String())
+	}
+}
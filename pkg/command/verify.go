@@ -0,0 +1,60 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+)
+
+// binaryStamp identifies the state of a binary and of its directory, so a binary is only checked again once one
+// of them changed
+type binaryStamp struct {
+	size       int64
+	modTime    time.Time
+	mode       os.FileMode
+	dirModTime time.Time
+	dirMode    os.FileMode
+}
+
+// stampBinary gets the state of the file a binary resolves to and of its directory
+func stampBinary(cmdPath string) (binaryStamp, bool) {
+	target, err := filepath.EvalSymlinks(cmdPath)
+	if err != nil {
+		return binaryStamp{}, false
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		return binaryStamp{}, false
+	}
+	dirInfo, err := os.Stat(filepath.Dir(target))
+	if err != nil {
+		return binaryStamp{}, false
+	}
+	return binaryStamp{size: info.Size(), modTime: info.ModTime(), mode: info.Mode(), dirModTime: dirInfo.ModTime(), dirMode: dirInfo.Mode()}, true
+}
+
+// VerifyBinary checks the safety and the integrity of a binary before master-mold runs it, whether as a subcommand,
+// to describe itself, to print its version or to complete words; master-mold installs it with binary.SetVerifier. A
+// binary that passed is only checked again once it changed, so it's warned about once.
+func (r *Registry) VerifyBinary(cmdPath string) error {
+	stamp, ok := stampBinary(cmdPath)
+	r.verifiedMu.Lock()
+	defer r.verifiedMu.Unlock()
+	if ok && r.verified[cmdPath] == stamp {
+		return nil
+	}
+
+	name := binary.ExtractCommandName(cmdPath)
+	if err := checkSafety(r.config, r.Logger(), name, cmdPath); err != nil {
+		return err
+	}
+	if err := checkIntegrity(r.config, r.Logger(), name, cmdPath); err != nil {
+		return err
+	}
+	if ok {
+		r.verified[cmdPath] = stamp
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/display"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/pkg/errors"
+)
+
+// Version is the version of master-mold, set at build time with
+// -ldflags "-X github.com/oscarrieken/master-mold/pkg/command.Version=<version>"
+var Version = "dev"
+
+// unknownVersion is reported for plugins that do not tell their version
+const unknownVersion = "unknown"
+
+// pluginVersionTimeout limits how long a plugin may take to print its version
+const pluginVersionTimeout = 5 * time.Second
+
+// PluginVersion is the version of a discovered plugin
+type PluginVersion struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Path    string `json:"path"`
+	// Pin is the version the plugin is pinned to in the configuration
+	Pin string `json:"pin,omitempty"`
+}
+
+// VersionReport is the version of master-mold and of its plugins
+type VersionReport struct {
+	Version string          `json:"version"`
+	Plugins []PluginVersion `json:"plugins"`
+}
+
+// VersionHandler handles the version command
+type VersionHandler struct {
+	config *config.Config
+	output io.Writer
+}
+
+// NewVersionHandler creates a new version command handler
+func NewVersionHandler(config *config.Config) *VersionHandler {
+	return &VersionHandler{
+		config: config,
+		output: os.Stdout,
+	}
+}
+
+// Help returns the usage of the version command
+func (h *VersionHandler) Help() string {
+	return "Usage: master-mold version [--json]\n\nPrints the version of master-mold and of every discovered plugin, as reported by '<plugin> --version'.\nPlugins reporting another version than the one they are pinned to with pins.<name> are marked."
+}
+
+// Execute executes the version command
+func (h *VersionHandler) Execute(args []string) error {
+	flags := newFlagSet("version", h.output)
+	jsonOutput := flags.Bool("json", env.JSONOutput(), "Print the versions in JSON format")
+	if _, err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	binaryPaths, err := findBinaries(h.config)
+	if err != nil {
+		return errors.Wrap(err, "failed to find binaries")
+	}
+
+	report := VersionReport{
+		Version: dispatcherVersion(),
+		Plugins: getPluginVersions(display.ProcessBinaries(binaryPaths)),
+	}
+	for i, plugin := range report.Plugins {
+		report.Plugins[i].Pin = h.config.Pins[plugin.Name]
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(h.output)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	fmt.Fprintf(h.output, "master-mold %s\n", report.Version)
+	if len(report.Plugins) > 0 {
+		fmt.Fprintln(h.output, "Plugins:")
+		for _, plugin := range report.Plugins {
+			fmt.Fprintf(h.output, "  - %s %s (%s)", plugin.Name, plugin.Version, plugin.Path)
+			if plugin.Pin != "" && !matchesPin(plugin.Version, plugin.Pin) {
+				fmt.Fprintf(h.output, " [pinned to %s]", plugin.Pin)
+			}
+			fmt.Fprintln(h.output)
+		}
+	}
+	return nil
+}
+
+// dispatcherVersion gets the version of master-mold, falling back to the module version of the build
+func dispatcherVersion() string {
+	if Version != "dev" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return Version
+}
+
+// getPluginVersions asks every plugin for its version, concurrently
+func getPluginVersions(binaries []display.BinaryInfo) []PluginVersion {
+	versions := make([]PluginVersion, len(binaries))
+
+	var wg sync.WaitGroup
+	for i, info := range binaries {
+		wg.Add(1)
+		go func(i int, info display.BinaryInfo) {
+			defer wg.Done()
+			versions[i] = PluginVersion{Name: info.Name, Version: getPluginVersion(info.FullPath), Path: info.FullPath}
+		}(i, info)
+	}
+	wg.Wait()
+
+	return versions
+}
+
+// getPluginVersion runs a plugin with --version and reads the version it prints
+func getPluginVersion(path string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), pluginVersionTimeout)
+	defer cancel()
+
+	var stdout bytes.Buffer
+	cmd, err := binary.CommandContext(ctx, path, []string{"--version"})
+	if err != nil {
+		return unknownVersion
+	}
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return unknownVersion
+	}
+
+	return ParseVersionOutput(stdout.String())
+}
+
+// matchesPin checks if a version reported by a plugin is the version it is pinned to, ignoring a "v" prefix
+func matchesPin(version string, pin string) bool {
+	return strings.TrimPrefix(version, "v") == strings.TrimPrefix(pin, "v")
+}
+
+// ParseVersionOutput extracts the version from the output of --version, such as "azure-devops version 1.2.0"
+func ParseVersionOutput(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		// Skip log lines printed before the version
+		if strings.HasPrefix(line, "time=") || strings.HasPrefix(line, "{") {
+			continue
+		}
+
+		if i := strings.LastIndex(line, "version "); i >= 0 {
+			line = line[i+len("version "):]
+		}
+		fields := strings.Fields(line)
+		return fields[len(fields)-1]
+	}
+	return unknownVersion
+}
+
+// RegisterVersionCommand registers the version command
+func RegisterVersionCommand(registry *Registry) {
+	registry.Register("version", NewVersionHandler(registry.Config()))
+}
@@ -0,0 +1,70 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+func TestParseVersionOutput(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{"azure-devops version 1.2.0\n", "1.2.0"},
+		{"1.0.0", "1.0.0"},
+		{"mm-deploy v2.3.4", "v2.3.4"},
+		{"time=2024-05-01T12:00:00Z level=INFO msg=\"Starting\"\nk8s-pods version 0.9.0\n", "0.9.0"},
+		{"", "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := ParseVersionOutput(tt.output); got != tt.want {
+			t.Errorf("ParseVersionOutput(%q) = %s, want %s", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestVersionHandler_Execute(t *testing.T) {
+	tempDir := t.TempDir()
+	scripts := map[string]string{
+		"mm-versioned": "#!/bin/sh\necho 'versioned version 1.2.3'\n",
+		"mm-silent":    "#!/bin/sh\nexit 2\n",
+	}
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(script), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	t.Setenv("PATH", "")
+
+	output := &bytes.Buffer{}
+	handler := NewVersionHandler(&config.Config{BaseDir: tempDir, Pins: map[string]string{"versioned": "1.1.0"}})
+	handler.output = output
+
+	if err := handler.Execute(nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{"master-mold ", "  - versioned 1.2.3", "[pinned to 1.1.0]", "  - silent unknown"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("Execute() output = %q, want it to contain %q", output.String(), want)
+		}
+	}
+
+	output.Reset()
+	if err := handler.Execute([]string{"--json"}); err != nil {
+		t.Fatalf("Execute(--json) error = %v", err)
+	}
+	var report VersionReport
+	if err := json.Unmarshal(output.Bytes(), &report); err != nil {
+		t.Fatalf("Execute(--json) output is not JSON: %v", err)
+	}
+	if report.Version == "" || len(report.Plugins) != 2 || report.Plugins[1].Pin != "1.1.0" {
+		t.Errorf("Execute(--json) = %+v, want the version and 2 plugins", report)
+	}
+}
@@ -0,0 +1,179 @@
+package command
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultWatchInterval is how often the watch command looks for changed files
+const defaultWatchInterval = 250 * time.Millisecond
+
+// WatchHandler handles the watch command
+type WatchHandler struct {
+	registry *Registry
+	// root is the directory the globs are relative to
+	root string
+	// interval is how often the files are checked for changes
+	interval time.Duration
+	// stop ends watching when closed; watching goes on until master-mold is interrupted when it is nil
+	stop <-chan struct{}
+}
+
+// NewWatchHandler creates a new watch command handler
+func NewWatchHandler(registry *Registry) *WatchHandler {
+	return &WatchHandler{
+		registry: registry,
+		root:     ".",
+		interval: defaultWatchInterval,
+	}
+}
+
+// Help returns the usage of the watch command
+func (h *WatchHandler) Help() string {
+	return "Usage: master-mold watch --glob <pattern> [--glob <pattern>] [--debounce <duration>] -- <command> [arguments]\n\nRuns the command, then runs it again whenever files matching the patterns change. Patterns are\nrelative to the current directory, and '**' matches any number of directories, as in '**/*.go'.\nChanges are debounced: the command runs once the files stop changing for the debounce duration."
+}
+
+// fileState is what the watch command compares to find out if a file changed
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Execute executes the watch command
+func (h *WatchHandler) Execute(args []string) error {
+	flags := newFlagSet("watch", h.registry.Output())
+	globs := flags.StringArray("glob", nil, "Pattern of the files to watch; can be repeated")
+	debounce := flags.Duration("debounce", 300*time.Millisecond, "How long the files must stop changing before the command runs")
+	// The flags after the command belong to the command
+	flags.SetInterspersed(false)
+
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(*globs) == 0 || len(args) == 0 {
+		return errors.New("usage: master-mold watch --glob <pattern> -- <command> [arguments]")
+	}
+	for _, glob := range *globs {
+		if _, err := path.Match(glob, ""); err != nil {
+			return errors.Wrapf(err, "invalid pattern '%s'", glob)
+		}
+	}
+
+	commandLine := strings.Join(args, " ")
+	run := func() {
+		fmt.Fprintf(h.registry.Output(), "[watch] running %s\n", commandLine)
+		if err := h.registry.Execute(args[0], args[1:]); err != nil {
+			fmt.Fprintf(h.registry.Output(), "[watch] failed: %v\n", err)
+		}
+	}
+
+	snapshot, err := h.scan(*globs)
+	if err != nil {
+		return err
+	}
+	run()
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	// changedAt is when the files last changed, or zero once the command ran for the change
+	var changedAt time.Time
+	for {
+		select {
+		case <-h.stop:
+			return nil
+		case now := <-ticker.C:
+			current, err := h.scan(*globs)
+			if err != nil {
+				h.registry.Logger().Warn("Failed to check the watched files", "error", err)
+				continue
+			}
+			if !maps.Equal(current, snapshot) {
+				snapshot, changedAt = current, now
+				continue
+			}
+			if !changedAt.IsZero() && now.Sub(changedAt) >= *debounce {
+				changedAt = time.Time{}
+				run()
+			}
+		}
+	}
+}
+
+// scan gets the state of the files under the root matching any of the globs. Hidden directories are skipped.
+func (h *WatchHandler) scan(globs []string) (map[string]fileState, error) {
+	files := make(map[string]fileState)
+	err := filepath.WalkDir(h.root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if filePath != h.root && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		relPath, err := filepath.Rel(h.root, filePath)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		for _, glob := range globs {
+			if matchGlob(glob, relPath) {
+				info, err := entry.Info()
+				if err != nil {
+					return err
+				}
+				files[relPath] = fileState{modTime: info.ModTime(), size: info.Size()}
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to scan the watched files")
+	}
+	return files, nil
+}
+
+// matchGlob matches a slash-separated path against a glob in which "**" matches any number of directories
+func matchGlob(glob, name string) bool {
+	return matchSegments(strings.Split(glob, "/"), strings.Split(name, "/"))
+}
+
+// matchSegments matches the segments of a path against the segments of a glob
+func matchSegments(glob, name []string) bool {
+	for len(glob) > 0 {
+		if glob[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(glob[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(glob[0], name[0]); !ok {
+			return false
+		}
+		glob, name = glob[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// RegisterWatchCommand registers the watch command
+func RegisterWatchCommand(registry *Registry) {
+	registry.Register("watch", NewWatchHandler(registry))
+}
@@ -0,0 +1,115 @@
+package command
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+func TestWatchHandler_Execute(t *testing.T) {
+	root := t.TempDir()
+	source := filepath.Join(root, "pkg", "main.go")
+	if err := os.MkdirAll(filepath.Dir(source), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(source, []byte("package main\n"), 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+
+	registry := NewRegistry(&config.Config{}, slog.New(slog.DiscardHandler))
+	var mu sync.Mutex
+	var runs [][]string
+	registry.RegisterFunc("build", func(args []string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		runs = append(runs, args)
+		return nil
+	})
+	runCount := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(runs)
+	}
+	waitForRuns := func(want int) {
+		t.Helper()
+		for deadline := time.Now().Add(5 * time.Second); runCount() < want; time.Sleep(5 * time.Millisecond) {
+			if time.Now().After(deadline) {
+				t.Fatalf("command ran %d times, want %d", runCount(), want)
+			}
+		}
+	}
+
+	stop := make(chan struct{})
+	registry.SetOutput(&bytes.Buffer{})
+	handler := NewWatchHandler(registry)
+	handler.root = root
+	handler.interval = 5 * time.Millisecond
+	handler.stop = stop
+
+	done := make(chan error)
+	go func() {
+		done <- handler.Execute([]string{"--glob", "**/*.go", "--debounce", "20ms", "--", "build", "--race"})
+	}()
+
+	// The command runs once at the start, and again when a matching file changes
+	waitForRuns(1)
+	if err := os.WriteFile(filepath.Join(root, "notes.txt"), []byte("ignored\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := os.WriteFile(source, []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	waitForRuns(2)
+
+	close(stop)
+	if err := <-done; err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := runCount(); got != 2 {
+		t.Errorf("command ran %d times, want 2", got)
+	}
+	if want := []string{"--race"}; !reflect.DeepEqual(runs[0], want) {
+		t.Errorf("command got args %v, want %v", runs[0], want)
+	}
+}
+
+func TestWatchHandler_Execute_Usage(t *testing.T) {
+	registry := NewRegistry(&config.Config{}, slog.New(slog.DiscardHandler))
+	registry.SetOutput(&bytes.Buffer{})
+	handler := NewWatchHandler(registry)
+
+	for _, args := range [][]string{{"--", "build"}, {"--glob", "*.go"}, {"--glob", "[", "--", "build"}} {
+		if err := handler.Execute(args); err == nil {
+			t.Errorf("Execute(%q) error = nil, want an error", args)
+		}
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		glob string
+		name string
+		want bool
+	}{
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "pkg/command/watch.go", true},
+		{"**/*.go", "README.md", false},
+		{"pkg/**", "pkg/command/watch.go", true},
+		{"pkg/*.go", "pkg/command/watch.go", false},
+		{"cmd/**/main.go", "cmd/master-mold/main.go", true},
+		{"*.toml", "config/config.toml", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchGlob(tt.glob, tt.name); got != tt.want {
+			t.Errorf("matchGlob(%s, %s) = %v, want %v", tt.glob, tt.name, got, tt.want)
+		}
+	}
+}
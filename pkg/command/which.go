@@ -0,0 +1,88 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/oscarrieken/master-mold/pkg/config"
+	"github.com/oscarrieken/master-mold/pkg/env"
+	"github.com/pkg/errors"
+)
+
+// WhichHandler handles the which command
+type WhichHandler struct {
+	registry *Registry
+	output   io.Writer
+}
+
+// NewWhichHandler creates a new which command handler
+func NewWhichHandler(registry *Registry) *WhichHandler {
+	return &WhichHandler{
+		registry: registry,
+		output:   os.Stdout,
+	}
+}
+
+// Help returns the usage of the which command
+func (h *WhichHandler) Help() string {
+	return "Usage: master-mold which [-v] <command> [<subcommand>...]\n\nPrints the path of the binary a subcommand resolves to, such as mm-ado-prs for \"which ado prs\".\nWith -v, also prints where it was found (PATH or base directory), its prefix and the other binaries of the\nsubcommand it shadows. Aliases are expanded first."
+}
+
+// Execute executes the which command
+func (h *WhichHandler) Execute(args []string) error {
+	flags := newFlagSet("which", h.output)
+	verbose := flags.BoolP("verbose", "v", env.Enabled(env.Verbose), "Print where the binary was found and its prefix")
+
+	names, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return errors.New("usage: master-mold which [-v] <command> [<subcommand>...]")
+	}
+
+	name, args, err := h.registry.expandAliases(names[0], names[1:])
+	if err != nil {
+		return err
+	}
+	if name != names[0] && *verbose {
+		fmt.Fprintf(h.output, "%s: alias for '%s'\n", names[0], h.registry.Config().Aliases[names[0]])
+	}
+
+	if _, ok := h.registry.Get(name); ok {
+		fmt.Fprintf(h.output, "%s: built-in command\n", name)
+		return nil
+	}
+
+	cfg := h.registry.Config()
+	baseDir := config.GetExpandedBaseDir(cfg)
+	name, _, resolution, err := resolveNamespaced(cfg, name, args)
+	if _, ok := h.registry.bundledFallback(name, err); ok {
+		fmt.Fprintf(h.output, "%s: bundled with master-mold\n", name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(h.output, resolution.Path)
+	if *verbose {
+		source := string(resolution.Source)
+		if resolution.Source == binary.SourceBaseDir {
+			source = fmt.Sprintf("%s (%s)", source, baseDir)
+		}
+		fmt.Fprintf(h.output, "  source: %s\n", source)
+		fmt.Fprintf(h.output, "  prefix: %s\n", resolution.Prefix)
+		for _, path := range shadowedBinaries(cfg, name, resolution.Path) {
+			fmt.Fprintf(h.output, "  shadows: %s\n", path)
+		}
+	}
+	return nil
+}
+
+// RegisterWhichCommand registers the which command
+func RegisterWhichCommand(registry *Registry) {
+	registry.Register("which", NewWhichHandler(registry))
+}
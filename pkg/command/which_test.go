@@ -0,0 +1,55 @@
+package command
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/config"
+)
+
+func TestWhichHandler_Execute(t *testing.T) {
+	pathDir := t.TempDir()
+	baseDir := t.TempDir()
+	for _, path := range []string{filepath.Join(pathDir, "mm-deploy"), filepath.Join(baseDir, "master-mold-k8s-pods"), filepath.Join(baseDir, "mm-deploy")} {
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", path, err)
+		}
+	}
+	t.Setenv("PATH", pathDir)
+	t.Setenv("MM_VERBOSE", "")
+
+	cfg := &config.Config{BaseDir: baseDir, Aliases: map[string]string{"ship": "deploy --env prod"}}
+	registry := NewRegistry(cfg, slog.New(slog.DiscardHandler))
+	RegisterCommands(registry)
+	output := &bytes.Buffer{}
+	handler := NewWhichHandler(registry)
+	handler.output = output
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"deploy"}, filepath.Join(pathDir, "mm-deploy") + "\n"},
+		{[]string{"-v", "k8s-pods"}, filepath.Join(baseDir, "master-mold-k8s-pods") + "\n  source: base directory (" + baseDir + ")\n  prefix: master-mold-\n"},
+		{[]string{"ship", "-v"}, "ship: alias for 'deploy --env prod'\n" + filepath.Join(pathDir, "mm-deploy") + "\n  source: PATH\n  prefix: mm-\n  shadows: " + filepath.Join(baseDir, "mm-deploy") + "\n"},
+		{[]string{"list-binaries"}, "list-binaries: built-in command\n"},
+	}
+
+	for _, tt := range tests {
+		output.Reset()
+		if err := handler.Execute(tt.args); err != nil {
+			t.Errorf("Execute(%v) error = %v", tt.args, err)
+			continue
+		}
+		if output.String() != tt.want {
+			t.Errorf("Execute(%v) output = %q, want %q", tt.args, output.String(), tt.want)
+		}
+	}
+
+	if err := handler.Execute([]string{"missing"}); err == nil {
+		t.Error("Execute(missing) error = nil, want a not found error")
+	}
+}
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
-	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -13,13 +16,74 @@ import (
 type Config struct {
 	BaseDir string `mapstructure:"base_dir"`
 	Timeout int    `mapstructure:"timeout"`
+	// Registries are the URLs or paths of the plugin indexes searched for installable plugins
+	Registries []string `mapstructure:"registries"`
+	// Aliases map command names to the command lines they stand for
+	Aliases map[string]string `mapstructure:"aliases"`
+	// LogFormat is the format of logs: text or json
+	LogFormat string `mapstructure:"log_format"`
+	// LogLevel is the minimum level of logs: debug, info, warn or error
+	LogLevel string `mapstructure:"log_level"`
+	// UpdateNotifications enables the notices of newer plugin versions in the registries
+	UpdateNotifications bool `mapstructure:"update_notifications"`
+	// Audit records every command in an append-only audit log in the base directory
+	Audit bool `mapstructure:"audit"`
+	// CaptureLogs keeps the stderr of every run of a subcommand in the logs directory of the base directory
+	CaptureLogs bool `mapstructure:"capture_logs"`
+	// Exclusive are the subcommands, by name, that never run concurrently, such as deploy plugins
+	Exclusive []string `mapstructure:"exclusive"`
+	// LockWait is how many seconds an exclusive subcommand waits for another run to finish, zero failing right away
+	LockWait int `mapstructure:"lock_wait"`
+	// Pins map subcommand names to the versions they are expected to report
+	Pins map[string]string `mapstructure:"pins"`
+	// Retries map subcommand names to how their failed runs are retried
+	Retries map[string]RetryPolicy `mapstructure:"retries"`
+	// BaseDirOnly resolves subcommands from the base directory only, ignoring the binaries in the PATH
+	BaseDirOnly bool `mapstructure:"base_dir_only"`
+	// UnsafeBinaries is what happens to the binaries other users could replace: warn, refuse or allow
+	UnsafeBinaries string `mapstructure:"unsafe_binaries"`
+	// StrictIntegrity refuses to run the binaries that changed since their hashes were recorded, instead of warning
+	StrictIntegrity bool `mapstructure:"strict_integrity"`
+	// Disabled are the subcommands, by name, and the binaries, by path, skipped by discovery and dispatch
+	Disabled []string `mapstructure:"disabled"`
+	// Env are the variables added to the environment of subcommands, from their [env.<name>] tables. Their
+	// references to secrets are only resolved when the subcommand runs.
+	Env map[string]map[string]string `mapstructure:"env"`
+	// Plugins are the settings of the plugins, from their [plugins.<name>] tables
+	Plugins map[string]map[string]interface{} `mapstructure:"plugins"`
+	// File is the path of the configuration file the configuration was loaded from
+	File string `mapstructure:"-"`
+
+	// secrets are the values of the keyring and age secrets referenced by the configuration
+	secrets []string
+}
+
+// RetryPolicy is how the failed runs of a subcommand are retried, from its [retries.<name>] table
+type RetryPolicy struct {
+	// Retries is how many times a failed run is retried
+	Retries int `mapstructure:"retries"`
+	// RetryOnExitCodes are the exit codes retried, all of them when empty
+	RetryOnExitCodes []int `mapstructure:"retry_on_exit_codes"`
+	// Backoff is the wait before the first retry, doubled before each of the next ones
+	Backoff time.Duration `mapstructure:"backoff"`
 }
 
+// Values of unsafe_binaries; an empty value warns
+const (
+	UnsafeBinariesWarn   = "warn"
+	UnsafeBinariesRefuse = "refuse"
+	UnsafeBinariesAllow  = "allow"
+)
+
+// EnvPrefix is the prefix of the environment variables overriding settings, such as MM_BASE_DIR for base_dir
+const EnvPrefix = "MM"
+
 // DefaultConfig returns the default configuration
 func DefaultConfig() Config {
 	return Config{
-		BaseDir: "${HOME}/.master-mold",
-		Timeout: 10,
+		BaseDir:             "${HOME}/.master-mold",
+		Timeout:             0,
+		UpdateNotifications: true,
 	}
 }
 
@@ -37,8 +101,6 @@ func LoadConfig(configPaths []string, logger *slog.Logger) (*Config, error) {
 		v.AddConfigPath(path)
 	}
 	
-	v.AutomaticEnv() // Enable environment variable substitution
-
 	// Load the configuration
 	if err := v.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
@@ -46,48 +108,177 @@ func LoadConfig(configPaths []string, logger *slog.Logger) (*Config, error) {
 			logger.Info("No config file found, creating default config")
 
 			defaultConfig := DefaultConfig()
-			v.Set("base_dir", defaultConfig.BaseDir)
-			v.Set("timeout", defaultConfig.Timeout)
-
-			// Ensure the config directory exists
-			configDir := filepath.Dir(v.ConfigFileUsed())
-			if configDir == "" {
-				// If no config file was found, use the first config path
-				if len(configPaths) > 0 {
-					configDir = configPaths[0]
-				} else {
-					configDir = "."
-				}
-			}
-
-			if err := os.MkdirAll(configDir, 0755); err != nil {
-				return nil, errors.Wrap(err, "failed to create config directory")
-			}
-
-			v.SetConfigFile(filepath.Join(configDir, "config.toml"))
-			if err := v.SafeWriteConfig(); err != nil {
+			configFile := DefaultConfigFile(configPaths)
+			if err := CreateConfigFile(configFile, map[string]interface{}{
+				"base_dir": defaultConfig.BaseDir,
+				"timeout":  defaultConfig.Timeout,
+			}); err != nil {
 				return nil, errors.Wrap(err, "failed to create default config")
 			}
+			v.SetConfigFile(configFile)
+			if err := v.ReadInConfig(); err != nil {
+				return nil, errors.Wrap(err, "failed to read config file")
+			}
 		} else {
 			return nil, errors.Wrap(err, "failed to read config file")
 		}
 	}
 
+	// Settings missing from the file keep their default
+	v.SetDefault("update_notifications", DefaultConfig().UpdateNotifications)
+
+	// MM_ environment variables override the file
+	if err := bindEnv(v); err != nil {
+		return nil, err
+	}
+
+	readBackoffSeconds(v)
+
+	// Replace the references to secrets with the secrets
+	secrets, err := ResolveSecrets(v)
+	if err != nil {
+		return nil, err
+	}
+
 	// Unmarshal config
 	var config Config
 	if err := v.Unmarshal(&config); err != nil {
 		return nil, errors.Wrap(err, "failed to unmarshal config")
 	}
+	config.secrets = secrets
+
+	if err := validateLogSettings(&config); err != nil {
+		return nil, err
+	}
+	if err := validateRetries(&config); err != nil {
+		return nil, err
+	}
+	if err := validateUnsafeBinaries(&config); err != nil {
+		return nil, err
+	}
+	config.File = v.ConfigFileUsed()
 
 	logger.Info("Configuration loaded", "base_dir", config.BaseDir, "timeout", config.Timeout)
 	return &config, nil
 }
 
+// bindEnv makes environment variables named after the settings with the MM_ prefix, such as MM_BASE_DIR, MM_TIMEOUT
+// and MM_PLUGINS_AZURE_DEVOPS_PROJECT, take precedence over the settings of the file
+func bindEnv(v *viper.Viper) error {
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	v.AutomaticEnv()
+
+	// Settings missing from the file are only looked up in the environment when bound
+	for _, key := range SettingKeys() {
+		if err := v.BindEnv(key); err != nil {
+			return errors.Wrapf(err, "failed to bind %s to the environment", key)
+		}
+	}
+	return nil
+}
+
+// validateLogSettings checks the log format and level of the configuration
+func validateLogSettings(config *Config) error {
+	switch strings.ToLower(config.LogFormat) {
+	case "", "text", "json":
+	default:
+		return errors.Errorf("invalid log_format '%s', expected text or json", config.LogFormat)
+	}
+
+	if config.LogLevel != "" {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(config.LogLevel)); err != nil {
+			return errors.Errorf("invalid log_level '%s', expected debug, info, warn or error", config.LogLevel)
+		}
+	}
+	return nil
+}
+
+// validateUnsafeBinaries checks the unsafe_binaries setting of the configuration
+func validateUnsafeBinaries(config *Config) error {
+	switch strings.ToLower(config.UnsafeBinaries) {
+	case "", UnsafeBinariesWarn, UnsafeBinariesRefuse, UnsafeBinariesAllow:
+		return nil
+	}
+	return errors.Errorf("invalid unsafe_binaries '%s', expected warn, refuse or allow", config.UnsafeBinaries)
+}
+
+// readBackoffSeconds reads the backoffs of retry policies given as bare numbers as seconds, like timeout, rather than
+// as nanoseconds
+func readBackoffSeconds(v *viper.Viper) {
+	for _, key := range v.AllKeys() {
+		if !strings.HasPrefix(key, retriesKey+".") || !strings.HasSuffix(key, ".backoff") {
+			continue
+		}
+		switch value := v.Get(key).(type) {
+		case int, int64, float64:
+			v.Set(key, fmt.Sprintf("%vs", value))
+		}
+	}
+}
+
+// validateRetries checks the retry policies of the configuration
+func validateRetries(config *Config) error {
+	for name, policy := range config.Retries {
+		if policy.Retries < 0 {
+			return errors.Errorf("invalid retries %d for '%s', expected a number of retries", policy.Retries, name)
+		}
+		if policy.Backoff < 0 {
+			return errors.Errorf("invalid backoff %s for '%s', expected a duration such as 2s", policy.Backoff, name)
+		}
+	}
+	return nil
+}
+
+// GetLockWait returns how long an exclusive subcommand waits for another run to finish
+func GetLockWait(config *Config) time.Duration {
+	return time.Duration(config.LockWait) * time.Second
+}
+
+// GetRetryPolicy gets the retry policy of a subcommand, which is empty when its runs aren't retried
+func GetRetryPolicy(config *Config, name string) RetryPolicy {
+	return config.Retries[name]
+}
+
+// SubcommandEnv gets the "NAME=value" variables of the [env.<name>] table of a subcommand, sorted by name, with the
+// secrets they reference read from the keyring or decrypted now, so they only ever reach the subcommand. Names are
+// upper-cased, since the keys of the configuration are case-insensitive.
+func SubcommandEnv(config *Config, name string) ([]string, error) {
+	table := config.Env[name]
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	variables := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, err := ResolveSecret(table[key])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid secret for '%s.%s.%s'", envKey, name, key)
+		}
+		if IsSecret(table[key]) && value != "" {
+			config.secrets = append(config.secrets, value)
+		}
+		variables = append(variables, strings.ToUpper(key)+"="+value)
+	}
+	return variables, nil
+}
+
 // GetExpandedBaseDir returns the base directory with environment variables expanded
 func GetExpandedBaseDir(config *Config) string {
 	return os.ExpandEnv(config.BaseDir)
 }
 
+// GetTimeout returns the timeout of subcommand execution, zero meaning no limit
+func GetTimeout(config *Config) time.Duration {
+	if config.Timeout <= 0 {
+		return 0
+	}
+	return time.Duration(config.Timeout) * time.Second
+}
+
 // EnsureBaseDirExists ensures that the base directory exists
 func EnsureBaseDirExists(config *Config) error {
 	baseDir := GetExpandedBaseDir(config)
@@ -3,7 +3,9 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 
 	"log/slog"
 )
@@ -16,8 +18,8 @@ func TestDefaultConfig(t *testing.T) {
 	if config.BaseDir != "${HOME}/.master-mold" {
 		t.Errorf("DefaultConfig().BaseDir = %s, want ${HOME}/.master-mold", config.BaseDir)
 	}
-	if config.Timeout != 10 {
-		t.Errorf("DefaultConfig().Timeout = %d, want 10", config.Timeout)
+	if config.Timeout != 0 {
+		t.Errorf("DefaultConfig().Timeout = %d, want 0", config.Timeout)
 	}
 }
 
@@ -88,8 +90,8 @@ func TestLoadConfig(t *testing.T) {
 		if config.BaseDir != "${HOME}/.master-mold" {
 			t.Errorf("LoadConfig().BaseDir = %s, want ${HOME}/.master-mold", config.BaseDir)
 		}
-		if config.Timeout != 10 {
-			t.Errorf("LoadConfig().Timeout = %d, want 10", config.Timeout)
+		if config.Timeout != 0 {
+			t.Errorf("LoadConfig().Timeout = %d, want 0", config.Timeout)
 		}
 
 		// Check that the config file was created
@@ -97,6 +99,9 @@ func TestLoadConfig(t *testing.T) {
 		if _, err := os.Stat(configFile); os.IsNotExist(err) {
 			t.Errorf("LoadConfig() did not create the config file: %s", configFile)
 		}
+		if config.File != configFile {
+			t.Errorf("LoadConfig().File = %s, want %s", config.File, configFile)
+		}
 	})
 
 	// Test loading configuration from an existing config file
@@ -125,4 +130,130 @@ timeout = 20
 			t.Errorf("LoadConfig().Timeout = %d, want 20", config.Timeout)
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestGetTimeout(t *testing.T) {
+	tests := []struct {
+		timeout int
+		want    time.Duration
+	}{
+		{10, 10 * time.Second},
+		{0, 0},
+		{-1, 0},
+	}
+
+	for _, tt := range tests {
+		if got := GetTimeout(&Config{Timeout: tt.timeout}); got != tt.want {
+			t.Errorf("GetTimeout(%d) = %s, want %s", tt.timeout, got, tt.want)
+		}
+	}
+}
+
+func TestValidateLogSettings(t *testing.T) {
+	tests := []struct {
+		format  string
+		level   string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"json", "debug", false},
+		{"TEXT", "WARN", false},
+		{"xml", "", true},
+		{"", "chatty", true},
+	}
+
+	for _, tt := range tests {
+		err := validateLogSettings(&Config{LogFormat: tt.format, LogLevel: tt.level})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateLogSettings(%q, %q) error = %v, wantErr %v", tt.format, tt.level, err, tt.wantErr)
+		}
+	}
+}
+
+func TestLoadConfig_Plugins(t *testing.T) {
+	tempDir := t.TempDir()
+	content := "timeout = 10\n\n[plugins.azure-devops]\nproject = \"web\"\nretries = 3\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "config.toml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+
+	config, err := LoadConfig([]string{tempDir}, slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	settings := config.Plugins["azure-devops"]
+	if settings["project"] != "web" || settings["retries"] != int64(3) {
+		t.Errorf("LoadConfig().Plugins[azure-devops] = %v, want its table", settings)
+	}
+}
+
+func TestLoadConfig_Retries(t *testing.T) {
+	tempDir := t.TempDir()
+	content := "timeout = 10\n\n[retries.deploy]\nretries = 2\nretry_on_exit_codes = [75]\nbackoff = \"500ms\"\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "config.toml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+
+	config, err := LoadConfig([]string{tempDir}, slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	want := RetryPolicy{Retries: 2, RetryOnExitCodes: []int{75}, Backoff: 500 * time.Millisecond}
+	if got := GetRetryPolicy(config, "deploy"); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetRetryPolicy(deploy) = %+v, want %+v", got, want)
+	}
+	if got := GetRetryPolicy(config, "other"); !reflect.DeepEqual(got, RetryPolicy{}) {
+		t.Errorf("GetRetryPolicy(other) = %+v, want no retries", got)
+	}
+
+	// Bare numbers are seconds, like timeout
+	content = "[retries.deploy]\nretries = 2\nbackoff = 3\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "config.toml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+	if config, err = LoadConfig([]string{tempDir}, slog.New(slog.DiscardHandler)); err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if got := GetRetryPolicy(config, "deploy").Backoff; got != 3*time.Second {
+		t.Errorf("GetRetryPolicy(deploy).Backoff = %s, want 3s", got)
+	}
+}
+
+func TestLoadConfig_Env(t *testing.T) {
+	tempDir := t.TempDir()
+	content := "base_dir = \"/custom/dir\"\ntimeout = 20\nlog_level = \"warn\"\n\n[plugins.azure-devops]\nproject = \"web\"\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "config.toml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+	t.Setenv("MM_BASE_DIR", "/env/dir")
+	t.Setenv("MM_TIMEOUT", "5")
+	t.Setenv("MM_REGISTRIES", "https://a.example/index.json,./index.json")
+	t.Setenv("MM_LOG_LEVEL", "")
+	t.Setenv("MM_PLUGINS_AZURE_DEVOPS_PROJECT", "api")
+
+	config, err := LoadConfig([]string{tempDir}, slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if config.BaseDir != "/env/dir" || config.Timeout != 5 {
+		t.Errorf("LoadConfig() = base_dir %s, timeout %d, want the environment to win", config.BaseDir, config.Timeout)
+	}
+	if want := []string{"https://a.example/index.json", "./index.json"}; !reflect.DeepEqual(config.Registries, want) {
+		t.Errorf("LoadConfig().Registries = %v, want %v", config.Registries, want)
+	}
+	// Empty variables are ignored
+	if config.LogLevel != "warn" {
+		t.Errorf("LoadConfig().LogLevel = %s, want warn from the file", config.LogLevel)
+	}
+	if project := config.Plugins["azure-devops"]["project"]; project != "api" {
+		t.Errorf("LoadConfig().Plugins[azure-devops][project] = %v, want api", project)
+	}
+
+	t.Setenv("MM_TIMEOUT", "soon")
+	if _, err := LoadConfig([]string{tempDir}, slog.New(slog.DiscardHandler)); err == nil {
+		t.Error("LoadConfig() with an invalid MM_TIMEOUT error = nil, want an error")
+	}
+}
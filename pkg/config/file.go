@@ -0,0 +1,385 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// Tables of the configuration; "aliases.<name>" is the key of an alias, "pins.<name>" the key of the version a
+// plugin is pinned to, "retries.<name>.<key>" the key of a setting of the retry policy of a plugin,
+// "env.<name>.<variable>" the key of an environment variable of a plugin and "plugins.<name>.<key>" the key of a
+// setting of a plugin
+const (
+	aliasesKey = "aliases"
+	pinsKey    = "pins"
+	retriesKey = "retries"
+	envKey     = "env"
+	pluginsKey = "plugins"
+)
+
+// settingParsers parse the values of the settings that can be set, by key
+var settingParsers = map[string]func(value string) (interface{}, error){
+	"base_dir":             parseStringSetting,
+	"base_dir_only":        parseBoolSetting,
+	"timeout":              parseTimeoutSetting,
+	"registries":           parseListSetting,
+	"disabled":             parseListSetting,
+	"unsafe_binaries":      parseStringSetting,
+	"strict_integrity":     parseBoolSetting,
+	"exclusive":            parseListSetting,
+	"lock_wait":            parseTimeoutSetting,
+	"update_notifications": parseBoolSetting,
+	"audit":                parseBoolSetting,
+	"capture_logs":         parseBoolSetting,
+	"log_format":           parseStringSetting,
+	"log_level":            parseStringSetting,
+}
+
+// retryParsers parse the values of the settings of retry policies, by key
+var retryParsers = map[string]func(value string) (interface{}, error){
+	"retries":             parseCountSetting,
+	"retry_on_exit_codes": parseExitCodesSetting,
+	"backoff":             parseDurationSetting,
+}
+
+// SettingKeys returns the keys of the settings that can be set, sorted. Aliases are set with "aliases.<name>", pins
+// with "pins.<name>", retry policies with "retries.<name>.<key>", the environment variables of plugins with
+// "env.<name>.<variable>" and the settings of plugins with "plugins.<name>.<key>".
+func SettingKeys() []string {
+	keys := make([]string, 0, len(settingParsers))
+	for key := range settingParsers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GetSetting gets the value of a setting, or of a table such as "aliases", from a configuration file
+func GetSetting(file, key string) (interface{}, error) {
+	v, err := readConfigFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	key = strings.ToLower(key)
+	if !v.IsSet(key) {
+		return nil, errors.Errorf("'%s' is not set", key)
+	}
+	return v.Get(key), nil
+}
+
+// ListSettings gets the values of all the settings of a configuration file, by key; aliases have their own keys
+func ListSettings(file string) (map[string]interface{}, error) {
+	v, err := readConfigFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]interface{})
+	for _, key := range v.AllKeys() {
+		settings[key] = v.Get(key)
+	}
+	return settings, nil
+}
+
+// SetSetting sets a setting in a configuration file. The value is parsed according to the setting, and the file
+// is only written when the resulting configuration is valid.
+func SetSetting(file, key, value string) error {
+	key = strings.ToLower(key)
+	parse, err := settingParser(key)
+	if err != nil {
+		return err
+	}
+	parsed, err := parse(value)
+	if err != nil {
+		return errors.Wrapf(err, "invalid value for '%s'", key)
+	}
+
+	v, err := readConfigFile(file)
+	if err != nil {
+		return err
+	}
+	v.Set(key, parsed)
+	return writeConfigFile(v)
+}
+
+// UnsetSetting removes a setting, or a table such as "aliases", from a configuration file
+func UnsetSetting(file, key string) error {
+	v, err := readConfigFile(file)
+	if err != nil {
+		return err
+	}
+
+	key = strings.ToLower(key)
+	if !v.IsSet(key) {
+		return errors.Errorf("'%s' is not set", key)
+	}
+
+	// viper can't remove keys, so the file is rewritten from its settings without the key
+	settings := v.AllSettings()
+	path := strings.Split(key, ".")
+	table := settings
+	for _, name := range path[:len(path)-1] {
+		nested, ok := table[name].(map[string]interface{})
+		if !ok {
+			return errors.Errorf("'%s' is not set", key)
+		}
+		table = nested
+	}
+	delete(table, path[len(path)-1])
+
+	rewritten := viper.New()
+	rewritten.SetConfigFile(v.ConfigFileUsed())
+	if err := rewritten.MergeConfigMap(settings); err != nil {
+		return errors.Wrap(err, "failed to remove the setting")
+	}
+	return writeConfigFile(rewritten)
+}
+
+// AddListItem adds an item to a list setting of a configuration file, reporting whether it was not already there
+func AddListItem(file, key, item string) (bool, error) {
+	v, err := readConfigFile(file)
+	if err != nil {
+		return false, err
+	}
+
+	key = strings.ToLower(key)
+	items := v.GetStringSlice(key)
+	if slices.Contains(items, item) {
+		return false, nil
+	}
+	v.Set(key, append(items, item))
+	return true, writeConfigFile(v)
+}
+
+// RemoveListItem removes an item from a list setting of a configuration file, reporting whether it was there
+func RemoveListItem(file, key, item string) (bool, error) {
+	v, err := readConfigFile(file)
+	if err != nil {
+		return false, err
+	}
+
+	key = strings.ToLower(key)
+	items := v.GetStringSlice(key)
+	index := slices.Index(items, item)
+	if index < 0 {
+		return false, nil
+	}
+	v.Set(key, slices.Delete(items, index, index+1))
+	return true, writeConfigFile(v)
+}
+
+// FindConfigFile gets the configuration file LoadConfig reads from the paths, or an empty string when there is none
+func FindConfigFile(configPaths []string) string {
+	for _, path := range configPaths {
+		file := filepath.Join(os.ExpandEnv(path), "config.toml")
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			return file
+		}
+	}
+	return ""
+}
+
+// DefaultConfigFile gets the configuration file LoadConfig creates when there is none: config.toml in the first path
+func DefaultConfigFile(configPaths []string) string {
+	configDir := "."
+	if len(configPaths) > 0 {
+		configDir = os.ExpandEnv(configPaths[0])
+	}
+	return filepath.Join(configDir, "config.toml")
+}
+
+// CreateConfigFile creates a configuration file with settings, which may include tables such as "plugins", and its
+// directory. The file is only written when the settings make a valid configuration, and never replaces a file.
+func CreateConfigFile(file string, settings map[string]interface{}) error {
+	v := viper.New()
+	v.SetConfigFile(file)
+	v.SetConfigType("toml")
+	if err := v.MergeConfigMap(settings); err != nil {
+		return errors.Wrap(err, "invalid configuration")
+	}
+	if err := validateSettings(v); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return errors.Wrap(err, "failed to create config directory")
+	}
+	if err := v.SafeWriteConfigAs(file); err != nil {
+		return errors.Wrapf(err, "failed to write config file %s", file)
+	}
+	return nil
+}
+
+// ValidateFile checks that a configuration file can be read and holds a valid configuration
+func ValidateFile(file string) error {
+	v, err := readConfigFile(file)
+	if err != nil {
+		return err
+	}
+	return validateSettings(v)
+}
+
+// settingParser gets the parser of the value of a setting, which must be known, an alias, a pin, a setting of a
+// retry policy, an environment variable of a plugin or a setting of a plugin
+func settingParser(key string) (func(value string) (interface{}, error), error) {
+	if parse, ok := settingParsers[key]; ok {
+		return parse, nil
+	}
+	if name, ok := strings.CutPrefix(key, aliasesKey+"."); ok && name != "" && !strings.Contains(name, ".") {
+		return parseStringSetting, nil
+	}
+	if name, ok := strings.CutPrefix(key, pinsKey+"."); ok && name != "" && !strings.Contains(name, ".") {
+		return parseVersionSetting, nil
+	}
+	if setting, ok := strings.CutPrefix(key, retriesKey+"."); ok {
+		if name, key, ok := strings.Cut(setting, "."); ok && name != "" {
+			if parse, ok := retryParsers[key]; ok {
+				return parse, nil
+			}
+		}
+	}
+	for _, table := range []string{envKey, pluginsKey} {
+		if setting, ok := strings.CutPrefix(key, table+"."); ok {
+			if name, key, ok := strings.Cut(setting, "."); ok && name != "" && key != "" && !strings.Contains(key, ".") {
+				return parseStringSetting, nil
+			}
+		}
+	}
+	return nil, errors.Errorf("unknown setting '%s', expected one of %s, %s.<name>, %s.<name>, %s.<name>.<retries|retry_on_exit_codes|backoff>, %s.<name>.<variable> or %s.<name>.<key>", key, strings.Join(SettingKeys(), ", "), aliasesKey, pinsKey, retriesKey, envKey, pluginsKey)
+}
+
+// parseStringSetting parses the value of a text setting
+func parseStringSetting(value string) (interface{}, error) {
+	return value, nil
+}
+
+// parseTimeoutSetting parses a timeout in seconds, zero meaning no limit
+func parseTimeoutSetting(value string) (interface{}, error) {
+	timeout, err := strconv.Atoi(value)
+	if err != nil || timeout < 0 {
+		return nil, errors.Errorf("'%s' is not a number of seconds", value)
+	}
+	return timeout, nil
+}
+
+// parseVersionSetting parses a version, such as 1.2.0 or v1.2.0
+func parseVersionSetting(value string) (interface{}, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.ContainsAny(value, " \t") {
+		return nil, errors.Errorf("'%s' is not a version", value)
+	}
+	return value, nil
+}
+
+// parseBoolSetting parses a boolean, such as true, false, 1 or 0
+func parseBoolSetting(value string) (interface{}, error) {
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, errors.Errorf("'%s' is not true or false", value)
+	}
+	return enabled, nil
+}
+
+// parseCountSetting parses a count, such as a number of retries
+func parseCountSetting(value string) (interface{}, error) {
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 0 {
+		return nil, errors.Errorf("'%s' is not a count", value)
+	}
+	return count, nil
+}
+
+// parseDurationSetting parses a duration, such as 500ms or 2s, or a number of seconds
+func parseDurationSetting(value string) (interface{}, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+		return seconds, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return nil, errors.Errorf("'%s' is not a duration such as 2s", value)
+	}
+	return value, nil
+}
+
+// parseExitCodesSetting parses a comma-separated list of exit codes
+func parseExitCodesSetting(value string) (interface{}, error) {
+	codes := []int{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		code, err := strconv.Atoi(item)
+		if err != nil || code < 0 || code > 255 {
+			return nil, errors.Errorf("'%s' is not an exit code", item)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// parseListSetting parses a comma-separated list
+func parseListSetting(value string) (interface{}, error) {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// readConfigFile reads a configuration file with viper
+func readConfigFile(file string) (*viper.Viper, error) {
+	if file == "" {
+		return nil, errors.New("no configuration file")
+	}
+
+	v := viper.New()
+	v.SetConfigFile(file)
+	v.SetConfigType("toml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read config file %s", file)
+	}
+	return v, nil
+}
+
+// writeConfigFile writes the settings of viper to its configuration file when they make a valid configuration
+func writeConfigFile(v *viper.Viper) error {
+	if err := validateSettings(v); err != nil {
+		return err
+	}
+	if err := v.WriteConfigAs(v.ConfigFileUsed()); err != nil {
+		return errors.Wrapf(err, "failed to write config file %s", v.ConfigFileUsed())
+	}
+	return nil
+}
+
+// validateSettings checks that the settings of viper make a valid configuration
+func validateSettings(v *viper.Viper) error {
+	var config Config
+	if err := v.Unmarshal(&config); err != nil {
+		return errors.Wrap(err, "invalid configuration")
+	}
+	if config.Timeout < 0 {
+		return errors.Errorf("invalid timeout %d, expected a number of seconds", config.Timeout)
+	}
+	if config.LockWait < 0 {
+		return errors.Errorf("invalid lock_wait %d, expected a number of seconds", config.LockWait)
+	}
+	if err := validateRetries(&config); err != nil {
+		return err
+	}
+	if err := validateUnsafeBinaries(&config); err != nil {
+		return err
+	}
+	return validateLogSettings(&config)
+}
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeConfigFileForTest writes a configuration file in a temporary directory
+func writeConfigFileForTest(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return file
+}
+
+func TestSetSetting(t *testing.T) {
+	file := writeConfigFileForTest(t, "base_dir = \"/opt/mm\"\ntimeout = 10\n")
+
+	for key, value := range map[string]string{
+		"timeout":                            "30",
+		"registries":                         "https://a.example/index.json, ./index.json",
+		"LOG_LEVEL":                          "debug",
+		"aliases.prs":                        "azure-devops pull-requests list-open",
+		"plugins.azure-devops.project":       "web",
+		"pins.azure-devops":                  "1.2.0",
+		"retries.deploy.retries":             "2",
+		"retries.deploy.backoff":             "2s",
+		"retries.ci.backoff":                 "5",
+		"retries.deploy.retry_on_exit_codes": "75, 111",
+		"env.deploy.API_TOKEN":               "keyring:deploy-token",
+		"strict_integrity":                   "true",
+	} {
+		if err := SetSetting(file, key, value); err != nil {
+			t.Fatalf("SetSetting(%s) error = %v", key, err)
+		}
+	}
+
+	settings, err := ListSettings(file)
+	if err != nil {
+		t.Fatalf("ListSettings() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"base_dir":                           "/opt/mm",
+		"timeout":                            int64(30),
+		"registries":                         []interface{}{"https://a.example/index.json", "./index.json"},
+		"log_level":                          "debug",
+		"aliases.prs":                        "azure-devops pull-requests list-open",
+		"plugins.azure-devops.project":       "web",
+		"pins.azure-devops":                  "1.2.0",
+		"retries.deploy.retries":             int64(2),
+		"retries.deploy.backoff":             "2s",
+		"retries.ci.backoff":                 int64(5),
+		"retries.deploy.retry_on_exit_codes": []interface{}{int64(75), int64(111)},
+		"env.deploy.api_token":               "keyring:deploy-token",
+		"strict_integrity":                   true,
+	}
+	if !reflect.DeepEqual(settings, want) {
+		t.Errorf("ListSettings() = %#v, want %#v", settings, want)
+	}
+}
+
+func TestSetSetting_Invalid(t *testing.T) {
+	content := "timeout = 10\n"
+	file := writeConfigFileForTest(t, content)
+
+	tests := []struct {
+		key     string
+		value   string
+		wantErr string
+	}{
+		{"timeout", "-1", "invalid value for 'timeout'"},
+		{"timeout", "soon", "invalid value for 'timeout'"},
+		{"log_format", "xml", "invalid log_format 'xml'"},
+		{"lock_wait", "-5", "invalid value for 'lock_wait'"},
+		{"unsafe_binaries", "sometimes", "invalid unsafe_binaries 'sometimes'"},
+		{"colour", "red", "unknown setting 'colour'"},
+		{"aliases.a.b", "x", "unknown setting"},
+		{"plugins.deploy", "x", "unknown setting"},
+		{"pins.deploy", "1.2 beta", "invalid value for 'pins.deploy'"},
+		{"retries.deploy", "2", "unknown setting"},
+		{"retries.deploy.attempts", "2", "unknown setting"},
+		{"retries.deploy.retries", "-1", "invalid value for 'retries.deploy.retries'"},
+		{"retries.deploy.backoff", "soon", "invalid value for 'retries.deploy.backoff'"},
+		{"retries.deploy.retry_on_exit_codes", "75,x", "invalid value for 'retries.deploy.retry_on_exit_codes'"},
+	}
+
+	for _, tt := range tests {
+		err := SetSetting(file, tt.key, tt.value)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("SetSetting(%s, %s) error = %v, want %q", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+
+	// Invalid settings are never written
+	if data, _ := os.ReadFile(file); string(data) != content {
+		t.Errorf("config file = %q, want it unchanged", data)
+	}
+}
+
+func TestUnsetSetting(t *testing.T) {
+	file := writeConfigFileForTest(t, "timeout = 10\nlog_level = \"warn\"\n\n[aliases]\nprs = \"ado prs\"\nship = \"deploy\"\n")
+
+	if err := UnsetSetting(file, "log_level"); err != nil {
+		t.Fatalf("UnsetSetting(log_level) error = %v", err)
+	}
+	if err := UnsetSetting(file, "aliases.ship"); err != nil {
+		t.Fatalf("UnsetSetting(aliases.ship) error = %v", err)
+	}
+	if err := UnsetSetting(file, "log_level"); err == nil {
+		t.Error("UnsetSetting() of a setting that is not set error = nil, want an error")
+	}
+
+	if _, err := GetSetting(file, "log_level"); err == nil {
+		t.Error("GetSetting(log_level) error = nil, want it to be unset")
+	}
+	aliases, err := GetSetting(file, "aliases")
+	if err != nil {
+		t.Fatalf("GetSetting(aliases) error = %v", err)
+	}
+	if want := map[string]interface{}{"prs": "ado prs"}; !reflect.DeepEqual(aliases, want) {
+		t.Errorf("GetSetting(aliases) = %v, want %v", aliases, want)
+	}
+}
+
+func TestAddListItem(t *testing.T) {
+	file := writeConfigFileForTest(t, "timeout = 10\n")
+
+	for _, item := range []string{"deploy", "/opt/bin/mm-lint", "deploy"} {
+		if _, err := AddListItem(file, "disabled", item); err != nil {
+			t.Fatalf("AddListItem(%s) error = %v", item, err)
+		}
+	}
+	if removed, err := RemoveListItem(file, "disabled", "deploy"); err != nil || !removed {
+		t.Fatalf("RemoveListItem(deploy) = %v, %v, want it removed", removed, err)
+	}
+	if removed, err := RemoveListItem(file, "disabled", "deploy"); err != nil || removed {
+		t.Errorf("RemoveListItem() of a missing item = %v, %v, want nothing removed", removed, err)
+	}
+
+	disabled, err := GetSetting(file, "disabled")
+	if err != nil {
+		t.Fatalf("GetSetting(disabled) error = %v", err)
+	}
+	if want := []interface{}{"/opt/bin/mm-lint"}; !reflect.DeepEqual(disabled, want) {
+		t.Errorf("GetSetting(disabled) = %v, want %v", disabled, want)
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	if err := ValidateFile(writeConfigFileForTest(t, "timeout = 10\n")); err != nil {
+		t.Errorf("ValidateFile() of a valid file error = %v", err)
+	}
+	if err := ValidateFile(writeConfigFileForTest(t, "log_level = \"loud\"\n")); err == nil {
+		t.Error("ValidateFile() with an invalid log level error = nil, want an error")
+	}
+	if err := ValidateFile(writeConfigFileForTest(t, "timeout = \n")); err == nil {
+		t.Error("ValidateFile() of a malformed file error = nil, want an error")
+	}
+}
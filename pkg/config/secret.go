@@ -0,0 +1,191 @@
+package config
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/oscarrieken/master-mold/pkg/keyring"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// Prefixes of secret values: "keyring:<name>" is a secret stored in the system keyring, and "age:<ciphertext>"
+// a secret encrypted with age, as an armored or base64 encoded file
+const (
+	KeyringSecretPrefix = "keyring:"
+	AgeSecretPrefix     = "age:"
+)
+
+// KeyringService is the service name secrets referenced in the configuration are stored under in the system
+// keyring, with the name of the secret as account
+const KeyringService = "master-mold"
+
+// EnvAgeIdentity is the environment variable holding the path of the age identity file that decrypts secrets
+const EnvAgeIdentity = "MM_AGE_IDENTITY"
+
+// RedactedSecret replaces secrets in text shown to the user
+const RedactedSecret = "********"
+
+// Secret sources, replaced in tests
+var (
+	keyringGet = keyring.Get
+	ageDecrypt = runAgeDecrypt
+)
+
+// IsSecret checks if a value of the configuration references a secret
+func IsSecret(value string) bool {
+	return strings.HasPrefix(value, KeyringSecretPrefix) || strings.HasPrefix(value, AgeSecretPrefix)
+}
+
+// ResolveSecret gets the secret a value references, or the value itself when it is not a secret
+func ResolveSecret(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, KeyringSecretPrefix):
+		name := strings.TrimPrefix(value, KeyringSecretPrefix)
+		if name == "" {
+			return "", errors.New("missing keyring secret name")
+		}
+		secret, err := keyringGet(KeyringService, name)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to read secret '%s' from keyring", name)
+		}
+		return strings.TrimSuffix(secret, "\n"), nil
+
+	case strings.HasPrefix(value, AgeSecretPrefix):
+		ciphertext, err := ageCiphertext(strings.TrimSpace(strings.TrimPrefix(value, AgeSecretPrefix)))
+		if err != nil {
+			return "", err
+		}
+		secret, err := ageDecrypt(ciphertext)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(secret, "\n"), nil
+	}
+	return value, nil
+}
+
+// ResolveSecrets replaces the values of viper that reference secrets, including those of tables and lists, with
+// the secrets. It returns the secrets, so they can be redacted from output. The [env.<name>] tables are left
+// alone; SubcommandEnv resolves their secrets when their subcommand runs.
+func ResolveSecrets(v *viper.Viper) ([]string, error) {
+	var secrets []string
+	var changed bool
+	var resolve func(key string, value interface{}) (interface{}, error)
+	resolve = func(key string, value interface{}) (interface{}, error) {
+		switch value := value.(type) {
+		case string:
+			if !IsSecret(value) {
+				return value, nil
+			}
+			secret, err := ResolveSecret(value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid secret for '%s'", key)
+			}
+			if secret != "" {
+				secrets = append(secrets, secret)
+			}
+			changed = true
+			return secret, nil
+		case []interface{}:
+			resolved := make([]interface{}, len(value))
+			for i, item := range value {
+				var err error
+				if resolved[i], err = resolve(key, item); err != nil {
+					return nil, err
+				}
+			}
+			return resolved, nil
+		}
+		return value, nil
+	}
+
+	for _, key := range v.AllKeys() {
+		if strings.HasPrefix(key, envKey+".") {
+			continue
+		}
+		changed = false
+		resolved, err := resolve(key, v.Get(key))
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			v.Set(key, resolved)
+		}
+	}
+	return secrets, nil
+}
+
+// RedactSecrets replaces the secrets of the configuration in text, so it can be shown
+func RedactSecrets(config *Config, text string) string {
+	for _, secret := range config.secrets {
+		text = strings.ReplaceAll(text, secret, RedactedSecret)
+	}
+	return text
+}
+
+// RedactSecretValues replaces the secrets of the configuration in the strings of a decoded value, such as a table
+// of the configuration, so it can be serialized and shown. Serialized values can't be redacted, since JSON escapes
+// the quotes and backslashes of secrets.
+func RedactSecretValues(config *Config, value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		return RedactSecrets(config, value)
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			redacted[key] = RedactSecretValues(config, item)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(value))
+		for i, item := range value {
+			redacted[i] = RedactSecretValues(config, item)
+		}
+		return redacted
+	}
+	return value
+}
+
+// ageCiphertext decodes the ciphertext of an age secret, which is an armored file or a base64 encoded binary one
+func ageCiphertext(value string) ([]byte, error) {
+	if strings.HasPrefix(value, "-----BEGIN AGE ENCRYPTED FILE-----") {
+		return []byte(value + "\n"), nil
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "age secret is neither armored nor base64 encoded")
+	}
+	return ciphertext, nil
+}
+
+// ageIdentityFile gets the path of the age identity file: MM_AGE_IDENTITY, or age-identity.txt in ~/.master-mold
+func ageIdentityFile() string {
+	if path := os.Getenv(EnvAgeIdentity); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".master-mold", "age-identity.txt")
+	}
+	return filepath.Join(home, ".master-mold", "age-identity.txt")
+}
+
+// runAgeDecrypt decrypts a secret with the age command, passing the ciphertext on standard input
+func runAgeDecrypt(ciphertext []byte) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("age", "--decrypt", "--identity", ageIdentityFile())
+	cmd.Stdin = bytes.NewReader(ciphertext)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", errors.New("age not found; install age (https://age-encryption.org) to decrypt secrets")
+		}
+		return "", errors.Wrapf(err, "failed to decrypt age secret: %s", strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
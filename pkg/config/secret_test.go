@@ -0,0 +1,176 @@
+package config
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/keyring"
+)
+
+// stubSecrets replaces the keyring and age with maps of secrets for the duration of a test
+func stubSecrets(t *testing.T, stored map[string]string, encrypted map[string]string) {
+	t.Helper()
+	originalGet, originalDecrypt := keyringGet, ageDecrypt
+	t.Cleanup(func() { keyringGet, ageDecrypt = originalGet, originalDecrypt })
+
+	keyringGet = func(service, account string) (string, error) {
+		if secret, ok := stored[account]; ok && service == KeyringService {
+			return secret, nil
+		}
+		return "", keyring.ErrNotFound
+	}
+	ageDecrypt = func(ciphertext []byte) (string, error) {
+		if secret, ok := encrypted[string(ciphertext)]; ok {
+			return secret, nil
+		}
+		return "", os.ErrInvalid
+	}
+}
+
+func TestResolveSecret(t *testing.T) {
+	stubSecrets(t, map[string]string{"ado-pat": "pat-from-keyring\n"}, map[string]string{
+		"binary ciphertext": "pat-from-age\n",
+		"-----BEGIN AGE ENCRYPTED FILE-----\nabc\n-----END AGE ENCRYPTED FILE-----\n": "armored-pat",
+	})
+
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"plain", "plain", false},
+		{"keyring:ado-pat", "pat-from-keyring", false},
+		{"keyring:missing", "", true},
+		{"keyring:", "", true},
+		{"age:" + base64.StdEncoding.EncodeToString([]byte("binary ciphertext")), "pat-from-age", false},
+		{"age:\n-----BEGIN AGE ENCRYPTED FILE-----\nabc\n-----END AGE ENCRYPTED FILE-----\n", "armored-pat", false},
+		{"age:not base64!", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ResolveSecret(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ResolveSecret(%q) = %q, %v, want %q (error %v)", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestLoadConfig_Secrets(t *testing.T) {
+	stubSecrets(t, map[string]string{"ado-pat": "s3cret"}, nil)
+
+	tempDir := t.TempDir()
+	content := "timeout = 10\n\n[plugins.azure-devops]\ntoken = \"keyring:ado-pat\"\nproject = \"web\"\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "config.toml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+
+	config, err := LoadConfig([]string{tempDir}, slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if settings := config.Plugins["azure-devops"]; settings["token"] != "s3cret" || settings["project"] != "web" {
+		t.Errorf("LoadConfig().Plugins[azure-devops] = %v, want the secret resolved", settings)
+	}
+	if got := RedactSecrets(config, `{"token":"s3cret"}`); strings.Contains(got, "s3cret") {
+		t.Errorf("RedactSecrets() = %s, want the secret hidden", got)
+	}
+
+	// A secret that can't be resolved fails the load
+	stubSecrets(t, nil, nil)
+	if _, err := LoadConfig([]string{tempDir}, slog.New(slog.DiscardHandler)); err == nil || !strings.Contains(err.Error(), "plugins.azure-devops.token") {
+		t.Errorf("LoadConfig() error = %v, want it to name the setting", err)
+	}
+}
+
+func TestRedactSecretValues(t *testing.T) {
+	// JSON escapes the quote and the backslash of the secret
+	stubSecrets(t, map[string]string{"ado-pat": `s3"c\ret`}, nil)
+
+	tempDir := t.TempDir()
+	content := "timeout = 10\n\n[plugins.azure-devops]\ntoken = \"keyring:ado-pat\"\nproject = \"web\"\nretries = 3\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "config.toml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+	config, err := LoadConfig([]string{tempDir}, slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	settings := map[string]interface{}{}
+	for key, value := range config.Plugins["azure-devops"] {
+		settings[key] = value
+	}
+	settings["headers"] = []interface{}{map[string]interface{}{"authorization": `s3"c\ret`}}
+	data, err := json.Marshal(RedactSecretValues(config, settings))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if got := string(data); strings.Contains(got, "s3") || !strings.Contains(got, `"project":"web"`) || !strings.Contains(got, `"retries":3`) {
+		t.Errorf("RedactSecretValues() = %s, want the secrets hidden and the other settings kept", got)
+	}
+	if got := config.Plugins["azure-devops"]["token"]; got != `s3"c\ret` {
+		t.Errorf("RedactSecretValues() changed the configuration to %q", got)
+	}
+}
+
+func TestSubcommandEnv(t *testing.T) {
+	// The keyring is locked while the configuration loads
+	stubSecrets(t, nil, nil)
+
+	tempDir := t.TempDir()
+	content := "timeout = 10\n\n[env.deploy]\nDEPLOY_TOKEN = \"keyring:deploy-token\"\nregion = \"eu\"\n\n[env.lint]\nLINT_TOKEN = \"keyring:missing\"\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "config.toml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+	config, err := LoadConfig([]string{tempDir}, slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want the secrets of the env tables left for later", err)
+	}
+	if got := config.Env["deploy"]["deploy_token"]; got != "keyring:deploy-token" {
+		t.Errorf("LoadConfig().Env[deploy][deploy_token] = %q, want the reference", got)
+	}
+
+	stubSecrets(t, map[string]string{"deploy-token": "t0ken\n"}, nil)
+	variables, err := SubcommandEnv(config, "deploy")
+	if err != nil {
+		t.Fatalf("SubcommandEnv(deploy) error = %v", err)
+	}
+	if want := []string{"DEPLOY_TOKEN=t0ken", "REGION=eu"}; !reflect.DeepEqual(variables, want) {
+		t.Errorf("SubcommandEnv(deploy) = %q, want %q", variables, want)
+	}
+	if got := RedactSecrets(config, "--token t0ken"); strings.Contains(got, "t0ken") {
+		t.Errorf("RedactSecrets() = %s, want the resolved secret hidden", got)
+	}
+
+	if _, err := SubcommandEnv(config, "lint"); err == nil || !strings.Contains(err.Error(), "env.lint.lint_token") {
+		t.Errorf("SubcommandEnv(lint) error = %v, want it to name the variable", err)
+	}
+	if variables, err := SubcommandEnv(config, "other"); err != nil || len(variables) != 0 {
+		t.Errorf("SubcommandEnv(other) = %q, %v, want no variables", variables, err)
+	}
+}
+
+func TestRunAgeDecrypt(t *testing.T) {
+	// The fake age prints its identity file and the ciphertext it reads
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho \"$3\"\ncat\n"
+	if err := os.WriteFile(filepath.Join(dir, "age"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create age: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv(EnvAgeIdentity, "/keys/identity.txt")
+
+	got, err := runAgeDecrypt([]byte("ciphertext"))
+	if err != nil {
+		t.Fatalf("runAgeDecrypt() error = %v", err)
+	}
+	if got != "/keys/identity.txt\nciphertext" {
+		t.Errorf("runAgeDecrypt() = %q, want the identity file and the ciphertext", got)
+	}
+}
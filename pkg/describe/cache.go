@@ -0,0 +1,121 @@
+package describe
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// cacheEntry is the cached description of a binary, valid while the binary is unchanged
+type cacheEntry struct {
+	ModTime time.Time `json:"modTime"`
+	Size    int64     `json:"size"`
+	// Description is nil when the binary doesn't implement the protocol
+	Description *Description `json:"description,omitempty"`
+}
+
+// Cache caches the descriptions of plugins in a file, so plugins are only run with --mm-describe when they change
+type Cache struct {
+	path    string
+	timeout time.Duration
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	changed bool
+}
+
+// LoadCache loads the cache from a file; a missing or unreadable file gives an empty cache
+func LoadCache(path string, timeout time.Duration) *Cache {
+	cache := &Cache{
+		path:    path,
+		timeout: timeout,
+		entries: make(map[string]cacheEntry),
+	}
+
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &cache.entries)
+	}
+	return cache
+}
+
+// Describe gets the description of a binary, and reports whether the binary implements the protocol
+func (c *Cache) Describe(binaryPath string) (Description, bool) {
+	info, err := os.Stat(binaryPath)
+	if err != nil {
+		return Description{}, false
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[binaryPath]
+	c.mu.Unlock()
+	if ok && entry.ModTime.Equal(info.ModTime()) && entry.Size == info.Size() {
+		if entry.Description == nil {
+			return Description{}, false
+		}
+		return *entry.Description, true
+	}
+
+	entry = cacheEntry{ModTime: info.ModTime(), Size: info.Size()}
+	if description, err := Query(binaryPath, c.timeout); err == nil {
+		entry.Description = &description
+	}
+
+	c.mu.Lock()
+	c.entries[binaryPath] = entry
+	c.changed = true
+	c.mu.Unlock()
+
+	if entry.Description == nil {
+		return Description{}, false
+	}
+	return *entry.Description, true
+}
+
+// DescribeAll gets the descriptions of the binaries concurrently, keyed by path, for those implementing the protocol
+func (c *Cache) DescribeAll(binaryPaths []string) map[string]Description {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	descriptions := make(map[string]Description)
+
+	for _, binaryPath := range binaryPaths {
+		wg.Add(1)
+		go func(binaryPath string) {
+			defer wg.Done()
+			if description, ok := c.Describe(binaryPath); ok {
+				mu.Lock()
+				descriptions[binaryPath] = description
+				mu.Unlock()
+			}
+		}(binaryPath)
+	}
+	wg.Wait()
+
+	return descriptions
+}
+
+// Save writes the cache to its file when it changed
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.changed {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the description cache")
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return errors.Wrap(err, "failed to create the cache directory")
+	}
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return errors.Wrap(err, "failed to write the description cache")
+	}
+
+	c.changed = false
+	return nil
+}
@@ -0,0 +1,42 @@
+package describe
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// FromCobra describes a cobra command and its subcommands, leaving out hidden ones and the help commands. The root
+// command completes with cobra's hidden completion command.
+func FromCobra(cmd *cobra.Command) Description {
+	description := Description{
+		Name:        cmd.Name(),
+		Version:     cmd.Version,
+		Description: cmd.Short,
+	}
+	if !cmd.HasParent() {
+		description.Completion = cobra.ShellCompNoDescRequestCmd
+	}
+
+	addFlag := func(flag *pflag.Flag) {
+		if flag.Hidden || flag.Name == "help" {
+			return
+		}
+		description.Flags = append(description.Flags, FlagInfo{
+			Name:      flag.Name,
+			Shorthand: flag.Shorthand,
+			Type:      flag.Value.Type(),
+			Default:   flag.DefValue,
+			Usage:     flag.Usage,
+		})
+	}
+	cmd.NonInheritedFlags().VisitAll(addFlag)
+	cmd.InheritedFlags().VisitAll(addFlag)
+
+	for _, subcommand := range cmd.Commands() {
+		if subcommand.Hidden || subcommand.Name() == "help" || subcommand.Name() == "completion" {
+			continue
+		}
+		description.Subcommands = append(description.Subcommands, FromCobra(subcommand))
+	}
+	return description
+}
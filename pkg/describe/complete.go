@@ -0,0 +1,60 @@
+package describe
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/pkg/errors"
+)
+
+// directiveError is the completion directive of cobra telling the completion failed
+const directiveError = 1
+
+// Complete runs the completion command of a plugin with the words typed after the plugin name, the last one being
+// the word to complete, and parses the completions it prints. The completion command follows cobra's protocol: it
+// prints one completion per line, optionally followed by a tab and a description, then a ":<directive>" line.
+// The variables are added to the environment of the plugin.
+func Complete(path string, command string, words []string, timeout time.Duration, variables []string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout bytes.Buffer
+	cmd, err := binary.CommandContext(ctx, path, append([]string{command}, words...))
+	if err != nil {
+		return nil, err
+	}
+	cmd.Env = append(os.Environ(), variables...)
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "failed to complete %s", path)
+	}
+
+	return ParseCompletions(stdout.Bytes())
+}
+
+// ParseCompletions parses the output of a completion command, without the descriptions of the completions
+func ParseCompletions(output []byte) ([]string, error) {
+	var completions []string
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if directive, ok := strings.CutPrefix(line, ":"); ok {
+			if value, err := strconv.Atoi(directive); err == nil && value&directiveError != 0 {
+				return nil, errors.New("the completion failed")
+			}
+			return completions, nil
+		}
+
+		completion, _, _ := strings.Cut(line, "\t")
+		if completion = strings.TrimSpace(completion); completion != "" {
+			completions = append(completions, completion)
+		}
+	}
+	return nil, errors.New("no completion directive in the output")
+}
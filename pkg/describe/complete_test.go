@@ -0,0 +1,50 @@
+package describe
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseCompletions(t *testing.T) {
+	tests := []struct {
+		output  string
+		want    []string
+		wantErr bool
+	}{
+		{"prod\tProduction\nstaging\n:4\n", []string{"prod", "staging"}, false},
+		{":4\n", nil, false},
+		{"prod\n:1\n", nil, true},
+		{"Error: unknown command\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseCompletions([]byte(tt.output))
+		if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseCompletions(%q) = %q, %v, want %q (error %v)", tt.output, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestComplete(t *testing.T) {
+	// The fake plugin completes with its arguments and the environment it was given
+	path := filepath.Join(t.TempDir(), "mm-deploy")
+	script := "#!/bin/sh\n[ \"$1\" = \"__complete\" ] || exit 1\nshift\necho \"$*\"\necho \"$MM_TEST_VALUE\"\necho :0\n"
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", path, err)
+	}
+
+	got, err := Complete(path, "__complete", []string{"--env", ""}, 5*time.Second, []string{"MM_TEST_VALUE=web"})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if want := []string{"--env", "web"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Complete() = %q, want %q", got, want)
+	}
+
+	if _, err := Complete(path, "__other", nil, 5*time.Second, nil); err == nil {
+		t.Error("Complete() with a failing plugin error = nil, want an error")
+	}
+}
@@ -0,0 +1,143 @@
+// Package describe implements the describe protocol between master-mold and its plugins. A plugin run with
+// --mm-describe prints a JSON description of itself (name, version, description, flags, subcommands and environment
+// variables), which master-mold uses to enrich list-binaries, help, completion and env.
+//
+// A plugin implements the protocol at the start of main:
+//
+//	if describe.Handle(os.Args[1:], describe.Description{Name: "deploy", Description: "Deploy services"}) {
+//		return
+//	}
+//
+// Plugins built with cobra can describe their command tree with FromCobra, which also delegates the completion of
+// their flags and arguments to cobra.
+package describe
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/pkg/errors"
+)
+
+// Flag is the flag that asks a plugin for its description
+const Flag = "--mm-describe"
+
+// Description describes a plugin or one of its subcommands
+type Description struct {
+	Name        string        `json:"name"`
+	Version     string        `json:"version,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Flags       []FlagInfo    `json:"flags,omitempty"`
+	Subcommands []Description `json:"subcommands,omitempty"`
+	// Completion is the hidden command completing the command lines of the plugin, such as cobra's
+	// "__completeNoDesc"; see Complete. It is only set on the plugin itself.
+	Completion string `json:"completion,omitempty"`
+	// Env are the environment variables the plugin reads. It is only set on the plugin itself.
+	Env []EnvVar `json:"env,omitempty"`
+}
+
+// EnvVar describes an environment variable a plugin reads
+type EnvVar struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Required is set when the plugin can't run without the variable
+	Required bool `json:"required,omitempty"`
+}
+
+// FlagInfo describes a flag of a plugin or subcommand
+type FlagInfo struct {
+	Name      string `json:"name"`
+	Shorthand string `json:"shorthand,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Default   string `json:"default,omitempty"`
+	Usage     string `json:"usage,omitempty"`
+}
+
+// Requested checks if the arguments ask for the description
+func Requested(args []string) bool {
+	return len(args) == 1 && args[0] == Flag
+}
+
+// Handle prints the description to stdout when the arguments ask for it, and reports whether it did
+func Handle(args []string, description Description) bool {
+	return HandleWithOutput(args, description, os.Stdout)
+}
+
+// HandleWithOutput prints the description to the output when the arguments ask for it, and reports whether it did
+func HandleWithOutput(args []string, description Description, output io.Writer) bool {
+	if !Requested(args) {
+		return false
+	}
+
+	// The description is printed on a single line, so it can be told apart from logs
+	data, err := json.Marshal(description)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to describe %s: %v\n", description.Name, err)
+		return true
+	}
+	fmt.Fprintln(output, string(data))
+	return true
+}
+
+// Find finds the subcommand at the path of subcommand names, such as ["pull-requests", "list-open"]
+func (d Description) Find(path []string) (Description, bool) {
+	current := d
+	for _, name := range path {
+		found := false
+		for _, subcommand := range current.Subcommands {
+			if subcommand.Name == name {
+				current, found = subcommand, true
+				break
+			}
+		}
+		if !found {
+			return Description{}, false
+		}
+	}
+	return current, true
+}
+
+// Query runs a plugin with --mm-describe and parses its description
+func Query(path string, timeout time.Duration) (Description, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout bytes.Buffer
+	cmd, err := binary.CommandContext(ctx, path, []string{Flag})
+	if err != nil {
+		return Description{}, err
+	}
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return Description{}, errors.Wrapf(err, "failed to describe %s", path)
+	}
+
+	return Parse(stdout.Bytes())
+}
+
+// Parse parses the output of a plugin run with --mm-describe, skipping the lines that aren't the description
+func Parse(output []byte) (Description, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+
+		var description Description
+		if err := json.Unmarshal([]byte(line), &description); err != nil || description.Name == "" {
+			continue
+		}
+		return description, nil
+	}
+	return Description{}, errors.New("no description in the output")
+}
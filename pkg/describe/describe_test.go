@@ -0,0 +1,120 @@
+package describe
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestHandleWithOutput(t *testing.T) {
+	description := Description{Name: "deploy", Version: "1.0.0", Description: "Deploy services"}
+
+	var output bytes.Buffer
+	if HandleWithOutput([]string{"status"}, description, &output) || output.Len() > 0 {
+		t.Error("HandleWithOutput() handled arguments without --mm-describe")
+	}
+
+	if !HandleWithOutput([]string{Flag}, description, &output) {
+		t.Fatal("HandleWithOutput() did not handle --mm-describe")
+	}
+	if want := `{"name":"deploy","version":"1.0.0","description":"Deploy services"}` + "\n"; output.String() != want {
+		t.Errorf("HandleWithOutput() output = %q, want %q", output.String(), want)
+	}
+}
+
+func TestParse(t *testing.T) {
+	output := "time=2024-05-01T12:00:00Z level=INFO msg=Starting\n{\"name\":\"deploy\",\"subcommands\":[{\"name\":\"status\"}]}\ntime=2024-05-01T12:00:01Z level=INFO msg=Done\n"
+	description, err := Parse([]byte(output))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := Description{Name: "deploy", Subcommands: []Description{{Name: "status"}}}
+	if !reflect.DeepEqual(description, want) {
+		t.Errorf("Parse() = %+v, want %+v", description, want)
+	}
+
+	if _, err := Parse([]byte("Usage: deploy [options]\n")); err == nil {
+		t.Error("Parse() error = nil, want no description")
+	}
+}
+
+func TestFromCobra(t *testing.T) {
+	root := &cobra.Command{Use: "azure-devops", Short: "Manage Azure DevOps", Version: "1.2.0"}
+	root.PersistentFlags().String("org", "", "Organization")
+	pullRequests := &cobra.Command{Use: "pull-requests", Short: "Manage pull requests"}
+	listOpen := &cobra.Command{Use: "list-open", Short: "List open pull requests", Run: func(*cobra.Command, []string) {}}
+	listOpen.Flags().BoolP("json", "j", false, "Output JSON")
+	hidden := &cobra.Command{Use: "secret", Hidden: true, Run: func(*cobra.Command, []string) {}}
+	pullRequests.AddCommand(listOpen)
+	root.AddCommand(pullRequests, hidden)
+
+	description := FromCobra(root)
+	if description.Name != "azure-devops" || description.Version != "1.2.0" || description.Description != "Manage Azure DevOps" {
+		t.Errorf("FromCobra() = %+v, want the root command", description)
+	}
+	if len(description.Subcommands) != 1 {
+		t.Fatalf("FromCobra() subcommands = %+v, want pull-requests only", description.Subcommands)
+	}
+	if description.Completion != cobra.ShellCompNoDescRequestCmd || description.Subcommands[0].Completion != "" {
+		t.Errorf("FromCobra() completion = %q, want cobra's completion command on the root only", description.Completion)
+	}
+
+	subcommand, ok := description.Find([]string{"pull-requests", "list-open"})
+	if !ok {
+		t.Fatal("Find(pull-requests list-open) found nothing")
+	}
+	want := []FlagInfo{
+		{Name: "json", Shorthand: "j", Type: "bool", Default: "false", Usage: "Output JSON"},
+		{Name: "org", Type: "string", Usage: "Organization"},
+	}
+	if !reflect.DeepEqual(subcommand.Flags, want) {
+		t.Errorf("FromCobra() list-open flags = %+v, want %+v", subcommand.Flags, want)
+	}
+
+	if _, ok := description.Find([]string{"pull-requests", "missing"}); ok {
+		t.Error("Find(pull-requests missing) found a subcommand")
+	}
+}
+
+func TestCache(t *testing.T) {
+	tempDir := t.TempDir()
+	counter := filepath.Join(tempDir, "count")
+	described := filepath.Join(tempDir, "mm-deploy")
+	script := "#!/bin/sh\necho run >> " + counter + "\necho '{\"name\":\"deploy\",\"description\":\"Deploy services\"}'\n"
+	if err := os.WriteFile(described, []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", described, err)
+	}
+	unsupported := filepath.Join(tempDir, "mm-old")
+	if err := os.WriteFile(unsupported, []byte("#!/bin/sh\nexit 1\n"), 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", unsupported, err)
+	}
+
+	cachePath := filepath.Join(tempDir, "cache", "describe.json")
+	cache := LoadCache(cachePath, 5*time.Second)
+	descriptions := cache.DescribeAll([]string{described, unsupported})
+	if len(descriptions) != 1 || descriptions[described].Description != "Deploy services" {
+		t.Errorf("DescribeAll() = %+v, want the description of mm-deploy only", descriptions)
+	}
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// The saved cache answers without running the binary again
+	cache = LoadCache(cachePath, 5*time.Second)
+	if description, ok := cache.Describe(described); !ok || description.Name != "deploy" {
+		t.Errorf("Describe() = %+v, %v, want the cached description", description, ok)
+	}
+	if _, ok := cache.Describe(unsupported); ok {
+		t.Error("Describe() of a binary without the protocol = true")
+	}
+	runs, _ := os.ReadFile(counter)
+	if got := strings.Count(string(runs), "run"); got != 1 {
+		t.Errorf("mm-deploy ran %d times, want 1", got)
+	}
+}
@@ -1,45 +1,119 @@
 package display
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/oscarrieken/master-mold/pkg/binary"
 )
 
 // BinaryInfo represents information about a binary
 type BinaryInfo struct {
-	Name     string
-	FullPath string
+	Name     string `json:"name"`
+	FullPath string `json:"path"`
+	// Source is where the binary was found, the base directory or the PATH, and Dir the directory it was found in,
+	// the base directory or the entry of the PATH
+	Source binary.Source `json:"source"`
+	Dir    string        `json:"dir"`
+	// Version and Description are those the binary gives of itself with the describe protocol, if any
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	// Shadowed are the paths of other binaries of the same command, which never run because this one wins
+	Shadowed []string `json:"shadowed,omitempty"`
+	// Problem is why the binary can't run, such as a script whose interpreter isn't installed
+	Problem string `json:"problem,omitempty"`
+	// Namespace is the first word of the name when other binaries share it, such as "ado" for ado-prs and
+	// ado-workitems
+	Namespace string `json:"namespace,omitempty"`
 }
 
-// FormatBinaryInfo formats binary information for display
+// FormatBinaryInfo formats binary information for display, with warning lines when it shadows other binaries or
+// can't run.
+// Binaries in a namespace are indented under it and named without it.
 func FormatBinaryInfo(info BinaryInfo) string {
-	return fmt.Sprintf("  - %s (%s)", info.Name, info.FullPath)
+	indent, name := "  ", info.Name
+	if info.Namespace != "" {
+		indent = "    "
+		if rest, ok := strings.CutPrefix(info.Name, info.Namespace+"-"); ok {
+			name = rest
+		}
+	}
+
+	location := info.FullPath
+	if info.Source != "" {
+		location += ", " + string(info.Source)
+	}
+	line := fmt.Sprintf("%s- %s (%s)", indent, name, location)
+	if info.Description != "" {
+		line += ": " + info.Description
+	}
+	if len(info.Shadowed) > 0 {
+		line += fmt.Sprintf("\n%s    warning: shadows %s", indent, strings.Join(info.Shadowed, ", "))
+	}
+	if info.Problem != "" {
+		line += fmt.Sprintf("\n%s    warning: %s", indent, info.Problem)
+	}
+	return line
+}
+
+// GroupNamespaces sets the namespace of the binaries whose name starts with the same word as another binary's, up
+// to the first dash, so ado-prs and ado-workitems are listed together under ado
+func GroupNamespaces(binaries []BinaryInfo) []BinaryInfo {
+	counts := make(map[string]int)
+	for _, info := range binaries {
+		counts[namespaceOf(info.Name)]++
+	}
+	for i, info := range binaries {
+		if namespace := namespaceOf(info.Name); counts[namespace] > 1 {
+			binaries[i].Namespace = namespace
+		}
+	}
+	return binaries
+}
+
+// namespaceOf gets the first word of a command name, up to the first dash
+func namespaceOf(name string) string {
+	namespace, _, _ := strings.Cut(name, "-")
+	return namespace
 }
 
-// ProcessBinaries processes a list of binary paths and returns unique binary information
+// ProcessBinaries processes a list of binary paths, in order of precedence, and returns unique binary information
+// with the directory each was found in. Later binaries of a command are recorded as shadowed by the first.
 func ProcessBinaries(binaryPaths []string) []BinaryInfo {
 	var result []BinaryInfo
-	seenCommands := make(map[string]bool)
+	seenCommands := make(map[string]int)
 
 	for _, binaryPath := range binaryPaths {
 		commandName := binary.ExtractCommandName(binaryPath)
 
-		// Skip if we've already seen this command
-		if seenCommands[commandName] {
+		// Record the binary as shadowed if we've already seen this command
+		if i, ok := seenCommands[commandName]; ok {
+			result[i].Shadowed = append(result[i].Shadowed, binaryPath)
 			continue
 		}
 
-		seenCommands[commandName] = true
+		seenCommands[commandName] = len(result)
 		result = append(result, BinaryInfo{
 			Name:     commandName,
 			FullPath: binaryPath,
+			Dir:      filepath.Dir(binaryPath),
 		})
 	}
 
 	return result
 }
 
+// SetSources sets where each binary was found, the base directory or the PATH, which is shown in the listing
+func SetSources(binaries []BinaryInfo, baseDir string) []BinaryInfo {
+	for i, info := range binaries {
+		binaries[i].Source = binary.SourceOf(info.FullPath, baseDir)
+	}
+	return binaries
+}
+
 // PrintBinaries prints a list of binaries to stdout
 func PrintBinaries(binaries []BinaryInfo) {
 	if len(binaries) == 0 {
@@ -48,11 +122,38 @@ func PrintBinaries(binaries []BinaryInfo) {
 	}
 
 	fmt.Println("Available subcommands:")
+	printed := make(map[string]bool)
 	for _, info := range binaries {
-		fmt.Println(FormatBinaryInfo(info))
+		if info.Namespace == "" {
+			fmt.Println(FormatBinaryInfo(info))
+			continue
+		}
+		if printed[info.Namespace] {
+			continue
+		}
+
+		// The binaries of a namespace are printed together, where the first of them is
+		printed[info.Namespace] = true
+		fmt.Printf("  %s:\n", info.Namespace)
+		for _, member := range binaries {
+			if member.Namespace == info.Namespace {
+				fmt.Println(FormatBinaryInfo(member))
+			}
+		}
 	}
 }
 
+// PrintBinariesJSON prints a list of binaries to stdout in JSON format
+func PrintBinariesJSON(binaries []BinaryInfo) error {
+	if binaries == nil {
+		binaries = []BinaryInfo{}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(binaries)
+}
+
 // PrintBinaryPaths prints a list of binary paths to stdout
 func PrintBinaryPaths(binaryPaths []string) {
 	binaries := ProcessBinaries(binaryPaths)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -40,6 +41,43 @@ func TestFormatBinaryInfo(t *testing.T) {
 			},
 			want: "  - test ()",
 		},
+		{
+			name: "with description",
+			info: BinaryInfo{
+				Name:        "test",
+				FullPath:    "/usr/bin/mm-test",
+				Description: "Run the tests",
+			},
+			want: "  - test (/usr/bin/mm-test): Run the tests",
+		},
+		{
+			name: "shadowing other binaries",
+			info: BinaryInfo{
+				Name:     "test",
+				FullPath: "/usr/bin/mm-test",
+				Shadowed: []string{"/opt/bin/mm-test", "/home/user/.master-mold/master-mold-test"},
+			},
+			want: "  - test (/usr/bin/mm-test)\n      warning: shadows /opt/bin/mm-test, /home/user/.master-mold/master-mold-test",
+		},
+		{
+			name: "with source",
+			info: BinaryInfo{
+				Name:     "test",
+				FullPath: "/home/user/.master-mold/mm-test",
+				Source:   binary.SourceBaseDir,
+				Shadowed: []string{"/usr/bin/mm-test"},
+			},
+			want: "  - test (/home/user/.master-mold/mm-test, base directory)\n      warning: shadows /usr/bin/mm-test",
+		},
+		{
+			name: "script that can't run",
+			info: BinaryInfo{
+				Name:     "test",
+				FullPath: "/usr/bin/mm-test",
+				Problem:  "interpreter 'python9' not found in the PATH",
+			},
+			want: "  - test (/usr/bin/mm-test)\n      warning: interpreter 'python9' not found in the PATH",
+		},
 	}
 
 	for _, tt := range tests {
@@ -114,11 +152,42 @@ func TestProcessBinaries(t *testing.T) {
 				if got[i].FullPath != expectedPath {
 					t.Errorf("ProcessBinaries()[%d].FullPath = %v, want %v", i, got[i].FullPath, expectedPath)
 				}
+				if got[i].Dir != filepath.Dir(expectedPath) {
+					t.Errorf("ProcessBinaries()[%d].Dir = %v, want %v", i, got[i].Dir, filepath.Dir(expectedPath))
+				}
+
+				// The later binaries of the command are shadowed by the first
+				var wantShadowed []string
+				for _, path := range tt.binaryPaths {
+					if path != expectedPath && binary.ExtractCommandName(path) == wantName {
+						wantShadowed = append(wantShadowed, path)
+					}
+				}
+				if strings.Join(got[i].Shadowed, ",") != strings.Join(wantShadowed, ",") {
+					t.Errorf("ProcessBinaries()[%d].Shadowed = %v, want %v", i, got[i].Shadowed, wantShadowed)
+				}
 			}
 		})
 	}
 }
 
+func TestGroupNamespaces(t *testing.T) {
+	binaries := GroupNamespaces([]BinaryInfo{
+		{Name: "ado-prs"},
+		{Name: "ado-workitems"},
+		{Name: "ado"},
+		{Name: "azure-devops"},
+		{Name: "deploy"},
+	})
+
+	want := []string{"ado", "ado", "ado", "", ""}
+	for i, info := range binaries {
+		if info.Namespace != want[i] {
+			t.Errorf("GroupNamespaces() namespace of %s = %q, want %q", info.Name, info.Namespace, want[i])
+		}
+	}
+}
+
 func TestPrintBinaries(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -133,6 +202,15 @@ func TestPrintBinaries(t *testing.T) {
 			},
 			want: "Available subcommands:\n  - test1 (/usr/bin/mm-test1)\n  - test2 (/usr/bin/mm-test2)\n",
 		},
+		{
+			name: "namespaced binaries",
+			binaries: []BinaryInfo{
+				{Name: "ado-prs", FullPath: "/usr/bin/mm-ado-prs", Namespace: "ado"},
+				{Name: "deploy", FullPath: "/usr/bin/mm-deploy"},
+				{Name: "ado", FullPath: "/usr/bin/mm-ado", Namespace: "ado"},
+			},
+			want: "Available subcommands:\n  ado:\n    - prs (/usr/bin/mm-ado-prs)\n    - ado (/usr/bin/mm-ado)\n  - deploy (/usr/bin/mm-deploy)\n",
+		},
 		{
 			name:     "no binaries",
 			binaries: []BinaryInfo{},
@@ -210,3 +288,63 @@ func TestPrintBinaryPaths(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintBinariesJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		binaries []BinaryInfo
+		want     string
+	}{
+		{
+			name:     "one binary",
+			binaries: []BinaryInfo{{Name: "test1", FullPath: "/usr/bin/mm-test1", Source: binary.SourcePath, Dir: "/usr/bin", Version: "1.0.0"}},
+			want:     "[\n  {\n    \"name\": \"test1\",\n    \"path\": \"/usr/bin/mm-test1\",\n    \"source\": \"PATH\",\n    \"dir\": \"/usr/bin\",\n    \"version\": \"1.0.0\",\n    \"description\": \"\"\n  }\n]\n",
+		},
+		{
+			name:     "no binaries",
+			binaries: nil,
+			want:     "[]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Redirect stdout to capture output
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := PrintBinariesJSON(tt.binaries)
+
+			// Restore stdout
+			w.Close()
+			os.Stdout = oldStdout
+
+			// Read the captured output
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+
+			if err != nil {
+				t.Fatalf("PrintBinariesJSON() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("PrintBinariesJSON() output = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetSources(t *testing.T) {
+	binaries := SetSources([]BinaryInfo{
+		{Name: "test1", FullPath: "/home/user/.master-mold/mm-test1"},
+		{Name: "test2", FullPath: "/usr/bin/mm-test2"},
+		{Name: "test3", FullPath: "/home/user/.master-mold/bin/mm-test3"},
+	}, "/home/user/.master-mold/")
+
+	want := []binary.Source{binary.SourceBaseDir, binary.SourcePath, binary.SourcePath}
+	for i, info := range binaries {
+		if info.Source != want[i] {
+			t.Errorf("SetSources()[%d].Source = %q, want %q", i, info.Source, want[i])
+		}
+	}
+}
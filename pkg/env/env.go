@@ -0,0 +1,127 @@
+// Package env defines the environment variables through which master-mold passes its global flags
+// to subcommands, and helpers for subcommands to read them.
+package env
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Environment variables set by master-mold for its global flags
+const (
+	// Output is the output format requested with --json; it is "json" or empty
+	Output = "MM_OUTPUT"
+	// Verbose is set with --verbose to ask for debug logs
+	Verbose = "MM_VERBOSE"
+	// Quiet is set with --quiet to ask for errors only
+	Quiet = "MM_QUIET"
+	// NoColor is set with --no-color to ask for output without colors
+	NoColor = "MM_NO_COLOR"
+	// LogLevel is the minimum level of logs: debug, info, warn or error
+	LogLevel = "MM_LOG_LEVEL"
+	// LogFormat is the format of logs: text or json
+	LogFormat = "MM_LOG_FORMAT"
+	// PluginConfig is the [plugins.<name>] table of the configuration of the subcommand, as a JSON object
+	PluginConfig = "MM_PLUGIN_CONFIG"
+	// ParentPID is the process ID of the master-mold running the subcommand
+	ParentPID = "MM_PARENT_PID"
+	// BaseDir is the expanded base directory of master-mold, where subcommands keep their caches
+	BaseDir = "MM_BASE_DIR"
+)
+
+// OutputJSON is the value of Output when JSON output is requested
+const OutputJSON = "json"
+
+// overrides are the variables given to a subcommand running in the process of master-mold, which read as if they
+// were in its environment
+var overrides map[string]string
+
+// Override gives variables, as KEY=VALUE, to a subcommand running in the process of master-mold without changing
+// the environment of master-mold, until the returned function is called
+func Override(variables []string) func() {
+	previous := overrides
+	overrides = make(map[string]string, len(previous)+len(variables))
+	for key, value := range previous {
+		overrides[key] = value
+	}
+	for _, variable := range variables {
+		key, value, _ := strings.Cut(variable, "=")
+		overrides[key] = value
+	}
+	return func() { overrides = previous }
+}
+
+// Getenv gets an environment variable, or the value it was given with Override
+func Getenv(name string) string {
+	if value, ok := overrides[name]; ok {
+		return value
+	}
+	return os.Getenv(name)
+}
+
+// Environ gets the environment with the variables given with Override, for the processes a subcommand starts
+func Environ() []string {
+	environ := os.Environ()
+	for key, value := range overrides {
+		environ = append(environ, key+"="+value)
+	}
+	return environ
+}
+
+// Enabled checks if a boolean environment variable is set to a true value
+func Enabled(name string) bool {
+	switch strings.ToLower(Getenv(name)) {
+	case "", "0", "false", "no":
+		return false
+	}
+	return true
+}
+
+// JSONOutput checks if JSON output is requested
+func JSONOutput() bool {
+	return Getenv(Output) == OutputJSON
+}
+
+// ColorDisabled checks if output without colors is requested, with --no-color or the NO_COLOR convention
+func ColorDisabled() bool {
+	return Enabled(NoColor) || Getenv("NO_COLOR") != ""
+}
+
+// ResolveLogLevel returns the log level requested with --verbose, --quiet or MM_LOG_LEVEL, in that order,
+// or the given level when none is set
+func ResolveLogLevel(level slog.Level) slog.Level {
+	switch {
+	case Enabled(Quiet):
+		return slog.LevelError
+	case Enabled(Verbose):
+		return slog.LevelDebug
+	}
+
+	var requested slog.Level
+	if err := requested.UnmarshalText([]byte(Getenv(LogLevel))); err == nil {
+		return requested
+	}
+	return level
+}
+
+// JSONLogs checks if logs in JSON format are requested
+func JSONLogs() bool {
+	return strings.EqualFold(Getenv(LogFormat), "json")
+}
+
+// DecodePluginConfig decodes the configuration master-mold passes to the subcommand into v, which is usually a
+// pointer to a struct with json tags; keys are lowercase. It reports whether there is a configuration.
+func DecodePluginConfig(v interface{}) (bool, error) {
+	value := Getenv(PluginConfig)
+	if value == "" {
+		return false, nil
+	}
+	if err := json.Unmarshal([]byte(value), v); err != nil {
+		return false, errors.Wrapf(err, "invalid %s", PluginConfig)
+	}
+	return true, nil
+}
@@ -0,0 +1,142 @@
+package env
+
+import (
+	"log/slog"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"", false},
+		{"0", false},
+		{"false", false},
+		{"FALSE", false},
+		{"1", true},
+		{"true", true},
+		{"yes", true},
+	}
+
+	for _, tt := range tests {
+		t.Setenv(Verbose, tt.value)
+		if got := Enabled(Verbose); got != tt.want {
+			t.Errorf("Enabled(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestResolveLogLevel(t *testing.T) {
+	t.Setenv(Verbose, "")
+	t.Setenv(Quiet, "")
+	t.Setenv(LogLevel, "")
+	if got := ResolveLogLevel(slog.LevelInfo); got != slog.LevelInfo {
+		t.Errorf("ResolveLogLevel() = %s, want INFO", got)
+	}
+
+	t.Setenv(LogLevel, "warn")
+	if got := ResolveLogLevel(slog.LevelInfo); got != slog.LevelWarn {
+		t.Errorf("ResolveLogLevel() with %s = %s, want WARN", LogLevel, got)
+	}
+
+	// An invalid level is ignored
+	t.Setenv(LogLevel, "chatty")
+	if got := ResolveLogLevel(slog.LevelInfo); got != slog.LevelInfo {
+		t.Errorf("ResolveLogLevel() with an invalid %s = %s, want INFO", LogLevel, got)
+	}
+
+	// The flags win over the level
+	t.Setenv(LogLevel, "warn")
+	t.Setenv(Verbose, "1")
+	if got := ResolveLogLevel(slog.LevelInfo); got != slog.LevelDebug {
+		t.Errorf("ResolveLogLevel() with %s = %s, want DEBUG", Verbose, got)
+	}
+
+	// Quiet wins over verbose
+	t.Setenv(Quiet, "1")
+	if got := ResolveLogLevel(slog.LevelInfo); got != slog.LevelError {
+		t.Errorf("ResolveLogLevel() with %s = %s, want ERROR", Quiet, got)
+	}
+}
+
+func TestJSONLogs(t *testing.T) {
+	t.Setenv(LogFormat, "")
+	if JSONLogs() {
+		t.Error("JSONLogs() = true without the variable")
+	}
+
+	t.Setenv(LogFormat, "JSON")
+	if !JSONLogs() {
+		t.Errorf("JSONLogs() = false with %s=JSON", LogFormat)
+	}
+}
+
+func TestJSONOutputAndColorDisabled(t *testing.T) {
+	t.Setenv(Output, "")
+	t.Setenv(NoColor, "")
+	t.Setenv("NO_COLOR", "")
+	if JSONOutput() || ColorDisabled() {
+		t.Error("JSONOutput() or ColorDisabled() = true without the variables")
+	}
+
+	t.Setenv(Output, OutputJSON)
+	t.Setenv("NO_COLOR", "1")
+	if !JSONOutput() || !ColorDisabled() {
+		t.Error("JSONOutput() or ColorDisabled() = false with the variables")
+	}
+}
+
+func TestDecodePluginConfig(t *testing.T) {
+	var settings struct {
+		Project string `json:"project"`
+		Retries int    `json:"retries"`
+	}
+
+	t.Setenv(PluginConfig, "")
+	if found, err := DecodePluginConfig(&settings); found || err != nil {
+		t.Errorf("DecodePluginConfig() without a configuration = %v, %v, want false, nil", found, err)
+	}
+
+	t.Setenv(PluginConfig, `{"project":"web","retries":3}`)
+	if found, err := DecodePluginConfig(&settings); !found || err != nil {
+		t.Fatalf("DecodePluginConfig() = %v, %v, want true, nil", found, err)
+	}
+	if settings.Project != "web" || settings.Retries != 3 {
+		t.Errorf("DecodePluginConfig() decoded %+v, want project web and 3 retries", settings)
+	}
+
+	t.Setenv(PluginConfig, "{")
+	if _, err := DecodePluginConfig(&settings); err == nil {
+		t.Error("DecodePluginConfig() of invalid JSON error = nil, want an error")
+	}
+}
+
+func TestOverride(t *testing.T) {
+	t.Setenv(PluginConfig, "")
+	t.Setenv(Output, OutputJSON)
+
+	restore := Override([]string{PluginConfig + `={"region":"eu"}`, "DEPLOY_TOKEN=a=b"})
+	if got := Getenv(PluginConfig); got != `{"region":"eu"}` {
+		t.Errorf("Getenv(%s) = %q, want the override", PluginConfig, got)
+	}
+	if got := Getenv("DEPLOY_TOKEN"); got != "a=b" {
+		t.Errorf("Getenv(DEPLOY_TOKEN) = %q, want a=b", got)
+	}
+	if !JSONOutput() {
+		t.Error("JSONOutput() = false, want the environment read without an override")
+	}
+	if got := os.Getenv(PluginConfig); got != "" {
+		t.Errorf("os.Getenv(%s) = %q, want the environment unchanged", PluginConfig, got)
+	}
+	if environ := Environ(); !slices.Contains(environ, "DEPLOY_TOKEN=a=b") {
+		t.Errorf("Environ() = %v, want the overrides", environ)
+	}
+
+	restore()
+	if got := Getenv("DEPLOY_TOKEN"); got != "" {
+		t.Errorf("Getenv(DEPLOY_TOKEN) after restoring = %q, want it unset", got)
+	}
+}
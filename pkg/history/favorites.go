@@ -0,0 +1,55 @@
+package history
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// Favorite is a command line kept for quick reuse
+type Favorite struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+}
+
+// Line gets the command line of the favorite, quoting the arguments that need it
+func (f Favorite) Line() string {
+	return CommandLine(f.Command, f.Args)
+}
+
+// LoadFavorites reads the favorites of a file, in the order they were added. A missing file has no favorites.
+func LoadFavorites(path string) ([]Favorite, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the favorites")
+	}
+
+	var favorites []Favorite
+	if err := json.Unmarshal(data, &favorites); err != nil {
+		return nil, errors.Wrapf(err, "invalid favorites file %s", path)
+	}
+	return favorites, nil
+}
+
+// SaveFavorites writes the favorites to a file, creating its directory when needed
+func SaveFavorites(path string, favorites []Favorite) error {
+	if favorites == nil {
+		favorites = []Favorite{}
+	}
+	data, err := json.MarshalIndent(favorites, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the favorites")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "failed to create the favorites directory")
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return errors.Wrap(err, "failed to write the favorites")
+	}
+	return nil
+}
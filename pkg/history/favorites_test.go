@@ -0,0 +1,36 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSaveLoadFavorites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mm", "favorites.json")
+
+	favorites, err := LoadFavorites(path)
+	if err != nil || favorites != nil {
+		t.Fatalf("LoadFavorites() of a missing file = %v, %v, want no favorites", favorites, err)
+	}
+
+	want := []Favorite{{Command: "deploy", Args: []string{"--env", "prod"}}, {Command: "prs", Args: []string{}}}
+	if err := SaveFavorites(path, want); err != nil {
+		t.Fatalf("SaveFavorites() error = %v", err)
+	}
+	favorites, err = LoadFavorites(path)
+	if err != nil {
+		t.Fatalf("LoadFavorites() error = %v", err)
+	}
+	if !reflect.DeepEqual(favorites, want) {
+		t.Errorf("LoadFavorites() = %+v, want %+v", favorites, want)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0600); err != nil {
+		t.Fatalf("Failed to write favorites: %v", err)
+	}
+	if _, err := LoadFavorites(path); err == nil {
+		t.Error("LoadFavorites() of an invalid file error = nil, want an error")
+	}
+}
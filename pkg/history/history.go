@@ -0,0 +1,154 @@
+// Package history records the commands master-mold dispatches in a file, one JSON object per line, so they can be
+// searched and run again.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxSize is the size past which the oldest half of the history is dropped
+const maxSize = 1 << 20
+
+// Entry is a dispatched command
+type Entry struct {
+	// Command is the command as typed, before aliases are expanded
+	Command string    `json:"command"`
+	Args    []string  `json:"args"`
+	Time    time.Time `json:"time"`
+	// DurationMS is how long the command ran, in milliseconds
+	DurationMS int64 `json:"duration_ms"`
+	ExitCode   int   `json:"exit_code"`
+}
+
+// Line gets the command line of the entry, quoting the arguments that need it
+func (e Entry) Line() string {
+	return CommandLine(e.Command, e.Args)
+}
+
+// Duration gets how long the command ran
+func (e Entry) Duration() time.Duration {
+	return time.Duration(e.DurationMS) * time.Millisecond
+}
+
+// Append adds an entry at the end of a history file, creating it and its directory when needed. When the file grows
+// past 1 MiB, the oldest half of it is dropped.
+func Append(path string, entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the history entry")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "failed to create the history directory")
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the history")
+	}
+	_, err = file.Write(append(data, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to write the history")
+	}
+
+	if info, err := os.Stat(path); err == nil && info.Size() > maxSize {
+		return truncate(path)
+	}
+	return nil
+}
+
+// Load reads the entries of a history file, oldest first. A missing file is an empty history, and lines that can't
+// be read are skipped.
+func Load(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the history")
+	}
+
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSize)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil && entry.Command != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// Recent is a distinct command line of the history
+type Recent struct {
+	// Number is the number of the last run of the command line in the history, counted from 1
+	Number int `json:"number"`
+	Entry
+	// Count is how many times the command line ran
+	Count int `json:"count"`
+}
+
+// FindRecent gets the distinct command lines of the entries, most recently run first, with the last run of each.
+// A limit of zero means no limit.
+func FindRecent(entries []Entry, limit int) []Recent {
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		counts[entry.Line()]++
+	}
+
+	var recent []Recent
+	seen := make(map[string]bool)
+	for i := len(entries) - 1; i >= 0 && (limit == 0 || len(recent) < limit); i-- {
+		line := entries[i].Line()
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+		recent = append(recent, Recent{Number: i + 1, Entry: entries[i], Count: counts[line]})
+	}
+	return recent
+}
+
+// CommandLine joins a command and its arguments, quoting the arguments that need it
+func CommandLine(command string, args []string) string {
+	words := []string{command}
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'\\$") {
+			arg = strconv.Quote(arg)
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
+}
+
+// truncate drops the oldest half of the entries of a history file
+func truncate(path string) error {
+	entries, err := Load(path)
+	if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	for _, entry := range entries[len(entries)/2:] {
+		if err := encoder.Encode(entry); err != nil {
+			return errors.Wrap(err, "failed to encode the history entry")
+		}
+	}
+	if err := os.WriteFile(path, buffer.Bytes(), 0600); err != nil {
+		return errors.Wrap(err, "failed to write the history")
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history", "history.jsonl")
+
+	entries, err := Load(path)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("Load() of a missing history = %v, %v, want no entries", entries, err)
+	}
+
+	start := time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC)
+	for _, entry := range []Entry{
+		{Command: "deploy", Args: []string{"--env", "prod"}, Time: start, DurationMS: 1500},
+		{Command: "prs", Args: []string{"fix the build", ""}, Time: start.Add(time.Minute), ExitCode: 2},
+	} {
+		if err := Append(path, entry); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	// Lines that can't be read are skipped
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatalf("Failed to open history: %v", err)
+	}
+	file.WriteString("not json\n")
+	file.Close()
+
+	entries, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Load() = %v, want 2 entries", entries)
+	}
+	if got := entries[0].Line(); got != "deploy --env prod" {
+		t.Errorf("Line() = %q, want %q", got, "deploy --env prod")
+	}
+	if got := entries[1].Line(); got != `prs "fix the build" ""` {
+		t.Errorf("Line() = %q, want the arguments quoted", got)
+	}
+	if entries[0].Duration() != 1500*time.Millisecond || !entries[1].Time.Equal(start.Add(time.Minute)) || entries[1].ExitCode != 2 {
+		t.Errorf("Load() = %+v, want the entries as appended", entries)
+	}
+}
+
+func TestAppend_Truncates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	arg := strings.Repeat("x", 1000)
+	for i := 0; i < maxSize/1000+10; i++ {
+		if err := Append(path, Entry{Command: "deploy", Args: []string{arg}}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat history: %v", err)
+	}
+	if info.Size() > maxSize {
+		t.Errorf("history size = %d, want at most %d", info.Size(), maxSize)
+	}
+}
+
+func TestFindRecent(t *testing.T) {
+	entries := []Entry{
+		{Command: "deploy", Args: []string{"--env", "prod"}},
+		{Command: "lint"},
+		{Command: "deploy", Args: []string{"--env", "prod"}},
+		{Command: "prs"},
+	}
+
+	recent := FindRecent(entries, 2)
+	if len(recent) != 2 {
+		t.Fatalf("FindRecent() = %+v, want 2 command lines", recent)
+	}
+	if recent[0].Number != 4 || recent[0].Line() != "prs" || recent[0].Count != 1 {
+		t.Errorf("FindRecent()[0] = %+v, want prs, run once as command 4", recent[0])
+	}
+	if recent[1].Number != 3 || recent[1].Line() != "deploy --env prod" || recent[1].Count != 2 {
+		t.Errorf("FindRecent()[1] = %+v, want deploy, run twice and last as command 3", recent[1])
+	}
+	if all := FindRecent(entries, 0); len(all) != 3 {
+		t.Errorf("FindRecent() without a limit = %+v, want 3 command lines", all)
+	}
+}
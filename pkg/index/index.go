@@ -0,0 +1,145 @@
+package index
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// fetchTimeout limits how long fetching an index may take
+const fetchTimeout = 30 * time.Second
+
+// Index is a plugin registry index, listing the plugins that can be installed
+type Index struct {
+	Plugins []Plugin `json:"plugins"`
+}
+
+// Plugin describes an installable plugin of an index
+type Plugin struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+	// URL is the download URL of the plugin binary
+	URL string `json:"url,omitempty"`
+	// Downloads holds download URLs per platform, keyed by "GOOS/GOARCH", taking precedence over URL
+	Downloads map[string]string `json:"downloads,omitempty"`
+	// Registry is the URL of the index the plugin was found in
+	Registry string `json:"-"`
+}
+
+// DownloadURL returns the download URL of the plugin for a platform, or an empty string when there is none
+func (p Plugin) DownloadURL(goos, goarch string) string {
+	if downloadURL, ok := p.Downloads[goos+"/"+goarch]; ok {
+		return downloadURL
+	}
+	return p.URL
+}
+
+// CurrentDownloadURL returns the download URL of the plugin for the running platform
+func (p Plugin) CurrentDownloadURL() string {
+	return p.DownloadURL(runtime.GOOS, runtime.GOARCH)
+}
+
+// Parse parses an index
+func Parse(data []byte) (*Index, error) {
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, errors.Wrap(err, "failed to parse index")
+	}
+
+	for i, plugin := range index.Plugins {
+		if plugin.Name == "" {
+			return nil, errors.Errorf("plugin %d of the index has no name", i+1)
+		}
+	}
+	return &index, nil
+}
+
+// Fetch fetches the index at a URL; http(s) URLs, file URLs and local paths are supported
+func Fetch(location string) (*Index, error) {
+	data, err := read(location)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := Parse(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid index %s", location)
+	}
+	for i := range index.Plugins {
+		index.Plugins[i].Registry = location
+	}
+	return index, nil
+}
+
+// read reads the content at a URL or local path
+func read(location string) ([]byte, error) {
+	parsed, err := url.Parse(location)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https" && parsed.Scheme != "file") {
+		data, err := os.ReadFile(os.ExpandEnv(location))
+		return data, errors.Wrapf(err, "failed to read index %s", location)
+	}
+
+	if parsed.Scheme == "file" {
+		data, err := os.ReadFile(filepath.FromSlash(parsed.Path))
+		return data, errors.Wrapf(err, "failed to read index %s", location)
+	}
+
+	client := &http.Client{Timeout: fetchTimeout}
+	response, err := client.Get(location)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch index %s", location)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch index %s: %s", location, response.Status)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	return data, errors.Wrapf(err, "failed to read index %s", location)
+}
+
+// FetchAll fetches the indexes of several registries. Registries that cannot be fetched are reported in the
+// returned errors, so the others can still be used.
+func FetchAll(locations []string) ([]Plugin, []error) {
+	var plugins []Plugin
+	var errs []error
+	for _, location := range locations {
+		index, err := Fetch(location)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		plugins = append(plugins, index.Plugins...)
+	}
+	return plugins, errs
+}
+
+// Search finds the plugins whose name or description contains the term, ignoring case, sorted by name.
+// An empty term matches every plugin.
+func Search(plugins []Plugin, term string) []Plugin {
+	term = strings.ToLower(term)
+
+	var matches []Plugin
+	for _, plugin := range plugins {
+		if strings.Contains(strings.ToLower(plugin.Name), term) || strings.Contains(strings.ToLower(plugin.Description), term) {
+			matches = append(matches, plugin)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Name < matches[j].Name
+	})
+	return matches
+}
@@ -0,0 +1,123 @@
+package index
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testIndex = `{
+  "plugins": [
+    {"name": "k8s-pods", "description": "Show Kubernetes pod status", "version": "1.2.0", "url": "https://example.com/mm-k8s-pods"},
+    {"name": "azure-devops", "description": "Manage Azure DevOps work items", "version": "2.0.0",
+     "downloads": {"linux/amd64": "https://example.com/linux/mm-azure-devops", "darwin/arm64": "https://example.com/darwin/mm-azure-devops"}}
+  ]
+}`
+
+func TestParse(t *testing.T) {
+	index, err := Parse([]byte(testIndex))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(index.Plugins) != 2 || index.Plugins[0].Name != "k8s-pods" || index.Plugins[1].Version != "2.0.0" {
+		t.Errorf("Parse() = %+v", index)
+	}
+
+	if _, err := Parse([]byte(`{"plugins": [{"description": "nameless"}]}`)); err == nil {
+		t.Error("Parse() of a plugin without name error = nil, want an error")
+	}
+	if _, err := Parse([]byte(`not json`)); err == nil {
+		t.Error("Parse() of invalid JSON error = nil, want an error")
+	}
+}
+
+func TestPluginDownloadURL(t *testing.T) {
+	index, _ := Parse([]byte(testIndex))
+
+	if got := index.Plugins[0].DownloadURL("linux", "amd64"); got != "https://example.com/mm-k8s-pods" {
+		t.Errorf("DownloadURL() = %s, want the plugin URL", got)
+	}
+	if got := index.Plugins[1].DownloadURL("darwin", "arm64"); got != "https://example.com/darwin/mm-azure-devops" {
+		t.Errorf("DownloadURL(darwin/arm64) = %s, want the darwin download", got)
+	}
+	if got := index.Plugins[1].DownloadURL("windows", "amd64"); got != "" {
+		t.Errorf("DownloadURL(windows/amd64) = %s, want none", got)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/index.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(testIndex))
+	}))
+	defer server.Close()
+
+	index, err := Fetch(server.URL + "/index.json")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(index.Plugins) != 2 || index.Plugins[0].Registry != server.URL+"/index.json" {
+		t.Errorf("Fetch() = %+v, want the plugins with their registry", index.Plugins)
+	}
+
+	if _, err := Fetch(server.URL + "/missing.json"); err == nil {
+		t.Error("Fetch() of a missing index error = nil, want an error")
+	}
+
+	path := filepath.Join(t.TempDir(), "index.json")
+	if err := os.WriteFile(path, []byte(testIndex), 0644); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+	if index, err := Fetch(path); err != nil || len(index.Plugins) != 2 {
+		t.Errorf("Fetch(local path) = %v, %v, want 2 plugins", index, err)
+	}
+}
+
+func TestFetchAll(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.json")
+	if err := os.WriteFile(path, []byte(testIndex), 0644); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	plugins, errs := FetchAll([]string{path, filepath.Join(t.TempDir(), "missing.json")})
+	if len(plugins) != 2 || len(errs) != 1 {
+		t.Errorf("FetchAll() = %d plugins, %d errors, want 2 plugins and 1 error", len(plugins), len(errs))
+	}
+}
+
+func TestSearch(t *testing.T) {
+	index, _ := Parse([]byte(testIndex))
+
+	tests := []struct {
+		term string
+		want []string
+	}{
+		{"pods", []string{"k8s-pods"}},
+		{"AZURE", []string{"azure-devops"}},
+		{"kubernetes", []string{"k8s-pods"}},
+		{"", []string{"azure-devops", "k8s-pods"}},
+		{"terraform", nil},
+	}
+
+	for _, tt := range tests {
+		matches := Search(index.Plugins, tt.term)
+		var got []string
+		for _, plugin := range matches {
+			got = append(got, plugin.Name)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("Search(%q) = %v, want %v", tt.term, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("Search(%q) = %v, want %v", tt.term, got, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Package integrity keeps the hashes of the binaries of subcommands, so that binaries changed since they were
+// recorded, by tampering or by accident, can be detected before they run.
+package integrity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Record is the hash of a binary and when it was recorded
+type Record struct {
+	SHA256   string    `json:"sha256"`
+	Recorded time.Time `json:"recorded"`
+}
+
+// Database holds the records of the binaries, by path
+type Database map[string]Record
+
+// Status is how a binary compares to its record
+type Status string
+
+const (
+	// StatusUnknown is a binary without a record
+	StatusUnknown Status = "unknown"
+	// StatusVerified is a binary with the hash of its record
+	StatusVerified Status = "verified"
+	// StatusChanged is a binary whose hash differs from its record
+	StatusChanged Status = "changed"
+)
+
+// Load reads a database; a missing file is an empty database
+func Load(path string) (Database, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Database{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the integrity database")
+	}
+
+	database := Database{}
+	if err := json.Unmarshal(data, &database); err != nil {
+		return nil, errors.Wrapf(err, "invalid integrity database %s", path)
+	}
+	return database, nil
+}
+
+// Save writes a database, replacing the file at once so that concurrent runs never read half of it
+func (d Database) Save(path string) error {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the integrity database")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "failed to create the integrity database directory")
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return errors.Wrap(err, "failed to write the integrity database")
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	return errors.Wrap(err, "failed to write the integrity database")
+}
+
+// Record records the hash of a binary
+func (d Database) Record(binaryPath string, hash string) {
+	d[key(binaryPath)] = Record{SHA256: hash, Recorded: time.Now().UTC()}
+}
+
+// Forget drops the record of a binary, such as one that was uninstalled
+func (d Database) Forget(binaryPath string) {
+	delete(d, key(binaryPath))
+}
+
+// Check compares the hash of a binary to its record
+func (d Database) Check(binaryPath string, hash string) (Status, Record) {
+	record, ok := d[key(binaryPath)]
+	switch {
+	case !ok:
+		return StatusUnknown, record
+	case record.SHA256 != hash:
+		return StatusChanged, record
+	}
+	return StatusVerified, record
+}
+
+// Hash computes the SHA-256 hash of a binary, hex encoded
+func Hash(binaryPath string) (string, error) {
+	file, err := os.Open(binaryPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to hash '%s'", binaryPath)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", errors.Wrapf(err, "failed to hash '%s'", binaryPath)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// key gets the key of a binary in the database, its absolute path
+func key(binaryPath string) string {
+	if absolute, err := filepath.Abs(binaryPath); err == nil {
+		return absolute
+	}
+	return filepath.Clean(binaryPath)
+}
@@ -0,0 +1,67 @@
+package integrity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDatabase(t *testing.T) {
+	dir := t.TempDir()
+	binaryPath := filepath.Join(dir, "mm-deploy")
+	if err := os.WriteFile(binaryPath, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("Failed to create mm-deploy: %v", err)
+	}
+	path := filepath.Join(dir, "state", "integrity.json")
+
+	database, err := Load(path)
+	if err != nil || len(database) != 0 {
+		t.Fatalf("Load() of a missing file = %v, %v, want an empty database", database, err)
+	}
+
+	hash, err := Hash(binaryPath)
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if status, _ := database.Check(binaryPath, hash); status != StatusUnknown {
+		t.Errorf("Check() before recording = %s, want %s", status, StatusUnknown)
+	}
+	database.Record(binaryPath, hash)
+	if err := database.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	database, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if status, record := database.Check(binaryPath, hash); status != StatusVerified || record.Recorded.IsZero() {
+		t.Errorf("Check() after recording = %s, %v, want %s", status, record, StatusVerified)
+	}
+
+	if err := os.WriteFile(binaryPath, []byte("#!/bin/sh\necho changed\n"), 0755); err != nil {
+		t.Fatalf("Failed to change mm-deploy: %v", err)
+	}
+	changed, err := Hash(binaryPath)
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if status, _ := database.Check(binaryPath, changed); status != StatusChanged {
+		t.Errorf("Check() of the changed binary = %s, want %s", status, StatusChanged)
+	}
+
+	database.Forget(binaryPath)
+	if status, _ := database.Check(binaryPath, changed); status != StatusUnknown {
+		t.Errorf("Check() after forgetting = %s, want %s", status, StatusUnknown)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "integrity.json")
+	if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatalf("Failed to create integrity.json: %v", err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() of an invalid file error = nil")
+	}
+}
@@ -0,0 +1,8 @@
+// Package keyring reads and writes secrets in the system keyring: the macOS Keychain, the Windows Credential
+// Manager or the Secret Service (libsecret) on Linux. Secrets are identified by a service and an account.
+package keyring
+
+import "github.com/pkg/errors"
+
+// ErrNotFound is returned when the keyring holds no secret for an account
+var ErrNotFound = errors.New("secret not found in keyring")
@@ -0,0 +1,50 @@
+package keyring
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// securityItemNotFound is the exit code of the security tool when no keychain item matches
+const securityItemNotFound = 44
+
+// Get reads a secret from the macOS Keychain
+func Get(service, account string) (string, error) {
+	output, err := exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w").Output()
+	if err != nil {
+		return "", keychainError(err)
+	}
+	return strings.TrimSuffix(string(output), "\n"), nil
+}
+
+// Set stores a secret in the macOS Keychain, replacing any existing one.
+// The secret is passed hex-encoded on standard input so it does not show up in the process list.
+func Set(service, account, secret string) error {
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %q -a %q -X %q\n", service, account, hex.EncodeToString([]byte(secret))))
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "failed to store secret in keychain: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Delete removes a secret from the macOS Keychain
+func Delete(service, account string) error {
+	if err := exec.Command("security", "delete-generic-password", "-s", service, "-a", account).Run(); err != nil {
+		return keychainError(err)
+	}
+	return nil
+}
+
+// keychainError converts a failure of the security tool to an error
+func keychainError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == securityItemNotFound {
+		return ErrNotFound
+	}
+	return errors.Wrap(err, "failed to access keychain")
+}
@@ -0,0 +1,55 @@
+package keyring
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Get reads a secret from the Secret Service (libsecret) keyring
+func Get(service, account string) (string, error) {
+	output, err := exec.Command("secret-tool", "lookup", "service", service, "account", account).Output()
+	if err != nil {
+		// secret-tool exits with status 1 and no output when nothing matches
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 && len(output) == 0 {
+			return "", ErrNotFound
+		}
+		return "", secretToolError(err)
+	}
+	if len(output) == 0 {
+		return "", ErrNotFound
+	}
+	return string(output), nil
+}
+
+// Set stores a secret in the Secret Service keyring, replacing any existing one; the secret is passed on standard input
+func Set(service, account, secret string) error {
+	cmd := exec.Command("secret-tool", "store", "--label", fmt.Sprintf("%s (%s)", service, account), "service", service, "account", account)
+	cmd.Stdin = strings.NewReader(secret)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(secretToolError(err), "failed to store secret: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Delete removes a secret from the Secret Service keyring
+func Delete(service, account string) error {
+	if _, err := Get(service, account); err != nil {
+		return err
+	}
+	if err := exec.Command("secret-tool", "clear", "service", service, "account", account).Run(); err != nil {
+		return secretToolError(err)
+	}
+	return nil
+}
+
+// secretToolError converts a failure of secret-tool to an error, explaining how to install it when it is missing
+func secretToolError(err error) error {
+	if errors.Is(err, exec.ErrNotFound) {
+		return errors.New("secret-tool not found; install libsecret-tools (or libsecret) to use the keyring")
+	}
+	return errors.Wrap(err, "failed to access keyring")
+}
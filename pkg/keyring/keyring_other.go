@@ -0,0 +1,24 @@
+//go:build !darwin && !linux && !windows
+
+package keyring
+
+import (
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// Get reports that no keyring is supported on this platform
+func Get(service, account string) (string, error) {
+	return "", errors.Errorf("no keyring is supported on %s", runtime.GOOS)
+}
+
+// Set reports that no keyring is supported on this platform
+func Set(service, account, secret string) error {
+	return errors.Errorf("no keyring is supported on %s", runtime.GOOS)
+}
+
+// Delete reports that no keyring is supported on this platform
+func Delete(service, account string) error {
+	return errors.Errorf("no keyring is supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,113 @@
+package keyring
+
+import (
+	"syscall"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// Windows Credential Manager constants
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+var (
+	advapi32       = syscall.NewLazyDLL("advapi32.dll")
+	procCredRead   = advapi32.NewProc("CredReadW")
+	procCredWrite  = advapi32.NewProc("CredWriteW")
+	procCredDelete = advapi32.NewProc("CredDeleteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+)
+
+// credential mirrors the CREDENTIALW structure of the Windows Credential Manager
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// credentialTarget gets the Credential Manager target name of an account
+func credentialTarget(service, account string) (*uint16, error) {
+	return syscall.UTF16PtrFromString(service + ":" + account)
+}
+
+// Get reads a secret from the Windows Credential Manager
+func Get(service, account string) (string, error) {
+	target, err := credentialTarget(service, account)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid credential name")
+	}
+
+	var cred *credential
+	result, _, err := procCredRead.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if result == 0 {
+		return "", credentialError(err)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	return string(unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)), nil
+}
+
+// Set stores a secret in the Windows Credential Manager, replacing any existing one
+func Set(service, account, secret string) error {
+	target, err := credentialTarget(service, account)
+	if err != nil {
+		return errors.Wrap(err, "invalid credential name")
+	}
+	userName, err := syscall.UTF16PtrFromString(account)
+	if err != nil {
+		return errors.Wrap(err, "invalid account name")
+	}
+
+	blob := []byte(secret)
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+		UserName:           userName,
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+
+	result, _, err := procCredWrite.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if result == 0 {
+		return credentialError(err)
+	}
+	return nil
+}
+
+// Delete removes a secret from the Windows Credential Manager
+func Delete(service, account string) error {
+	target, err := credentialTarget(service, account)
+	if err != nil {
+		return errors.Wrap(err, "invalid credential name")
+	}
+
+	result, _, err := procCredDelete.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0)
+	if result == 0 {
+		return credentialError(err)
+	}
+	return nil
+}
+
+// credentialError converts a failed Credential Manager call to an error
+func credentialError(err error) error {
+	if errors.Is(err, errorNotFound) {
+		return ErrNotFound
+	}
+	return errors.Wrap(err, "failed to access Credential Manager")
+}
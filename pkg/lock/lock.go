@@ -0,0 +1,114 @@
+// Package lock keeps commands from running concurrently with lock files, which hold the process ID of the
+// master-mold holding them. The files are locked with the locks of the system, which are dropped when their process
+// is gone, so locks left behind by processes that are gone are taken over.
+package lock
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oscarrieken/master-mold/pkg/binary"
+	"github.com/pkg/errors"
+)
+
+// pollInterval is how often a held lock is checked while waiting for it
+var pollInterval = 100 * time.Millisecond
+
+// HeldError is the error of acquiring a lock another process holds
+type HeldError struct {
+	// Name is the name of the lock, such as the command it is for
+	Name string
+	// PID is the process ID of the holder
+	PID int
+}
+
+func (e *HeldError) Error() string {
+	return "'" + e.Name + "' is already running (pid " + strconv.Itoa(e.PID) + ")"
+}
+
+// Acquire takes the lock file at a path, waiting up to wait for its holder to release it; with no wait, it fails
+// right away when the lock is held. The returned function releases the lock.
+func Acquire(path string, wait time.Duration) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to create the locks directory")
+	}
+
+	deadline := time.Now().Add(wait)
+	for {
+		file, locked, err := tryAcquire(path)
+		if err != nil {
+			return nil, err
+		}
+		if locked {
+			return func() { release(path, file) }, nil
+		}
+
+		if !time.Now().Before(deadline) {
+			pid, _ := Holder(path)
+			return nil, &HeldError{Name: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), PID: pid}
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// tryAcquire locks the lock file at a path and writes the process ID to it, reporting false when another process
+// holds it. The system drops the locks of processes that are gone, so their lock files are taken over.
+func tryAcquire(path string) (*os.File, bool, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "failed to create lock '%s'", path)
+	}
+	locked, err := lockFile(file)
+	if err != nil || !locked {
+		file.Close()
+		return nil, false, errors.Wrapf(err, "failed to lock '%s'", path)
+	}
+
+	// A holder releasing the lock removes the file it was opened from, and the next one creates another
+	info, err := file.Stat()
+	if err != nil {
+		unlockFile(file)
+		file.Close()
+		return nil, false, errors.Wrapf(err, "failed to read lock '%s'", path)
+	}
+	if current, err := os.Stat(path); err != nil || !os.SameFile(info, current) {
+		unlockFile(file)
+		file.Close()
+		return tryAcquire(path)
+	}
+
+	if err := file.Truncate(0); err == nil {
+		_, err = file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	}
+	if err != nil {
+		release(path, file)
+		return nil, false, errors.Wrapf(err, "failed to write lock '%s'", path)
+	}
+	return file, true, nil
+}
+
+// release removes the lock file before unlocking it, so the processes waiting on it try again with a new one. The
+// process ID is cleared first, where the file can't be removed while it is open.
+func release(path string, file *os.File) {
+	file.Truncate(0)
+	os.Remove(path)
+	unlockFile(file)
+	file.Close()
+}
+
+// Holder gets the process ID of the holder of the lock file at a path. It reports false when the lock isn't held:
+// when the file is missing or unreadable, or its process is gone.
+func Holder(path string) (int, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 || !binary.ProcessRunning(pid) {
+		return 0, false
+	}
+	return pid, true
+}
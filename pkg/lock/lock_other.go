@@ -0,0 +1,20 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+
+package lock
+
+import (
+	"os"
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// lockFile reports that files can't be locked on this platform
+func lockFile(file *os.File) (bool, error) {
+	return false, errors.Errorf("files can't be locked on %s", runtime.GOOS)
+}
+
+// unlockFile does nothing on this platform, where files are never locked
+func unlockFile(file *os.File) error {
+	return nil
+}
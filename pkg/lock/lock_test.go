@@ -0,0 +1,132 @@
+package lock
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAcquire(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "locks", "deploy.lock")
+
+	release, err := Acquire(path, 0)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	if pid, ok := Holder(path); !ok || pid != os.Getpid() {
+		t.Errorf("Holder() = %d, %v, want the current process", pid, ok)
+	}
+
+	// A held lock fails fast without a wait
+	_, err = Acquire(path, 0)
+	var held *HeldError
+	if !errors.As(err, &held) || held.PID != os.Getpid() || held.Name != "deploy" {
+		t.Fatalf("Acquire() of a held lock error = %v, want a HeldError", err)
+	}
+	if want := "'deploy' is already running (pid " + strconv.Itoa(os.Getpid()) + ")"; err.Error() != want {
+		t.Errorf("Acquire() error = %q, want %q", err, want)
+	}
+
+	// A waiting acquire gets the lock once it is released
+	go func(release func()) {
+		time.Sleep(50 * time.Millisecond)
+		release()
+	}(release)
+	release, err = Acquire(path, 5*time.Second)
+	if err != nil {
+		t.Fatalf("Acquire() with a wait error = %v", err)
+	}
+	release()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("lock file after release error = %v, want it removed", err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 0 {
+		t.Errorf("locks directory = %v, want it empty", entries)
+	}
+}
+
+func TestAcquire_Stale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deploy.lock")
+	// No process has the largest process ID
+	if err := os.WriteFile(path, []byte("2147483647\n"), 0644); err != nil {
+		t.Fatalf("Failed to create stale lock: %v", err)
+	}
+
+	release, err := Acquire(path, 0)
+	if err != nil {
+		t.Fatalf("Acquire() of a stale lock error = %v, want it taken over", err)
+	}
+	defer release()
+	if pid, ok := Holder(path); !ok || pid != os.Getpid() {
+		t.Errorf("Holder() = %d, %v, want the current process", pid, ok)
+	}
+}
+
+func TestAcquire_StaleConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deploy.lock")
+
+	// Only one of the waiters that find the stale lock takes it over
+	const waiters = 20
+	for round := range 20 {
+		if err := os.WriteFile(path, []byte("2147483647\n"), 0644); err != nil {
+			t.Fatalf("Failed to create stale lock: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		var releases []func()
+		var failures int
+		start := make(chan struct{})
+		for range waiters {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				release, err := Acquire(path, 0)
+				mu.Lock()
+				defer mu.Unlock()
+				var held *HeldError
+				switch {
+				case err == nil:
+					releases = append(releases, release)
+				case errors.As(err, &held):
+					failures++
+				default:
+					t.Errorf("Acquire() error = %v, want a HeldError", err)
+				}
+			}()
+		}
+		close(start)
+		wg.Wait()
+		for _, release := range releases {
+			release()
+		}
+
+		if len(releases) != 1 || failures != waiters-1 {
+			t.Fatalf("round %d: Acquire() of a stale lock succeeded %d times and failed %d times, want one success", round, len(releases), failures)
+		}
+	}
+}
+
+func TestAcquire_Timeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deploy.lock")
+	release, err := Acquire(path, 0)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	defer release()
+
+	start := time.Now()
+	_, err = Acquire(path, 200*time.Millisecond)
+	var held *HeldError
+	if !errors.As(err, &held) {
+		t.Fatalf("Acquire() error = %v, want a HeldError after the wait", err)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("Acquire() gave up after %s, want it to wait", elapsed)
+	}
+}
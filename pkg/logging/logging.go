@@ -0,0 +1,28 @@
+// Package logging creates the loggers of master-mold and its subcommands from the log settings in the environment
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/oscarrieken/master-mold/pkg/env"
+)
+
+// New creates a logger writing to stderr, so logs never mix with the output of commands, whether it's JSON or
+// redirected to a file
+func New() *slog.Logger {
+	return NewWithOutput(os.Stderr)
+}
+
+// NewWithOutput creates a logger writing to the output, in the format and at the level from the environment
+func NewWithOutput(output io.Writer) *slog.Logger {
+	options := &slog.HandlerOptions{
+		Level: env.ResolveLogLevel(slog.LevelInfo),
+	}
+
+	if env.JSONLogs() {
+		return slog.New(slog.NewJSONHandler(output, options))
+	}
+	return slog.New(slog.NewTextHandler(output, options))
+}
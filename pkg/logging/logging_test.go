@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oscarrieken/master-mold/pkg/env"
+)
+
+func TestNewWithOutput(t *testing.T) {
+	t.Setenv(env.Verbose, "")
+	t.Setenv(env.Quiet, "")
+	t.Setenv(env.LogLevel, "")
+	t.Setenv(env.LogFormat, "")
+
+	var buf bytes.Buffer
+	logger := NewWithOutput(&buf)
+	logger.Debug("Hidden")
+	logger.Info("Shown", "key", "value")
+	if got := buf.String(); strings.Contains(got, "Hidden") || !strings.Contains(got, `msg=Shown key=value`) {
+		t.Errorf("text logs = %q, want the info message only", got)
+	}
+
+	t.Setenv(env.LogLevel, "debug")
+	t.Setenv(env.LogFormat, "json")
+	buf.Reset()
+	logger = NewWithOutput(&buf)
+	logger.Debug("Shown", "key", "value")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("JSON logs = %q, want a JSON object: %v", buf.String(), err)
+	}
+	if entry["level"] != "DEBUG" || entry["msg"] != "Shown" || entry["key"] != "value" {
+		t.Errorf("JSON log entry = %v, want the debug message", entry)
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv(env.LogFormat, "")
+	stdout, stderr := os.Stdout, os.Stderr
+	defer func() { os.Stdout, os.Stderr = stdout, stderr }()
+	dir := t.TempDir()
+	var err error
+	if os.Stdout, err = os.Create(filepath.Join(dir, "stdout")); err != nil {
+		t.Fatal(err)
+	}
+	if os.Stderr, err = os.Create(filepath.Join(dir, "stderr")); err != nil {
+		t.Fatal(err)
+	}
+
+	New().Error("Logged")
+	if got, _ := os.ReadFile(filepath.Join(dir, "stdout")); len(got) != 0 {
+		t.Errorf("stdout = %q, want no logs", got)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, "stderr")); !strings.Contains(string(got), "msg=Logged") {
+		t.Errorf("stderr = %q, want the logs", got)
+	}
+}
@@ -0,0 +1,10 @@
+// Package terminal tells whether files are interactive terminals, so that prompts, notices and tables are only used
+// with people rather than scripts
+package terminal
+
+import "os"
+
+// IsTerminal checks if a file is an interactive terminal. Other character devices, like /dev/null, are not.
+func IsTerminal(file *os.File) bool {
+	return isTerminal(file.Fd())
+}
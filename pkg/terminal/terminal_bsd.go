@@ -0,0 +1,8 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+package terminal
+
+import "golang.org/x/sys/unix"
+
+// ioctlReadTermios is the request reading the attributes of a terminal
+const ioctlReadTermios = unix.TIOCGETA
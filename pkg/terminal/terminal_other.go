@@ -0,0 +1,8 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris && !windows
+
+package terminal
+
+// isTerminal reports that terminals can't be told apart on this platform
+func isTerminal(fd uintptr) bool {
+	return false
+}
@@ -0,0 +1,8 @@
+//go:build aix || linux || solaris
+
+package terminal
+
+import "golang.org/x/sys/unix"
+
+// ioctlReadTermios is the request reading the attributes of a terminal
+const ioctlReadTermios = unix.TCGETS
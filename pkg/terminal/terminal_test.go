@@ -0,0 +1,27 @@
+package terminal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsTerminal(t *testing.T) {
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", os.DevNull, err)
+	}
+	defer devNull.Close()
+	if IsTerminal(devNull) {
+		t.Errorf("IsTerminal(%s) = true, want false", os.DevNull)
+	}
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "file"))
+	if err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	defer file.Close()
+	if IsTerminal(file) {
+		t.Error("IsTerminal(file) = true, want false")
+	}
+}
@@ -0,0 +1,11 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package terminal
+
+import "golang.org/x/sys/unix"
+
+// isTerminal checks if a file descriptor has terminal attributes
+func isTerminal(fd uintptr) bool {
+	_, err := unix.IoctlGetTermios(int(fd), ioctlReadTermios)
+	return err == nil
+}
@@ -0,0 +1,9 @@
+package terminal
+
+import "golang.org/x/sys/windows"
+
+// isTerminal checks if a handle is a console
+func isTerminal(fd uintptr) bool {
+	var mode uint32
+	return windows.GetConsoleMode(windows.Handle(fd), &mode) == nil
+}
@@ -57,7 +57,7 @@ timeout = 10
 	newPath := tempDir + string(os.PathListSeparator) + oldPath
 
 	// Run the list-binaries command using go run
-	cmd := exec.Command("go", "run", "../cmd/master-mold/main.go", "list-binaries")
+	cmd := exec.Command("go", "run", "../cmd/master-mold", "list-binaries")
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -125,7 +125,7 @@ timeout = 10
 	newPath := tempDir + string(os.PathListSeparator) + oldPath
 
 	// Run the test command using go run
-	cmd := exec.Command("go", "run", "../cmd/master-mold/main.go", "test-command", "arg1", "arg2")
+	cmd := exec.Command("go", "run", "../cmd/master-mold", "test-command", "arg1", "arg2")
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr